@@ -9,13 +9,17 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
 
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	"github.com/okex/exchain/app/crypto/secp256r1"
 	evmtypes "github.com/okex/exchain/x/evm/types"
+	feegranttypes "github.com/okex/exchain/x/feegrant/types"
 
 	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
 )
 
 func init() {
 	ethsecp256k1.RegisterCodec(types.ModuleCdc)
+	secp256r1.RegisterCodec(types.ModuleCdc)
 }
 
 const (
@@ -23,19 +27,21 @@ const (
 	// which currently defaults at 10, if intended
 	// memoCostPerByte     sdk.Gas = 3
 	secp256k1VerifyCost uint64 = 21000
+	secp256r1VerifyCost uint64 = 25000
 )
 
 // NewAnteHandler returns an ante handler responsible for attempting to route an
 // Ethereum or SDK transaction to an internal ante handler for performing
 // transaction-level processing (e.g. fee payment, signature verification) before
 // being passed onto it's respective handler.
-func NewAnteHandler(ak auth.AccountKeeper, evmKeeper EVMKeeper, sk types.SupplyKeeper, validateMsgHandler ValidateMsgHandler) sdk.AnteHandler {
+func NewAnteHandler(ak auth.AccountKeeper, evmKeeper EVMKeeper, sk types.SupplyKeeper, fgk FeeGrantKeeper,
+	azk AuthzKeeper, validateMsgHandler ValidateMsgHandler) sdk.AnteHandler {
 	return func(
 		ctx sdk.Context, tx sdk.Tx, sim bool,
 	) (newCtx sdk.Context, err error) {
 		var anteHandler sdk.AnteHandler
 		switch tx.(type) {
-		case auth.StdTx:
+		case auth.StdTx, feegranttypes.FeeGrantTx:
 			anteHandler = sdk.ChainAnteDecorators(
 				authante.NewSetUpContextDecorator(), // outermost AnteDecorator. SetUpContext must be called first
 				NewAccountSetupDecorator(ak),
@@ -46,10 +52,11 @@ func NewAnteHandler(ak auth.AccountKeeper, evmKeeper EVMKeeper, sk types.SupplyK
 				authante.NewConsumeGasForTxSizeDecorator(ak),
 				authante.NewSetPubKeyDecorator(ak), // SetPubKeyDecorator must be called before all signature verification decorators
 				authante.NewValidateSigCountDecorator(ak),
-				authante.NewDeductFeeDecorator(ak, sk),
+				NewDeductGrantedFeeDecorator(ak, sk, fgk),
 				authante.NewSigGasConsumeDecorator(ak, sigGasConsumer),
 				authante.NewSigVerificationDecorator(ak),
 				authante.NewIncrementSequenceDecorator(ak), // innermost AnteDecorator
+				NewAuthzExecDecorator(azk),
 				NewValidateMsgHandlerDecorator(validateMsgHandler),
 			)
 
@@ -61,11 +68,19 @@ func NewAnteHandler(ak auth.AccountKeeper, evmKeeper EVMKeeper, sk types.SupplyK
 				authante.NewValidateBasicDecorator(),
 				NewEthSigVerificationDecorator(),
 				NewAccountBlockedVerificationDecorator(evmKeeper), //account blocked check AnteDecorator
-				NewAccountVerificationDecorator(ak, evmKeeper),
+				NewAccountVerificationDecorator(ak, evmKeeper, fgk),
 				NewNonceVerificationDecorator(ak),
-				NewEthGasConsumeDecorator(ak, sk, evmKeeper),
+				NewEthGasConsumeDecorator(ak, sk, evmKeeper, fgk),
 				NewIncrementSenderSequenceDecorator(ak), // innermost AnteDecorator.
 			)
+
+		case evmtypes.BundleTx:
+			anteHandler = sdk.ChainAnteDecorators(
+				NewEthSetupContextDecorator(), // outermost AnteDecorator. EthSetUpContext must be called first
+				authante.NewValidateBasicDecorator(),
+				NewBundleConditionsDecorator(evmKeeper),
+				NewBundleTxsDecorator(ak, sk, evmKeeper), // innermost AnteDecorator.
+			)
 		default:
 			return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
 		}
@@ -75,14 +90,24 @@ func NewAnteHandler(ak auth.AccountKeeper, evmKeeper EVMKeeper, sk types.SupplyK
 }
 
 // sigGasConsumer overrides the DefaultSigVerificationGasConsumer from the x/auth
-// module on the SDK. It doesn't allow ed25519 nor multisig thresholds.
+// module on the SDK. Multisig threshold keys are charged for every sub-key that
+// contributed a signature.
 func sigGasConsumer(
-	meter sdk.GasMeter, _ []byte, pubkey tmcrypto.PubKey, _ types.Params,
+	meter sdk.GasMeter, sig []byte, pubkey tmcrypto.PubKey, params types.Params,
 ) error {
-	switch pubkey.(type) {
+	switch pubkey := pubkey.(type) {
 	case ethsecp256k1.PubKey:
 		meter.ConsumeGas(secp256k1VerifyCost, "ante verify: secp256k1")
 		return nil
+	case secp256r1.PubKey:
+		meter.ConsumeGas(secp256r1VerifyCost, "ante verify: secp256r1")
+		return nil
+	case multisig.PubKeyMultisigThreshold:
+		var multisignature multisig.Multisignature
+		if err := types.ModuleCdc.UnmarshalBinaryBare(sig, &multisignature); err != nil {
+			return sdkerrors.Wrapf(sdkerrors.ErrTxDecode, "invalid multisignature: %s", err)
+		}
+		return consumeMultisignatureVerificationGas(meter, multisignature, pubkey, params)
 	case tmcrypto.PubKey:
 		meter.ConsumeGas(secp256k1VerifyCost, "ante verify: tendermint secp256k1")
 		return nil
@@ -91,6 +116,32 @@ func sigGasConsumer(
 	}
 }
 
+// consumeMultisignatureVerificationGas consumes gas for every signature included in
+// the multisignature, using the cost of the sub-key that produced it.
+func consumeMultisignatureVerificationGas(
+	meter sdk.GasMeter, sig multisig.Multisignature, pubkey multisig.PubKeyMultisigThreshold, params types.Params,
+) error {
+	if sig.BitArray == nil || sig.BitArray.Size() != len(pubkey.PubKeys) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "multisignature bit array does not match the multisig public key")
+	}
+
+	sigIndex := 0
+	for i := 0; i < sig.BitArray.Size(); i++ {
+		if !sig.BitArray.GetIndex(i) {
+			continue
+		}
+		if sigIndex >= len(sig.Sigs) {
+			return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "multisignature has fewer signatures than marked signers")
+		}
+		if err := sigGasConsumer(meter, sig.Sigs[sigIndex], pubkey.PubKeys[i], params); err != nil {
+			return err
+		}
+		sigIndex++
+	}
+
+	return nil
+}
+
 // AccountSetupDecorator sets an account to state if it's not stored already. This only applies for MsgEthermint.
 type AccountSetupDecorator struct {
 	ak auth.AccountKeeper
@@ -167,7 +218,7 @@ func (abvd AccountBlockedVerificationDecorator) AnteHandle(ctx sdk.Context, tx s
 func getSigners(tx sdk.Tx) ([]sdk.AccAddress, error) {
 	signers := make([]sdk.AccAddress, 0)
 	switch tx.(type) {
-	case auth.StdTx:
+	case auth.StdTx, feegranttypes.FeeGrantTx:
 		sigTx, ok := tx.(authante.SigVerifiableTx)
 		if !ok {
 			return signers, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "invalid transaction type")
@@ -11,13 +11,18 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 
 	"github.com/okex/exchain/app"
 	"github.com/okex/exchain/app/ante"
+	"github.com/okex/exchain/app/crypto/secp256r1"
 	"github.com/okex/exchain/app/types"
+	authztypes "github.com/okex/exchain/x/authz/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
+	feegranttypes "github.com/okex/exchain/x/feegrant/types"
 )
 
 func requireValidTx(
@@ -256,7 +261,7 @@ func (suite *AnteTestSuite) TestEthInvalidMempoolFees() {
 	suite.ctx = suite.app.BaseApp.NewContext(true, abci.Header{Height: 1, ChainID: "ethermint-3", Time: time.Now().UTC()})
 	suite.app.EvmKeeper.SetParams(suite.ctx, evmtypes.DefaultParams())
 
-	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.EvmKeeper, suite.app.SupplyKeeper, nil)
+	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.EvmKeeper, suite.app.SupplyKeeper, suite.app.FeeGrantKeeper, suite.app.AuthzKeeper, nil)
 	suite.ctx = suite.ctx.WithMinGasPrices(sdk.NewDecCoins(sdk.NewDecCoinFromDec(types.NativeToken, sdk.NewDecFromBigIntWithPrec(big.NewInt(500000), sdk.Precision))))
 	addr1, priv1 := newTestAddrKey()
 	addr2, _ := newTestAddrKey()
@@ -298,3 +303,260 @@ func (suite *AnteTestSuite) TestEthInvalidChainID() {
 	ctx := suite.ctx.WithChainID("bad-chain-id")
 	requireInvalidTx(suite.T(), suite.anteHandler, ctx, tx, false)
 }
+
+func (suite *AnteTestSuite) TestMultisigTx() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	_, priv1 := newTestAddrKey()
+	_, priv2 := newTestAddrKey()
+	_, priv3 := newTestAddrKey()
+	pubKeys := []tmcrypto.PubKey{priv1.PubKey(), priv2.PubKey(), priv3.PubKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(2, pubKeys)
+	multisigAddr := sdk.AccAddress(multisigKey.Address())
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, multisigAddr)
+	_ = acc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	fee := newTestStdFee()
+	msgs := []sdk.Msg{newTestMsg(multisigAddr)}
+
+	newMultisigTx := func(signers ...tmcrypto.PrivKey) sdk.Tx {
+		signBytes := auth.StdSignBytes(suite.ctx.ChainID(), acc.GetAccountNumber(), acc.GetSequence(), fee, msgs, "")
+		multisignature := multisig.NewMultisig(len(pubKeys))
+		for _, signer := range signers {
+			sig, err := signer.Sign(signBytes)
+			suite.Require().NoError(err)
+			suite.Require().NoError(multisignature.AddSignatureFromPubKey(sig, signer.PubKey(), pubKeys))
+		}
+		sigs := []auth.StdSignature{{PubKey: multisigKey, Signature: multisignature.Marshal()}}
+		return auth.NewStdTx(msgs, fee, sigs, "")
+	}
+
+	// require the threshold to be reached
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, newMultisigTx(priv1), false)
+
+	// gas is charged for every sub-key signature
+	ctx, err := suite.anteHandler(suite.ctx, newMultisigTx(priv1, priv3), false)
+	suite.Require().NoError(err)
+	twoOfThreeGas := ctx.GasMeter().GasConsumed()
+
+	acc = suite.app.AccountKeeper.GetAccount(suite.ctx, multisigAddr)
+	ctx, err = suite.anteHandler(suite.ctx, newMultisigTx(priv1, priv2, priv3), false)
+	suite.Require().NoError(err)
+	suite.Require().True(ctx.GasMeter().GasConsumed() > twoOfThreeGas)
+}
+
+func (suite *AnteTestSuite) TestSecp256r1Tx() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	priv1, err := secp256r1.GenerateKey()
+	suite.Require().NoError(err)
+	addr1 := sdk.AccAddress(priv1.PubKey().Address())
+
+	acc1 := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	_ = acc1.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc1)
+
+	fee := newTestStdFee()
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	tx := newTestSDKTx(suite.ctx, msgs, []tmcrypto.PrivKey{priv1}, []uint64{acc1.GetAccountNumber()}, []uint64{acc1.GetSequence()}, fee)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+}
+
+func (suite *AnteTestSuite) TestFeeGrantTx() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	granter, _ := newTestAddrKey()
+	grantee, priv := newTestAddrKey()
+
+	granterAcc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, granter)
+	_ = granterAcc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, granterAcc)
+
+	granteeAcc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, grantee)
+	suite.app.AccountKeeper.SetAccount(suite.ctx, granteeAcc)
+
+	fee := newTestStdFee()
+	msgs := []sdk.Msg{newTestMsg(grantee)}
+	newFeeGrantTx := func(feeAccount sdk.AccAddress) sdk.Tx {
+		signBytes := feegranttypes.FeeGrantSignBytes(suite.ctx.ChainID(), granteeAcc.GetAccountNumber(),
+			granteeAcc.GetSequence(), fee, feeAccount, msgs, "")
+		sig, err := priv.Sign(signBytes)
+		suite.Require().NoError(err)
+		return feegranttypes.NewFeeGrantTx(msgs, fee, feeAccount,
+			[]auth.StdSignature{{PubKey: priv.PubKey(), Signature: sig}}, "")
+	}
+
+	// require a tx without fee allowance to fail
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, newFeeGrantTx(granter), false)
+
+	// require a tx whose messages aren't allowed to fail
+	suite.app.FeeGrantKeeper.GrantFeeAllowance(suite.ctx, feegranttypes.NewFeeAllowanceGrant(granter, grantee,
+		feegranttypes.NewFeeAllowance(nil, time.Time{}, []string{"token/send"})))
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, newFeeGrantTx(granter), false)
+
+	// require the granter to pay the fee out of the allowance
+	suite.app.FeeGrantKeeper.GrantFeeAllowance(suite.ctx, feegranttypes.NewFeeAllowanceGrant(granter, grantee,
+		feegranttypes.NewFeeAllowance(fee.Amount.Add(fee.Amount...), time.Time{}, nil)))
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, newFeeGrantTx(granter), false)
+
+	balance := suite.app.AccountKeeper.GetAccount(suite.ctx, granter).GetCoins()
+	suite.Require().True(newTestCoins().Sub(fee.Amount).IsEqual(balance))
+	grant, found := suite.app.FeeGrantKeeper.GetFeeAllowance(suite.ctx, granter, grantee)
+	suite.Require().True(found)
+	suite.Require().True(fee.Amount.IsEqual(grant.Allowance.SpendLimit))
+
+	// require a tx signed over another fee account to fail
+	tx := newFeeGrantTx(granter).(feegranttypes.FeeGrantTx)
+	tx.FeeAccount = grantee
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+}
+
+func (suite *AnteTestSuite) TestEthSponsoredContract() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	sponsor, _ := newTestAddrKey()
+	addr1, priv1 := newTestAddrKey()
+	contract, _ := newTestAddrKey()
+
+	sponsorAcc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, sponsor)
+	_ = sponsorAcc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, sponsorAcc)
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	to := ethcmn.BytesToAddress(contract.Bytes())
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &to, big.NewInt(0), 22000, big.NewInt(20), []byte("test"))
+	tx, err := newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NoError(err)
+
+	// require a sender without funds to fail while the contract isn't sponsored
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+
+	// require a tx priced above the max gas price of the sponsor to fail
+	suite.app.FeeGrantKeeper.SetContractSponsorship(suite.ctx, feegranttypes.NewContractSponsorship(sponsor, contract,
+		feegranttypes.NewFeeAllowance(nil, time.Time{}, nil), sdk.NewDecWithPrec(19, sdk.Precision)))
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+
+	suite.app.FeeGrantKeeper.SetContractSponsorship(suite.ctx, feegranttypes.NewContractSponsorship(sponsor, contract,
+		feegranttypes.NewFeeAllowance(nil, time.Time{}, nil), sdk.NewDecWithPrec(20, sdk.Precision)))
+
+	newCtx, err := suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().NoError(err)
+
+	payment, ok := feegranttypes.FeePaymentFromContext(newCtx)
+	suite.Require().True(ok)
+	suite.Require().Equal(sponsor, payment.Granter)
+	suite.Require().Equal(contract, payment.Contract)
+
+	balance := suite.app.AccountKeeper.GetAccount(suite.ctx, sponsor).GetCoins()
+	suite.Require().True(balance.IsAllLT(newTestCoins()))
+}
+
+func (suite *AnteTestSuite) TestAuthzExecTx() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	granter, _ := newTestAddrKey()
+	grantee, priv := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, grantee)
+	_ = acc.SetCoins(newTestCoins())
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	msgs := []sdk.Msg{authztypes.NewMsgExec(grantee, []sdk.Msg{newTestMsg(granter)})}
+	newTx := func() sdk.Tx {
+		acc := suite.app.AccountKeeper.GetAccount(suite.ctx, grantee)
+		return newTestSDKTx(suite.ctx, msgs, []tmcrypto.PrivKey{priv},
+			[]uint64{acc.GetAccountNumber()}, []uint64{acc.GetSequence()}, newTestStdFee())
+	}
+
+	// require a tx executing messages of an account which didn't authorize it to fail
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, newTx(), false)
+
+	suite.app.AuthzKeeper.SaveGrant(suite.ctx, authztypes.NewGrant(granter, grantee,
+		authztypes.NewAuthorization(authztypes.MsgTypeURL(newTestMsg(granter)), time.Time{}, nil, sdk.ZeroDec())))
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, newTx(), false)
+}
+
+func (suite *AnteTestSuite) TestBundleTx() {
+	suite.ctx = suite.ctx.WithBlockHeight(10)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, priv2 := newTestAddrKey()
+	for _, addr := range []sdk.AccAddress{addr1, addr2} {
+		acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr)
+		_ = acc.SetCoins(newTestCoins())
+		suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+	}
+
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	slot, value := ethcmn.BytesToHash([]byte("slot")), ethcmn.BytesToHash([]byte("value"))
+	csdb := evmtypes.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), suite.ctx)
+	csdb.SetState(contract, slot, value)
+	suite.Require().NoError(csdb.Finalise(false))
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	newTx := func(nonce uint64, price int64, priv tmcrypto.PrivKey) evmtypes.MsgEthereumTx {
+		tx, err := newTestEthTx(suite.ctx, evmtypes.NewMsgEthereumTx(nonce, &to, big.NewInt(32), 22000, big.NewInt(price), nil), priv)
+		suite.Require().NoError(err)
+		return tx.(evmtypes.MsgEthereumTx)
+	}
+
+	testCases := []struct {
+		name       string
+		txs        []evmtypes.MsgEthereumTx
+		conditions evmtypes.BundleConditions
+		checkTx    bool
+		expPass    bool
+	}{
+		{"valid bundle", []evmtypes.MsgEthereumTx{newTx(0, 20, priv1), newTx(1, 20, priv1)},
+			evmtypes.BundleConditions{BlockNumberMin: 9, BlockNumberMax: 10}, false, true},
+		{"checked against the next block", []evmtypes.MsgEthereumTx{newTx(0, 20, priv1)},
+			evmtypes.BundleConditions{BlockNumberMin: 11}, true, true},
+		{"empty bundle", nil, evmtypes.BundleConditions{}, false, false},
+		{"nonces not consecutive", []evmtypes.MsgEthereumTx{newTx(0, 20, priv1), newTx(2, 20, priv1)},
+			evmtypes.BundleConditions{}, false, false},
+		{"different gas prices", []evmtypes.MsgEthereumTx{newTx(0, 20, priv1), newTx(1, 30, priv1)},
+			evmtypes.BundleConditions{}, false, false},
+		{"different senders", []evmtypes.MsgEthereumTx{newTx(0, 20, priv1), newTx(1, 20, priv2)},
+			evmtypes.BundleConditions{}, false, false},
+		{"invalid nonce", []evmtypes.MsgEthereumTx{newTx(1, 20, priv1), newTx(2, 20, priv1)},
+			evmtypes.BundleConditions{}, false, false},
+		{"above the block range", []evmtypes.MsgEthereumTx{newTx(0, 20, priv1)},
+			evmtypes.BundleConditions{BlockNumberMax: 9}, false, false},
+		{"below the block range", []evmtypes.MsgEthereumTx{newTx(0, 20, priv1)},
+			evmtypes.BundleConditions{BlockNumberMin: 11}, false, false},
+		{"above the timestamp range", []evmtypes.MsgEthereumTx{newTx(0, 20, priv1)},
+			evmtypes.BundleConditions{TimestampMax: 1}, false, false},
+		{"storage slot matches", []evmtypes.MsgEthereumTx{newTx(0, 20, priv1)},
+			evmtypes.BundleConditions{StorageSlots: []evmtypes.StorageCondition{{Address: contract, Key: slot, Value: value}}}, false, true},
+		{"storage slot mismatches", []evmtypes.MsgEthereumTx{newTx(0, 20, priv1)},
+			evmtypes.BundleConditions{StorageSlots: []evmtypes.StorageCondition{{Address: contract, Key: slot}}}, false, false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			ctx, _ := suite.ctx.WithIsCheckTx(tc.checkTx).CacheContext()
+			bundle := evmtypes.NewBundleTx(tc.txs, tc.conditions)
+			newCtx, err := suite.anteHandler(ctx, bundle, false)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+
+			// the bundle is metered with the sum of the gas limits, and recorded for the evm handler
+			suite.Require().Equal(bundle.GetGas(), newCtx.GasMeter().Limit())
+			suite.Require().Nil(newCtx.SigCache())
+			recorded, ok := evmtypes.BundleFromContext(newCtx)
+			suite.Require().True(ok)
+			suite.Require().Equal(len(tc.txs), len(recorded.Txs))
+			if !tc.checkTx {
+				acc := suite.app.AccountKeeper.GetAccount(newCtx, addr1)
+				suite.Require().Equal(uint64(len(tc.txs)), acc.GetSequence())
+			}
+		})
+	}
+}
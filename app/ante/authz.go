@@ -0,0 +1,40 @@
+package ante
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	authztypes "github.com/okex/exchain/x/authz/types"
+)
+
+// AuthzKeeper defines the expected keeper interface used to check delegated message execution
+type AuthzKeeper interface {
+	ValidateExec(ctx sdk.Context, grantee sdk.AccAddress, msgs []sdk.Msg) error
+}
+
+// AuthzExecDecorator rejects txs executing messages on behalf of accounts which didn't
+// authorize the grantee to do so, before they reach the authz handler
+type AuthzExecDecorator struct {
+	azk AuthzKeeper
+}
+
+// NewAuthzExecDecorator creates a new AuthzExecDecorator instance
+func NewAuthzExecDecorator(azk AuthzKeeper) AuthzExecDecorator {
+	return AuthzExecDecorator{azk: azk}
+}
+
+// AnteHandle checks the grants of every MsgExec of the tx
+func (aed AuthzExecDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	if aed.azk == nil {
+		return next(ctx, tx, simulate)
+	}
+
+	for _, msg := range tx.GetMsgs() {
+		if execMsg, ok := msg.(authztypes.MsgExec); ok {
+			if err := aed.azk.ValidateExec(ctx, execMsg.Grantee, execMsg.Msgs); err != nil {
+				return ctx, err
+			}
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
@@ -0,0 +1,109 @@
+package ante
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/ethereum/go-ethereum/common"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// BundleConditionsDecorator checks the conditions of a bundle against the block it's executed in, so that its txs are
+// only executed while they hold. In the check mode the conditions are checked against the next block, whose
+// timestamp isn't known yet, so its min timestamp is left to the deliver mode.
+type BundleConditionsDecorator struct {
+	evmKeeper EVMKeeper
+}
+
+// NewBundleConditionsDecorator creates a new BundleConditionsDecorator
+func NewBundleConditionsDecorator(evmKeeper EVMKeeper) BundleConditionsDecorator {
+	return BundleConditionsDecorator{
+		evmKeeper: evmKeeper,
+	}
+}
+
+// AnteHandle checks the block number, the timestamp and the storage slots of the conditions of the bundle
+func (bcd BundleConditionsDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	bundle, ok := tx.(evmtypes.BundleTx)
+	if !ok {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
+	}
+
+	conditions := bundle.Conditions
+	height, timestamp := ctx.BlockHeight(), ctx.BlockTime().Unix()
+	if ctx.IsCheckTx() {
+		height++
+		conditions.TimestampMin = 0
+	}
+	getState := func(addr common.Address, key common.Hash) common.Hash {
+		return bcd.evmKeeper.GetState(ctx, addr, key)
+	}
+	if err := conditions.Check(height, timestamp, getState); err != nil {
+		return ctx, err
+	}
+	return next(ctx, tx, simulate)
+}
+
+// BundleTxsDecorator runs the ethereum ante handler of every tx of a bundle in order, which must be sent by a single
+// sender, and sets the gas meter of the bundle with the sum of their gas limits. The fees of the txs of a bundle are
+// never paid by the sponsors of the called contracts.
+// CONTRACT: the nonces of the txs of the bundle are consecutive, which is checked by the validate basic
+type BundleTxsDecorator struct {
+	ak        auth.AccountKeeper
+	sk        types.SupplyKeeper
+	evmKeeper EVMKeeper
+}
+
+// NewBundleTxsDecorator creates a new BundleTxsDecorator
+func NewBundleTxsDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper, ek EVMKeeper) BundleTxsDecorator {
+	return BundleTxsDecorator{
+		ak:        ak,
+		sk:        sk,
+		evmKeeper: ek,
+	}
+}
+
+// AnteHandle runs the ethereum ante handler of the txs of the bundle, and records the bundle in the context for the
+// evm handler. The signature cache of the txs is dropped, as the evm handler verifies the signature of every tx.
+func (btd BundleTxsDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	bundle, ok := tx.(evmtypes.BundleTx)
+	if !ok {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
+	}
+
+	var sender common.Address
+	for i, msg := range bundle.Txs {
+		decorators := []sdk.AnteDecorator{
+			NewGasLimitDecorator(btd.evmKeeper),
+			NewEthMempoolFeeDecorator(btd.evmKeeper),
+			NewEthSigVerificationDecorator(),
+			NewAccountBlockedVerificationDecorator(btd.evmKeeper),
+			NewAccountVerificationDecorator(btd.ak, btd.evmKeeper, nil),
+		}
+		// the sequence of the sender isn't incremented by the check mode of the mempool without recheck, so only the
+		// nonce of the first tx is verified, which the others follow
+		if i == 0 {
+			decorators = append(decorators, NewNonceVerificationDecorator(btd.ak))
+		}
+		decorators = append(decorators,
+			NewEthGasConsumeDecorator(btd.ak, btd.sk, btd.evmKeeper, nil),
+			NewIncrementSenderSequenceDecorator(btd.ak),
+		)
+
+		ctx, err = sdk.ChainAnteDecorators(decorators...)(
+			ctx.WithGasMeter(sdk.NewInfiniteGasMeter()).WithSigCache(nil), msg, simulate)
+		if err != nil {
+			return ctx, sdkerrors.Wrapf(err, "tx %d of the bundle", i)
+		}
+
+		from := ctx.SigCache().GetFrom()
+		if i > 0 && from != sender {
+			return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "the txs of the bundle must be sent by %s", sender.Hex())
+		}
+		sender = from
+	}
+
+	newCtx = auth.SetGasMeter(simulate, ctx, bundle.GetGas()).WithSigCache(nil)
+	return next(evmtypes.ContextWithBundle(newCtx, bundle), tx, simulate)
+}
@@ -16,12 +16,14 @@ import (
 	ethcore "github.com/ethereum/go-ethereum/core"
 	ethermint "github.com/okex/exchain/app/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
+	feegranttypes "github.com/okex/exchain/x/feegrant/types"
 )
 
 // EVMKeeper defines the expected keeper interface used on the Eth AnteHandler
 type EVMKeeper interface {
 	GetParams(ctx sdk.Context) evmtypes.Params
 	IsAddressBlocked(ctx sdk.Context, addr sdk.AccAddress) bool
+	GetState(ctx sdk.Context, addr common.Address, hash common.Hash) common.Hash
 }
 
 // EthSetupContextDecorator sets the infinite GasMeter in the Context and wraps
@@ -165,13 +167,15 @@ func (esvd EthSigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, s
 type AccountVerificationDecorator struct {
 	ak        auth.AccountKeeper
 	evmKeeper EVMKeeper
+	fgk       FeeGrantKeeper
 }
 
 // NewAccountVerificationDecorator creates a new AccountVerificationDecorator
-func NewAccountVerificationDecorator(ak auth.AccountKeeper, ek EVMKeeper) AccountVerificationDecorator {
+func NewAccountVerificationDecorator(ak auth.AccountKeeper, ek EVMKeeper, fgk FeeGrantKeeper) AccountVerificationDecorator {
 	return AccountVerificationDecorator{
 		ak:        ak,
 		evmKeeper: ek,
+		fgk:       fgk,
 	}
 }
 
@@ -208,18 +212,37 @@ func (avd AccountVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, s
 
 	evmDenom := sdk.DefaultBondDenom
 
+	// the gas cost of a call to a sponsored contract is paid by its sponsor,
+	// so the sender only needs to cover the value transferred
+	cost := msgEthTx.Cost()
+	if _, sponsored := sponsoredFeePayer(ctx, avd.fgk, msgEthTx); sponsored {
+		cost = msgEthTx.Data.Amount
+	}
+
 	// validate sender has enough funds to pay for gas cost
 	balance := acc.GetCoins().AmountOf(evmDenom)
-	if balance.BigInt().Cmp(msgEthTx.Cost()) < 0 {
+	if balance.BigInt().Cmp(cost) < 0 {
 		return ctx, sdkerrors.Wrapf(
 			sdkerrors.ErrInsufficientFunds,
-			"sender balance < tx gas cost (%s%s < %s%s)", balance.String(), evmDenom, sdk.NewDecFromBigIntWithPrec(msgEthTx.Cost(), sdk.Precision).String(), evmDenom,
+			"sender balance < tx gas cost (%s%s < %s%s)", balance.String(), evmDenom, sdk.NewDecFromBigIntWithPrec(cost, sdk.Precision).String(), evmDenom,
 		)
 	}
 
 	return next(ctx, tx, simulate)
 }
 
+// sponsoredFeePayer returns the sponsor paying the gas cost of the given tx, if any
+func sponsoredFeePayer(ctx sdk.Context, fgk FeeGrantKeeper, msgEthTx evmtypes.MsgEthereumTx) (sdk.AccAddress, bool) {
+	if fgk == nil || msgEthTx.To() == nil || msgEthTx.Data.GasLimit == 0 {
+		return nil, false
+	}
+
+	evmDenom := sdk.DefaultBondDenom
+	gasPrice := sdk.NewDecFromBigIntWithPrec(msgEthTx.Data.Price, sdk.Precision)
+	fee := sdk.NewCoins(sdk.NewCoin(evmDenom, sdk.NewDecFromBigIntWithPrec(msgEthTx.Fee(), sdk.Precision)))
+	return fgk.GetSponsoredFeePayer(ctx, sdk.AccAddress(msgEthTx.To().Bytes()), gasPrice, fee)
+}
+
 // NonceVerificationDecorator checks that the account nonce from the transaction matches
 // the sender account sequence.
 type NonceVerificationDecorator struct {
@@ -335,14 +358,16 @@ type EthGasConsumeDecorator struct {
 	ak        auth.AccountKeeper
 	sk        types.SupplyKeeper
 	evmKeeper EVMKeeper
+	fgk       FeeGrantKeeper
 }
 
 // NewEthGasConsumeDecorator creates a new EthGasConsumeDecorator
-func NewEthGasConsumeDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper, ek EVMKeeper) EthGasConsumeDecorator {
+func NewEthGasConsumeDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper, ek EVMKeeper, fgk FeeGrantKeeper) EthGasConsumeDecorator {
 	return EthGasConsumeDecorator{
 		ak:        ak,
 		sk:        sk,
 		evmKeeper: ek,
+		fgk:       fgk,
 	}
 }
 
@@ -400,7 +425,22 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 			sdk.NewCoin(evmDenom, sdk.NewDecFromBigIntWithPrec(cost, sdk.Precision)), // int2dec
 		)
 
-		err = auth.DeductFees(egcd.sk, ctx, senderAcc, feeAmt)
+		payerAcc := senderAcc
+		// charge the sponsor of the called contract instead, while its allowance lasts and
+		// the gas price doesn't exceed the max gas price it pays for
+		if msgEthTx.To() != nil && egcd.fgk != nil {
+			contract := sdk.AccAddress(msgEthTx.To().Bytes())
+			gasPrice := sdk.NewDecFromBigIntWithPrec(msgEthTx.Data.Price, sdk.Precision)
+			if payment, ok := egcd.fgk.UseSponsoredFees(ctx, address, contract, gasPrice, feeAmt); ok {
+				payerAcc = egcd.ak.GetAccount(ctx, payment.Granter)
+				if payerAcc == nil {
+					return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "sponsor account %s does not exist", payment.Granter)
+				}
+				ctx = feegranttypes.ContextWithFeePayment(ctx, payment)
+			}
+		}
+
+		err = auth.DeductFees(egcd.sk, ctx, payerAcc, feeAmt)
 		if err != nil {
 			return ctx, err
 		}
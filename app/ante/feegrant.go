@@ -0,0 +1,87 @@
+package ante
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authante "github.com/cosmos/cosmos-sdk/x/auth/ante"
+	"github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	feegranttypes "github.com/okex/exchain/x/feegrant/types"
+)
+
+// FeeGrantKeeper defines the expected keeper interface used to let a third party pay tx fees
+type FeeGrantKeeper interface {
+	UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins, msgs []sdk.Msg) (feegranttypes.FeePayment, error)
+	GetSponsoredFeePayer(ctx sdk.Context, contract sdk.AccAddress, gasPrice sdk.Dec, fee sdk.Coins) (sdk.AccAddress, bool)
+	UseSponsoredFees(ctx sdk.Context, sender, contract sdk.AccAddress, gasPrice sdk.Dec, fee sdk.Coins) (feegranttypes.FeePayment, bool)
+}
+
+// FeeGranterTx defines a tx whose fee may be paid by another account than its fee payer
+type FeeGranterTx interface {
+	FeeGranter() sdk.AccAddress
+}
+
+// DeductGrantedFeeDecorator deducts fees from the first signer of the tx, or from the fee
+// granter of the tx out of the allowance it granted to the first signer.
+// CONTRACT: Tx must implement FeeTx interface to use DeductGrantedFeeDecorator
+type DeductGrantedFeeDecorator struct {
+	ak           auth.AccountKeeper
+	supplyKeeper types.SupplyKeeper
+	fgk          FeeGrantKeeper
+}
+
+// NewDeductGrantedFeeDecorator creates a new DeductGrantedFeeDecorator instance
+func NewDeductGrantedFeeDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper, fgk FeeGrantKeeper) DeductGrantedFeeDecorator {
+	return DeductGrantedFeeDecorator{
+		ak:           ak,
+		supplyKeeper: sk,
+		fgk:          fgk,
+	}
+}
+
+// AnteHandle deducts the fees of the tx and records the fee grant used, if any, in the context.
+func (dgfd DeductGrantedFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	feeTx, ok := tx.(authante.FeeTx)
+	if !ok {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrTxDecode, "Tx must be a FeeTx")
+	}
+
+	if addr := dgfd.supplyKeeper.GetModuleAddress(types.FeeCollectorName); addr == nil {
+		panic(fmt.Sprintf("%s module account has not been set", types.FeeCollectorName))
+	}
+
+	feePayer := feeTx.FeePayer(ctx)
+	deductFeesFrom := feePayer
+
+	if grantTx, ok := tx.(FeeGranterTx); ok && !grantTx.FeeGranter().Empty() {
+		granter := grantTx.FeeGranter()
+		if dgfd.fgk == nil {
+			return ctx, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "fee grants are not enabled")
+		}
+		payment, err := dgfd.fgk.UseGrantedFees(ctx, granter, feePayer, feeTx.GetFee(), tx.GetMsgs())
+		if err != nil {
+			return ctx, sdkerrors.Wrapf(err, "%s not allowed to pay fees for %s", granter, feePayer)
+		}
+
+		deductFeesFrom = granter
+		ctx = feegranttypes.ContextWithFeePayment(ctx, payment)
+	}
+
+	deductFeesFromAcc := dgfd.ak.GetAccount(ctx, deductFeesFrom)
+	if deductFeesFromAcc == nil {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "fee payer address: %s does not exist", deductFeesFrom)
+	}
+
+	// deduct the fees
+	if !feeTx.GetFee().IsZero() {
+		err = auth.DeductFees(dgfd.supplyKeeper, ctx, deductFeesFromAcc, feeTx.GetFee())
+		if err != nil {
+			return ctx, err
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
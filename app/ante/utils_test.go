@@ -16,6 +16,7 @@ import (
 	ante "github.com/okex/exchain/app/ante"
 	appconfig "github.com/okex/exchain/app/config"
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	"github.com/okex/exchain/app/crypto/secp256r1"
 	okexchain "github.com/okex/exchain/app/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 
@@ -23,8 +24,15 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
 )
 
+func init() {
+	// the binaries register the sub-keys of a multisig threshold key on startup
+	multisig.RegisterKeyType(ethsecp256k1.PubKey{}, ethsecp256k1.PubKeyName)
+	multisig.RegisterKeyType(secp256r1.PubKey{}, secp256r1.PubKeyName)
+}
+
 type AnteTestSuite struct {
 	suite.Suite
 
@@ -42,7 +50,7 @@ func (suite *AnteTestSuite) SetupTest() {
 	suite.ctx = suite.app.BaseApp.NewContext(checkTx, abci.Header{Height: 1, ChainID: "okexchain-3", Time: time.Now().UTC()})
 	suite.app.EvmKeeper.SetParams(suite.ctx, evmtypes.DefaultParams())
 
-	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.EvmKeeper, suite.app.SupplyKeeper, nil)
+	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.EvmKeeper, suite.app.SupplyKeeper, suite.app.FeeGrantKeeper, suite.app.AuthzKeeper, nil)
 
 	appconfig.RegisterDynamicConfig(server.NewDefaultContext())
 }
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/okex/exchain/x/analyzer"
 	"io"
-	"math/big"
 	"os"
 	"sync"
 
@@ -21,12 +20,14 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/cosmos/cosmos-sdk/x/upgrade"
+	ethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/okex/exchain/app/ante"
 	okexchaincodec "github.com/okex/exchain/app/codec"
 	appconfig "github.com/okex/exchain/app/config"
 	"github.com/okex/exchain/app/refund"
 	okexchain "github.com/okex/exchain/app/types"
 	"github.com/okex/exchain/x/ammswap"
+	"github.com/okex/exchain/x/authz"
 	"github.com/okex/exchain/x/backend"
 	commonversion "github.com/okex/exchain/x/common/version"
 	"github.com/okex/exchain/x/debug"
@@ -36,9 +37,11 @@ import (
 	"github.com/okex/exchain/x/evidence"
 	"github.com/okex/exchain/x/evm"
 	evmclient "github.com/okex/exchain/x/evm/client"
+	evmprecompile "github.com/okex/exchain/x/evm/precompile"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/farm"
 	farmclient "github.com/okex/exchain/x/farm/client"
+	"github.com/okex/exchain/x/feegrant"
 	"github.com/okex/exchain/x/genutil"
 	"github.com/okex/exchain/x/gov"
 	"github.com/okex/exchain/x/gov/keeper"
@@ -91,6 +94,8 @@ var (
 			dexclient.DelistProposalHandler, farmclient.ManageWhiteListProposalHandler,
 			evmclient.ManageContractDeploymentWhitelistProposalHandler,
 			evmclient.ManageContractBlockedListProposalHandler,
+			evmclient.ManageContractDeploymentFactoryProposalHandler,
+			evmclient.ManageContractMethodBlockedListProposalHandler,
 		),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
@@ -107,6 +112,8 @@ var (
 		debug.AppModuleBasic{},
 		ammswap.AppModuleBasic{},
 		farm.AppModuleBasic{},
+		feegrant.AppModuleBasic{},
+		authz.AppModuleBasic{},
 	)
 
 	// module account permissions
@@ -127,7 +134,7 @@ var (
 		farm.MintFarmingAccount:   {supply.Burner},
 	}
 
-	GlobalGpIndex = GasPriceIndex{}
+	GlobalGpOracle = NewGasPriceOracle()
 
     onceLog sync.Once
 )
@@ -169,6 +176,8 @@ type OKExChainApp struct {
 	OrderKeeper    order.Keeper
 	SwapKeeper     ammswap.Keeper
 	FarmKeeper     farm.Keeper
+	FeeGrantKeeper feegrant.Keeper
+	AuthzKeeper    authz.Keeper
 	BackendKeeper  backend.Keeper
 	StreamKeeper   stream.Keeper
 
@@ -178,7 +187,13 @@ type OKExChainApp struct {
 	// simulation manager
 	sm *module.SimulationManager
 
-	blockGasPrice []*big.Int
+	blockGasPrice []TxGasPrice
+
+	// whether the stores added by the Venus upgrade are mounted
+	venusStoresMounted bool
+
+	// the signature cache of the tx being delivered, from which the backend gets the sender of an evm tx
+	deliverTxSigCache sdk.SigCache
 }
 
 // NewOKExChainApp returns a reference to a new initialized OKExChain application.
@@ -214,7 +229,8 @@ func NewOKExChainApp(
 		supply.StoreKey, mint.StoreKey, distr.StoreKey, slashing.StoreKey,
 		gov.StoreKey, params.StoreKey, upgrade.StoreKey, evidence.StoreKey,
 		evm.StoreKey, token.StoreKey, token.KeyLock, dex.StoreKey, dex.TokenPairStoreKey,
-		order.OrderStoreKey, ammswap.StoreKey, farm.StoreKey,
+		order.OrderStoreKey, ammswap.StoreKey, farm.StoreKey, feegrant.StoreKey,
+		authz.StoreKey,
 	)
 
 	tkeys := sdk.NewTransientStoreKeys(params.TStoreKey)
@@ -279,6 +295,7 @@ func NewOKExChainApp(
 	app.UpgradeKeeper = upgrade.NewKeeper(skipUpgradeHeights, keys[upgrade.StoreKey], app.cdc)
 	app.EvmKeeper = evm.NewKeeper(
 		app.cdc, keys[evm.StoreKey], app.subspaces[evm.ModuleName], &app.AccountKeeper, app.SupplyKeeper, app.BankKeeper)
+	app.EvmKeeper.SetMetrics(evmMetrics)
 
 	app.TokenKeeper = token.NewKeeper(app.BankKeeper, app.subspaces[token.ModuleName], auth.FeeCollectorName, app.SupplyKeeper,
 		keys[token.StoreKey], keys[token.KeyLock],
@@ -297,6 +314,9 @@ func NewOKExChainApp(
 	app.FarmKeeper = farm.NewKeeper(auth.FeeCollectorName, app.SupplyKeeper, app.TokenKeeper, app.SwapKeeper, app.subspaces[farm.StoreKey],
 		app.keys[farm.StoreKey], app.cdc)
 
+	app.FeeGrantKeeper = feegrant.NewKeeper(app.cdc, app.keys[feegrant.StoreKey])
+	app.AuthzKeeper = authz.NewKeeper(app.cdc, app.keys[authz.StoreKey], app.Router())
+
 	app.StreamKeeper = stream.NewKeeper(app.OrderKeeper, app.TokenKeeper, &app.DexKeeper, &app.AccountKeeper, &app.SwapKeeper,
 		&app.FarmKeeper, app.cdc, logger, appConfig, streamMetrics)
 	app.BackendKeeper = backend.NewKeeper(app.OrderKeeper, app.TokenKeeper, &app.DexKeeper, &app.SwapKeeper, &app.FarmKeeper,
@@ -360,6 +380,8 @@ func NewOKExChainApp(
 		order.NewAppModule(commonversion.ProtocolVersionV0, app.OrderKeeper, app.SupplyKeeper),
 		ammswap.NewAppModule(app.SwapKeeper),
 		farm.NewAppModule(app.FarmKeeper),
+		feegrant.NewAppModule(app.FeeGrantKeeper),
+		authz.NewAppModule(app.AuthzKeeper),
 		backend.NewAppModule(app.BackendKeeper),
 		stream.NewAppModule(app.StreamKeeper),
 		params.NewAppModule(app.ParamsKeeper),
@@ -398,11 +420,14 @@ func NewOKExChainApp(
 		auth.ModuleName, distr.ModuleName, staking.ModuleName, bank.ModuleName,
 		slashing.ModuleName, gov.ModuleName, mint.ModuleName, supply.ModuleName,
 		token.ModuleName, dex.ModuleName, order.ModuleName, ammswap.ModuleName, farm.ModuleName,
-		evm.ModuleName, crisis.ModuleName, genutil.ModuleName, params.ModuleName, evidence.ModuleName,
+		feegrant.ModuleName, authz.ModuleName, evm.ModuleName, crisis.ModuleName, genutil.ModuleName, params.ModuleName, evidence.ModuleName,
 	)
 
 	app.mm.RegisterInvariants(&app.CrisisKeeper)
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
+	// the stateful precompiles route the messages sent by the contracts, and are enabled by the evm params after the
+	// upgrade height of the precompiles
+	evmprecompile.Register(app.Router())
 
 	// create the simulation manager and define the order of the modules for deterministic simulations
 	//
@@ -423,15 +448,16 @@ func NewOKExChainApp(
 	app.sm.RegisterStoreDecoders()
 
 	// initialize stores
-	app.MountKVStores(keys)
+	app.mountKVStores(keys)
 	app.MountTransientStores(tkeys)
 
 	// initialize BaseApp
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
-	app.SetAnteHandler(ante.NewAnteHandler(app.AccountKeeper, app.EvmKeeper, app.SupplyKeeper, validateMsgHook(app.OrderKeeper)))
+	app.SetAnteHandler(app.keepDeliverTxSigCache(ante.NewAnteHandler(app.AccountKeeper, app.EvmKeeper, app.SupplyKeeper,
+		app.FeeGrantKeeper, app.AuthzKeeper, validateMsgHook(app.OrderKeeper))))
 	app.SetEndBlocker(app.EndBlocker)
-	app.SetGasRefundHandler(refund.NewGasRefundHandler(app.AccountKeeper, app.SupplyKeeper))
+	app.SetGasRefundHandler(refund.NewGasRefundHandler(app.AccountKeeper, app.SupplyKeeper, app.FeeGrantKeeper))
 	app.SetAccHandler(NewAccHandler(app.AccountKeeper))
 
 	if loadLatest {
@@ -462,6 +488,7 @@ func NewOKExChainApp(
 }
 
 func (app *OKExChainApp) LoadStartVersion(height int64) error {
+	app.mountVenusStores(height)
 	return app.LoadVersion(height, app.keys[bam.MainStoreKey])
 }
 
@@ -470,13 +497,18 @@ func (app *OKExChainApp) Name() string { return app.BaseApp.Name() }
 
 // BeginBlocker updates every begin block
 func (app *OKExChainApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	app.checkVenusStores(ctx)
 	return app.mm.BeginBlock(ctx, req)
 }
 
 // EndBlocker updates every end block
 func (app *OKExChainApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 	if appconfig.GetOecConfig().GetEnableDynamicGp() {
-		GlobalGpIndex = CalBlockGasPriceIndex(app.blockGasPrice, appconfig.GetOecConfig().GetDynamicGpWeight())
+		var maxGas int64
+		if params := ctx.ConsensusParams(); params != nil && params.Block != nil {
+			maxGas = params.Block.MaxGas
+		}
+		GlobalGpOracle.AddBlock(ctx.BlockHeight(), app.blockGasPrice, maxGas, appconfig.GetOecConfig().GetDynamicGpBlocks())
 		app.blockGasPrice = app.blockGasPrice[:0]
 	}
 
@@ -484,17 +516,43 @@ func (app *OKExChainApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) a
 }
 
 
-func (app *OKExChainApp) syncTx(txBytes []byte) {
+// keepDeliverTxSigCache keeps the signature cache derived by the ante handler for the tx being delivered
+func (app *OKExChainApp) keepDeliverTxSigCache(anteHandler sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		newCtx, err := anteHandler(ctx, tx, simulate)
+		if !ctx.IsCheckTx() && !simulate {
+			app.deliverTxSigCache = newCtx.SigCache()
+		}
+		return newCtx, err
+	}
+}
+
+// syncTx syncs a delivered tx to the backend and the stream. A failed evm tx is recorded with its status, while the
+// other failed txs are skipped. The evm txs of a bundle aren't synced, as their results are joined in the response.
+func (app *OKExChainApp) syncTx(txBytes []byte, res abci.ResponseDeliverTx) {
 
-	if tx, err := auth.DefaultTxDecoder(app.Codec())(txBytes); err == nil {
-		if stdTx, ok := tx.(auth.StdTx); ok {
+	if tx, err := evm.TxDecoder(app.Codec())(txBytes); err == nil {
+		if grantTx, ok := tx.(feegrant.FeeGrantTx); ok {
+			tx = auth.NewStdTx(grantTx.Msgs, grantTx.Fee, grantTx.Signatures, grantTx.Memo)
+		}
+		switch tx := tx.(type) {
+		case auth.StdTx:
+			if !res.IsOK() {
+				return
+			}
 			txHash := fmt.Sprintf("%X", tmhash.Sum(txBytes))
 			app.Logger().Debug(fmt.Sprintf("[Sync Tx(%s) to backend module]", txHash))
 			ctx := app.GetDeliverStateCtx()
-			app.BackendKeeper.SyncTx(ctx, &stdTx, txHash,
+			app.BackendKeeper.SyncTx(ctx, &tx, txHash,
 				ctx.BlockHeader().Time.Unix())
-			app.StreamKeeper.SyncTx(ctx, &stdTx, txHash,
+			app.StreamKeeper.SyncTx(ctx, &tx, txHash,
 				ctx.BlockHeader().Time.Unix())
+		case evmtypes.MsgEthereumTx:
+			txHash := ethcmn.BytesToHash(tmhash.Sum(txBytes)).Hex()
+			app.Logger().Debug(fmt.Sprintf("[Sync Evm Tx(%s) to backend module]", txHash))
+			ctx := app.GetDeliverStateCtx()
+			app.BackendKeeper.SyncEvmTx(ctx, &tx, txHash,
+				ctx.BlockHeader().Time.Unix(), res, app.deliverTxSigCache)
 		}
 	}
 }
@@ -511,6 +569,7 @@ func (app *OKExChainApp) InitChainer(ctx sdk.Context, req abci.RequestInitChain)
 
 // LoadHeight loads state at a particular height
 func (app *OKExChainApp) LoadHeight(height int64) error {
+	app.mountVenusStores(height)
 	return app.LoadVersion(height, app.keys[bam.MainStoreKey])
 }
 
@@ -580,6 +639,16 @@ func validateMsgHook(orderKeeper order.Keeper) ante.ValidateMsgHandler {
 					return wrongMsgErr
 				}
 				err = order.ValidateMsgCancelOrders(newCtx, orderKeeper, assertedMsg)
+			case authz.MsgExec:
+				// the executed messages are validated as if they were sent by their signers
+				if len(msgs) > 1 {
+					for _, execMsg := range assertedMsg.Msgs {
+						if execMsg.Route() == order.RouterKey {
+							return wrongMsgErr
+						}
+					}
+				}
+				err = validateMsgHook(orderKeeper)(newCtx, assertedMsg.Msgs)
 			case evmtypes.MsgEthereumTx:
 				if len(msgs) > 1 {
 					return wrongMsgErr
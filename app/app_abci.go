@@ -24,13 +24,19 @@ func (app *OKExChainApp) DeliverTx(req abci.RequestDeliverTx) (res abci.Response
 	analyzer.OnAppDeliverTxEnter()
 	defer analyzer.OnAppDeliverTxExit()
 
+	// the ante handler isn't run for a tx failed to decode
+	app.deliverTxSigCache = nil
 	resp := app.BaseApp.DeliverTx(req)
 
+	if app.BackendKeeper.Config.EnableBackend || app.StreamKeeper.AnalysisEnable() {
+		app.syncTx(req.Tx, resp)
+	}
+
 	if appconfig.GetOecConfig().GetEnableDynamicGp() {
 		tx, err := evm.TxDecoder(app.Codec())(req.Tx)
 		if err == nil {
 			//optimize get tx gas price can not get value from verifySign method
-			app.blockGasPrice = append(app.blockGasPrice, tx.GetGasPrice())
+			app.blockGasPrice = append(app.blockGasPrice, TxGasPrice{Price: tx.GetGasPrice(), GasUsed: uint64(resp.GasUsed)})
 		}
 	}
 
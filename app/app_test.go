@@ -17,6 +17,9 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 func TestOKExChainAppExport(t *testing.T) {
@@ -59,7 +62,6 @@ func TestProposalManager(t *testing.T) {
 	db := dbm.NewMemDB()
 	app := NewOKExChainApp(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), db, nil, true, map[int64]bool{}, 0)
 
-
 	require.True(t, app.GovKeeper.Router().HasRoute(params.RouterKey))
 	require.True(t, app.GovKeeper.Router().HasRoute(dex.RouterKey))
 	require.True(t, app.GovKeeper.Router().HasRoute(distr.RouterKey))
@@ -69,3 +71,28 @@ func TestProposalManager(t *testing.T) {
 	require.True(t, app.GovKeeper.ProposalHandleRouter().HasRoute(dex.RouterKey))
 	require.True(t, app.GovKeeper.ProposalHandleRouter().HasRoute(farm.RouterKey))
 }
+
+type testSigCache struct{ from ethcmn.Address }
+
+func (c testSigCache) GetFrom() ethcmn.Address                { return c.from }
+func (c testSigCache) GetSigner() ethtypes.Signer             { return ethtypes.HomesteadSigner{} }
+func (c testSigCache) EqualSiger(signer ethtypes.Signer) bool { return signer.Equal(c.GetSigner()) }
+
+func TestKeepDeliverTxSigCache(t *testing.T) {
+	app := NewOKExChainApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, 0)
+	sigCache := testSigCache{from: ethcmn.HexToAddress("0x01")}
+	anteHandler := app.keepDeliverTxSigCache(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		return ctx.WithSigCache(sigCache), nil
+	})
+
+	// only the cache of a delivered tx is kept
+	_, err := anteHandler(sdk.NewContext(nil, abci.Header{}, true, app.Logger()), nil, false)
+	require.NoError(t, err)
+	require.Nil(t, app.deliverTxSigCache)
+	_, err = anteHandler(sdk.NewContext(nil, abci.Header{}, false, app.Logger()), nil, true)
+	require.NoError(t, err)
+	require.Nil(t, app.deliverTxSigCache)
+	_, err = anteHandler(sdk.NewContext(nil, abci.Header{}, false, app.Logger()), nil, false)
+	require.NoError(t, err)
+	require.Equal(t, sigCache, app.deliverTxSigCache)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/vesting"
 
 	cryptocodec "github.com/okex/exchain/app/crypto/ethsecp256k1"
+	"github.com/okex/exchain/app/crypto/secp256r1"
 	ethermint "github.com/okex/exchain/app/types"
 )
 
@@ -23,6 +24,7 @@ func MakeCodec(bm module.BasicManager) *codec.Codec {
 	vesting.RegisterCodec(cdc)
 	sdk.RegisterCodec(cdc)
 	cryptocodec.RegisterCodec(cdc)
+	secp256r1.RegisterCodec(cdc)
 	codec.RegisterCrypto(cdc)
 	ethermint.RegisterCodec(cdc)
 	keys.RegisterCodec(cdc) // temporary. Used to register keyring.Info
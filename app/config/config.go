@@ -25,6 +25,8 @@ type OecConfig struct {
 	enableDynamicGp bool
 	// dynamic-gp-weight
 	dynamicGpWeight int
+	// dynamic-gp-blocks
+	dynamicGpBlocks int
 }
 
 const (
@@ -36,6 +38,7 @@ const (
 	FlagGasLimitBuffer         = "gas-limit-buffer"
 	FlagEnableDynamicGp        = "enable-dynamic-gp"
 	FlagDynamicGpWeight        = "dynamic-gp-weight"
+	FlagDynamicGpBlocks        = "dynamic-gp-blocks"
 )
 
 var oecConfig *OecConfig
@@ -78,6 +81,7 @@ func (c *OecConfig) loadFromConfig() {
 	c.SetGasLimitBuffer(viper.GetUint64(FlagGasLimitBuffer))
 	c.SetEnableDynamicGp(viper.GetBool(FlagEnableDynamicGp))
 	c.SetDynamicGpWeight(viper.GetInt(FlagDynamicGpWeight))
+	c.SetDynamicGpBlocks(viper.GetInt(FlagDynamicGpBlocks))
 }
 
 func (c *OecConfig) loadFromApollo() bool {
@@ -124,6 +128,12 @@ func (c *OecConfig) update(key, value interface{}) {
 			return
 		}
 		c.SetDynamicGpWeight(r)
+	case FlagDynamicGpBlocks:
+		r, err := strconv.Atoi(v)
+		if err != nil {
+			return
+		}
+		c.SetDynamicGpBlocks(r)
 	}
 }
 
@@ -182,3 +192,13 @@ func (c *OecConfig) SetDynamicGpWeight(value int) {
 	}
 	c.dynamicGpWeight = value
 }
+
+func (c *OecConfig) GetDynamicGpBlocks() int {
+	return c.dynamicGpBlocks
+}
+func (c *OecConfig) SetDynamicGpBlocks(value int) {
+	if value <= 0 {
+		value = 1
+	}
+	c.dynamicGpBlocks = value
+}
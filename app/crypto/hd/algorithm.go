@@ -15,17 +15,24 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/keys"
 
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	"github.com/okex/exchain/app/crypto/secp256r1"
 )
 
 const (
 	// EthSecp256k1 defines the ECDSA secp256k1 used on Ethereum
 	EthSecp256k1 = keys.SigningAlgo(ethsecp256k1.KeyType)
+	// Secp256r1 defines the ECDSA NIST P-256 curve
+	Secp256r1 = keys.SigningAlgo(secp256r1.KeyType)
 )
 
 // SupportedAlgorithms defines the list of signing algorithms used on Ethermint:
 //  - eth_secp256k1 (Ethereum)
 //  - secp256k1 (Tendermint)
-var SupportedAlgorithms = []keys.SigningAlgo{EthSecp256k1, keys.Secp256k1}
+//  - secp256r1 (NIST P-256)
+var SupportedAlgorithms = []keys.SigningAlgo{EthSecp256k1, keys.Secp256k1, Secp256r1}
+
+// SupportedAlgorithmsLedger defines the list of signing algorithms supported by Ledger devices
+var SupportedAlgorithmsLedger = []keys.SigningAlgo{EthSecp256k1, keys.Secp256k1}
 
 // EthSecp256k1Options defines a keys options for the ethereum Secp256k1 curve.
 func EthSecp256k1Options() []keys.KeybaseOption {
@@ -33,7 +40,7 @@ func EthSecp256k1Options() []keys.KeybaseOption {
 		keys.WithKeygenFunc(EthermintKeygenFunc),
 		keys.WithDeriveFunc(DeriveKey),
 		keys.WithSupportedAlgos(SupportedAlgorithms),
-		keys.WithSupportedAlgosLedger(SupportedAlgorithms),
+		keys.WithSupportedAlgosLedger(SupportedAlgorithmsLedger),
 	}
 }
 
@@ -41,6 +48,9 @@ func DeriveKey(mnemonic, bip39Passphrase, hdPath string, algo keys.SigningAlgo)
 	switch algo {
 	case keys.Secp256k1:
 		return keys.StdDeriveKey(mnemonic, bip39Passphrase, hdPath, algo)
+	case Secp256r1:
+		// the BIP32 derived scalar is used as the P-256 private key
+		return keys.StdDeriveKey(mnemonic, bip39Passphrase, hdPath, keys.Secp256k1)
 	case EthSecp256k1:
 		return DeriveSecp256k1(mnemonic, bip39Passphrase, hdPath)
 	default:
@@ -55,6 +65,12 @@ func EthermintKeygenFunc(bz []byte, algo keys.SigningAlgo) (tmcrypto.PrivKey, er
 		return keys.StdPrivKeyGen(bz, algo)
 	case EthSecp256k1:
 		return ethsecp256k1.PrivKey(bz), nil
+	case Secp256r1:
+		privKey, err := secp256r1.NewPrivKey(bz)
+		if err != nil {
+			return nil, err
+		}
+		return privKey, nil
 	default:
 		return nil, errors.Wrap(keys.ErrUnsupportedSigningAlgo, string(algo))
 	}
@@ -16,12 +16,15 @@ import (
 	"github.com/cosmos/cosmos-sdk/tests"
 
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	"github.com/okex/exchain/app/crypto/secp256r1"
 	ethermint "github.com/okex/exchain/app/types"
 )
 
 func TestEthermintKeygenFunc(t *testing.T) {
 	privkey, err := ethsecp256k1.GenerateKey()
 	require.NoError(t, err)
+	r1PrivKey, err := secp256r1.GenerateKey()
+	require.NoError(t, err)
 
 	testCases := []struct {
 		name    string
@@ -47,6 +50,18 @@ func TestEthermintKeygenFunc(t *testing.T) {
 			EthSecp256k1,
 			true,
 		},
+		{
+			"valid secp256r1 privKey",
+			r1PrivKey,
+			Secp256r1,
+			true,
+		},
+		{
+			"invalid secp256r1 scalar",
+			make([]byte, secp256r1.PrivKeySize),
+			Secp256r1,
+			false,
+		},
 		{
 			"invalid algo",
 			nil,
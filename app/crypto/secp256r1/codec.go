@@ -0,0 +1,22 @@
+package secp256r1
+
+import (
+	cryptoamino "github.com/tendermint/tendermint/crypto/encoding/amino"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// CryptoCodec is the amino codec used to encode secp256r1 keys
+var CryptoCodec = codec.New()
+
+func init() {
+	cryptoamino.RegisterAmino(CryptoCodec)
+	RegisterCodec(CryptoCodec)
+}
+
+// RegisterCodec registers all the necessary types with amino for the given
+// codec.
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(PubKey{}, PubKeyName, nil)
+	cdc.RegisterConcrete(PrivKey{}, PrivKeyName, nil)
+}
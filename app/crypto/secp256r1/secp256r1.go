@@ -0,0 +1,187 @@
+package secp256r1
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+const (
+	// PrivKeySize defines the size of the PrivKey bytes
+	PrivKeySize = 32
+	// PubKeySize defines the size of the compressed PubKey bytes
+	PubKeySize = 33
+	// SignatureSize defines the size of a [R || S] signature
+	SignatureSize = 64
+	// KeyType is the string constant for the Secp256r1 algorithm
+	KeyType = "secp256r1"
+)
+
+// Amino encoding names
+const (
+	// PrivKeyName defines the amino encoding name for the Secp256r1 private key
+	PrivKeyName = "okexchain/PrivKeySecp256r1"
+	// PubKeyName defines the amino encoding name for the Secp256r1 public key
+	PubKeyName = "okexchain/PubKeySecp256r1"
+)
+
+var (
+	curve     = elliptic.P256()
+	halfOrder = new(big.Int).Rsh(curve.Params().N, 1)
+)
+
+// ----------------------------------------------------------------------------
+// secp256r1 Private Key
+
+var _ tmcrypto.PrivKey = PrivKey{}
+
+// PrivKey defines a NIST P-256 private key scalar that implements Tendermint's
+// PrivateKey interface.
+type PrivKey []byte
+
+// GenerateKey generates a new random private key. It returns an error upon
+// failure.
+func GenerateKey() (PrivKey, error) {
+	priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return PrivKey{}, err
+	}
+
+	return PrivKey(paddedBytes(priv.D, PrivKeySize)), nil
+}
+
+// NewPrivKey returns a private key from the given scalar bytes. It returns an
+// error if the scalar is not a valid P-256 private key.
+func NewPrivKey(bz []byte) (PrivKey, error) {
+	if len(bz) != PrivKeySize {
+		return PrivKey{}, errors.New("invalid secp256r1 private key length")
+	}
+
+	d := new(big.Int).SetBytes(bz)
+	if d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+		return PrivKey{}, errors.New("invalid secp256r1 private key scalar")
+	}
+
+	return PrivKey(bz), nil
+}
+
+// PubKey returns the ECDSA private key's public key.
+func (privkey PrivKey) PubKey() tmcrypto.PubKey {
+	ecdsaPKey := privkey.ToECDSA()
+	return PubKey(elliptic.MarshalCompressed(curve, ecdsaPKey.X, ecdsaPKey.Y))
+}
+
+// Bytes returns the amino encoded private key bytes.
+func (privkey PrivKey) Bytes() []byte {
+	return CryptoCodec.MustMarshalBinaryBare(privkey)
+}
+
+// Sign creates an ECDSA signature on the P-256 curve over the SHA256 hash of
+// the provided message. The produced signature is 64 bytes in [R || S] format
+// with S normalized to the lower half of the curve order.
+func (privkey PrivKey) Sign(msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	r, s, err := ecdsa.Sign(rand.Reader, privkey.ToECDSA(), digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Cmp(halfOrder) > 0 {
+		s = new(big.Int).Sub(curve.Params().N, s)
+	}
+
+	return append(paddedBytes(r, 32), paddedBytes(s, 32)...), nil
+}
+
+// Equals returns true if two ECDSA private keys are equal and false otherwise.
+func (privkey PrivKey) Equals(other tmcrypto.PrivKey) bool {
+	if other, ok := other.(PrivKey); ok {
+		return bytes.Equal(privkey.Bytes(), other.Bytes())
+	}
+
+	return false
+}
+
+// ToECDSA returns the ECDSA private key as a reference to ecdsa.PrivateKey type.
+func (privkey PrivKey) ToECDSA() *ecdsa.PrivateKey {
+	priv := new(ecdsa.PrivateKey)
+	priv.Curve = curve
+	priv.D = new(big.Int).SetBytes(privkey)
+	priv.X, priv.Y = curve.ScalarBaseMult(privkey)
+	return priv
+}
+
+// ----------------------------------------------------------------------------
+// secp256r1 Public Key
+
+var _ tmcrypto.PubKey = (*PubKey)(nil)
+
+// PubKey defines a NIST P-256 public key that implements Tendermint's PubKey
+// interface. It represents the 33-byte compressed public key format.
+type PubKey []byte
+
+// Address returns the address of the public key, which is the truncated
+// SHA256 hash of the compressed key bytes.
+func (key PubKey) Address() tmcrypto.Address {
+	return tmcrypto.Address(tmhash.SumTruncated(key))
+}
+
+// Bytes returns the amino encoded public key bytes.
+// The function panics if the key cannot be marshaled to bytes.
+func (key PubKey) Bytes() []byte {
+	bz, err := CryptoCodec.MarshalBinaryBare(key)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// VerifyBytes verifies that the ECDSA public key created a given signature over
+// the provided message. Signatures with a high S value are rejected to prevent
+// malleability.
+func (key PubKey) VerifyBytes(msg []byte, sig []byte) bool {
+	if len(key) != PubKeySize || len(sig) != SignatureSize {
+		return false
+	}
+
+	x, y := elliptic.UnmarshalCompressed(curve, key)
+	if x == nil {
+		return false
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if s.Cmp(halfOrder) > 0 {
+		return false
+	}
+
+	digest := sha256.Sum256(msg)
+	return ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, digest[:], r, s)
+}
+
+// Equals returns true if two ECDSA public keys are equal and false otherwise.
+func (key PubKey) Equals(other tmcrypto.PubKey) bool {
+	if other, ok := other.(PubKey); ok {
+		return bytes.Equal(key.Bytes(), other.Bytes())
+	}
+
+	return false
+}
+
+func paddedBytes(n *big.Int, size int) []byte {
+	bz := n.Bytes()
+	if len(bz) >= size {
+		return bz
+	}
+
+	padded := make([]byte, size)
+	copy(padded[size-len(bz):], bz)
+	return padded
+}
@@ -0,0 +1,76 @@
+package secp256r1
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+)
+
+func TestPrivKeyPrivKey(t *testing.T) {
+	// validate type and equality
+	privKey, err := GenerateKey()
+	require.NoError(t, err)
+	require.True(t, privKey.Equals(privKey))
+	require.Implements(t, (*tmcrypto.PrivKey)(nil), privKey)
+
+	// validate inequality
+	privKey2, err := GenerateKey()
+	require.NoError(t, err)
+	require.False(t, privKey.Equals(privKey2))
+
+	// validate scalar checks
+	_, err = NewPrivKey(privKey)
+	require.NoError(t, err)
+	_, err = NewPrivKey(make([]byte, PrivKeySize))
+	require.Error(t, err)
+	_, err = NewPrivKey(curve.Params().N.Bytes())
+	require.Error(t, err)
+
+	// validate we can sign some bytes
+	sig, err := privKey.Sign([]byte("hello world"))
+	require.NoError(t, err)
+	require.Len(t, sig, SignatureSize)
+}
+
+func TestPrivKeyPubKey(t *testing.T) {
+	privKey, err := GenerateKey()
+	require.NoError(t, err)
+
+	// validate type and equality
+	pubKey := privKey.PubKey().(PubKey)
+	require.Implements(t, (*tmcrypto.PubKey)(nil), pubKey)
+	require.Len(t, pubKey, PubKeySize)
+	require.Len(t, pubKey.Address(), 20)
+
+	// validate inequality
+	privKey2, err := GenerateKey()
+	require.NoError(t, err)
+	require.False(t, pubKey.Equals(privKey2.PubKey()))
+
+	// validate signature
+	msg := []byte("hello world")
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifyBytes(msg, sig))
+	require.False(t, pubKey.VerifyBytes([]byte("hello world!"), sig))
+	require.False(t, privKey2.PubKey().VerifyBytes(msg, sig))
+
+	// a malleated high-S signature must be rejected
+	s := new(big.Int).SetBytes(sig[32:])
+	highS := new(big.Int).Sub(curve.Params().N, s)
+	malleated := append(append([]byte{}, sig[:32]...), paddedBytes(highS, 32)...)
+	require.False(t, pubKey.VerifyBytes(msg, malleated))
+}
+
+func TestCodec(t *testing.T) {
+	privKey, err := GenerateKey()
+	require.NoError(t, err)
+
+	var pubKey tmcrypto.PubKey
+	bz := privKey.PubKey().Bytes()
+	require.NoError(t, CryptoCodec.UnmarshalBinaryBare(bz, &pubKey))
+	require.True(t, pubKey.Equals(privKey.PubKey()))
+}
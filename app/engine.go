@@ -8,4 +8,5 @@ var (
 	// init monitor prometheus metrics
 	orderMetrics  = monitor.DefaultOrderMetrics(monitor.DefaultPrometheusConfig())
 	streamMetrics = monitor.DefaultStreamMetrics(monitor.DefaultPrometheusConfig())
+	evmMetrics    = monitor.DefaultEvmMetrics(monitor.DefaultPrometheusConfig())
 )
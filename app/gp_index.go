@@ -1,9 +1,11 @@
 package app
 
 import (
+	"errors"
 	"math"
 	"math/big"
 	"sort"
+	"sync"
 )
 
 type GasPriceIndex struct {
@@ -28,4 +30,177 @@ func CalBlockGasPriceIndex(blockGasPrice []*big.Int, weight int) GasPriceIndex {
 	return GasPriceIndex{
 		RecommendGp: blockGasPrice[idx],
 	}
-}
\ No newline at end of file
+}
+
+// TxGasPrice is the gas price and the gas used of a tx
+type TxGasPrice struct {
+	Price   *big.Int
+	GasUsed uint64
+}
+
+// BlockGasStats is the gas prices and the utilization of a block
+type BlockGasStats struct {
+	Height       int64
+	GasUsedRatio float64
+	// sorted by the price in ascending order
+	TxGasPrices []TxGasPrice
+}
+
+// FeeHistory is the reward percentiles and the utilization of the successive blocks from the oldest one
+type FeeHistory struct {
+	OldestBlock  int64
+	Reward       [][]*big.Int
+	GasUsedRatio []float64
+}
+
+// GasPriceOracle keeps the gas prices and the utilization of the latest blocks, and recommends the gas price from
+// them, so that the recommendation doesn't swing between a busy block and an empty one
+type GasPriceOracle struct {
+	mu     sync.RWMutex
+	blocks []BlockGasStats
+
+	// the recommendation is cached until a block is added or the arguments change
+	cacheHeight   int64
+	cacheWeight   int
+	cacheMinPrice *big.Int
+	cachePrice    *big.Int
+}
+
+// NewGasPriceOracle creates a new GasPriceOracle
+func NewGasPriceOracle() *GasPriceOracle {
+	return &GasPriceOracle{}
+}
+
+// AddBlock adds the gas prices of the txs of the block, and drops the blocks out of the window
+func (o *GasPriceOracle) AddBlock(height int64, txGasPrices []TxGasPrice, maxGas int64, window int) {
+	stats := BlockGasStats{
+		Height:      height,
+		TxGasPrices: append([]TxGasPrice{}, txGasPrices...),
+	}
+	sort.SliceStable(stats.TxGasPrices, func(i, j int) bool {
+		return stats.TxGasPrices[i].Price.Cmp(stats.TxGasPrices[j].Price) < 0
+	})
+	var gasUsed uint64
+	for _, tx := range txGasPrices {
+		gasUsed += tx.GasUsed
+	}
+	if maxGas > 0 {
+		stats.GasUsedRatio = float64(gasUsed) / float64(maxGas)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.blocks = append(o.blocks, stats)
+	if window < 1 {
+		window = 1
+	}
+	if len(o.blocks) > window {
+		o.blocks = append([]BlockGasStats{}, o.blocks[len(o.blocks)-window:]...)
+	}
+}
+
+// RecommendGp returns the median of the weighted percentiles of the gas prices of the blocks in the window. The gas
+// prices below the minimum are ignored, and nil is returned if there's no gas price in the window.
+func (o *GasPriceOracle) RecommendGp(weight int, minPrice *big.Int) *big.Int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.blocks) == 0 {
+		return nil
+	}
+
+	height := o.blocks[len(o.blocks)-1].Height
+	if o.cacheMinPrice != nil && o.cacheHeight == height && o.cacheWeight == weight && o.cacheMinPrice.Cmp(minPrice) == 0 {
+		return o.cachePrice
+	}
+
+	var samples []*big.Int
+	for _, block := range o.blocks {
+		var prices []*big.Int
+		for _, tx := range block.TxGasPrices {
+			if tx.Price.Cmp(minPrice) >= 0 {
+				prices = append(prices, tx.Price)
+			}
+		}
+		if index := CalBlockGasPriceIndex(prices, weight); index.RecommendGp != nil {
+			samples = append(samples, index.RecommendGp)
+		}
+	}
+
+	var price *big.Int
+	if len(samples) != 0 {
+		sort.Slice(samples, func(i, j int) bool {
+			return samples[i].Cmp(samples[j]) < 0
+		})
+		price = new(big.Int).Set(samples[(len(samples)-1)/2])
+	}
+
+	o.cacheHeight, o.cacheWeight, o.cacheMinPrice, o.cachePrice = height, weight, new(big.Int).Set(minPrice), price
+	return price
+}
+
+// FeeHistory returns the gas price percentiles weighted by the gas used and the utilization of at most blockCount
+// blocks up to the last height in the window
+func (o *GasPriceOracle) FeeHistory(blockCount int, lastHeight int64, percentiles []float64) (*FeeHistory, error) {
+	for i, p := range percentiles {
+		if p < 0 || p > 100 || (i > 0 && p < percentiles[i-1]) {
+			return nil, errors.New("invalid reward percentiles")
+		}
+	}
+
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if len(o.blocks) == 0 {
+		return nil, errors.New("no block in the fee history window")
+	}
+	oldest, newest := o.blocks[0].Height, o.blocks[len(o.blocks)-1].Height
+	if lastHeight > newest {
+		lastHeight = newest
+	}
+	if lastHeight < oldest {
+		return nil, errors.New("block out of the fee history window")
+	}
+
+	end := sort.Search(len(o.blocks), func(i int) bool { return o.blocks[i].Height > lastHeight })
+	start := end - blockCount
+	if start < 0 {
+		start = 0
+	}
+
+	history := &FeeHistory{OldestBlock: lastHeight}
+	if start < end {
+		history.OldestBlock = o.blocks[start].Height
+	}
+	for _, block := range o.blocks[start:end] {
+		history.GasUsedRatio = append(history.GasUsedRatio, block.GasUsedRatio)
+		if len(percentiles) != 0 {
+			history.Reward = append(history.Reward, block.rewards(percentiles))
+		}
+	}
+	return history, nil
+}
+
+// rewards returns the gas prices at the percentiles of the gas used of the block
+func (b BlockGasStats) rewards(percentiles []float64) []*big.Int {
+	rewards := make([]*big.Int, len(percentiles))
+	if len(b.TxGasPrices) == 0 {
+		for i := range rewards {
+			rewards[i] = new(big.Int)
+		}
+		return rewards
+	}
+
+	var gasUsed uint64
+	for _, tx := range b.TxGasPrices {
+		gasUsed += tx.GasUsed
+	}
+	idx, sum := 0, b.TxGasPrices[0].GasUsed
+	for i, p := range percentiles {
+		threshold := uint64(float64(gasUsed) * p / 100)
+		for sum < threshold && idx < len(b.TxGasPrices)-1 {
+			idx++
+			sum += b.TxGasPrices[idx].GasUsed
+		}
+		rewards[i] = b.TxGasPrices[idx].Price
+	}
+	return rewards
+}
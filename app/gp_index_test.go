@@ -0,0 +1,59 @@
+package app
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func txGasPrices(gasUsed uint64, prices ...int64) []TxGasPrice {
+	txs := make([]TxGasPrice, len(prices))
+	for i, price := range prices {
+		txs[i] = TxGasPrice{Price: big.NewInt(price), GasUsed: gasUsed}
+	}
+	return txs
+}
+
+func TestGasPriceOracle(t *testing.T) {
+	oracle := NewGasPriceOracle()
+	require.Nil(t, oracle.RecommendGp(80, big.NewInt(1)))
+
+	oracle.AddBlock(1, txGasPrices(100, 30, 10, 20), 1000, 3)
+	oracle.AddBlock(2, nil, 1000, 3)
+	oracle.AddBlock(3, txGasPrices(100, 100, 1), 1000, 3)
+	// the empty block doesn't drag the recommendation down, and the price below the minimum is ignored
+	require.Equal(t, big.NewInt(20), oracle.RecommendGp(80, big.NewInt(5)))
+	require.Equal(t, big.NewInt(20), oracle.RecommendGp(80, big.NewInt(5)))
+
+	// the oldest block drops out of the window
+	oracle.AddBlock(4, txGasPrices(100, 200), 1000, 3)
+	require.Equal(t, big.NewInt(100), oracle.RecommendGp(80, big.NewInt(5)))
+	require.Nil(t, oracle.RecommendGp(80, big.NewInt(1000)))
+}
+
+func TestGasPriceOracleFeeHistory(t *testing.T) {
+	oracle := NewGasPriceOracle()
+	_, err := oracle.FeeHistory(1, 1, nil)
+	require.Error(t, err)
+
+	oracle.AddBlock(5, append(txGasPrices(100, 10), txGasPrices(300, 20)...), 800, 10)
+	oracle.AddBlock(6, nil, 800, 10)
+
+	history, err := oracle.FeeHistory(10, 100, []float64{0, 25, 50, 100})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), history.OldestBlock)
+	require.Equal(t, []float64{0.5, 0}, history.GasUsedRatio)
+	require.Equal(t, []*big.Int{big.NewInt(10), big.NewInt(10), big.NewInt(20), big.NewInt(20)}, history.Reward[0])
+	require.Equal(t, []*big.Int{new(big.Int), new(big.Int), new(big.Int), new(big.Int)}, history.Reward[1])
+
+	history, err = oracle.FeeHistory(1, 5, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), history.OldestBlock)
+	require.Len(t, history.GasUsedRatio, 1)
+
+	_, err = oracle.FeeHistory(1, 4, nil)
+	require.Error(t, err)
+	_, err = oracle.FeeHistory(1, 6, []float64{50, 10})
+	require.Error(t, err)
+}
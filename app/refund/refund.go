@@ -13,16 +13,22 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
+	feegranttypes "github.com/okex/exchain/x/feegrant/types"
 )
 
-func NewGasRefundHandler(ak auth.AccountKeeper, sk types.SupplyKeeper) sdk.GasRefundHandler {
+// FeeGrantKeeper defines the expected keeper interface used to give refunded fees back to fee grants
+type FeeGrantKeeper interface {
+	RestoreFeeAllowance(ctx sdk.Context, payment feegranttypes.FeePayment, refund sdk.Coins)
+}
+
+func NewGasRefundHandler(ak auth.AccountKeeper, sk types.SupplyKeeper, fgk FeeGrantKeeper) sdk.GasRefundHandler {
 	return func(
 		ctx sdk.Context, tx sdk.Tx,
 	) (err error) {
 		var gasRefundHandler sdk.GasRefundHandler
 		switch tx.(type) {
-		case evmtypes.MsgEthereumTx:
-			gasRefundHandler = NewGasRefundDecorator(ak, sk)
+		case evmtypes.MsgEthereumTx, evmtypes.BundleTx:
+			gasRefundHandler = NewGasRefundDecorator(ak, sk, fgk)
 		default:
 			return nil
 		}
@@ -31,8 +37,9 @@ func NewGasRefundHandler(ak auth.AccountKeeper, sk types.SupplyKeeper) sdk.GasRe
 }
 
 type Handler struct {
-	ak           keeper.AccountKeeper
-	supplyKeeper types.SupplyKeeper
+	ak             keeper.AccountKeeper
+	supplyKeeper   types.SupplyKeeper
+	feeGrantKeeper FeeGrantKeeper
 }
 
 func (handler Handler) GasRefund(ctx sdk.Context, tx sdk.Tx) (err error) {
@@ -58,6 +65,11 @@ func (handler Handler) GasRefund(ctx sdk.Context, tx sdk.Tx) (err error) {
 	}
 
 	feePayer := feeTx.FeePayer(ctx)
+	// fees paid out of a fee grant are refunded to the granter
+	payment, granted := feegranttypes.FeePaymentFromContext(ctx)
+	if granted {
+		feePayer = payment.Granter
+	}
 	feePayerAcc := handler.ak.GetAccount(ctx, feePayer)
 	if feePayerAcc == nil {
 		return sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "fee payer address: %s does not exist", feePayer)
@@ -81,13 +93,18 @@ func (handler Handler) GasRefund(ctx sdk.Context, tx sdk.Tx) (err error) {
 		return err
 	}
 
+	if granted && handler.feeGrantKeeper != nil {
+		handler.feeGrantKeeper.RestoreFeeAllowance(ctx, payment, gasFees)
+	}
+
 	return nil
 }
 
-func NewGasRefundDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper) sdk.GasRefundHandler {
+func NewGasRefundDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper, fgk FeeGrantKeeper) sdk.GasRefundHandler {
 	chandler := Handler{
-		ak:           ak,
-		supplyKeeper: sk,
+		ak:             ak,
+		supplyKeeper:   sk,
+		feeGrantKeeper: fgk,
 	}
 
 	return func(ctx sdk.Context, tx sdk.Tx) (err error) {
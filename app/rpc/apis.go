@@ -22,6 +22,7 @@ import (
 	"github.com/okex/exchain/app/rpc/namespaces/eth"
 	"github.com/okex/exchain/app/rpc/namespaces/eth/filters"
 	"github.com/okex/exchain/app/rpc/namespaces/net"
+	"github.com/okex/exchain/app/rpc/namespaces/okt"
 	"github.com/okex/exchain/app/rpc/namespaces/personal"
 	"github.com/okex/exchain/app/rpc/namespaces/web3"
 	rpctypes "github.com/okex/exchain/app/rpc/types"
@@ -34,6 +35,7 @@ const (
 	PersonalNamespace = "personal"
 	NetNamespace      = "net"
 	TxpoolNamespace   = "txpool"
+	OKTNamespace      = "okt"
 
 	apiVersion = "1.0"
 )
@@ -99,6 +101,15 @@ func GetAPIs(clientCtx context.CLIContext, log log.Logger, keys ...ethsecp256k1.
 		})
 	}
 
+	if viper.GetString(okt.FlagSolcPath) != "" {
+		apis = append(apis, rpc.API{
+			Namespace: OKTNamespace,
+			Version:   apiVersion,
+			Service:   okt.NewAPI(ethAPI, log),
+			Public:    false,
+		})
+	}
+
 	if viper.GetBool(FlagEnableMonitor) {
 		for _, api := range apis {
 			makeMonitorMetrics(api.Namespace, api.Service)
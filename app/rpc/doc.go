@@ -7,4 +7,5 @@
 // * `rpc/namespaces/personal`: `personal` namespace. Exposes the `PrivateAccountAPI`.
 // * `rpc/namespaces/net`: `net` namespace. Exposes the `PublicNetAPI`.
 // * `rpc/namespaces/web3`: `web3` namespace. Exposes the `PublicWeb3API`
+// * `rpc/namespaces/okt`: `okt` namespace. Exposes the `PublicOKTAPI` if the solc binary is configured.
 package rpc
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -33,6 +35,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
 	tmtypes "github.com/tendermint/tendermint/types"
 
@@ -41,6 +44,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	clientcontext "github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -231,10 +235,65 @@ func (api *PublicEthereumAPI) GasPrice() *hexutil.Big {
 	monitor := monitor.GetMonitor("eth_gasPrice", api.logger, api.Metrics).OnBegin()
 	defer monitor.OnEnd()
 
-	if app.GlobalGpIndex.RecommendGp != nil {
-		return (*hexutil.Big)(app.GlobalGpIndex.RecommendGp)
+	return api.recommendGasPrice()
+}
+
+// MaxPriorityFeePerGas returns the recommended priority fee per gas. Without the base fee, it's the whole gas price.
+func (api *PublicEthereumAPI) MaxPriorityFeePerGas() *hexutil.Big {
+	monitor := monitor.GetMonitor("eth_maxPriorityFeePerGas", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd()
+	return api.recommendGasPrice()
+}
+
+// FeeHistory returns the gas price percentiles weighted by the gas used and the utilization of the blocks up to
+// lastBlock, which are served from the window of the gas price oracle. Without the base fee, the rewards are the gas
+// prices and the base fees are zeros.
+func (api *PublicEthereumAPI) FeeHistory(blockCount gethrpc.DecimalOrHex, lastBlock rpctypes.BlockNumber, rewardPercentiles []float64) (*rpctypes.FeeHistoryResult, error) {
+	monitor := monitor.GetMonitor("eth_feeHistory", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("block count", blockCount, "last block", lastBlock, "reward percentiles", rewardPercentiles)
+	if blockCount == 0 {
+		return &rpctypes.FeeHistoryResult{OldestBlock: (*hexutil.Big)(new(big.Int))}, nil
 	}
 
+	lastHeight := lastBlock.Int64()
+	if lastBlock == rpctypes.LatestBlockNumber || lastBlock == rpctypes.PendingBlockNumber {
+		latest, err := api.backend.LatestBlockNumber()
+		if err != nil {
+			return nil, err
+		}
+		lastHeight = latest
+	}
+	history, err := app.GlobalGpOracle.FeeHistory(int(blockCount), lastHeight, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &rpctypes.FeeHistoryResult{
+		OldestBlock:  (*hexutil.Big)(big.NewInt(history.OldestBlock)),
+		GasUsedRatio: history.GasUsedRatio,
+	}
+	for _, rewards := range history.Reward {
+		reward := make([]*hexutil.Big, len(rewards))
+		for i, r := range rewards {
+			reward[i] = (*hexutil.Big)(r)
+		}
+		res.Reward = append(res.Reward, reward)
+	}
+	res.BaseFee = make([]*hexutil.Big, len(history.GasUsedRatio)+1)
+	for i := range res.BaseFee {
+		res.BaseFee[i] = (*hexutil.Big)(new(big.Int))
+	}
+	return res, nil
+}
+
+// recommendGasPrice returns the gas price recommended by the oracle, or the minimum gas price without any
+func (api *PublicEthereumAPI) recommendGasPrice() *hexutil.Big {
+	if !config.GetOecConfig().GetEnableDynamicGp() {
+		return api.gasPrice
+	}
+	if gp := app.GlobalGpOracle.RecommendGp(config.GetOecConfig().GetDynamicGpWeight(), api.gasPrice.ToInt()); gp != nil {
+		return (*hexutil.Big)(gp)
+	}
 	return api.gasPrice
 }
 
@@ -275,7 +334,18 @@ func (api *PublicEthereumAPI) BlockNumber() (hexutil.Uint64, error) {
 func (api *PublicEthereumAPI) GetBalance(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*hexutil.Big, error) {
 	monitor := monitor.GetMonitor("eth_getBalance", api.logger, api.Metrics).OnBegin()
 	defer monitor.OnEnd("address", address, "block number", blockNrOrHash)
-	acc, err := api.wrappedBackend.MustGetAccount(address.Bytes())
+	blockNum, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	height, historical := historicalHeight(blockNum)
+
+	var acc *ethermint.EthAccount
+	if historical {
+		acc, err = api.wrappedBackend.GetAccountAtHeight(address.Bytes(), height)
+	} else {
+		acc, err = api.wrappedBackend.MustGetAccount(address.Bytes())
+	}
 	if err == nil {
 		balance := acc.GetCoins().AmountOf(sdk.DefaultBondDenom).BigInt()
 		if balance == nil {
@@ -284,12 +354,8 @@ func (api *PublicEthereumAPI) GetBalance(address common.Address, blockNrOrHash r
 		return (*hexutil.Big)(balance), nil
 	}
 
-	blockNum, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
-	if err != nil {
-		return nil, err
-	}
 	clientCtx := api.clientCtx
-	if !(blockNum == rpctypes.PendingBlockNumber || blockNum == rpctypes.LatestBlockNumber) {
+	if historical {
 		clientCtx = api.clientCtx.WithHeight(blockNum.Int64())
 	}
 
@@ -300,7 +366,9 @@ func (api *PublicEthereumAPI) GetBalance(address common.Address, blockNrOrHash r
 
 	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", auth.QuerierRoute, auth.QueryAccount), bs)
 	if err != nil {
-		api.saveZeroAccount(address)
+		if !historical {
+			api.saveZeroAccount(address)
+		}
 		return (*hexutil.Big)(sdk.ZeroInt().BigInt()), nil
 	}
 
@@ -310,7 +378,10 @@ func (api *PublicEthereumAPI) GetBalance(address common.Address, blockNrOrHash r
 	}
 
 	val := account.Balance(sdk.DefaultBondDenom).BigInt()
-	api.watcherBackend.CommitAccountToRpcDb(account)
+	// only the latest account is cached, the historical one would shadow it
+	if !historical {
+		api.watcherBackend.CommitAccountToRpcDb(account)
+	}
 	if blockNum != rpctypes.PendingBlockNumber {
 		return (*hexutil.Big)(val), nil
 	}
@@ -367,7 +438,15 @@ func (api *PublicEthereumAPI) GetAccount(address common.Address) (*ethermint.Eth
 
 func (api *PublicEthereumAPI) getStorageAt(address common.Address, key []byte, blockNum rpctypes.BlockNumber, directlyKey bool) (hexutil.Bytes, error) {
 	clientCtx := api.clientCtx.WithHeight(blockNum.Int64())
-	res, err := api.wrappedBackend.MustGetState(address, key)
+	height, historical := historicalHeight(blockNum)
+
+	var res []byte
+	var err error
+	if historical {
+		res, err = api.wrappedBackend.GetStateAtHeight(address, key, height)
+	} else {
+		res, err = api.wrappedBackend.MustGetState(address, key)
+	}
 	if err == nil {
 		return res, nil
 	}
@@ -386,7 +465,9 @@ func (api *PublicEthereumAPI) getStorageAt(address common.Address, key []byte, b
 	var out evmtypes.QueryResStorage
 	api.clientCtx.Codec.MustUnmarshalJSON(res, &out)
 
-	api.watcherBackend.CommitStateToRpcDb(address, key, out.Value)
+	if !historical {
+		api.watcherBackend.CommitStateToRpcDb(address, key, out.Value)
+	}
 	return out.Value, nil
 }
 
@@ -418,7 +499,11 @@ func (api *PublicEthereumAPI) GetTransactionCount(address common.Address, blockN
 	clientCtx := api.clientCtx
 	pending := blockNum == rpctypes.PendingBlockNumber
 	// pass the given block height to the context if the height is not pending or latest
-	if !pending && blockNum != rpctypes.LatestBlockNumber {
+	if height, historical := historicalHeight(blockNum); historical {
+		if acc, err := api.wrappedBackend.GetAccountAtHeight(address.Bytes(), height); err == nil {
+			n := hexutil.Uint64(acc.GetSequence())
+			return &n, nil
+		}
 		clientCtx = api.clientCtx.WithHeight(blockNum.Int64())
 	}
 
@@ -633,24 +718,7 @@ func (api *PublicEthereumAPI) SendTransaction(args rpctypes.SendTxArgs) (common.
 		return common.Hash{}, err
 	}
 
-	// send chanData to txPool
-	if api.txPool != nil {
-		return broadcastTxByTxPool(api, tx, txBytes)
-	}
-
-	// Broadcast transaction in sync mode (default)
-	// NOTE: If error is encountered on the node, the broadcast will not return an error
-	res, err := api.clientCtx.BroadcastTx(txBytes)
-	if err != nil {
-		return common.Hash{}, err
-	}
-
-	if res.Code != abci.CodeTypeOK {
-		return CheckError(res)
-	}
-
-	// Return transaction hash
-	return common.HexToHash(res.TxHash), nil
+	return api.broadcastTx(tx, txBytes)
 }
 
 // SendRawTransaction send a raw Ethereum transaction.
@@ -672,11 +740,77 @@ func (api *PublicEthereumAPI) SendRawTransaction(data hexutil.Bytes) (common.Has
 		return common.Hash{}, err
 	}
 
+	return api.broadcastTx(tx, txBytes)
+}
+
+// SendRawTransactionConditional sends a raw Ethereum transaction, which is only executed if the conditions hold in
+// the block it's included in. It's sent as a bundle of the single transaction.
+func (api *PublicEthereumAPI) SendRawTransactionConditional(data hexutil.Bytes, options rpctypes.ConditionalOptions) (common.Hash, error) {
+	monitor := monitor.GetMonitor("eth_sendRawTransactionConditional", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("data", data)
+	hashes, err := api.sendBundle([]hexutil.Bytes{data}, options)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return hashes[0], nil
+}
+
+// SendBundle sends a bundle of raw Ethereum transactions of a single sender with consecutive nonces and the same gas
+// price. The transactions are executed in order in the same block, and their state changes are committed all or
+// none, only if the conditions hold in that block. It returns the hashes of the transactions.
+func (api *PublicEthereumAPI) SendBundle(args rpctypes.SendBundleArgs) ([]common.Hash, error) {
+	monitor := monitor.GetMonitor("eth_sendBundle", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("args", args)
+	return api.sendBundle(args.Txs, args.ConditionalOptions)
+}
+
+// sendBundle broadcasts the raw Ethereum transactions as a bundle to the node in sync mode, bypassing the tx pool
+func (api *PublicEthereumAPI) sendBundle(data []hexutil.Bytes, options rpctypes.ConditionalOptions) ([]common.Hash, error) {
+	conditions, err := newBundleConditions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	txEncoder := authclient.GetTxEncoder(api.clientCtx.Codec)
+	txs := make([]evmtypes.MsgEthereumTx, len(data))
+	hashes := make([]common.Hash, len(data))
+	for i, raw := range data {
+		if err := rlp.DecodeBytes(raw, &txs[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode tx %d of the bundle: %s", i, err)
+		}
+		// a tx of a bundle is known by the hash it has out of the bundle
+		txBytes, err := txEncoder(txs[i])
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = common.BytesToHash(tmhash.Sum(txBytes))
+	}
+
+	bundle := evmtypes.NewBundleTx(txs, conditions)
+	if err := bundle.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	txBytes, err := txEncoder(bundle)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := api.broadcastTxSync(txBytes); err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// broadcastTx broadcasts the encoded transaction through the tx pool if it's enabled, or to the node in sync mode
+func (api *PublicEthereumAPI) broadcastTx(tx *evmtypes.MsgEthereumTx, txBytes []byte) (common.Hash, error) {
 	// send chanData to txPool
 	if api.txPool != nil {
 		return broadcastTxByTxPool(api, tx, txBytes)
 	}
+	return api.broadcastTxSync(txBytes)
+}
 
+// broadcastTxSync broadcasts the encoded transaction to the node in sync mode
+func (api *PublicEthereumAPI) broadcastTxSync(txBytes []byte) (common.Hash, error) {
 	// TODO: Possibly log the contract creation address (if recipient address is nil) or tx data
 	// If error is encountered on the node, the broadcast will not return an error
 	res, err := api.clientCtx.BroadcastTx(txBytes)
@@ -725,10 +859,14 @@ func (api *PublicEthereumAPI) addCallCache(key common.Hash, data []byte) {
 }
 
 // Call performs a raw contract call.
-func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash, _ *map[common.Address]rpctypes.Account) (hexutil.Bytes, error) {
+func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash, overrides *map[common.Address]rpctypes.Account) (hexutil.Bytes, error) {
 	monitor := monitor.GetMonitor("eth_call", api.logger, api.Metrics).OnBegin()
 	defer monitor.OnEnd("args", args, "block number", blockNrOrHash)
-	key := api.buildKey(args)
+	// the calls with the state overrides aren't cached as the key doesn't cover the overrides
+	var key common.Hash
+	if overrides == nil {
+		key = api.buildKey(args)
+	}
 	cacheData, ok := api.getFromCallCache(key)
 	if ok {
 		return cacheData, nil
@@ -737,7 +875,7 @@ func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNrOrHash rpctype
 	if err != nil {
 		return nil, err
 	}
-	simRes, err := api.doCall(args, blockNr, big.NewInt(ethermint.DefaultRPCGasLimit), false)
+	simRes, err := api.doCall(args, blockNr, big.NewInt(ethermint.DefaultRPCGasLimit), false, overrides)
 	if err != nil {
 		return []byte{}, TransformDataError(err, "eth_call")
 	}
@@ -751,7 +889,7 @@ func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNrOrHash rpctype
 }
 
 // MultiCall performs multiple raw contract call.
-func (api *PublicEthereumAPI) MultiCall(args []rpctypes.CallArgs, blockNr rpctypes.BlockNumber, _ *map[common.Address]rpctypes.Account) ([]hexutil.Bytes, error) {
+func (api *PublicEthereumAPI) MultiCall(args []rpctypes.CallArgs, blockNr rpctypes.BlockNumber, overrides *map[common.Address]rpctypes.Account) ([]hexutil.Bytes, error) {
 	if !viper.GetBool(FlagEnableMultiCall) {
 		return nil, errors.New("the method is not allowed")
 	}
@@ -762,7 +900,7 @@ func (api *PublicEthereumAPI) MultiCall(args []rpctypes.CallArgs, blockNr rpctyp
 	blockNrOrHash := rpctypes.BlockNumberOrHashWithNumber(blockNr)
 	rets := make([]hexutil.Bytes, 0, len(args))
 	for _, arg := range args {
-		ret, err := api.Call(arg, blockNrOrHash, nil)
+		ret, err := api.Call(arg, blockNrOrHash, overrides)
 		if err != nil {
 			return rets, err
 		}
@@ -772,10 +910,19 @@ func (api *PublicEthereumAPI) MultiCall(args []rpctypes.CallArgs, blockNr rpctyp
 }
 
 // DoCall performs a simulated call operation through the evmtypes. It returns the
-// estimated gas used on the operation or an error if fails.
+// estimated gas used on the operation or an error if fails. The state overrides
+// are applied to the state before the call if any.
 func (api *PublicEthereumAPI) doCall(
 	args rpctypes.CallArgs, blockNum rpctypes.BlockNumber, globalGasCap *big.Int, isEstimate bool,
+	overrides *map[common.Address]rpctypes.Account,
 ) (*sdk.SimulationResponse, error) {
+	var stateOverrides evmtypes.StateOverrides
+	if overrides != nil {
+		stateOverrides = evmtypes.StateOverrides(*overrides)
+		if err := stateOverrides.Check(); err != nil {
+			return nil, err
+		}
+	}
 
 	clientCtx := api.clientCtx
 	// pass the given block height to the context if the height is not pending or latest
@@ -840,7 +987,12 @@ func (api *PublicEthereumAPI) doCall(
 	sim := api.evmFactory.BuildSimulator(api)
 	//only worked when fast-query has been enabled
 	if sim != nil {
-		return sim.DoCall(msg)
+		return sim.DoCall(msg, stateOverrides)
+	}
+
+	// the state overrides are applied by the evm querier, which simulates the call only without the pending txs
+	if len(stateOverrides) != 0 {
+		return api.simulateWithOverrides(clientCtx, msg, stateOverrides)
 	}
 
 	//convert the pending transactions into ethermint msgs
@@ -881,13 +1033,40 @@ func (api *PublicEthereumAPI) doCall(
 	return &simResponse, nil
 }
 
+// simulateWithOverrides simulates the msg on the state of the height of clientCtx with the state overrides applied
+func (api *PublicEthereumAPI) simulateWithOverrides(
+	clientCtx clientcontext.CLIContext, msg evmtypes.MsgEthermint, overrides evmtypes.StateOverrides,
+) (*sdk.SimulationResponse, error) {
+	bz, err := json.Marshal(evmtypes.QuerySimulateParams{Msg: msg, Overrides: overrides})
+	if err != nil {
+		return nil, err
+	}
+	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", evmtypes.ModuleName, evmtypes.QuerySimulate), bz)
+	if err != nil {
+		return nil, err
+	}
+
+	var simResponse sdk.SimulationResponse
+	if err := clientCtx.Codec.UnmarshalBinaryBare(res, &simResponse); err != nil {
+		return nil, err
+	}
+	return &simResponse, nil
+}
+
 // EstimateGas returns an estimate of gas usage for the given smart contract call.
 // It adds 1,000 gas to the returned value instead of using the gas adjustment
 // param from the SDK.
-func (api *PublicEthereumAPI) EstimateGas(args rpctypes.CallArgs) (hexutil.Uint64, error) {
+func (api *PublicEthereumAPI) EstimateGas(args rpctypes.CallArgs, blockNrOrHash *rpctypes.BlockNumberOrHash, overrides *map[common.Address]rpctypes.Account) (hexutil.Uint64, error) {
 	monitor := monitor.GetMonitor("eth_estimateGas", api.logger, api.Metrics).OnBegin()
 	defer monitor.OnEnd("args", args)
-	simResponse, err := api.doCall(args, 0, big.NewInt(ethermint.DefaultRPCGasLimit), true)
+	blockNr := rpctypes.LatestBlockNumber
+	if blockNrOrHash != nil {
+		var err error
+		if blockNr, err = api.backend.ConvertToBlockNumber(*blockNrOrHash); err != nil {
+			return 0, err
+		}
+	}
+	simResponse, err := api.doCall(args, blockNr, big.NewInt(ethermint.DefaultRPCGasLimit), true, overrides)
 	if err != nil {
 		return 0, TransformDataError(err, "eth_estimateGas")
 	}
@@ -1123,21 +1302,91 @@ func (api *PublicEthereumAPI) GetTransactionReceipt(hash common.Hash) (interface
 		return nil, err
 	}
 
-	from := fromSigCache.GetFrom()
 	cumulativeGasUsed := uint64(tx.TxResult.GasUsed)
 	if tx.Index != 0 {
 		cumulativeGasUsed += rpctypes.GetBlockCumulativeGas(api.clientCtx.Codec, block.Block, int(tx.Index))
 	}
 
+	return newReceipt(ethTx, fromSigCache.GetFrom(), hash, blockHash, tx.Height, tx.Index, &tx.TxResult, cumulativeGasUsed), nil
+}
+
+// GetBlockReceipts returns the receipts of all the transactions of the block identified by number or hash.
+func (api *PublicEthereumAPI) GetBlockReceipts(blockNrOrHash rpctypes.BlockNumberOrHash) (interface{}, error) {
+	monitor := monitor.GetMonitor("eth_getBlockReceipts", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("block number or hash", blockNrOrHash)
+	blockNum, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
+	if err != nil {
+		return nil, nil
+	}
+
+	height := blockNum.Int64()
+	if blockNum == rpctypes.LatestBlockNumber || blockNum == rpctypes.PendingBlockNumber {
+		height, err = api.backend.LatestBlockNumber()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if receipts, e := api.wrappedBackend.GetBlockReceipts(uint64(height)); e == nil {
+		return receipts, nil
+	}
+
+	block, err := api.clientCtx.Client.Block(&height)
+	if err != nil {
+		// Return nil for block when not found
+		return nil, nil
+	}
+	results, err := api.clientCtx.Client.BlockResults(&height)
+	if err != nil {
+		return nil, err
+	}
+
+	// rebuild the receipts in a single pass over the txs of the block, with the cumulative gas used computed the same
+	// way as GetTransactionReceipt: the gas used by the tx plus the gas limits of the txs before it
+	blockHash := common.BytesToHash(block.Block.Hash())
+	txDecoder := evmtypes.TxDecoder(api.clientCtx.Codec)
+	var prevGas uint64
+	receipts := make([]map[string]interface{}, 0, len(block.Block.Txs))
+	for i, txBytes := range block.Block.Txs {
+		if i >= len(results.TxsResults) {
+			break
+		}
+		txResult := results.TxsResults[i]
+
+		tx, err := txDecoder(txBytes)
+		if err != nil {
+			continue
+		}
+		cumulativeGasUsed := uint64(txResult.GasUsed) + prevGas
+		prevGas += rpctypes.GetTxGas(tx)
+
+		ethTx, ok := tx.(evmtypes.MsgEthereumTx)
+		if !ok {
+			continue
+		}
+		fromSigCache, err := ethTx.VerifySig(ethTx.ChainID(), height, sdk.EmptyContext().SigCache())
+		if err != nil {
+			return nil, err
+		}
+
+		receipts = append(receipts, newReceipt(&ethTx, fromSigCache.GetFrom(), common.BytesToHash(txBytes.Hash()),
+			blockHash, height, uint32(i), txResult, cumulativeGasUsed))
+	}
+	return receipts, nil
+}
+
+// newReceipt builds the receipt of the eth tx from its result in the block
+func newReceipt(ethTx *evmtypes.MsgEthereumTx, from common.Address, hash, blockHash common.Hash, height int64, index uint32,
+	txResult *abci.ResponseDeliverTx, cumulativeGasUsed uint64) map[string]interface{} {
 	// Set status codes based on tx result
 	var status hexutil.Uint
-	if tx.TxResult.IsOK() {
+	if txResult.IsOK() {
 		status = hexutil.Uint(1)
 	} else {
 		status = hexutil.Uint(0)
 	}
 
-	txData := tx.TxResult.GetData()
+	txData := txResult.GetData()
 
 	data, err := evmtypes.DecodeResultData(txData)
 	if err != nil {
@@ -1152,7 +1401,7 @@ func (api *PublicEthereumAPI) GetTransactionReceipt(hash common.Hash) (interface
 		contractAddr = nil
 	}
 
-	receipt := map[string]interface{}{
+	return map[string]interface{}{
 		// Consensus fields: These fields are defined by the Yellow Paper
 		"status":            status,
 		"cumulativeGasUsed": hexutil.Uint64(cumulativeGasUsed),
@@ -1163,19 +1412,18 @@ func (api *PublicEthereumAPI) GetTransactionReceipt(hash common.Hash) (interface
 		// They are stored in the chain database.
 		"transactionHash": hash,
 		"contractAddress": contractAddr,
-		"gasUsed":         hexutil.Uint64(tx.TxResult.GasUsed),
+		"gasUsed":         hexutil.Uint64(txResult.GasUsed),
 
 		// Inclusion information: These fields provide information about the inclusion of the
 		// transaction corresponding to this receipt.
 		"blockHash":        blockHash,
-		"blockNumber":      hexutil.Uint64(tx.Height),
-		"transactionIndex": hexutil.Uint64(tx.Index),
+		"blockNumber":      hexutil.Uint64(height),
+		"transactionIndex": hexutil.Uint64(index),
 
 		// sender and receiver (contract or EOA) addresses
 		"from": from,
 		"to":   ethTx.To(),
 	}
-	return receipt, nil
 }
 
 // PendingTransactions returns the transactions that are in the transaction pool
@@ -1335,7 +1583,7 @@ func (api *PublicEthereumAPI) generateFromArgs(args rpctypes.SendTxArgs) (*evmty
 			Value:    args.Value,
 			Data:     &input,
 		}
-		gl, err := api.EstimateGas(callArgs)
+		gl, err := api.EstimateGas(callArgs, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -1395,8 +1643,9 @@ func (api *PublicEthereumAPI) accountNonce(
 ) (uint64, error) {
 	// Get nonce (sequence) from sender account
 	nonce := uint64(0)
+	// the watch db only holds the latest accounts, so it's skipped for a custom height
 	acc, err := api.wrappedBackend.MustGetAccount(address.Bytes())
-	if err == nil { // account in watch db
+	if err == nil && clientCtx.Height == 0 { // account in watch db
 		nonce = acc.GetSequence()
 	} else {
 		// use a the given client context in case its wrapped with a custom height
@@ -1408,7 +1657,9 @@ func (api *PublicEthereumAPI) accountNonce(
 			return 0, nil
 		}
 		nonce = account.GetSequence()
-		api.watcherBackend.CommitAccountToRpcDb(account)
+		if clientCtx.Height == 0 {
+			api.watcherBackend.CommitAccountToRpcDb(account)
+		}
 	}
 
 	if !pending {
@@ -1425,6 +1676,48 @@ func (api *PublicEthereumAPI) accountNonce(
 	return nonce, nil
 }
 
+// historicalHeight returns the height of the block number, and false if it's the latest or the pending block
+func historicalHeight(blockNum rpctypes.BlockNumber) (uint64, bool) {
+	if blockNum == rpctypes.PendingBlockNumber || blockNum == rpctypes.LatestBlockNumber {
+		return 0, false
+	}
+	return uint64(blockNum), true
+}
+
+// newBundleConditions converts the conditional options into the conditions of a bundle
+func newBundleConditions(options rpctypes.ConditionalOptions) (evmtypes.BundleConditions, error) {
+	toInt64 := func(v *hexutil.Uint64) int64 {
+		if v == nil {
+			return 0
+		}
+		return int64(*v)
+	}
+	conditions := evmtypes.BundleConditions{
+		BlockNumberMin: toInt64(options.BlockNumberMin),
+		BlockNumberMax: toInt64(options.BlockNumberMax),
+		TimestampMin:   toInt64(options.TimestampMin),
+		TimestampMax:   toInt64(options.TimestampMax),
+	}
+	for addr, account := range options.KnownAccounts {
+		// the storage of the accounts isn't kept in a trie, so there's no storage root
+		if account.StorageRoot != nil {
+			return evmtypes.BundleConditions{}, fmt.Errorf("storage root of %s is not supported, use the storage slots instead", addr.Hex())
+		}
+		for key, value := range account.StorageSlots {
+			conditions.StorageSlots = append(conditions.StorageSlots, evmtypes.StorageCondition{Address: addr, Key: key, Value: value})
+		}
+	}
+	// the slots are sorted to encode the same conditions into the same bundle
+	sort.Slice(conditions.StorageSlots, func(i, j int) bool {
+		a, b := conditions.StorageSlots[i], conditions.StorageSlots[j]
+		if a.Address != b.Address {
+			return bytes.Compare(a.Address.Bytes(), b.Address.Bytes()) < 0
+		}
+		return bytes.Compare(a.Key.Bytes(), b.Key.Bytes()) < 0
+	})
+	return conditions, nil
+}
+
 func (api *PublicEthereumAPI) saveZeroAccount(address common.Address) {
 	zeroAccount := ethermint.EthAccount{BaseAccount: &auth.BaseAccount{}}
 	zeroAccount.SetAddress(address.Bytes())
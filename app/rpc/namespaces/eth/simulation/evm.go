@@ -63,16 +63,22 @@ func (ef EvmFactory) BuildSimulator(qoc QueryOnChainProxy) *EvmSimulator {
 
 	return &EvmSimulator{
 		handler: evm.NewHandler(keeper),
+		keeper:  keeper,
 		ctx:     ctx,
 	}
 }
 
 type EvmSimulator struct {
 	handler sdk.Handler
+	keeper  *evm.Keeper
 	ctx     sdk.Context
 }
 
-func (es *EvmSimulator) DoCall(msg evmtypes.MsgEthermint) (*sdk.SimulationResponse, error) {
+// DoCall simulates the msg, the accounts read by the AccountKeeperProxy are overridden by the overrides if any
+func (es *EvmSimulator) DoCall(msg evmtypes.MsgEthermint, overrides evmtypes.StateOverrides) (*sdk.SimulationResponse, error) {
+	if len(overrides) != 0 {
+		return es.keeper.Simulate(es.ctx, msg, overrides)
+	}
 	r, e := es.handler(es.ctx, msg)
 	if e != nil {
 		return nil, e
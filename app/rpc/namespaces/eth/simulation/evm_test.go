@@ -5,12 +5,15 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/exchain/x/evm/types"
+	"github.com/okex/exchain/x/evm/watcher"
+	"github.com/spf13/viper"
 )
 
 func TestEvmFactory(t *testing.T) {
+	viper.Set(watcher.FlagFastQueryLru, 100)
 	ef := EvmFactory{ChainId: "ok-1"}
 
-	sr := ef.BuildSimulator()
+	sr := ef.BuildSimulator(nil)
 	if sr != nil {
 		sr.DoCall(types.MsgEthermint{
 			AccountNonce: 0,
@@ -20,6 +23,6 @@ func TestEvmFactory(t *testing.T) {
 			Amount:       sdk.NewInt(100),
 			Payload:      nil,
 			From:         nil,
-		})
+		}, nil)
 	}
 }
@@ -1,8 +1,10 @@
 package simulation
 
 import (
+	"bytes"
 	"encoding/binary"
 	"github.com/okex/exchain/x/evm"
+	"reflect"
 	"sync"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -125,9 +127,25 @@ func (p SubspaceProxy) GetParamSet(ctx sdk.Context, ps params.ParamSet) {
 		evmParam.EnableCreate = pr.EnableCreate
 		evmParam.ExtraEIPs = pr.ExtraEIPs
 		evmParam.EnableContractDeploymentWhitelist = pr.EnableContractDeploymentWhitelist
+		evmParam.EnableContractFactoryWhitelist = pr.EnableContractFactoryWhitelist
 	}
 
 }
+
+// GetIfExists gets the param of the key from the params in the watcher, which are mirrored from the evm keeper
+func (p SubspaceProxy) GetIfExists(ctx sdk.Context, key []byte, ptr interface{}) {
+	pr, err := p.q.GetParams()
+	if err != nil {
+		return
+	}
+	for _, pair := range pr.ParamSetPairs() {
+		if bytes.Equal(pair.Key, key) {
+			reflect.ValueOf(ptr).Elem().Set(reflect.ValueOf(pair.Value).Elem())
+			return
+		}
+	}
+}
+
 func (p SubspaceProxy) SetParamSet(ctx sdk.Context, ps params.ParamSet) {
 
 }
@@ -217,8 +235,16 @@ func (i InternalDba) NewStore(parent store.KVStore, Prefix []byte) evmtypes.Stor
 		return StateStore{addr: common.BytesToAddress(Prefix[1:21]), ocProxy: i.ocProxy}
 	case evmtypes.KeyPrefixContractBlockedList[0]:
 		return ContractBlockedListStore{watcher.NewQuerier()}
+	case evmtypes.KeyPrefixContractMethodBlockedList[0]:
+		return ContractMethodBlockedListStore{watcher.NewQuerier()}
+	case evmtypes.KeyContractMethodBlockedListCount[0]:
+		return ContractMethodBlockedListCountStore{watcher.NewQuerier()}
 	case evmtypes.KeyPrefixContractDeploymentWhitelist[0]:
 		return ContractDeploymentWhitelist{watcher.NewQuerier()}
+	case evmtypes.KeyPrefixContractDeploymentCodeHash[0]:
+		return ContractDeploymentCodeHashStore{watcher.NewQuerier()}
+	case evmtypes.KeyPrefixContractDeploymentFactory[0]:
+		return ContractDeploymentFactoryStore{watcher.NewQuerier()}
 	case evmtypes.KeyPrefixCode[0]:
 		return CodeStore{q: watcher.NewQuerier(), ocProxy: i.ocProxy}
 	case evmtypes.KeyPrefixHeightHash[0]:
@@ -383,6 +409,46 @@ func (s ContractBlockedListStore) Has(key []byte) bool {
 	return s.q.HasContractBlockedList(key)
 }
 
+type ContractMethodBlockedListStore struct {
+	q *watcher.Querier
+}
+
+func (s ContractMethodBlockedListStore) Set(key, value []byte) {
+	//just ignore all set opt
+}
+
+func (s ContractMethodBlockedListStore) Get(key []byte) []byte {
+	return nil
+}
+
+func (s ContractMethodBlockedListStore) Delete(key []byte) {
+	return
+}
+
+func (s ContractMethodBlockedListStore) Has(key []byte) bool {
+	return s.q.HasContractMethodBlockedList(key)
+}
+
+type ContractMethodBlockedListCountStore struct {
+	q *watcher.Querier
+}
+
+func (s ContractMethodBlockedListCountStore) Set(key, value []byte) {
+	//just ignore all set opt
+}
+
+func (s ContractMethodBlockedListCountStore) Get(key []byte) []byte {
+	return s.q.GetContractMethodBlockedListCount()
+}
+
+func (s ContractMethodBlockedListCountStore) Delete(key []byte) {
+	return
+}
+
+func (s ContractMethodBlockedListCountStore) Has(key []byte) bool {
+	return s.q.GetContractMethodBlockedListCount() != nil
+}
+
 type ContractDeploymentWhitelist struct {
 	q *watcher.Querier
 }
@@ -403,3 +469,43 @@ func (s ContractDeploymentWhitelist) Delete(key []byte) {
 func (s ContractDeploymentWhitelist) Has(key []byte) bool {
 	return s.q.HasContractDeploymentWhitelist(key)
 }
+
+type ContractDeploymentCodeHashStore struct {
+	q *watcher.Querier
+}
+
+func (s ContractDeploymentCodeHashStore) Set(key, value []byte) {
+	//just ignore all set opt
+}
+
+func (s ContractDeploymentCodeHashStore) Get(key []byte) []byte {
+	return nil
+}
+
+func (s ContractDeploymentCodeHashStore) Delete(key []byte) {
+	return
+}
+
+func (s ContractDeploymentCodeHashStore) Has(key []byte) bool {
+	return s.q.HasContractDeploymentCodeHash(key)
+}
+
+type ContractDeploymentFactoryStore struct {
+	q *watcher.Querier
+}
+
+func (s ContractDeploymentFactoryStore) Set(key, value []byte) {
+	//just ignore all set opt
+}
+
+func (s ContractDeploymentFactoryStore) Get(key []byte) []byte {
+	return nil
+}
+
+func (s ContractDeploymentFactoryStore) Delete(key []byte) {
+	return
+}
+
+func (s ContractDeploymentFactoryStore) Has(key []byte) bool {
+	return s.q.HasContractDeploymentFactory(key)
+}
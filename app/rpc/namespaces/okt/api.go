@@ -0,0 +1,175 @@
+package okt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/okex/exchain/app/rpc/namespaces/eth"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/log"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+const (
+	// FlagSolcPath is the path of the solc binary compiling the sources to verify, which enables the okt namespace
+	FlagSolcPath = "rpc.solc-path"
+
+	verifiedContractDb = "verified_contract"
+)
+
+// PublicOKTAPI is the okt_ prefixed set of APIs, which verifies the sources of the deployed contracts and serves
+// their abis
+type PublicOKTAPI struct {
+	ethAPI *eth.PublicEthereumAPI
+	logger log.Logger
+	solc   string
+	db     tmdb.DB
+}
+
+// NewAPI creates an instance of the okt API.
+func NewAPI(ethAPI *eth.PublicEthereumAPI, log log.Logger) *PublicOKTAPI {
+	db, err := sdk.NewLevelDB(verifiedContractDb, filepath.Join(viper.GetString("home"), "data"))
+	if err != nil {
+		panic(err)
+	}
+	return &PublicOKTAPI{
+		ethAPI: ethAPI,
+		logger: log.With("module", "json-rpc", "namespace", "okt"),
+		solc:   viper.GetString(FlagSolcPath),
+		db:     db,
+	}
+}
+
+// VerifyContract compiles the standard json input, and stores the source and the abi of the contract if the compiled
+// bytecode matches the deployed one. The first verification of the deployed code is kept, as the sources matching
+// the code may differ in their comments and names.
+func (api *PublicOKTAPI) VerifyContract(args VerifyContractArgs) (*VerifiedContract, error) {
+	api.logger.Debug("okt_verifyContract", "address", args.Address, "contract", args.ContractName)
+	deployed, err := api.ethAPI.GetCode(args.Address, rpctypes.BlockNumberOrHashWithNumber(rpctypes.LatestBlockNumber))
+	if err != nil {
+		return nil, err
+	}
+	if len(deployed) == 0 {
+		return nil, fmt.Errorf("no contract deployed at %s", args.Address.Hex())
+	}
+	codeHash := crypto.Keccak256Hash(deployed)
+	if verified, err := api.GetVerifiedContract(args.Address); err == nil && verified.CodeHash == codeHash {
+		return verified, nil
+	}
+
+	compiled, compiler, err := compile(api.solc, args.Input, args.ContractName)
+	if err != nil {
+		return nil, err
+	}
+	matched, err := matchBytecode(compiled, deployed)
+	if err != nil {
+		return nil, err
+	}
+	if !matched {
+		return nil, errors.New("compiled bytecode mismatches the deployed one")
+	}
+
+	contract := &VerifiedContract{
+		Address:      args.Address,
+		ContractName: args.ContractName,
+		Compiler:     compiler,
+		CodeHash:     codeHash,
+		ABI:          compiled.ABI,
+		Input:        args.Input,
+	}
+	bz, err := json.Marshal(contract)
+	if err != nil {
+		return nil, err
+	}
+	if err := api.db.Set(args.Address.Bytes(), bz); err != nil {
+		return nil, err
+	}
+	return contract, nil
+}
+
+// GetVerifiedContract returns the source and the abi of the verified contract.
+func (api *PublicOKTAPI) GetVerifiedContract(address common.Address) (*VerifiedContract, error) {
+	api.logger.Debug("okt_getVerifiedContract", "address", address)
+	bz, err := api.db.Get(address.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, fmt.Errorf("contract %s not verified", address.Hex())
+	}
+	var contract VerifiedContract
+	if err := json.Unmarshal(bz, &contract); err != nil {
+		return nil, err
+	}
+	return &contract, nil
+}
+
+// GetContractABI returns the abi of the verified contract.
+func (api *PublicOKTAPI) GetContractABI(address common.Address) (json.RawMessage, error) {
+	api.logger.Debug("okt_getContractABI", "address", address)
+	contract, err := api.GetVerifiedContract(address)
+	if err != nil {
+		return nil, err
+	}
+	return contract.ABI, nil
+}
+
+// GetDecodedTransactionReceipt returns the receipt of the transaction, with the input and the logs decoded by the
+// abis of the verified contracts.
+func (api *PublicOKTAPI) GetDecodedTransactionReceipt(hash common.Hash) (map[string]interface{}, error) {
+	api.logger.Debug("okt_getDecodedTransactionReceipt", "hash", hash)
+	tx, err := api.ethAPI.GetTransactionByHash(hash)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	receipt, err := api.ethAPI.GetTransactionReceipt(hash)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+
+	// the receipt is either from the watcher or built by the eth api, so it's decoded through its json
+	bz, err := json.Marshal(receipt)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(bz, &fields); err != nil {
+		return nil, err
+	}
+	var logs struct {
+		Logs []*ethtypes.Log `json:"logs"`
+	}
+	if err := json.Unmarshal(bz, &logs); err != nil {
+		return nil, err
+	}
+
+	abis := make(map[common.Address]*abi.ABI)
+	abiOf := func(address common.Address) (abi.ABI, bool) {
+		if contractABI, ok := abis[address]; ok {
+			return *contractABI, contractABI != nil
+		}
+		abis[address] = nil
+		rawABI, err := api.GetContractABI(address)
+		if err != nil {
+			return abi.ABI{}, false
+		}
+		contractABI, err := abi.JSON(strings.NewReader(string(rawABI)))
+		if err != nil {
+			return abi.ABI{}, false
+		}
+		abis[address] = &contractABI
+		return contractABI, true
+	}
+
+	fields["decodedInput"], fields["decodedLogs"] = decodeReceipt(tx.Input, tx.To, logs.Logs, abiOf)
+	return fields, nil
+}
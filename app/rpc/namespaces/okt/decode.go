@@ -0,0 +1,77 @@
+package okt
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// DecodedCall is a method call or an event decoded with the abi of a verified contract
+type DecodedCall struct {
+	Name      string                 `json:"name"`
+	Signature string                 `json:"signature"`
+	Args      map[string]interface{} `json:"args"`
+}
+
+// decodeInput decodes the input of a call to the contract
+func decodeInput(contractABI abi.ABI, input []byte) (*DecodedCall, error) {
+	method, err := contractABI.MethodById(input)
+	if err != nil {
+		return nil, err
+	}
+	args := make(map[string]interface{})
+	if err := method.Inputs.UnpackIntoMap(args, input[4:]); err != nil {
+		return nil, err
+	}
+	return &DecodedCall{Name: method.Name, Signature: method.Sig, Args: args}, nil
+}
+
+// decodeLog decodes the log emitted by the contract
+func decodeLog(contractABI abi.ABI, log *ethtypes.Log) (*DecodedCall, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("anonymous events aren't supported")
+	}
+	event, err := contractABI.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+
+	args := make(map[string]interface{})
+	if len(log.Data) != 0 {
+		if err := event.Inputs.UnpackIntoMap(args, log.Data); err != nil {
+			return nil, err
+		}
+	}
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(args, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+	return &DecodedCall{Name: event.Name, Signature: event.Sig, Args: args}, nil
+}
+
+// decodeReceipt decodes the input of the tx and the logs of its receipt with the abis returned by abiOf, and the
+// logs of the contracts not verified are decoded as nil
+func decodeReceipt(input []byte, to *common.Address, logs []*ethtypes.Log,
+	abiOf func(common.Address) (abi.ABI, bool)) (*DecodedCall, []*DecodedCall) {
+	var decodedInput *DecodedCall
+	if to != nil {
+		if contractABI, ok := abiOf(*to); ok {
+			decodedInput, _ = decodeInput(contractABI, input)
+		}
+	}
+
+	decodedLogs := make([]*DecodedCall, len(logs))
+	for i, log := range logs {
+		if contractABI, ok := abiOf(log.Address); ok {
+			decodedLogs[i], _ = decodeLog(contractABI, log)
+		}
+	}
+	return decodedInput, decodedLogs
+}
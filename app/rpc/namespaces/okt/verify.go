@@ -0,0 +1,213 @@
+package okt
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// the timeout of a compilation
+	compileTimeout = time.Minute
+	// the maximum number of compilations run at the same time
+	maxConcurrentCompiles = 2
+)
+
+// compileSlots limits the compilations run at the same time, as each one takes a solc process
+var compileSlots = make(chan struct{}, maxConcurrentCompiles)
+
+// VerifyContractArgs represents the arguments to verify the source of a deployed contract
+type VerifyContractArgs struct {
+	Address common.Address `json:"address"`
+	// the fully qualified name of the contract, e.g. contracts/Token.sol:Token
+	ContractName string `json:"contractName"`
+	// the solidity standard json input
+	Input json.RawMessage `json:"input"`
+}
+
+// VerifiedContract is the source and the abi of a verified contract
+type VerifiedContract struct {
+	Address      common.Address  `json:"address"`
+	ContractName string          `json:"contractName"`
+	Compiler     string          `json:"compiler"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	ABI          json.RawMessage `json:"abi"`
+	Input        json.RawMessage `json:"input"`
+}
+
+type immutableReference struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+type compiledContract struct {
+	ABI json.RawMessage `json:"abi"`
+	EVM struct {
+		DeployedBytecode struct {
+			Object              string                          `json:"object"`
+			ImmutableReferences map[string][]immutableReference `json:"immutableReferences"`
+		} `json:"deployedBytecode"`
+	} `json:"evm"`
+}
+
+type compilerOutput struct {
+	Errors []struct {
+		Severity         string `json:"severity"`
+		FormattedMessage string `json:"formattedMessage"`
+	} `json:"errors"`
+	Contracts map[string]map[string]compiledContract `json:"contracts"`
+}
+
+// prepareInput checks that the sources of the standard json input are all inlined, as the compiler mustn't read the
+// files of the node, and selects the outputs needed by the verification
+func prepareInput(input json.RawMessage) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(input, &fields); err != nil {
+		return nil, fmt.Errorf("invalid standard json input: %s", err)
+	}
+
+	var sources map[string]struct {
+		Content *string `json:"content"`
+	}
+	if err := json.Unmarshal(fields["sources"], &sources); err != nil || len(sources) == 0 {
+		return nil, fmt.Errorf("no sources in the standard json input")
+	}
+	for name, source := range sources {
+		if source.Content == nil {
+			return nil, fmt.Errorf("source %s has no content, the urls aren't supported", name)
+		}
+	}
+
+	settings := make(map[string]json.RawMessage)
+	if raw, ok := fields["settings"]; ok {
+		if err := json.Unmarshal(raw, &settings); err != nil {
+			return nil, fmt.Errorf("invalid settings of the standard json input: %s", err)
+		}
+	}
+	settings["outputSelection"] = json.RawMessage(
+		`{"*":{"*":["abi","evm.deployedBytecode.object","evm.deployedBytecode.immutableReferences"]}}`)
+
+	var err error
+	if fields["settings"], err = json.Marshal(settings); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// compile compiles the standard json input with the solc binary, and returns the named contract and the version of
+// the compiler. solc runs in an empty directory without the import callback, so it can't read the files of the node.
+func compile(solc string, input json.RawMessage, contractName string) (*compiledContract, string, error) {
+	idx := strings.LastIndex(contractName, ":")
+	if idx < 0 {
+		return nil, "", fmt.Errorf("contract name must be in the form of <source>:<name>")
+	}
+	source, name := contractName[:idx], contractName[idx+1:]
+
+	stdin, err := prepareInput(input)
+	if err != nil {
+		return nil, "", err
+	}
+
+	select {
+	case compileSlots <- struct{}{}:
+		defer func() { <-compileSlots }()
+	default:
+		return nil, "", fmt.Errorf("too many compilations in progress, please retry later")
+	}
+
+	dir, err := ioutil.TempDir("", "solc")
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), compileTimeout)
+	defer cancel()
+	version, err := exec.CommandContext(ctx, solc, "--version").Output()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to run solc: %s", err)
+	}
+	cmd := exec.CommandContext(ctx, solc, "--standard-json", "--no-import-callback")
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(stdin)
+	stdout, err := cmd.Output()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to run solc: %s", err)
+	}
+
+	var output compilerOutput
+	if err := json.Unmarshal(stdout, &output); err != nil {
+		return nil, "", fmt.Errorf("invalid solc output: %s", err)
+	}
+	for _, e := range output.Errors {
+		if e.Severity == "error" {
+			return nil, "", fmt.Errorf("compilation failed: %s", e.FormattedMessage)
+		}
+	}
+	compiled, ok := output.Contracts[source][name]
+	if !ok {
+		return nil, "", fmt.Errorf("contract %s not found in the compilation output", contractName)
+	}
+	return &compiled, parseCompilerVersion(string(version)), nil
+}
+
+// parseCompilerVersion returns the version in the output of solc --version
+func parseCompilerVersion(output string) string {
+	const prefix = "Version: "
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, prefix))
+		}
+	}
+	return strings.TrimSpace(output)
+}
+
+// matchBytecode compares the compiled runtime bytecode with the deployed one. The immutables, which are only filled
+// in the deployed code, and the metadata, which depends on the paths and the comments of the sources, are ignored.
+func matchBytecode(compiled *compiledContract, deployed []byte) (bool, error) {
+	object := strings.TrimPrefix(compiled.EVM.DeployedBytecode.Object, "0x")
+	if strings.Contains(object, "__$") {
+		return false, fmt.Errorf("contracts with unlinked libraries aren't supported")
+	}
+	code, err := hex.DecodeString(object)
+	if err != nil {
+		return false, fmt.Errorf("invalid compiled bytecode: %s", err)
+	}
+	if len(code) != len(deployed) {
+		return false, nil
+	}
+
+	deployed = append([]byte{}, deployed...)
+	for _, refs := range compiled.EVM.DeployedBytecode.ImmutableReferences {
+		for _, ref := range refs {
+			if ref.Start < 0 || ref.Length < 0 || ref.Start+ref.Length > len(deployed) {
+				return false, fmt.Errorf("invalid immutable reference")
+			}
+			copy(deployed[ref.Start:ref.Start+ref.Length], make([]byte, ref.Length))
+		}
+	}
+	return bytes.Equal(stripMetadata(code), stripMetadata(deployed)), nil
+}
+
+// stripMetadata strips the cbor encoded metadata appended to the code, whose length is in the last two bytes
+func stripMetadata(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+	size := int(code[len(code)-2])<<8 | int(code[len(code)-1])
+	start := len(code) - 2 - size
+	// the metadata is a cbor map, whose major type is 5
+	if size == 0 || start < 0 || code[start]>>5 != 5 {
+		return code
+	}
+	return code[:start]
+}
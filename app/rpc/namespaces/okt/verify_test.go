@@ -0,0 +1,87 @@
+package okt
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+const erc20ABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+func TestPrepareInput(t *testing.T) {
+	_, err := prepareInput(json.RawMessage(`{"language":"Solidity","sources":{"A.sol":{"urls":["/etc/passwd"]}}}`))
+	require.Error(t, err)
+
+	input, err := prepareInput(json.RawMessage(`{"language":"Solidity","sources":{"A.sol":{"content":"contract A {}"}},"settings":{"optimizer":{"enabled":true}}}`))
+	require.NoError(t, err)
+	var fields struct {
+		Settings map[string]json.RawMessage `json:"settings"`
+	}
+	require.NoError(t, json.Unmarshal(input, &fields))
+	require.Equal(t, `{"enabled":true}`, string(fields.Settings["optimizer"]))
+	require.Contains(t, string(fields.Settings["outputSelection"]), "evm.deployedBytecode.object")
+}
+
+func TestMatchBytecode(t *testing.T) {
+	// runtime code with a 32 bytes immutable at 1, followed by the cbor metadata {"a":1} and its length
+	code := "7f" + strings.Repeat("00", 32) + "00"
+	compiled := &compiledContract{}
+	compiled.EVM.DeployedBytecode.Object = code + "a1616101" + "0004"
+	compiled.EVM.DeployedBytecode.ImmutableReferences = map[string][]immutableReference{"3": {{Start: 1, Length: 32}}}
+
+	deployed, err := hex.DecodeString("7f" + strings.Repeat("11", 32) + "00" + "a1616102" + "0004")
+	require.NoError(t, err)
+	matched, err := matchBytecode(compiled, deployed)
+	require.NoError(t, err)
+	require.True(t, matched)
+
+	deployed[0] = 0x60
+	matched, err = matchBytecode(compiled, deployed)
+	require.NoError(t, err)
+	require.False(t, matched)
+
+	compiled.EVM.DeployedBytecode.Object = "73__$abc$__"
+	_, err = matchBytecode(compiled, deployed)
+	require.Error(t, err)
+}
+
+func TestDecodeReceipt(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(erc20ABI))
+	require.NoError(t, err)
+	contract, to := common.BytesToAddress([]byte("token")), common.BytesToAddress([]byte("to"))
+	abiOf := func(address common.Address) (abi.ABI, bool) {
+		return contractABI, address == contract
+	}
+
+	input, err := contractABI.Pack("transfer", to, big.NewInt(10))
+	require.NoError(t, err)
+	data, err := contractABI.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(10))
+	require.NoError(t, err)
+	logs := []*ethtypes.Log{
+		{Address: contract, Topics: []common.Hash{contractABI.Events["Transfer"].ID, common.Hash{}, to.Hash()}, Data: data},
+		{Address: to},
+	}
+
+	decodedInput, decodedLogs := decodeReceipt(input, &contract, logs, abiOf)
+	require.Equal(t, "transfer", decodedInput.Name)
+	require.Equal(t, to, decodedInput.Args["to"])
+	require.Equal(t, big.NewInt(10), decodedInput.Args["amount"])
+	require.Len(t, decodedLogs, 2)
+	require.Equal(t, "Transfer(address,address,uint256)", decodedLogs[0].Signature)
+	require.Equal(t, to, decodedLogs[0].Args["to"])
+	require.Equal(t, big.NewInt(10), decodedLogs[0].Args["value"])
+	require.Nil(t, decodedLogs[1])
+
+	decodedInput, _ = decodeReceipt(input, &to, nil, abiOf)
+	require.Nil(t, decodedInput)
+}
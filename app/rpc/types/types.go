@@ -1,12 +1,14 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
 )
 
 // Copied the Account and StorageResult types since they are registered under an
@@ -132,12 +134,47 @@ func (ca CallArgs) String() string {
 // set, message execution will only use the data in the given state. Otherwise
 // if statDiff is set, all diff will be applied first and then execute the call
 // message.
-type Account struct {
-	Nonce     *hexutil.Uint64              `json:"nonce"`
-	Code      *hexutil.Bytes               `json:"code"`
-	Balance   **hexutil.Big                `json:"balance"`
-	State     *map[common.Hash]common.Hash `json:"state"`
-	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+type Account = evmtypes.StateOverrideAccount
+
+// ConditionalOptions represents the preconditions of a conditional transaction or a bundle, in the format of the
+// ERC-4337 bundlers. The block number and the timestamp are the ones of the block the transactions are included in.
+type ConditionalOptions struct {
+	BlockNumberMin *hexutil.Uint64                 `json:"blockNumberMin,omitempty"`
+	BlockNumberMax *hexutil.Uint64                 `json:"blockNumberMax,omitempty"`
+	TimestampMin   *hexutil.Uint64                 `json:"timestampMin,omitempty"`
+	TimestampMax   *hexutil.Uint64                 `json:"timestampMax,omitempty"`
+	KnownAccounts  map[common.Address]KnownAccount `json:"knownAccounts,omitempty"`
+}
+
+// KnownAccount is either the storage root hash or the expected values of the storage slots of an account
+type KnownAccount struct {
+	StorageRoot  *common.Hash
+	StorageSlots map[common.Hash]common.Hash
+}
+
+// UnmarshalJSON unmarshals the storage root hash or the storage slots of the account
+func (ka *KnownAccount) UnmarshalJSON(data []byte) error {
+	var root common.Hash
+	if err := json.Unmarshal(data, &root); err == nil {
+		ka.StorageRoot = &root
+		return nil
+	}
+	return json.Unmarshal(data, &ka.StorageSlots)
+}
+
+// SendBundleArgs represents the arguments to submit a bundle of signed transactions of a single sender with
+// consecutive nonces, executed atomically in order in the same block
+type SendBundleArgs struct {
+	Txs []hexutil.Bytes `json:"txs"`
+	ConditionalOptions
+}
+
+// FeeHistoryResult represents the result of eth_feeHistory
+type FeeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
 }
 
 // EthHeaderWithBlockHash represents a block header in the Ethereum blockchain with block hash generated from Tendermint Block
@@ -18,6 +18,7 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
 	evmtypes "github.com/okex/exchain/x/evm/types"
+	feegranttypes "github.com/okex/exchain/x/feegrant/types"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmtypes "github.com/tendermint/tendermint/types"
 )
@@ -242,17 +243,27 @@ func GetBlockCumulativeGas(cdc *codec.Codec, block *tmtypes.Block, idx int) uint
 		if err != nil {
 			continue
 		}
-
-		switch tx := txi.(type) {
-		case authtypes.StdTx:
-			gasUsed += tx.GetGas()
-		case evmtypes.MsgEthereumTx:
-			gasUsed += tx.GetGas()
-		}
+		gasUsed += GetTxGas(txi)
 	}
 	return gasUsed
 }
 
+// GetTxGas returns the gas limit of the tx counted in the cumulative gas of a block
+func GetTxGas(txi sdk.Tx) uint64 {
+	switch tx := txi.(type) {
+	case authtypes.StdTx:
+		return tx.GetGas()
+	case feegranttypes.FeeGrantTx:
+		return tx.GetGas()
+	case evmtypes.MsgEthereumTx:
+		return tx.GetGas()
+	case evmtypes.BundleTx:
+		return tx.GetGas()
+	default:
+		return 0
+	}
+}
+
 // EthHeaderWithBlockHashFromTendermint gets the eth Header with block hash from Tendermint block inside
 func EthHeaderWithBlockHashFromTendermint(tmHeader *tmtypes.Header) (header *EthHeaderWithBlockHash, err error) {
 	if tmHeader == nil {
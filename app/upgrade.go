@@ -0,0 +1,86 @@
+package app
+
+import (
+	"fmt"
+
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/okex/exchain/x/authz"
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/feegrant"
+)
+
+// venusStoreKeys returns the names of the stores added by the Venus upgrade
+func venusStoreKeys() []string {
+	return []string{feegrant.StoreKey, authz.StoreKey}
+}
+
+// mountKVStores mounts the stores, except the ones added by the Venus upgrade which are mounted when the chain has them
+func (app *OKExChainApp) mountKVStores(keys map[string]*sdk.KVStoreKey) {
+	mounted := make(map[string]*sdk.KVStoreKey, len(keys))
+	for name, key := range keys {
+		mounted[name] = key
+	}
+	for _, name := range venusStoreKeys() {
+		delete(mounted, name)
+	}
+	app.MountKVStores(mounted)
+	app.SetStoreLoader(app.venusStoreLoader)
+}
+
+// venusStoreLoader loads the latest version with the stores added by the Venus upgrade if the next block has them. The
+// stores are added to the multistore right before the upgrade height, and a chain without them can't be loaded with
+// them, whose empty trees would change the app hash.
+func (app *OKExChainApp) venusStoreLoader(ms sdk.CommitMultiStore) error {
+	versioner, ok := ms.(interface{ GetLatestVersion() int64 })
+	if !ok {
+		return bam.DefaultStoreLoader(ms)
+	}
+	latest := versioner.GetLatestVersion()
+	if !common.HasVenusStores(latest + 1) {
+		return bam.DefaultStoreLoader(ms)
+	}
+
+	for _, name := range venusStoreKeys() {
+		ms.MountStoreWithDB(app.keys[name], sdk.StoreTypeIAVL, nil)
+	}
+	app.venusStoresMounted = true
+	if latest+1 == common.GetVenusHeight() {
+		return ms.LoadLatestVersionAndUpgrade(&storetypes.StoreUpgrades{Added: venusStoreKeys()})
+	}
+
+	if err := ms.LoadLatestVersion(); err != nil {
+		return err
+	}
+	// a store never committed is loaded at the start height of the chain
+	for _, name := range venusStoreKeys() {
+		if latest != 0 && ms.GetCommitKVStore(app.keys[name]).LastCommitID().Version <= tmtypes.GetStartBlockHeight() {
+			return fmt.Errorf("the store %s isn't in the chain at height %d, whose Venus upgrade height has to be set",
+				name, latest)
+		}
+	}
+	return nil
+}
+
+// mountVenusStores mounts the stores added by the Venus upgrade before a version is loaded, if the version has them
+func (app *OKExChainApp) mountVenusStores(version int64) {
+	if app.venusStoresMounted || !common.HasVenusStores(version) {
+		return
+	}
+	for _, name := range venusStoreKeys() {
+		app.MountStore(app.keys[name], sdk.StoreTypeIAVL)
+	}
+	app.venusStoresMounted = true
+}
+
+// checkVenusStores stops the node at the upgrade height of Venus if the stores it adds aren't mounted, which are added
+// by the store loader when the node restarts
+func (app *OKExChainApp) checkVenusStores(ctx sdk.Context) {
+	if !app.venusStoresMounted && common.HasVenusStores(ctx.BlockHeight()) {
+		panic(fmt.Sprintf("UPGRADE \"venus\" NEEDED at height %d: restart the node to mount the stores %v",
+			common.GetVenusHeight(), venusStoreKeys()))
+	}
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"testing"
+
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/okex/exchain/x/common"
+	feegranttypes "github.com/okex/exchain/x/feegrant/types"
+)
+
+func newVenusTestApp(db dbm.DB, loadLatest bool) *OKExChainApp {
+	return NewOKExChainApp(log.NewNopLogger(), db, nil, loadLatest, map[int64]bool{}, 0)
+}
+
+func initVenusTestChain(t *testing.T, app *OKExChainApp, height int64) {
+	stateBytes, err := codec.MarshalJSONIndent(app.Codec(), NewDefaultGenesisState())
+	require.NoError(t, err)
+	app.InitChain(abci.RequestInitChain{Validators: []abci.ValidatorUpdate{}, AppStateBytes: stateBytes})
+	for h := int64(1); h <= height; h++ {
+		commitVenusTestBlock(app, h)
+	}
+}
+
+func commitVenusTestBlock(app *OKExChainApp, height int64) {
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+	app.EndBlock(abci.RequestEndBlock{Height: height})
+	app.Commit()
+}
+
+func TestVenusStoreUpgrade(t *testing.T) {
+	common.MILESTONE_VENUS_HEIGHT = "3"
+	defer func() { common.MILESTONE_VENUS_HEIGHT = "" }()
+
+	db := dbm.NewMemDB()
+	app := newVenusTestApp(db, true)
+	require.False(t, app.venusStoresMounted)
+	initVenusTestChain(t, app, 2)
+
+	// the node without the stores stops at the upgrade height
+	require.Panics(t, func() {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	})
+
+	// the stores are added when the node restarts right before the upgrade height
+	app = newVenusTestApp(db, true)
+	require.True(t, app.venusStoresMounted)
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	granter := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	grantee := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	grant := feegranttypes.NewFeeAllowanceGrant(granter, grantee, feegranttypes.FeeAllowance{})
+	app.FeeGrantKeeper.GrantFeeAllowance(app.NewContext(false, abci.Header{Height: 3}), grant)
+	app.EndBlock(abci.RequestEndBlock{Height: 3})
+	app.Commit()
+
+	// and are loaded with the chain after the upgrade height
+	app = newVenusTestApp(db, true)
+	require.True(t, app.venusStoresMounted)
+	ctx := app.NewContext(true, abci.Header{Height: 4})
+	_, found := app.FeeGrantKeeper.GetFeeAllowance(ctx, granter, grantee)
+	require.True(t, found)
+	require.Empty(t, app.AuthzKeeper.GetGrants(ctx, granter, grantee))
+}
+
+func TestVenusStoreUpgrade_NotSet(t *testing.T) {
+	common.MILESTONE_VENUS_HEIGHT = "5"
+	defer func() { common.MILESTONE_VENUS_HEIGHT = "" }()
+	db := dbm.NewMemDB()
+	initVenusTestChain(t, newVenusTestApp(db, true), 2)
+
+	// a chain running before the upgrade can't be loaded without its upgrade height
+	common.MILESTONE_VENUS_HEIGHT = ""
+	app := newVenusTestApp(db, false)
+	require.Error(t, app.LoadLatestVersion(app.keys[bam.MainStoreKey]))
+}
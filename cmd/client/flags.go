@@ -1,14 +1,21 @@
 package client
 
 import (
+	"time"
+
 	"github.com/okex/exchain/app"
 	"github.com/okex/exchain/app/config"
 	"github.com/okex/exchain/app/rpc"
 	"github.com/okex/exchain/app/rpc/namespaces/eth"
 	"github.com/okex/exchain/app/rpc/namespaces/eth/filters"
+	"github.com/okex/exchain/app/rpc/namespaces/okt"
+	"github.com/okex/exchain/x/backend/graphql"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/evm/watcher"
 	"github.com/okex/exchain/x/stream"
+	"github.com/okex/exchain/x/stream/natsclient"
+	"github.com/okex/exchain/x/stream/webhook"
+	"github.com/okex/exchain/x/stream/websocket"
 	"github.com/okex/exchain/x/token"
 	"github.com/spf13/cobra"
 )
@@ -16,13 +23,28 @@ import (
 func RegisterAppFlag(cmd *cobra.Command) {
 	cmd.Flags().Bool(watcher.FlagFastQuery, false, "Enable the fast query mode for rpc queries")
 	cmd.Flags().Int(watcher.FlagFastQueryLru, 1000, "Set the size of LRU cache under fast-query mode")
+	cmd.Flags().Uint64(watcher.FlagFastQueryHistory, 0, "Set the number of the latest blocks whose account and storage changes are kept for the historical queries under fast-query mode, 0 disables the history")
 	cmd.Flags().Bool(rpc.FlagPersonalAPI, true, "Enable the personal_ prefixed set of APIs in the Web3 JSON-RPC spec")
 	cmd.Flags().Bool(evmtypes.FlagEnableBloomFilter, false, "Enable bloom filter for event logs")
+	cmd.Flags().Int64(evmtypes.FlagContractStatsBlocks, 0, "Set the number of the latest blocks whose gas used, calls, storage writes and failures per contract are kept for the metrics and the queries, 0 disables the contract stats")
+	cmd.Flags().Int(evmtypes.FlagContractStatsTop, 20, "Set the number of the contracts with the most gas used exported to the metrics every block, the others are summed as other")
 	cmd.Flags().Int64(filters.FlagGetLogsHeightSpan, 2000, "config the block height span for get logs")
 	cmd.Flags().String(stream.NacosTmrpcUrls, "", "Stream plugin`s nacos server urls for discovery service of tendermint rpc")
 	cmd.Flags().String(stream.NacosTmrpcNamespaceID, "", "Stream plugin`s nacos namepace id for discovery service of tendermint rpc")
 	cmd.Flags().String(stream.NacosTmrpcAppName, "", "Stream plugin`s tendermint rpc name in eureka or nacos")
 	cmd.Flags().String(stream.RpcExternalAddr, "127.0.0.1:26657", "Set the rpc-server external ip and port, when it is launched by Docker (default \"127.0.0.1:26657\")")
+	cmd.Flags().Bool(stream.FlagOutboxEnable, false, "Enable the stream outbox keeping the kafka or pulsar data of each block until it's written, so it's redelivered after restarts and can be replayed")
+	cmd.Flags().Int64(stream.FlagOutboxRetainBlocks, 100000, "Set the number of recent blocks whose data is retained in the stream outbox for replaying, 0 means no pruning")
+	cmd.Flags().String(natsclient.FlagNatsStream, "EXCHAIN", "Set the JetStream stream of the stream nats engine, which is created capturing the subjects of the prefix if it doesn't exist")
+	cmd.Flags().String(natsclient.FlagNatsSubjectPrefix, "exchain", "Set the subject prefix of the stream nats engine")
+	cmd.Flags().Int(webhook.FlagWebhookMaxRetries, 5, "Set the max retries of a failed delivery of the stream webhook engine")
+	cmd.Flags().Duration(webhook.FlagWebhookTimeout, 10*time.Second, "Set the request timeout of the stream webhook engine")
+	cmd.Flags().Int(webhook.FlagWebhookQueueSize, 1000, "Set the blocks queued per endpoint of the stream webhook engine")
+	cmd.Flags().Int(websocket.FlagNativeMaxConns, 1000, "Set the max connections of the stream native websocket engine, 0 means no limit")
+	cmd.Flags().Int(websocket.FlagNativeMaxSubscriptions, 100, "Set the max subscriptions per connection of the stream native websocket engine, 0 means no limit")
+	cmd.Flags().Int(websocket.FlagNativeSendBuffer, 256, "Set the messages buffered per connection of the stream native websocket engine before the slow consumer is disconnected")
+	cmd.Flags().Duration(websocket.FlagNativeLoginExpiry, 5*time.Minute, "Set how long the login signature of the stream native websocket engine is valid")
+	cmd.Flags().Int(graphql.FlagMaxComplexity, graphql.DefaultMaxComplexity, "Set the complexity limit of a backend GraphQL query, which is the total page sizes of the connections queried")
 
 	cmd.Flags().String(rpc.FlagRateLimitAPI, "", "Set the RPC API to be controlled by the rate limit policy, such as \"eth_getLogs,eth_newFilter,eth_newBlockFilter,eth_newPendingTransactionFilter,eth_getFilterChanges\"")
 	cmd.Flags().Int(rpc.FlagRateLimitCount, 0, "Set the count of requests allowed per second of rpc rate limiter")
@@ -31,6 +53,7 @@ func RegisterAppFlag(cmd *cobra.Command) {
 	cmd.Flags().String(rpc.FlagDisableAPI, "", "Set the RPC API to be disabled, such as \"eth_getLogs,eth_newFilter,eth_newBlockFilter,eth_newPendingTransactionFilter,eth_getFilterChanges\"")
 	cmd.Flags().Int(config.FlagDynamicGpWeight, 80, "The recommended weight of dynamic gas price [1,100])")
 	cmd.Flags().Bool(config.FlagEnableDynamicGp, true, "Enable node to dynamic support gas price suggest")
+	cmd.Flags().Int(config.FlagDynamicGpBlocks, 20, "The number of the latest blocks whose gas prices are used by the dynamic gas price suggest and eth_feeHistory")
 	cmd.Flags().Bool(eth.FlagEnableMultiCall, false, "Enable node to support the eth_multiCall RPC API")
 
 	cmd.Flags().Bool(token.FlagOSSEnable, false, "Enable the function of exporting account data and uploading to oss")
@@ -40,6 +63,7 @@ func RegisterAppFlag(cmd *cobra.Command) {
 	cmd.Flags().String(token.FlagOSSBucketName, "", "The OSS bucket name")
 	cmd.Flags().String(token.FlagOSSObjectPath, "", "The OSS object path")
 
+	cmd.Flags().String(okt.FlagSolcPath, "", "Set the path of the solc binary, which must support --no-import-callback, to enable the okt_ prefixed contract verification APIs")
 	cmd.Flags().Bool(eth.FlagEnableTxPool, false, "Enable the function of txPool to support concurrency call eth_sendRawTransaction")
 	cmd.Flags().Uint64(eth.TxPoolCap, 10000, "Set the txPool slice max length")
 	cmd.Flags().Int(eth.BroadcastPeriodSecond, 10, "every BroadcastPeriodSecond second check the txPool, and broadcast when it's eligible")
@@ -26,6 +26,7 @@ import (
 	"github.com/okex/exchain/app"
 	"github.com/okex/exchain/app/codec"
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	"github.com/okex/exchain/app/crypto/secp256r1"
 	okexchain "github.com/okex/exchain/app/types"
 	"github.com/okex/exchain/cmd/client"
 	tokencmd "github.com/okex/exchain/x/token/client/cli"
@@ -42,6 +43,9 @@ func main() {
 	tmamino.RegisterKeyType(ethsecp256k1.PubKey{}, ethsecp256k1.PubKeyName)
 	tmamino.RegisterKeyType(ethsecp256k1.PrivKey{}, ethsecp256k1.PrivKeyName)
 	multisig.RegisterKeyType(ethsecp256k1.PubKey{}, ethsecp256k1.PubKeyName)
+	tmamino.RegisterKeyType(secp256r1.PubKey{}, secp256r1.PubKeyName)
+	tmamino.RegisterKeyType(secp256r1.PrivKey{}, secp256r1.PrivKeyName)
+	multisig.RegisterKeyType(secp256r1.PubKey{}, secp256r1.PubKeyName)
 
 	keys.CryptoCdc = cdc
 	clientkeys.KeysCdc = cdc
@@ -29,6 +29,7 @@ import (
 	"github.com/okex/exchain/app/codec"
 	appconfig "github.com/okex/exchain/app/config"
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	"github.com/okex/exchain/app/crypto/secp256r1"
 	okexchain "github.com/okex/exchain/app/types"
 	"github.com/okex/exchain/cmd/client"
 	"github.com/okex/exchain/x/genutil"
@@ -49,6 +50,9 @@ func main() {
 	tmamino.RegisterKeyType(ethsecp256k1.PubKey{}, ethsecp256k1.PubKeyName)
 	tmamino.RegisterKeyType(ethsecp256k1.PrivKey{}, ethsecp256k1.PrivKeyName)
 	multisig.RegisterKeyType(ethsecp256k1.PubKey{}, ethsecp256k1.PubKeyName)
+	tmamino.RegisterKeyType(secp256r1.PubKey{}, secp256r1.PubKeyName)
+	tmamino.RegisterKeyType(secp256r1.PrivKey{}, secp256r1.PrivKeyName)
+	multisig.RegisterKeyType(secp256r1.PubKey{}, secp256r1.PubKeyName)
 
 	keys.CryptoCdc = cdc
 	genutil.ModuleCdc = cdc
@@ -81,6 +85,8 @@ func main() {
 		genutilcli.ValidateGenesisCmd(ctx, cdc, app.ModuleBasics),
 		client.TestnetCmd(ctx, cdc, app.ModuleBasics, auth.GenesisAccountIterator{}),
 		replayCmd(ctx),
+		reindexBackendCmd(ctx),
+		streamReplayCmd(ctx),
 		repairStateCmd(ctx),
 		// AddGenesisAccountCmd allows users to add accounts to the genesis file
 		AddGenesisAccountCmd(ctx, cdc, app.DefaultNodeHome, app.DefaultCLIHome),
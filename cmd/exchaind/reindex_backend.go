@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/okex/exchain/app"
+	backendorm "github.com/okex/exchain/x/backend/orm"
+	backendtypes "github.com/okex/exchain/x/backend/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/mock"
+	"github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	reindexStartHeightFlag = "start_height"
+	reindexEndHeightFlag   = "end_height"
+	reindexTablesFlag      = "tables"
+)
+
+func reindexBackendCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reindex-backend",
+		Short: "Re-index the backend database by replaying blocks from local db",
+		Long: `Re-index the backend database by replaying blocks from local db.
+
+The blocks are replayed on the application db of the node home, which should be a copy of the state before the start
+height, and the backend tables are written from the start height until the end height. The data of the re-indexed
+blocks is deleted from the tables first, and the latest block re-indexed is recorded as a checkpoint, so running the
+command again resumes the re-index from the application db.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tables, err := backendtypes.ParseReindexTables(viper.GetString(reindexTablesFlag))
+			if err != nil {
+				return err
+			}
+
+			log.Println("--------- reindex backend start ---------")
+			dataDir := viper.GetString(dataDirFlag)
+			err = reindexBackend(ctx, dataDir, tables, viper.GetInt64(reindexStartHeightFlag), viper.GetInt64(reindexEndHeightFlag))
+			if err != nil {
+				return err
+			}
+			log.Println("--------- reindex backend success ---------")
+			return nil
+		},
+	}
+	cmd.Flags().StringP(dataDirFlag, "d", ".exchaind/data", "Directory of block data for replaying")
+	cmd.Flags().Int64(reindexStartHeightFlag, 0, "Height of the first block to re-index, 0 means the block after the application db")
+	cmd.Flags().Int64(reindexEndHeightFlag, 0, "Height of the last block to re-index, 0 means the latest block of the block data")
+	cmd.Flags().String(reindexTablesFlag, "", fmt.Sprintf("Comma separated tables to re-index, empty means all of %v", backendtypes.ReindexTables()))
+	cmd.Flags().String(server.FlagPruning, storetypes.PruningOptionNothing, "Pruning strategy (default|nothing|everything|custom)")
+	return cmd
+}
+
+// reindexBackend replays the blocks from db and writes the backend tables of the blocks in [startHeight, endHeight]
+func reindexBackend(ctx *server.Context, originDataDir string, tables []string, startHeight, endHeight int64) error {
+	// enable the backend, which writes nothing until the blocks to re-index
+	viper.Set(server.FlagBackendEnableBackend, true)
+	viper.Set(server.FlagBackendEnableMktCompute, true)
+	backendorm.DefaultTableFilter = []string{}
+
+	proxyApp, exApp, err := createReindexProxyApp(ctx)
+	panicError(err)
+	backendOrm := exApp.BackendKeeper.Orm
+
+	res, err := proxyApp.Query().InfoSync(proxy.RequestInfo)
+	panicError(err)
+	appHeight := res.LastBlockHeight
+	log.Println("current block height", "height", appHeight)
+
+	dataDir := filepath.Join(ctx.Config.RootDir, "data")
+	stateStoreDB, err := openDB(stateDB, dataDir)
+	panicError(err)
+
+	genesisDocProvider := node.DefaultGenesisDocProviderFunc(ctx.Config)
+	state, genDoc, err := node.LoadStateFromDBOrGenesisDocProvider(stateStoreDB, genesisDocProvider)
+	panicError(err)
+
+	// If startBlockHeight == 0 it means that we are at genesis and hence should initChain.
+	if appHeight == types.GetStartBlockHeight() {
+		panicError(initChain(state, stateStoreDB, genDoc, proxyApp))
+		state = sm.LoadState(stateStoreDB)
+	}
+
+	originBlockStoreDB, err := openDB(blockStoreDB, originDataDir)
+	panicError(err)
+	originBlockStore := store.NewBlockStore(originBlockStoreDB)
+	if endHeight == 0 {
+		endHeight = originBlockStore.Height()
+	}
+	if startHeight == 0 {
+		startHeight = appHeight + 1
+	}
+	if endHeight > originBlockStore.Height() || startHeight > endHeight {
+		return fmt.Errorf("invalid height range [%d, %d], the latest block of the block data is %d",
+			startHeight, endHeight, originBlockStore.Height())
+	}
+
+	// the blocks before the application db can't be replayed, unless they've been re-indexed by the last run
+	writeFrom := startHeight
+	if appHeight >= startHeight {
+		checkpoint, err := backendOrm.GetReindexCheckpoint()
+		if err != nil {
+			return err
+		}
+		if checkpoint == nil || checkpoint.Tables != strings.Join(tables, ",") ||
+			checkpoint.Height < startHeight-1 || checkpoint.Height < appHeight-1 {
+			return fmt.Errorf("the application db is at height %d, restore it to a height before %d to re-index",
+				appHeight, startHeight)
+		}
+		writeFrom = appHeight + 1
+		log.Println("resume from checkpoint", "height", checkpoint.Height)
+	}
+	if writeFrom > endHeight {
+		log.Println("blocks have been re-indexed", "height", endHeight)
+		return nil
+	}
+
+	startTS := originBlockStore.LoadBlockMeta(writeFrom).Header.Time.Unix()
+	endTS := originBlockStore.LoadBlockMeta(endHeight).Header.Time.Unix()
+	if err := backendOrm.DeleteReindexData(tables, writeFrom, endHeight, startTS, endTS); err != nil {
+		return err
+	}
+	log.Println("reindex block range", "start", writeFrom, "end", endHeight)
+
+	// Apply the block committed by the app but not saved by the state, see doReplay
+	if appHeight == state.LastBlockHeight+1 {
+		abciResponses, err := sm.LoadABCIResponses(stateStoreDB, appHeight)
+		panicError(err)
+		mockApp := newMockProxyApp(res.LastBlockAppHash, abciResponses)
+		block := originBlockStore.LoadBlock(appHeight)
+		meta := originBlockStore.LoadBlockMeta(appHeight)
+		blockExec := sm.NewBlockExecutor(stateStoreDB, ctx.Logger, mockApp, mock.Mempool{}, sm.MockEvidencePool{})
+		state, _, err = blockExec.ApplyBlock(state, meta.BlockID, block)
+		panicError(err)
+	}
+
+	blockExec := sm.NewBlockExecutor(stateStoreDB, ctx.Logger, proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
+	for height := appHeight + 1; height <= endHeight; height++ {
+		if height == writeFrom {
+			backendOrm.SetTableFilter(tables)
+		}
+
+		block := originBlockStore.LoadBlock(height)
+		meta := originBlockStore.LoadBlockMeta(height)
+		state, _, err = blockExec.ApplyBlock(state, meta.BlockID, block)
+		panicError(err)
+
+		if height >= writeFrom {
+			if err := backendOrm.SaveReindexCheckpoint(height, block.Time.Unix(), tables); err != nil {
+				return err
+			}
+			log.Println("reindexed", height)
+		} else {
+			log.Println("replayed", height)
+		}
+	}
+
+	// the klines are merged in background when the blocks are replayed, build the rest of them
+	if endTS > startTS && backendOrm.IsTableEnabled(backendtypes.ReindexTableKlines) {
+		return backendOrm.RebuildKlines(startTS, endTS)
+	}
+	return nil
+}
+
+func createReindexProxyApp(ctx *server.Context) (proxy.AppConns, *app.OKExChainApp, error) {
+	dataDir := filepath.Join(ctx.Config.RootDir, "data")
+	db, err := openDB(applicationDB, dataDir)
+	panicError(err)
+	exApp := newApp(ctx.Logger, db, nil).(*app.OKExChainApp)
+	clientCreator := proxy.NewLocalClientCreator(exApp)
+	proxyApp, err := createAndStartProxyAppConns(clientCreator)
+	return proxyApp, exApp, err
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"log"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	appcfg "github.com/cosmos/cosmos-sdk/server/config"
+	"github.com/okex/exchain/x/stream"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	streamReplayKindFlag = "stream_kind"
+)
+
+func streamReplayCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stream-replay",
+		Short: "Replay the stream data of blocks kept in the stream outbox to an engine",
+		Long: `Replay the stream data of blocks kept in the stream outbox to an engine.
+
+The data of the blocks in [start_height, end_height] is written again to the engine of the stream kind configured in
+the stream engine of app.toml, e.g. kafka or pulsar. Every message carries the idempotent key
+"height-engine-sequence", so the consumers can deduplicate the messages written before. The node must be stopped as
+the outbox db is locked by it.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := appcfg.ParseConfig()
+			if err != nil {
+				return err
+			}
+
+			log.Println("--------- stream replay start ---------")
+			dataDir := filepath.Join(ctx.Config.RootDir, "data")
+			replayed, err := stream.ReplayOutbox(ctx.Logger, cfg.StreamConfig, dataDir, viper.GetString(streamReplayKindFlag),
+				viper.GetInt64(reindexStartHeightFlag), viper.GetInt64(reindexEndHeightFlag))
+			log.Printf("%d blocks replayed\n", replayed)
+			if err != nil {
+				return err
+			}
+			log.Println("--------- stream replay success ---------")
+			return nil
+		},
+	}
+	cmd.Flags().String(streamReplayKindFlag, "kafka", "Stream kind of the engine to replay to, kafka or pulsar")
+	cmd.Flags().Int64(reindexStartHeightFlag, 0, "Height of the first block to replay")
+	cmd.Flags().Int64(reindexEndHeightFlag, 0, "Height of the last block to replay")
+	return cmd
+}
@@ -0,0 +1,43 @@
+package authz
+
+import (
+	"github.com/okex/exchain/x/authz/keeper"
+	"github.com/okex/exchain/x/authz/types"
+)
+
+const (
+	// nolint
+	ModuleName   = types.ModuleName
+	RouterKey    = types.RouterKey
+	StoreKey     = types.StoreKey
+	QuerierRoute = types.QuerierRoute
+)
+
+var (
+	// functions aliases
+	// nolint
+	NewKeeper        = keeper.NewKeeper
+	NewQuerier       = keeper.NewQuerier
+	RegisterCodec    = types.RegisterCodec
+	NewAuthorization = types.NewAuthorization
+	NewGrant         = types.NewGrant
+	NewMsgGrant      = types.NewMsgGrant
+	NewMsgRevoke     = types.NewMsgRevoke
+	NewMsgExec       = types.NewMsgExec
+
+	// variable aliases
+	// nolint
+	ModuleCdc = types.ModuleCdc
+)
+
+type (
+	// nolint
+	Keeper = keeper.Keeper
+
+	// nolint
+	Authorization = types.Authorization
+	Grant         = types.Grant
+	MsgGrant      = types.MsgGrant
+	MsgRevoke     = types.MsgRevoke
+	MsgExec       = types.MsgExec
+)
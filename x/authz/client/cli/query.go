@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/spf13/cobra"
+
+	"github.com/okex/exchain/x/authz/types"
+)
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	queryCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Querying commands for the authz module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	queryCmd.AddCommand(flags.GetCommands(
+		GetCmdQueryGrant(queryRoute, cdc),
+		GetCmdQueryGrants(queryRoute, cdc),
+	)...)
+
+	return queryCmd
+}
+
+// GetCmdQueryGrant gets the grant query command.
+func GetCmdQueryGrant(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "grant [granter] [grantee] [msg-type]",
+		Short: "Query the authorization of a message type granted by granter to grantee",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the authorization of a message type granted by granter to grantee.
+The message type is given as route/type.
+
+Example:
+$ %s query authz grant ex1... ex1... order/new
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			route := fmt.Sprintf("custom/%s/%s/%s/%s/%s", queryRoute, types.QueryGrant, args[0], args[1], args[2])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var grant types.Grant
+			cdc.MustUnmarshalJSON(bz, &grant)
+			return cliCtx.PrintOutput(grant)
+		},
+	}
+}
+
+// GetCmdQueryGrants gets the query command of all the grants from granter to grantee.
+func GetCmdQueryGrants(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "grants [granter] [grantee]",
+		Short: "Query all the authorizations granted by granter to grantee",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query all the authorizations granted by granter to grantee.
+
+Example:
+$ %s query authz grants ex1... ex1...
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			route := fmt.Sprintf("custom/%s/%s/%s/%s", queryRoute, types.QueryGrants, args[0], args[1])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var grants []types.Grant
+			cdc.MustUnmarshalJSON(bz, &grants)
+			return cliCtx.PrintOutput(grants)
+		},
+	}
+}
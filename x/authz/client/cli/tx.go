@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/okex/exchain/x/authz/types"
+)
+
+// flags
+const (
+	flagExpiration      = "expiration"
+	flagAllowedProducts = "allowed-products"
+	flagMaxQuantity     = "max-quantity"
+)
+
+// GetTxCmd returns the transaction commands for this module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:   types.ModuleName,
+		Short: "Authorization transactions subcommands",
+	}
+
+	txCmd.AddCommand(flags.PostCommands(
+		GetCmdGrant(cdc),
+		GetCmdRevoke(cdc),
+		GetCmdExec(cdc),
+	)...)
+
+	return txCmd
+}
+
+// GetCmdGrant returns the command to grant an authorization.
+func GetCmdGrant(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee] [msg-type]",
+		Short: "Authorize an account to execute messages of a type on your behalf",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Authorize an account to execute messages of a type on your behalf, replacing any previous
+authorization of that type. The message type is given as route/type, e.g. order/new, order/cancel,
+farm/claim or ammswap/token_swap.
+
+Example:
+$ %s tx authz grant ex1... order/new --allowed-products xxb_okt --max-quantity 100 --expiration 2022-01-01T00:00:00Z --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			maxQuantity := sdk.ZeroDec()
+			if quantity := viper.GetString(flagMaxQuantity); quantity != "" {
+				if maxQuantity, err = sdk.NewDecFromStr(quantity); err != nil {
+					return err
+				}
+			}
+			var expiration time.Time
+			if expirationStr := viper.GetString(flagExpiration); expirationStr != "" {
+				if expiration, err = time.Parse(time.RFC3339, expirationStr); err != nil {
+					return err
+				}
+			}
+
+			authorization := types.NewAuthorization(args[1], expiration, viper.GetStringSlice(flagAllowedProducts), maxQuantity)
+			msg := types.NewMsgGrant(cliCtx.GetFromAddress(), grantee, authorization)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagExpiration, "", "The RFC3339 time after which the authorization expires (default never)")
+	cmd.Flags().StringSlice(flagAllowedProducts, nil, "Products, swap pairs or farm pools the messages may act on (default all)")
+	cmd.Flags().String(flagMaxQuantity, "", "The maximum quantity of every order or swap (default no limit)")
+	return cmd
+}
+
+// GetCmdRevoke returns the command to revoke an authorization.
+func GetCmdRevoke(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke [grantee] [msg-type]",
+		Short: "Revoke the authorization of a message type granted to an account",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Revoke the authorization of a message type granted to an account.
+
+Example:
+$ %s tx authz revoke ex1... order/new --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevoke(cliCtx.GetFromAddress(), grantee, args[1])
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdExec returns the command to execute messages on behalf of their granters.
+func GetCmdExec(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "exec [tx-json-file]",
+		Short: "Execute the messages of a generated tx on behalf of their signers",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Execute the messages of a tx generated with --generate-only on behalf of their signers,
+which must have authorized you to do so.
+
+Example:
+$ %s tx order new --product xxb_okt --side BUY --price 10 --quantity 1 --from ex1... --generate-only > tx.json
+$ %s tx authz exec tx.json --from mybot
+`,
+				version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			stdTx, err := utils.ReadStdTxFromFile(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgExec(cliCtx.GetFromAddress(), stdTx.GetMsgs())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package authz
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/authz/types"
+	"github.com/okex/exchain/x/common"
+)
+
+// GenesisState stores the authorization grants at genesis
+type GenesisState struct {
+	Grants []types.Grant `json:"grants"`
+}
+
+// DefaultGenesisState returns an empty genesis state
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// ValidateGenesis validates the format of the specified genesisState
+func ValidateGenesis(data GenesisState) error {
+	for _, grant := range data.Grants {
+		if err := grant.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid grant from %s to %s: %s", grant.Granter, grant.Grantee, err)
+		}
+	}
+	return nil
+}
+
+// InitGenesis init genesis data to keeper. A chain whose Venus upgrade comes after the genesis can't have any.
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	if !common.HasVenusStores(ctx.BlockHeight()) {
+		if len(data.Grants) != 0 {
+			panic(types.ErrAuthzUnavailable)
+		}
+		return
+	}
+	for _, grant := range data.Grants {
+		k.SaveGrant(ctx, grant)
+	}
+}
+
+// ExportGenesis exports genesis from keeper
+func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	var data GenesisState
+	if !common.HasVenusStores(ctx.BlockHeight()) {
+		return data
+	}
+	k.IterateGrants(ctx, func(grant types.Grant) bool {
+		data.Grants = append(data.Grants, grant)
+		return false
+	})
+	return data
+}
@@ -0,0 +1,77 @@
+package authz
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/okex/exchain/x/authz/types"
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/common/perf"
+)
+
+// NewHandler creates an sdk.Handler for all the authz type messages
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		if !common.HasVenusStores(ctx.BlockHeight()) {
+			return nil, types.ErrAuthzUnavailable
+		}
+		var handlerFun func() (*sdk.Result, error)
+		var name string
+		switch msg := msg.(type) {
+		case types.MsgGrant:
+			name = "handleMsgGrant"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgGrant(ctx, k, msg)
+			}
+		case types.MsgRevoke:
+			name = "handleMsgRevoke"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgRevoke(ctx, k, msg)
+			}
+		case types.MsgExec:
+			name = "handleMsgExec"
+			handlerFun = func() (*sdk.Result, error) {
+				return k.DispatchActions(ctx, msg.Grantee, msg.Msgs)
+			}
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
+		}
+		seq := perf.GetPerf().OnDeliverTxEnter(ctx, types.ModuleName, name)
+		defer perf.GetPerf().OnDeliverTxExit(ctx, types.ModuleName, name, seq)
+
+		res, err := handlerFun()
+		common.SanityCheckHandler(res, err)
+		return res, err
+	}
+}
+
+func handleMsgGrant(ctx sdk.Context, k Keeper, msg types.MsgGrant) (*sdk.Result, error) {
+	if msg.Authorization.IsExpired(ctx.BlockTime()) {
+		return nil, types.ErrGrantExpired
+	}
+
+	k.SaveGrant(ctx, types.NewGrant(msg.Granter, msg.Grantee, msg.Authorization))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeGrant,
+		sdk.NewAttribute(types.AttributeKeyGranter, msg.Granter.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantee, msg.Grantee.String()),
+		sdk.NewAttribute(types.AttributeKeyMsgType, msg.Authorization.MsgType),
+	))
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgRevoke(ctx sdk.Context, k Keeper, msg types.MsgRevoke) (*sdk.Result, error) {
+	if err := k.DeleteGrant(ctx, msg.Granter, msg.Grantee, msg.MsgType); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRevoke,
+		sdk.NewAttribute(types.AttributeKeyGranter, msg.Granter.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantee, msg.Grantee.String()),
+		sdk.NewAttribute(types.AttributeKeyMsgType, msg.MsgType),
+	))
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
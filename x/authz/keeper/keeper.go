@@ -0,0 +1,147 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/okex/exchain/x/authz/types"
+	"github.com/okex/exchain/x/common"
+)
+
+// Keeper of the authz store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+	router   sdk.Router
+}
+
+// NewKeeper creates an authz keeper. The router dispatches the messages executed on behalf of granters
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, router sdk.Router) Keeper {
+	return Keeper{
+		storeKey: key,
+		cdc:      cdc,
+		router:   router,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// SaveGrant stores the grant, replacing any previous one for the same message type
+func (k Keeper) SaveGrant(ctx sdk.Context, grant types.Grant) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetGrantKey(grant.Granter, grant.Grantee, grant.Authorization.MsgType)
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(grant))
+}
+
+// DeleteGrant removes the grant of msgType from granter to grantee
+func (k Keeper) DeleteGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, msgType string) error {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetGrantKey(granter, grantee, msgType)
+	if !store.Has(key) {
+		return sdkerrors.Wrapf(types.ErrGrantNotFound, "granter %s, grantee %s, msg type %s", granter, grantee, msgType)
+	}
+	store.Delete(key)
+	return nil
+}
+
+// GetGrant returns the grant of msgType from granter to grantee
+func (k Keeper) GetGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, msgType string) (grant types.Grant, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetGrantKey(granter, grantee, msgType))
+	if bz == nil {
+		return grant, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &grant)
+	return grant, true
+}
+
+// GetGrants returns all the grants from granter to grantee
+func (k Keeper) GetGrants(ctx sdk.Context, granter, grantee sdk.AccAddress) []types.Grant {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.GetGrantsKey(granter, grantee))
+	defer iterator.Close()
+
+	grants := make([]types.Grant, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var grant types.Grant
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &grant)
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+// IterateGrants iterates over all grants until the callback returns true
+func (k Keeper) IterateGrants(ctx sdk.Context, cb func(grant types.Grant) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.GrantKeyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var grant types.Grant
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &grant)
+		if cb(grant) {
+			break
+		}
+	}
+}
+
+// ValidateExec checks that grantee is authorized to execute every message on behalf of its signer
+func (k Keeper) ValidateExec(ctx sdk.Context, grantee sdk.AccAddress, msgs []sdk.Msg) error {
+	if !common.HasVenusStores(ctx.BlockHeight()) {
+		return types.ErrAuthzUnavailable
+	}
+	for _, msg := range msgs {
+		granter := msg.GetSigners()[0]
+		if granter.Equals(grantee) {
+			continue
+		}
+
+		msgType := types.MsgTypeURL(msg)
+		grant, found := k.GetGrant(ctx, granter, grantee, msgType)
+		if !found {
+			return sdkerrors.Wrapf(types.ErrGrantNotFound, "granter %s, grantee %s, msg type %s", granter, grantee, msgType)
+		}
+		if err := grant.Authorization.Accept(msg, ctx.BlockTime()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DispatchActions routes every message through the app router as if it was sent by its signer,
+// once grantee is authorized to do so
+func (k Keeper) DispatchActions(ctx sdk.Context, grantee sdk.AccAddress, msgs []sdk.Msg) (*sdk.Result, error) {
+	if err := k.ValidateExec(ctx, grantee, msgs); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	events := sdk.EmptyEvents()
+	for _, msg := range msgs {
+		handler := k.router.Route(ctx, msg.Route())
+		if handler == nil {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized message route: %s", msg.Route())
+		}
+
+		res, err := handler(ctx, msg)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(err, "failed to execute %s", types.MsgTypeURL(msg))
+		}
+
+		data = append(data, res.Data...)
+		events = events.AppendEvent(sdk.NewEvent(
+			types.EventTypeExec,
+			sdk.NewAttribute(types.AttributeKeyGranter, msg.GetSigners()[0].String()),
+			sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+			sdk.NewAttribute(types.AttributeKeyMsgType, types.MsgTypeURL(msg)),
+		))
+		events = events.AppendEvents(res.Events)
+	}
+
+	return &sdk.Result{Data: data, Events: events}, nil
+}
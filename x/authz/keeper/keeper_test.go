@@ -0,0 +1,174 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/okex/exchain/app"
+	ammswaptypes "github.com/okex/exchain/x/ammswap/types"
+	"github.com/okex/exchain/x/authz/types"
+	"github.com/okex/exchain/x/common"
+	ordertypes "github.com/okex/exchain/x/order/types"
+	tokentypes "github.com/okex/exchain/x/token/types"
+)
+
+type KeeperTestSuite struct {
+	suite.Suite
+
+	ctx sdk.Context
+	app *app.OKExChainApp
+
+	granter sdk.AccAddress
+	grantee sdk.AccAddress
+}
+
+func (suite *KeeperTestSuite) SetupSuite() {
+	suite.granter = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	suite.grantee = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+}
+
+func (suite *KeeperTestSuite) SetupTest() {
+	suite.app = app.Setup(false)
+	suite.ctx = suite.app.BaseApp.NewContext(false, abci.Header{Height: 1, ChainID: "okexchain-3", Time: time.Now().UTC()})
+}
+
+func TestKeeperTestSuite(t *testing.T) {
+	suite.Run(t, new(KeeperTestSuite))
+}
+
+func newCoins(amount int64) sdk.SysCoins {
+	return sdk.NewCoins(sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(amount)))
+}
+
+func (suite *KeeperTestSuite) TestGrants() {
+	keeper := suite.app.AuthzKeeper
+
+	orderAuth := types.NewAuthorization("order/new", time.Time{}, []string{"xxb_okt"}, sdk.NewDec(10))
+	cancelAuth := types.NewAuthorization("order/cancel", time.Time{}, nil, sdk.ZeroDec())
+	keeper.SaveGrant(suite.ctx, types.NewGrant(suite.granter, suite.grantee, orderAuth))
+	keeper.SaveGrant(suite.ctx, types.NewGrant(suite.granter, suite.grantee, cancelAuth))
+
+	grant, found := keeper.GetGrant(suite.ctx, suite.granter, suite.grantee, "order/new")
+	suite.Require().True(found)
+	suite.Require().Equal(orderAuth.AllowedProducts, grant.Authorization.AllowedProducts)
+	suite.Require().Len(keeper.GetGrants(suite.ctx, suite.granter, suite.grantee), 2)
+	suite.Require().Len(keeper.GetGrants(suite.ctx, suite.grantee, suite.granter), 0)
+
+	suite.Require().NoError(keeper.DeleteGrant(suite.ctx, suite.granter, suite.grantee, "order/new"))
+	suite.Require().Error(keeper.DeleteGrant(suite.ctx, suite.granter, suite.grantee, "order/new"))
+	suite.Require().Len(keeper.GetGrants(suite.ctx, suite.granter, suite.grantee), 1)
+}
+
+func (suite *KeeperTestSuite) TestValidateExecBeforeVenus() {
+	auth := types.NewAuthorization("order/cancel", time.Time{}, nil, sdk.ZeroDec())
+	suite.app.AuthzKeeper.SaveGrant(suite.ctx, types.NewGrant(suite.granter, suite.grantee, auth))
+	msgs := []sdk.Msg{ordertypes.NewMsgCancelOrders(suite.granter, []string{"ID0000000010-1"})}
+
+	common.MILESTONE_VENUS_HEIGHT = "10"
+	defer func() { common.MILESTONE_VENUS_HEIGHT = "" }()
+	err := suite.app.AuthzKeeper.ValidateExec(suite.ctx.WithBlockHeight(9), suite.grantee, msgs)
+	suite.Require().True(types.ErrAuthzUnavailable.Is(err))
+	suite.Require().NoError(suite.app.AuthzKeeper.ValidateExec(suite.ctx.WithBlockHeight(10), suite.grantee, msgs))
+}
+
+func (suite *KeeperTestSuite) TestValidateExec() {
+	newOrders := func(product, quantity string) sdk.Msg {
+		return ordertypes.NewMsgNewOrders(suite.granter,
+			[]ordertypes.OrderItem{ordertypes.NewOrderItem(product, "BUY", "1", quantity)})
+	}
+	swap := func(recipient sdk.AccAddress) sdk.Msg {
+		return ammswaptypes.NewMsgTokenToToken(sdk.NewDecCoinFromDec("xxb", sdk.NewDec(5)),
+			sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.NewDec(1)), time.Now().Unix(), recipient, suite.granter)
+	}
+
+	testCases := []struct {
+		msg           string
+		authorization types.Authorization
+		execMsg       sdk.Msg
+		expPass       bool
+	}{
+		{
+			"no grant",
+			types.NewAuthorization("order/cancel", time.Time{}, nil, sdk.ZeroDec()),
+			newOrders("xxb_okt", "1"), false,
+		},
+		{
+			"allowed product and quantity",
+			types.NewAuthorization("order/new", time.Time{}, []string{"xxb_okt"}, sdk.NewDec(10)),
+			newOrders("xxb_okt", "10"), true,
+		},
+		{
+			"product not allowed",
+			types.NewAuthorization("order/new", time.Time{}, []string{"xxb_okt"}, sdk.NewDec(10)),
+			newOrders("yyb_okt", "1"), false,
+		},
+		{
+			"quantity exceeded",
+			types.NewAuthorization("order/new", time.Time{}, []string{"xxb_okt"}, sdk.NewDec(10)),
+			newOrders("xxb_okt", "10.1"), false,
+		},
+		{
+			"expired grant",
+			types.NewAuthorization("order/new", suite.ctx.BlockTime().Add(-time.Second), nil, sdk.ZeroDec()),
+			newOrders("xxb_okt", "1"), false,
+		},
+		{
+			"swap to the granter",
+			types.NewAuthorization("ammswap/token_swap", time.Time{}, []string{"okt_xxb"}, sdk.NewDec(5)),
+			swap(suite.granter), true,
+		},
+		{
+			"swap to another recipient",
+			types.NewAuthorization("ammswap/token_swap", time.Time{}, nil, sdk.ZeroDec()),
+			swap(suite.grantee), false,
+		},
+		{
+			"limits on a message type without products",
+			types.NewAuthorization("token/send", time.Time{}, []string{"xxb_okt"}, sdk.ZeroDec()),
+			tokentypes.NewMsgTokenSend(suite.granter, suite.grantee, newCoins(1)), false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest()
+			suite.app.AuthzKeeper.SaveGrant(suite.ctx, types.NewGrant(suite.granter, suite.grantee, tc.authorization))
+
+			err := suite.app.AuthzKeeper.ValidateExec(suite.ctx, suite.grantee, []sdk.Msg{tc.execMsg})
+			if tc.expPass {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestDispatchActions() {
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, suite.granter)
+	suite.Require().NoError(acc.SetCoins(newCoins(100)))
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	recipient := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	msgs := []sdk.Msg{tokentypes.NewMsgTokenSend(suite.granter, recipient, newCoins(40))}
+
+	// require execution without grant to fail
+	_, err := suite.app.AuthzKeeper.DispatchActions(suite.ctx, suite.grantee, msgs)
+	suite.Require().Error(err)
+
+	suite.app.AuthzKeeper.SaveGrant(suite.ctx, types.NewGrant(suite.granter, suite.grantee,
+		types.NewAuthorization("token/send", time.Time{}, nil, sdk.ZeroDec())))
+
+	res, err := suite.app.AuthzKeeper.DispatchActions(suite.ctx, suite.grantee, msgs)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(res.Events)
+
+	suite.Require().True(newCoins(60).IsEqual(suite.app.AccountKeeper.GetAccount(suite.ctx, suite.granter).GetCoins()))
+	suite.Require().True(newCoins(40).IsEqual(suite.app.AccountKeeper.GetAccount(suite.ctx, recipient).GetCoins()))
+}
@@ -0,0 +1,90 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/okex/exchain/x/authz/types"
+	"github.com/okex/exchain/x/common"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, error) {
+		if len(path) < 1 {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+				"Insufficient parameters, at least 1 parameter is required")
+		}
+		if !common.HasVenusStores(ctx.BlockHeight()) {
+			return nil, types.ErrAuthzUnavailable
+		}
+
+		switch path[0] {
+		case types.QueryGrant:
+			return queryGrant(ctx, path, keeper)
+		case types.QueryGrants:
+			return queryGrants(ctx, path, keeper)
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown query endpoint")
+		}
+	}
+}
+
+// queryGrant returns the grant of a message type from granter to grantee.
+// The message type is "route/type", so it spans the last two parameters of the path
+func queryGrant(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 5 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 5 parameters is required")
+	}
+
+	granter, grantee, err := parseGranterGrantee(path[1], path[2])
+	if err != nil {
+		return nil, err
+	}
+	msgType := path[3] + "/" + path[4]
+
+	grant, found := keeper.GetGrant(ctx, granter, grantee, msgType)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrGrantNotFound, "granter %s, grantee %s, msg type %s", granter, grantee, msgType)
+	}
+
+	return marshalJSONIndent(grant)
+}
+
+// queryGrants returns all the grants from granter to grantee
+func queryGrants(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 3 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 3 parameters is required")
+	}
+
+	granter, grantee, err := parseGranterGrantee(path[1], path[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalJSONIndent(keeper.GetGrants(ctx, granter, grantee))
+}
+
+func parseGranterGrantee(granterStr, granteeStr string) (granter, grantee sdk.AccAddress, err error) {
+	granter, err = sdk.AccAddressFromBech32(granterStr)
+	if err != nil {
+		return nil, nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+	grantee, err = sdk.AccAddressFromBech32(granteeStr)
+	if err != nil {
+		return nil, nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+	return granter, grantee, nil
+}
+
+func marshalJSONIndent(v interface{}) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, v)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
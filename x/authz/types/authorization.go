@@ -0,0 +1,165 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	ammswaptypes "github.com/okex/exchain/x/ammswap/types"
+	farmtypes "github.com/okex/exchain/x/farm/types"
+	ordertypes "github.com/okex/exchain/x/order/types"
+)
+
+// Authorization lets a grantee execute messages of one type on behalf of the granter
+type Authorization struct {
+	// MsgType is the "route/type" of the messages the grantee may execute
+	MsgType string `json:"msg_type"`
+	// Expiration is the time after which the authorization can't be used. A zero time never expires
+	Expiration time.Time `json:"expiration"`
+	// AllowedProducts restricts the products, swap pairs or farm pools the messages may act on. Empty allows all
+	AllowedProducts []string `json:"allowed_products"`
+	// MaxQuantity bounds the quantity of every order or swap. Zero means no bound
+	MaxQuantity sdk.Dec `json:"max_quantity"`
+}
+
+// NewAuthorization creates a new Authorization object
+func NewAuthorization(msgType string, expiration time.Time, allowedProducts []string, maxQuantity sdk.Dec) Authorization {
+	if maxQuantity.IsNil() {
+		maxQuantity = sdk.ZeroDec()
+	}
+	return Authorization{
+		MsgType:         msgType,
+		Expiration:      expiration,
+		AllowedProducts: allowedProducts,
+		MaxQuantity:     maxQuantity,
+	}
+}
+
+// ValidateBasic runs stateless checks on the authorization
+func (a Authorization) ValidateBasic() error {
+	if len(strings.Split(a.MsgType, "/")) != 2 {
+		return sdkerrors.Wrapf(ErrInvalidAuthorization, "msg type %q must be in route/type format", a.MsgType)
+	}
+	if a.MaxQuantity.IsNil() || a.MaxQuantity.IsNegative() {
+		return sdkerrors.Wrapf(ErrInvalidAuthorization, "invalid max quantity: %s", a.MaxQuantity)
+	}
+	for _, product := range a.AllowedProducts {
+		if len(product) == 0 {
+			return sdkerrors.Wrap(ErrInvalidAuthorization, "allowed products can't be empty")
+		}
+	}
+	return nil
+}
+
+// IsExpired returns true if the authorization can no longer be used at the given block time
+func (a Authorization) IsExpired(blockTime time.Time) bool {
+	return !a.Expiration.IsZero() && !blockTime.Before(a.Expiration)
+}
+
+// Accept checks that msg may be executed under the authorization at the given block time
+func (a Authorization) Accept(msg sdk.Msg, blockTime time.Time) error {
+	if MsgTypeURL(msg) != a.MsgType {
+		return sdkerrors.Wrapf(ErrUnauthorized, "authorization is for %s, not %s", a.MsgType, MsgTypeURL(msg))
+	}
+	if a.IsExpired(blockTime) {
+		return ErrGrantExpired
+	}
+
+	switch msg := msg.(type) {
+	case ordertypes.MsgNewOrders:
+		for _, item := range msg.OrderItems {
+			if err := a.acceptProduct(item.Product); err != nil {
+				return err
+			}
+			if err := a.acceptQuantity(item.Quantity); err != nil {
+				return err
+			}
+		}
+	case ammswaptypes.MsgTokenToToken:
+		// the bought tokens must go back to the granter
+		if !msg.Recipient.Equals(msg.Sender) {
+			return sdkerrors.Wrap(ErrUnauthorized, "swap recipient must be the granter")
+		}
+		pair := ammswaptypes.GetSwapTokenPairName(msg.SoldTokenAmount.Denom, msg.MinBoughtTokenAmount.Denom)
+		if err := a.acceptProduct(pair); err != nil {
+			return err
+		}
+		if err := a.acceptQuantity(msg.SoldTokenAmount.Amount); err != nil {
+			return err
+		}
+	case farmtypes.MsgClaim:
+		if err := a.acceptProduct(msg.PoolName); err != nil {
+			return err
+		}
+		if a.MaxQuantity.IsPositive() {
+			return sdkerrors.Wrapf(ErrInvalidAuthorization, "max quantity is not supported by %s", a.MsgType)
+		}
+	default:
+		if len(a.AllowedProducts) != 0 || a.MaxQuantity.IsPositive() {
+			return sdkerrors.Wrapf(ErrInvalidAuthorization, "limits are not supported by %s", a.MsgType)
+		}
+	}
+	return nil
+}
+
+func (a Authorization) acceptProduct(product string) error {
+	if len(a.AllowedProducts) == 0 {
+		return nil
+	}
+	for _, allowed := range a.AllowedProducts {
+		if allowed == product {
+			return nil
+		}
+	}
+	return sdkerrors.Wrapf(ErrUnauthorized, "product %s is not allowed", product)
+}
+
+func (a Authorization) acceptQuantity(quantity sdk.Dec) error {
+	if a.MaxQuantity.IsPositive() && quantity.GT(a.MaxQuantity) {
+		return sdkerrors.Wrapf(ErrUnauthorized, "quantity %s exceeds max quantity %s", quantity, a.MaxQuantity)
+	}
+	return nil
+}
+
+// String implements the Stringer interface
+func (a Authorization) String() string {
+	return fmt.Sprintf(`MsgType:         %s
+Expiration:      %s
+AllowedProducts: %s
+MaxQuantity:     %s`, a.MsgType, a.Expiration, strings.Join(a.AllowedProducts, ","), a.MaxQuantity)
+}
+
+// MsgTypeURL returns the "route/type" identifier of a message used by Authorization.MsgType
+func MsgTypeURL(msg sdk.Msg) string {
+	return msg.Route() + "/" + msg.Type()
+}
+
+// Grant is an authorization given by granter to grantee
+type Grant struct {
+	Granter       sdk.AccAddress `json:"granter"`
+	Grantee       sdk.AccAddress `json:"grantee"`
+	Authorization Authorization  `json:"authorization"`
+}
+
+// NewGrant creates a new Grant object
+func NewGrant(granter, grantee sdk.AccAddress, authorization Authorization) Grant {
+	return Grant{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: authorization,
+	}
+}
+
+// ValidateBasic runs stateless checks on the grant
+func (g Grant) ValidateBasic() error {
+	if g.Granter.Empty() || g.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "granter and grantee are required")
+	}
+	if g.Granter.Equals(g.Grantee) {
+		return ErrSelfGrant
+	}
+	return g.Authorization.ValidateBasic()
+}
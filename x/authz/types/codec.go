@@ -0,0 +1,22 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers concrete types on codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgGrant{}, "okexchain/authz/MsgGrant", nil)
+	cdc.RegisterConcrete(MsgRevoke{}, "okexchain/authz/MsgRevoke", nil)
+	cdc.RegisterConcrete(MsgExec{}, "okexchain/authz/MsgExec", nil)
+}
+
+// ModuleCdc defines the module codec
+var ModuleCdc *codec.Codec
+
+func init() {
+	ModuleCdc = codec.New()
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
@@ -0,0 +1,28 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+var (
+	// ErrGrantNotFound returns an error when the grantee has no grant for a message
+	ErrGrantNotFound = sdkerrors.Register(ModuleName, 2, "authorization grant not found")
+
+	// ErrGrantExpired returns an error when the grant has expired
+	ErrGrantExpired = sdkerrors.Register(ModuleName, 3, "authorization grant expired")
+
+	// ErrInvalidAuthorization returns an error when the authorization is malformed
+	ErrInvalidAuthorization = sdkerrors.Register(ModuleName, 4, "invalid authorization")
+
+	// ErrUnauthorized returns an error when a message exceeds the limits of its authorization
+	ErrUnauthorized = sdkerrors.Register(ModuleName, 5, "message not authorized")
+
+	// ErrSelfGrant returns an error when the granter and the grantee are the same account
+	ErrSelfGrant = sdkerrors.Register(ModuleName, 6, "cannot grant authorization to self")
+
+	// ErrInvalidExecMsg returns an error when a message can't be executed on behalf of another account
+	ErrInvalidExecMsg = sdkerrors.Register(ModuleName, 7, "invalid message to execute")
+
+	// ErrAuthzUnavailable returns an error when the grants are used before the Venus upgrade adds their store
+	ErrAuthzUnavailable = sdkerrors.Register(ModuleName, 8, "authorization grants are not available before the Venus upgrade")
+)
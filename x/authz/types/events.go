@@ -0,0 +1,12 @@
+package types
+
+// authz module event types
+const (
+	EventTypeGrant  = "grant_authorization"
+	EventTypeRevoke = "revoke_authorization"
+	EventTypeExec   = "exec_authorization"
+
+	AttributeKeyGranter = "granter"
+	AttributeKeyGrantee = "grantee"
+	AttributeKeyMsgType = "msg_type"
+)
@@ -0,0 +1,41 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName is the name of the module
+	ModuleName = "authz"
+
+	// StoreKey to be used when creating the KVStore
+	StoreKey = ModuleName
+
+	// RouterKey to be used for routing msgs
+	RouterKey = ModuleName
+
+	// QuerierRoute to be used for querier msgs
+	QuerierRoute = ModuleName
+
+	// query endpoints supported by the authz Querier
+	QueryGrant  = "grant"
+	QueryGrants = "grants"
+)
+
+var (
+	// GrantKeyPrefix is the prefix of the grants keyed by granter, grantee and message type
+	GrantKeyPrefix = []byte{0x01}
+)
+
+// GetGrantKey returns the store key of the grant of msgType from granter to grantee
+func GetGrantKey(granter, grantee sdk.AccAddress, msgType string) []byte {
+	return append(GetGrantsKey(granter, grantee), []byte(msgType)...)
+}
+
+// GetGrantsKey returns the prefix of all the grants from granter to grantee
+func GetGrantsKey(granter, grantee sdk.AccAddress) []byte {
+	key := append(GrantKeyPrefix, byte(len(granter)))
+	key = append(key, granter.Bytes()...)
+	key = append(key, byte(len(grantee)))
+	return append(key, grantee.Bytes()...)
+}
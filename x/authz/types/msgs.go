@@ -0,0 +1,170 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// authz message types
+const (
+	TypeMsgGrant  = "grant"
+	TypeMsgRevoke = "revoke"
+	TypeMsgExec   = "exec"
+)
+
+var (
+	_ sdk.Msg = MsgGrant{}
+	_ sdk.Msg = MsgRevoke{}
+	_ sdk.Msg = MsgExec{}
+)
+
+// MsgGrant grants an authorization to the grantee, replacing any previous one for the same message type
+type MsgGrant struct {
+	Granter       sdk.AccAddress `json:"granter"`
+	Grantee       sdk.AccAddress `json:"grantee"`
+	Authorization Authorization  `json:"authorization"`
+}
+
+// NewMsgGrant is a constructor function for MsgGrant
+func NewMsgGrant(granter, grantee sdk.AccAddress, authorization Authorization) MsgGrant {
+	return MsgGrant{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: authorization,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgGrant) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgGrant) Type() string { return TypeMsgGrant }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgGrant) ValidateBasic() error {
+	return NewGrant(msg.Granter, msg.Grantee, msg.Authorization).ValidateBasic()
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgGrant) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgGrant) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgRevoke removes the authorization of a message type granted to the grantee
+type MsgRevoke struct {
+	Granter sdk.AccAddress `json:"granter"`
+	Grantee sdk.AccAddress `json:"grantee"`
+	MsgType string         `json:"msg_type"`
+}
+
+// NewMsgRevoke is a constructor function for MsgRevoke
+func NewMsgRevoke(granter, grantee sdk.AccAddress, msgType string) MsgRevoke {
+	return MsgRevoke{
+		Granter: granter,
+		Grantee: grantee,
+		MsgType: msgType,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRevoke) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRevoke) Type() string { return TypeMsgRevoke }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRevoke) ValidateBasic() error {
+	if msg.Granter.Empty() || msg.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "granter and grantee are required")
+	}
+	if len(msg.MsgType) == 0 {
+		return sdkerrors.Wrap(ErrInvalidAuthorization, "msg type is required")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRevoke) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRevoke) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgExec executes msgs on behalf of their signers, which granted the grantee an authorization for them
+type MsgExec struct {
+	Grantee sdk.AccAddress `json:"grantee"`
+	Msgs    []sdk.Msg      `json:"msgs"`
+}
+
+// NewMsgExec is a constructor function for MsgExec
+func NewMsgExec(grantee sdk.AccAddress, msgs []sdk.Msg) MsgExec {
+	return MsgExec{
+		Grantee: grantee,
+		Msgs:    msgs,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgExec) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgExec) Type() string { return TypeMsgExec }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgExec) ValidateBasic() error {
+	if msg.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "grantee is required")
+	}
+	if len(msg.Msgs) == 0 {
+		return sdkerrors.Wrap(ErrInvalidExecMsg, "no message to execute")
+	}
+
+	for _, m := range msg.Msgs {
+		switch m.Route() {
+		case RouterKey, evmtypes.RouterKey:
+			return sdkerrors.Wrapf(ErrInvalidExecMsg, "%s messages can't be executed on behalf of others", m.Route())
+		}
+		if len(m.GetSigners()) != 1 {
+			return sdkerrors.Wrapf(ErrInvalidExecMsg, "%s must have a single signer", MsgTypeURL(m))
+		}
+		if err := m.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing. The inner messages are encoded with their own
+// sign bytes, as the module codec doesn't know their concrete types.
+func (msg MsgExec) GetSignBytes() []byte {
+	msgs := make([]json.RawMessage, len(msg.Msgs))
+	for i, m := range msg.Msgs {
+		msgs[i] = json.RawMessage(m.GetSignBytes())
+	}
+
+	bz, err := json.Marshal(struct {
+		Grantee sdk.AccAddress    `json:"grantee"`
+		Msgs    []json.RawMessage `json:"msgs"`
+	}{msg.Grantee, msgs})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgExec) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Grantee}
+}
@@ -19,11 +19,13 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) {
 		// store data to db
 		storeNewOrders(ctx, keeper)
 		updateOrders(ctx, keeper)
-		storeDealAndMatchResult(ctx, keeper)
+		deals := storeDealAndMatchResult(ctx, keeper)
 		storeFeeDetails(keeper)
 		storeTransactions(keeper)
+		storeEvmTransactions(keeper)
 		storeSwapInfos(keeper)
 		storeClaimInfos(keeper)
+		storePortfolios(ctx, keeper, deals)
 		keeper.EmitAllWsItems(ctx)
 		// refresh cache
 		keeper.Flush()
@@ -33,6 +35,9 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) {
 
 func storeSwapInfos(keeper Keeper) {
 	defer types.PrintStackIfPanic()
+	if !keeper.Orm.IsTableEnabled(types.ReindexTableSwapInfos) {
+		return
+	}
 	swapInfos := keeper.Cache.GetSwapInfos()
 	total := len(swapInfos)
 	count, err := keeper.Orm.AddSwapInfo(swapInfos)
@@ -45,6 +50,9 @@ func storeSwapInfos(keeper Keeper) {
 
 func storeTransactions(keeper Keeper) {
 	defer types.PrintStackIfPanic()
+	if !keeper.Orm.IsTableEnabled(types.ReindexTableTransactions) {
+		return
+	}
 
 	txs := keeper.Cache.GetTransactions()
 	txsLen := len(txs)
@@ -57,7 +65,31 @@ func storeTransactions(keeper Keeper) {
 	}
 }
 
-func storeDealAndMatchResult(ctx sdk.Context, keeper Keeper) {
+func storeEvmTransactions(keeper Keeper) {
+	defer types.PrintStackIfPanic()
+
+	if keeper.Orm.IsTableEnabled(types.ReindexTableEvmTransactions) {
+		evmTxs := keeper.Cache.GetEvmTransactions()
+		cnt, err := keeper.Orm.AddEvmTransactions(evmTxs)
+		if err != nil {
+			keeper.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d evm txs, inserted Count %d, err: %+v", len(evmTxs), cnt, err))
+		} else {
+			keeper.Logger.Debug(fmt.Sprintf("[backend] Expect to insert %d evm txs, inserted Count %d", len(evmTxs), cnt))
+		}
+	}
+
+	if keeper.Orm.IsTableEnabled(types.ReindexTableTokenTransfers) {
+		transfers := keeper.Cache.GetTokenTransfers()
+		cnt, err := keeper.Orm.AddTokenTransfers(transfers)
+		if err != nil {
+			keeper.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d token transfers, inserted Count %d, err: %+v", len(transfers), cnt, err))
+		} else {
+			keeper.Logger.Debug(fmt.Sprintf("[backend] Expect to insert %d token transfers, inserted Count %d", len(transfers), cnt))
+		}
+	}
+}
+
+func storeDealAndMatchResult(ctx sdk.Context, keeper Keeper) []*types.Deal {
 	timestamp := ctx.BlockHeader().Time.Unix()
 	keeper.Orm.SetMaxBlockTimestamp(timestamp)
 	deals, results, err := GetNewDealsAndMatchResultsAtEndBlock(ctx, keeper.OrderKeeper)
@@ -66,7 +98,7 @@ func storeDealAndMatchResult(ctx sdk.Context, keeper Keeper) {
 		keeper.Logger.Error(fmt.Sprintf("[backend] failed to GetNewDealsAndMatchResultsAtEndBlock, error: %s", err.Error()))
 	}
 
-	if len(results) > 0 {
+	if len(results) > 0 && keeper.Orm.IsTableEnabled(types.ReindexTableMatchResults) {
 		cnt, err := keeper.Orm.AddMatchResults(results)
 		if err != nil {
 			keeper.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d matchResults, inserted Count %d, err: %+v", len(results), cnt, err))
@@ -74,7 +106,7 @@ func storeDealAndMatchResult(ctx sdk.Context, keeper Keeper) {
 			keeper.Logger.Debug(fmt.Sprintf("[backend] Expect to insert %d matchResults, inserted Count %d", len(results), cnt))
 		}
 	}
-	if len(deals) > 0 {
+	if len(deals) > 0 && keeper.Orm.IsTableEnabled(types.ReindexTableDeals) {
 		cnt, err := keeper.Orm.AddDeals(deals)
 		if err != nil {
 			keeper.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d deals, inserted Count %d, err: %+v", len(deals), cnt, err))
@@ -92,11 +124,12 @@ func storeDealAndMatchResult(ctx sdk.Context, keeper Keeper) {
 		ts := keeper.Orm.GetMaxBlockTimestamp()
 		keeper.UpdateTickersBuffer(ts-types.SecondsInADay, ts+1, productList)
 	}
+	return deals
 }
 
 func storeFeeDetails(keeper Keeper) {
 	feeDetails := keeper.TokenKeeper.GetFeeDetailList()
-	if len(feeDetails) > 0 {
+	if len(feeDetails) > 0 && keeper.Orm.IsTableEnabled(types.ReindexTableFeeDetails) {
 		cnt, err := keeper.Orm.AddFeeDetails(feeDetails)
 		if err != nil {
 			keeper.Logger.Error(fmt.Sprintf("[backend] Expect to insert %d feeDetails, inserted Count %d, err: %+v", len(feeDetails), cnt, err))
@@ -107,6 +140,9 @@ func storeFeeDetails(keeper Keeper) {
 }
 
 func storeNewOrders(ctx sdk.Context, keeper Keeper) {
+	if !keeper.Orm.IsTableEnabled(types.ReindexTableOrders) {
+		return
+	}
 	orders, err := GetNewOrdersAtEndBlock(ctx, keeper.OrderKeeper)
 	if err != nil {
 		keeper.Logger.Error(fmt.Sprintf("[backend] failed to GetNewOrdersAtEndBlock, error: %s", err.Error()))
@@ -123,6 +159,9 @@ func storeNewOrders(ctx sdk.Context, keeper Keeper) {
 }
 
 func updateOrders(ctx sdk.Context, keeper Keeper) {
+	if !keeper.Orm.IsTableEnabled(types.ReindexTableOrders) {
+		return
+	}
 	orders := GetUpdatedOrdersAtEndBlock(ctx, keeper.OrderKeeper)
 	if len(orders) > 0 {
 		cnt, err := keeper.Orm.UpdateOrders(orders)
@@ -247,6 +286,9 @@ func GetUpdatedOrdersAtEndBlock(ctx sdk.Context, orderKeeper types.OrderKeeper)
 
 func storeClaimInfos(keeper Keeper) {
 	defer types.PrintStackIfPanic()
+	if !keeper.Orm.IsTableEnabled(types.ReindexTableClaimInfos) {
+		return
+	}
 	claimInfos := keeper.Cache.GetClaimInfos()
 	total := len(claimInfos)
 	count, err := keeper.Orm.AddClaimInfo(claimInfos)
@@ -256,3 +298,16 @@ func storeClaimInfos(keeper Keeper) {
 		keeper.Logger.Debug(fmt.Sprintf("[backend] Expect to insert %d claimInfos, inserted Count %d", total, count))
 	}
 }
+
+func storePortfolios(ctx sdk.Context, keeper Keeper, deals []*types.Deal) {
+	defer types.PrintStackIfPanic()
+	if !keeper.Orm.IsTableEnabled(types.TablePortfolios) {
+		return
+	}
+	count, err := keeper.UpdatePortfolios(ctx, deals)
+	if err != nil {
+		keeper.Logger.Error(fmt.Sprintf("[backend] failed to update portfolios, saved Count %d, err: %+v", count, err))
+	} else {
+		keeper.Logger.Debug(fmt.Sprintf("[backend] update portfolios, saved Count %d", count))
+	}
+}
@@ -13,6 +13,10 @@ type Cache struct {
 	// swap infos, flush at EndBlocker
 	swapInfos  []*types.SwapInfo
 	claimInfos []*types.ClaimInfo
+
+	// evm txs and token transfers, flush at EndBlocker
+	evmTransactions []*types.EvmTransaction
+	tokenTransfers  []*types.TokenTransfer
 }
 
 // NewCache return  cache pointer address, called at NewKeeper
@@ -22,6 +26,9 @@ func NewCache() *Cache {
 		LatestTicker: make(map[string]*types.Ticker),
 		swapInfos:    make([]*types.SwapInfo, 0, 2000),
 		claimInfos:   make([]*types.ClaimInfo, 0, 2000),
+
+		evmTransactions: make([]*types.EvmTransaction, 0, 2000),
+		tokenTransfers:  make([]*types.TokenTransfer, 0, 2000),
 	}
 }
 
@@ -30,6 +37,8 @@ func (c *Cache) Flush() {
 	c.Transactions = make([]*types.Transaction, 0, 2000)
 	c.swapInfos = make([]*types.SwapInfo, 0, 2000)
 	c.claimInfos = make([]*types.ClaimInfo, 0, 2000)
+	c.evmTransactions = make([]*types.EvmTransaction, 0, 2000)
+	c.tokenTransfers = make([]*types.TokenTransfer, 0, 2000)
 }
 
 // AddTransaction append transaction to cache Transactions
@@ -61,3 +70,19 @@ func (c *Cache) AddClaimInfo(claimInfo *types.ClaimInfo) {
 func (c *Cache) GetClaimInfos() []*types.ClaimInfo {
 	return c.claimInfos
 }
+
+// AddEvmTransaction appends evm transaction and its token transfers to cache
+func (c *Cache) AddEvmTransaction(evmTx *types.EvmTransaction, transfers []*types.TokenTransfer) {
+	c.evmTransactions = append(c.evmTransactions, evmTx)
+	c.tokenTransfers = append(c.tokenTransfers, transfers...)
+}
+
+// nolint
+func (c *Cache) GetEvmTransactions() []*types.EvmTransaction {
+	return c.evmTransactions
+}
+
+// nolint
+func (c *Cache) GetTokenTransfers() []*types.TokenTransfer {
+	return c.tokenTransfers
+}
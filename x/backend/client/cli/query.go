@@ -31,6 +31,8 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdCandles(queryRoute, cdc),
 		GetCmdTickers(queryRoute, cdc),
 		GetCmdTxList(queryRoute, cdc),
+		GetCmdPortfolioTimeline(queryRoute, cdc),
+		GetCmdPortfolioPnl(queryRoute, cdc),
 		GetBlockTxHashesCommand(queryRoute, cdc),
 	)...)
 
@@ -359,6 +361,96 @@ func GetCmdTxList(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdPortfolioTimeline queries the daily positions of an address
+func GetCmdPortfolioTimeline(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "portfolio-timeline [addr]",
+		Short: "get the daily positions of an address",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			addr := args[0]
+			flags := cmd.Flags()
+			product, errProduct := flags.GetString("product")
+			after, errAfter := flags.GetInt64("after")
+			before, errBefore := flags.GetInt64("before")
+			limit, errLimit := flags.GetInt("limit")
+
+			mError := types.NewErrorsMerged(errProduct, errAfter, errBefore, errLimit)
+			if mError != nil {
+				return mError
+			}
+
+			params := types.QueryPortfolioParamsV2{
+				Address: addr,
+				Product: product,
+				Limit:   limit,
+			}
+			if after > 0 {
+				params.After = strconv.FormatInt(after, 10)
+			}
+			if before > 0 {
+				params.Before = strconv.FormatInt(before, 10)
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryPortfolioTimelineV2), bz)
+			if err != nil {
+				fmt.Printf("failed to get portfolio timeline of %s :%v\n", addr, err)
+				return nil
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().StringP("product", "", "", "filter positions by product")
+	cmd.Flags().Int64P("after", "", 0, "filter positions by > after timestamp")
+	cmd.Flags().Int64P("before", "", 0, "filter positions by < before timestamp")
+	cmd.Flags().IntP("limit", "", 100, "max number of positions")
+	return cmd
+}
+
+// GetCmdPortfolioPnl queries the PnL of the latest positions of an address per product
+func GetCmdPortfolioPnl(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pnl [addr]",
+		Short: "get the PnL of an address per product",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			addr := args[0]
+			product, err := cmd.Flags().GetString("product")
+			if err != nil {
+				return err
+			}
+
+			params := types.QueryPortfolioParamsV2{
+				Address: addr,
+				Product: product,
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryPortfolioPnlV2), bz)
+			if err != nil {
+				fmt.Printf("failed to get pnl of %s :%v\n", addr, err)
+				return nil
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().StringP("product", "", "", "filter pnl by product")
+	return cmd
+}
+
 //GetBlockTxHashesCommand queries the tx hashes in the block of the given height
 func GetBlockTxHashesCommand(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
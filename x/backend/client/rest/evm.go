@@ -0,0 +1,207 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/gorilla/mux"
+	"github.com/okex/exchain/x/backend/types"
+	"github.com/okex/exchain/x/common"
+)
+
+func registerEvmQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	r = r.PathPrefix("/evm").Subrouter()
+	r.HandleFunc("/transactions", evmTxListHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/token_transfers", tokenTransferListHandler(cliCtx)).Methods("GET")
+}
+
+func registerEvmQueryRoutesV2(cliCtx context.CLIContext, r *mux.Router) {
+	r = r.PathPrefix("/evm").Subrouter()
+	r.HandleFunc("/transactions", evmTxListHandlerV2(cliCtx)).Methods("GET")
+	r.HandleFunc("/token_transfers", tokenTransferListHandlerV2(cliCtx)).Methods("GET")
+}
+
+func evmTxListHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addr := r.URL.Query().Get("address")
+		startStr := r.URL.Query().Get("start")
+		endStr := r.URL.Query().Get("end")
+		pageStr := r.URL.Query().Get("page")
+		perPageStr := r.URL.Query().Get("per_page")
+
+		// validate request
+		if addr == "" {
+			common.HandleErrorMsg(w, cliCtx, types.CodeAddressIsRequired, "bad request: address is required")
+			return
+		}
+		start, end, err := parseTimeRange(startStr, endStr)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, common.CodeStrconvFailed, err.Error())
+			return
+		}
+		page, perPage, err := common.Paginate(pageStr, perPageStr)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, common.CodeInvalidPaginateParam, err.Error())
+			return
+		}
+
+		params := types.NewQueryEvmTxListParams(addr, start, end, page, perPage)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, common.CodeMarshalJSONFailed, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryEvmTxList), bz)
+		if err != nil {
+			sdkErr := common.ParseSDKError(err.Error())
+			common.HandleErrorMsg(w, cliCtx, sdkErr.Code, sdkErr.Message)
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func tokenTransferListHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addr := r.URL.Query().Get("address")
+		contract := r.URL.Query().Get("contract")
+		startStr := r.URL.Query().Get("start")
+		endStr := r.URL.Query().Get("end")
+		pageStr := r.URL.Query().Get("page")
+		perPageStr := r.URL.Query().Get("per_page")
+
+		// validate request
+		if addr == "" {
+			common.HandleErrorMsg(w, cliCtx, types.CodeAddressIsRequired, "bad request: address is required")
+			return
+		}
+		start, end, err := parseTimeRange(startStr, endStr)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, common.CodeStrconvFailed, err.Error())
+			return
+		}
+		page, perPage, err := common.Paginate(pageStr, perPageStr)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, common.CodeInvalidPaginateParam, err.Error())
+			return
+		}
+
+		params := types.NewQueryTokenTransferListParams(addr, contract, start, end, page, perPage)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			common.HandleErrorMsg(w, cliCtx, common.CodeMarshalJSONFailed, err.Error())
+			return
+		}
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryTokenTransferList), bz)
+		if err != nil {
+			sdkErr := common.ParseSDKError(err.Error())
+			common.HandleErrorMsg(w, cliCtx, sdkErr.Code, sdkErr.Message)
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func evmTxListHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := r.URL.Query().Get("address")
+		after := r.URL.Query().Get("after")
+		before := r.URL.Query().Get("before")
+		limit := r.URL.Query().Get("limit")
+
+		// validate request
+		if address == "" {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorMissingRequiredParam)
+			return
+		}
+		limitInt, ok := parseCursorsV2(w, after, before, limit)
+		if !ok {
+			return
+		}
+
+		params := types.QueryEvmTxListParamsV2{
+			Address: address,
+			After:   after,
+			Before:  before,
+			Limit:   limitInt,
+		}
+		req := cliCtx.Codec.MustMarshalJSON(params)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryEvmTxListV2), req)
+		common.HandleResponseV2(w, res, err)
+	}
+}
+
+func tokenTransferListHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := r.URL.Query().Get("address")
+		contract := r.URL.Query().Get("contract")
+		after := r.URL.Query().Get("after")
+		before := r.URL.Query().Get("before")
+		limit := r.URL.Query().Get("limit")
+
+		// validate request
+		if address == "" {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorMissingRequiredParam)
+			return
+		}
+		limitInt, ok := parseCursorsV2(w, after, before, limit)
+		if !ok {
+			return
+		}
+
+		params := types.QueryTokenTransferListParamsV2{
+			Address:  address,
+			Contract: contract,
+			After:    after,
+			Before:   before,
+			Limit:    limitInt,
+		}
+		req := cliCtx.Codec.MustMarshalJSON(params)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryTokenTransferListV2), req)
+		common.HandleResponseV2(w, res, err)
+	}
+}
+
+func parseTimeRange(startStr, endStr string) (start, end int64, err error) {
+	if startStr != "" {
+		if start, err = strconv.ParseInt(startStr, 10, 64); err != nil {
+			return
+		}
+	}
+	if endStr != "" {
+		end, err = strconv.ParseInt(endStr, 10, 64)
+	}
+	return
+}
+
+// parseCursorsV2 validates the after/before cursors and returns the limit, it writes the error response when failed
+func parseCursorsV2(w http.ResponseWriter, after, before, limit string) (int, bool) {
+	if after != "" {
+		if _, err := strconv.Atoi(after); err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return 0, false
+		}
+	}
+	if before != "" {
+		if _, err := strconv.Atoi(before); err != nil {
+			common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+			return 0, false
+		}
+	}
+	if limit == "" {
+		limit = defaultLimit
+	}
+	limitInt, err := strconv.Atoi(limit)
+	if err != nil {
+		common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+		return 0, false
+	}
+	return limitInt, true
+}
@@ -0,0 +1,71 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/gorilla/mux"
+	"github.com/okex/exchain/x/backend/types"
+)
+
+func registerGraphQLRoutesV2(cliCtx context.CLIContext, r *mux.Router) {
+	r.HandleFunc("/graphql", graphQLHandlerV2(cliCtx)).Methods("GET", "POST")
+}
+
+// graphQLHandlerV2 serves the GraphQL requests, which are the json body of a POST request or the query, operationName
+// and variables parameters of a GET request
+func graphQLHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var params types.QueryGraphQLParams
+		if r.Method == http.MethodPost {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				writeGraphQLError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if err := json.Unmarshal(body, &params); err != nil {
+				writeGraphQLError(w, http.StatusBadRequest, fmt.Sprintf("invalid GraphQL request: %s", err))
+				return
+			}
+		} else {
+			params.Query = r.URL.Query().Get("query")
+			params.OperationName = r.URL.Query().Get("operationName")
+			if variables := r.URL.Query().Get("variables"); variables != "" {
+				if err := json.Unmarshal([]byte(variables), &params.Variables); err != nil {
+					writeGraphQLError(w, http.StatusBadRequest, fmt.Sprintf("invalid GraphQL variables: %s", err))
+					return
+				}
+			}
+		}
+		if params.Query == "" {
+			writeGraphQLError(w, http.StatusBadRequest, "GraphQL query is required")
+			return
+		}
+
+		req, err := json.Marshal(params)
+		if err != nil {
+			writeGraphQLError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryGraphQL), req)
+		if err != nil {
+			writeGraphQLError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(res)
+	}
+}
+
+// writeGraphQLError writes the error in the shape of a GraphQL response, so the GraphQL clients can handle it
+func writeGraphQLError(w http.ResponseWriter, statusCode int, msg string) {
+	res, _ := json.Marshal(map[string]interface{}{
+		"errors": []map[string]string{{"message": msg}},
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(res)
+}
@@ -0,0 +1,87 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/gorilla/mux"
+	"github.com/okex/exchain/x/backend/types"
+	"github.com/okex/exchain/x/common"
+)
+
+func registerPortfolioQueryRoutesV2(cliCtx context.CLIContext, r *mux.Router) {
+	r = r.PathPrefix("/portfolio").Subrouter()
+	r.HandleFunc("/{address}/timeline", portfolioTimelineHandlerV2(cliCtx)).Methods("GET")
+	r.HandleFunc("/{address}/rewards", portfolioRewardsHandlerV2(cliCtx)).Methods("GET")
+	r.HandleFunc("/{address}/pnl", portfolioPnlHandlerV2(cliCtx)).Methods("GET")
+}
+
+func portfolioTimelineHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := mux.Vars(r)["address"]
+		product := r.URL.Query().Get("product")
+		after := r.URL.Query().Get("after")
+		before := r.URL.Query().Get("before")
+		limit := r.URL.Query().Get("limit")
+
+		// validate request
+		limitInt, ok := parseCursorsV2(w, after, before, limit)
+		if !ok {
+			return
+		}
+
+		params := types.QueryPortfolioParamsV2{
+			Address: address,
+			Product: product,
+			After:   after,
+			Before:  before,
+			Limit:   limitInt,
+		}
+		req := cliCtx.Codec.MustMarshalJSON(params)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryPortfolioTimelineV2), req)
+		common.HandleResponseV2(w, res, err)
+	}
+}
+
+func portfolioRewardsHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := mux.Vars(r)["address"]
+		poolName := r.URL.Query().Get("pool_name")
+		after := r.URL.Query().Get("after")
+		before := r.URL.Query().Get("before")
+		limit := r.URL.Query().Get("limit")
+
+		// validate request
+		limitInt, ok := parseCursorsV2(w, after, before, limit)
+		if !ok {
+			return
+		}
+
+		params := types.QueryPortfolioRewardsParamsV2{
+			Address:  address,
+			PoolName: poolName,
+			After:    after,
+			Before:   before,
+			Limit:    limitInt,
+		}
+		req := cliCtx.Codec.MustMarshalJSON(params)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryPortfolioRewardsV2), req)
+		common.HandleResponseV2(w, res, err)
+	}
+}
+
+func portfolioPnlHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		address := mux.Vars(r)["address"]
+		product := r.URL.Query().Get("product")
+
+		params := types.QueryPortfolioParamsV2{
+			Address: address,
+			Product: product,
+		}
+		req := cliCtx.Codec.MustMarshalJSON(params)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/backend/%s", types.QueryPortfolioPnlV2), req)
+		common.HandleResponseV2(w, res, err)
+	}
+}
@@ -33,6 +33,13 @@ func RegisterRoutesV2(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/fees", feesHandlerV2(cliCtx)).Methods("GET")
 	r.HandleFunc("/deals", dealsHandlerV2(cliCtx)).Methods("GET")
 	r.HandleFunc("/transactions", txListHandlerV2(cliCtx)).Methods("GET")
+
+	// register evm rest
+	registerEvmQueryRoutesV2(cliCtx, r)
+	// register portfolio rest
+	registerPortfolioQueryRoutesV2(cliCtx, r)
+	// register graphql rest
+	registerGraphQLRoutesV2(cliCtx, r)
 }
 
 func txListHandlerV2(cliCtx context.CLIContext) http.HandlerFunc {
@@ -17,6 +17,7 @@ func registerSwapQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/tokens", swapTokensHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/token_pairs", querySwapTokenPairsHandler(cliCtx)).Methods("GET")
 	r.HandleFunc("/liquidity/histories", swapLiquidityHistoriesHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/tickers", swapTickersHandler(cliCtx)).Methods("GET")
 }
 
 func swapWatchlistHandler(cliCtx context.CLIContext) http.HandlerFunc {
@@ -110,3 +111,16 @@ func swapLiquidityHistoriesHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
+
+func swapTickersHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QuerySwapTickers), nil)
+		if err != nil {
+			sdkErr := common.ParseSDKError(err.Error())
+			common.HandleErrorMsg(w, cliCtx, sdkErr.Code, sdkErr.Message)
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
@@ -0,0 +1,458 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/okex/exchain/x/backend/orm"
+	"github.com/okex/exchain/x/backend/types"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// tickersCost is the cost of the tickers of all the products
+	tickersCost = maxPageSize
+)
+
+// TickersFunc returns the latest tickers of the products, all products if products is empty
+type TickersFunc func(products []string) []types.Ticker
+
+// Resolver is the root resolver of the queries
+type Resolver struct {
+	orm     *orm.ORM
+	tickers TickersFunc
+}
+
+type budgetKey struct{}
+
+// budget is the complexity left of a query, the fields of a query are resolved concurrently
+type budget struct {
+	left int64
+}
+
+func withBudget(ctx context.Context, maxComplexity int) context.Context {
+	return context.WithValue(ctx, budgetKey{}, &budget{left: int64(maxComplexity)})
+}
+
+// charge charges the cost of a field to the budget of the query, no budget means no limit
+func charge(ctx context.Context, cost int) error {
+	b, ok := ctx.Value(budgetKey{}).(*budget)
+	if !ok {
+		return nil
+	}
+	if atomic.AddInt64(&b.left, -int64(cost)) < 0 {
+		return fmt.Errorf("query is too complex, reduce the fields or their page sizes")
+	}
+	return nil
+}
+
+func pageSize(first *int32) (int, error) {
+	if first == nil {
+		return defaultPageSize, nil
+	}
+	if *first < 1 || *first > maxPageSize {
+		return 0, fmt.Errorf("first must be between 1 and %d", maxPageSize)
+	}
+	return int(*first), nil
+}
+
+// encodeCursor encodes the key values of a row into an opaque cursor
+func encodeCursor(keys ...interface{}) string {
+	bz, _ := json.Marshal(keys)
+	return base64.RawURLEncoding.EncodeToString(bz)
+}
+
+func decodeCursor(cursor string) ([]interface{}, error) {
+	bz, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(bz)))
+	decoder.UseNumber()
+	var keys []interface{}
+	if err := decoder.Decode(&keys); err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	for i, key := range keys {
+		if number, ok := key.(json.Number); ok {
+			if keys[i], err = number.Int64(); err != nil {
+				keys[i] = number.String()
+			}
+		}
+	}
+	return keys, nil
+}
+
+// fetch finds a page of the rows after the cursor into out, and returns the page size. One more row than the page
+// size is found to know whether there is a next page
+func (r *Resolver) fetch(ctx context.Context, filters []orm.Filter, keyColumns []string, first *int32, after *string,
+	out interface{}) (int, error) {
+	limit, err := pageSize(first)
+	if err != nil {
+		return 0, err
+	}
+	if err := charge(ctx, limit); err != nil {
+		return 0, err
+	}
+
+	var cursor []interface{}
+	if after != nil && *after != "" {
+		if cursor, err = decodeCursor(*after); err != nil {
+			return 0, err
+		}
+		if len(cursor) != len(keyColumns) {
+			return 0, fmt.Errorf("invalid cursor %q", *after)
+		}
+	}
+	return limit, r.orm.GetConnection(filters, keyColumns, cursor, limit+1, out)
+}
+
+func appendEqual(filters []orm.Filter, column string, value *string) []orm.Filter {
+	if value == nil || *value == "" {
+		return filters
+	}
+	return append(filters, orm.NewFilter(column+" = ?", *value))
+}
+
+func appendTimeRange(filters []orm.Filter, startTime, endTime *Long) []orm.Filter {
+	if startTime != nil {
+		filters = append(filters, orm.NewFilter("timestamp >= ?", int64(*startTime)))
+	}
+	if endTime != nil {
+		filters = append(filters, orm.NewFilter("timestamp < ?", int64(*endTime)))
+	}
+	return filters
+}
+
+type orderFilter struct {
+	Address   *string
+	Product   *string
+	Side      *string
+	Open      *bool
+	StartTime *Long
+	EndTime   *Long
+}
+
+// Orders resolves the orders sorted by time desc
+func (r *Resolver) Orders(ctx context.Context, args struct {
+	Filter *orderFilter
+	First  *int32
+	After  *string
+}) (*orderConnection, error) {
+	var filters []orm.Filter
+	if f := args.Filter; f != nil {
+		filters = appendEqual(filters, "sender", f.Address)
+		filters = appendEqual(filters, "product", f.Product)
+		filters = appendEqual(filters, "side", f.Side)
+		if f.Open != nil {
+			if *f.Open {
+				filters = append(filters, orm.NewFilter("status = 0"))
+			} else {
+				filters = append(filters, orm.NewFilter("status > 0"))
+			}
+		}
+		filters = appendTimeRange(filters, f.StartTime, f.EndTime)
+	}
+
+	var orders []types.Order
+	limit, err := r.fetch(ctx, filters, []string{"timestamp", "order_id"}, args.First, args.After, &orders)
+	if err != nil {
+		return nil, err
+	}
+	hasNextPage := len(orders) > limit
+	if hasNextPage {
+		orders = orders[:limit]
+	}
+
+	connection := &orderConnection{edges: []*orderEdge{}}
+	cursors := make([]string, 0, len(orders))
+	for _, order := range orders {
+		cursor := encodeCursor(order.Timestamp, order.OrderID)
+		cursors = append(cursors, cursor)
+		connection.edges = append(connection.edges, &orderEdge{cursor: cursor, node: &orderResolver{order}})
+	}
+	connection.pageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
+
+type dealFilter struct {
+	Address   *string
+	Product   *string
+	Side      *string
+	StartTime *Long
+	EndTime   *Long
+}
+
+// Deals resolves the deals sorted by time desc
+func (r *Resolver) Deals(ctx context.Context, args struct {
+	Filter *dealFilter
+	First  *int32
+	After  *string
+}) (*dealConnection, error) {
+	var filters []orm.Filter
+	if f := args.Filter; f != nil {
+		filters = appendEqual(filters, "sender", f.Address)
+		filters = appendEqual(filters, "product", f.Product)
+		filters = appendEqual(filters, "side", f.Side)
+		filters = appendTimeRange(filters, f.StartTime, f.EndTime)
+	}
+
+	var deals []types.Deal
+	limit, err := r.fetch(ctx, filters, []string{"timestamp", "block_height", "order_id"}, args.First, args.After, &deals)
+	if err != nil {
+		return nil, err
+	}
+	hasNextPage := len(deals) > limit
+	if hasNextPage {
+		deals = deals[:limit]
+	}
+
+	connection := &dealConnection{edges: []*dealEdge{}}
+	cursors := make([]string, 0, len(deals))
+	for _, deal := range deals {
+		cursor := encodeCursor(deal.Timestamp, deal.BlockHeight, deal.OrderID)
+		cursors = append(cursors, cursor)
+		connection.edges = append(connection.edges, &dealEdge{cursor: cursor, node: &dealResolver{deal}})
+	}
+	connection.pageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
+
+type matchResultFilter struct {
+	Product   *string
+	StartTime *Long
+	EndTime   *Long
+}
+
+// MatchResults resolves the match results sorted by time desc
+func (r *Resolver) MatchResults(ctx context.Context, args struct {
+	Filter *matchResultFilter
+	First  *int32
+	After  *string
+}) (*matchResultConnection, error) {
+	var filters []orm.Filter
+	if f := args.Filter; f != nil {
+		filters = appendEqual(filters, "product", f.Product)
+		filters = appendTimeRange(filters, f.StartTime, f.EndTime)
+	}
+
+	var results []types.MatchResult
+	limit, err := r.fetch(ctx, filters, []string{"timestamp", "block_height", "product"}, args.First, args.After, &results)
+	if err != nil {
+		return nil, err
+	}
+	hasNextPage := len(results) > limit
+	if hasNextPage {
+		results = results[:limit]
+	}
+
+	connection := &matchResultConnection{edges: []*matchResultEdge{}}
+	cursors := make([]string, 0, len(results))
+	for _, result := range results {
+		cursor := encodeCursor(result.Timestamp, result.BlockHeight, result.Product)
+		cursors = append(cursors, cursor)
+		connection.edges = append(connection.edges, &matchResultEdge{cursor: cursor, node: &matchResultResolver{result}})
+	}
+	connection.pageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
+
+type transactionFilter struct {
+	Address   string
+	Type      *int32
+	StartTime *Long
+	EndTime   *Long
+}
+
+// Transactions resolves the transactions of an address sorted by time desc
+func (r *Resolver) Transactions(ctx context.Context, args struct {
+	Filter transactionFilter
+	First  *int32
+	After  *string
+}) (*transactionConnection, error) {
+	f := args.Filter
+	filters := []orm.Filter{orm.NewFilter("address = ?", f.Address)}
+	if f.Type != nil {
+		filters = append(filters, orm.NewFilter("type = ?", int64(*f.Type)))
+	}
+	filters = appendTimeRange(filters, f.StartTime, f.EndTime)
+
+	var txs []types.Transaction
+	limit, err := r.fetch(ctx, filters, []string{"timestamp", "tx_hash", "side", "id"}, args.First, args.After, &txs)
+	if err != nil {
+		return nil, err
+	}
+	hasNextPage := len(txs) > limit
+	if hasNextPage {
+		txs = txs[:limit]
+	}
+
+	connection := &transactionConnection{edges: []*transactionEdge{}}
+	cursors := make([]string, 0, len(txs))
+	for _, tx := range txs {
+		cursor := encodeCursor(tx.Timestamp, tx.TxHash, tx.Side, tx.Id)
+		cursors = append(cursors, cursor)
+		connection.edges = append(connection.edges, &transactionEdge{cursor: cursor, node: &transactionResolver{tx}})
+	}
+	connection.pageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
+
+type klineFilter struct {
+	Product     string
+	Granularity int32
+	StartTime   *Long
+	EndTime     *Long
+}
+
+// Klines resolves the klines of a product and a granularity sorted by time desc
+func (r *Resolver) Klines(ctx context.Context, args struct {
+	Filter klineFilter
+	First  *int32
+	After  *string
+}) (*klineConnection, error) {
+	f := args.Filter
+	klines, err := types.NewKlinesFactory(types.GetKlineTableNameByFreq(int(f.Granularity)))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported granularity %d", f.Granularity)
+	}
+	filters := []orm.Filter{orm.NewFilter("product = ?", f.Product)}
+	filters = appendTimeRange(filters, f.StartTime, f.EndTime)
+
+	limit, err := r.fetch(ctx, filters, []string{"timestamp"}, args.First, args.After, klines)
+	if err != nil {
+		return nil, err
+	}
+
+	// klines is a pointer to a slice of a kline type, which embeds *BaseKline
+	elements := reflect.ValueOf(klines).Elem()
+	hasNextPage := elements.Len() > limit
+	if hasNextPage {
+		elements = elements.Slice(0, limit)
+	}
+
+	connection := &klineConnection{edges: []*klineEdge{}}
+	cursors := make([]string, 0, elements.Len())
+	for i := 0; i < elements.Len(); i++ {
+		kline, ok := elements.Index(i).FieldByName("BaseKline").Interface().(*types.BaseKline)
+		if !ok || kline == nil {
+			continue
+		}
+		cursor := encodeCursor(kline.Timestamp)
+		cursors = append(cursors, cursor)
+		connection.edges = append(connection.edges, &klineEdge{cursor: cursor, node: &klineResolver{kline}})
+	}
+	connection.pageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
+
+type swapInfoFilter struct {
+	Address       *string
+	TokenPairName *string
+	StartTime     *Long
+	EndTime       *Long
+}
+
+// SwapInfos resolves the swaps sorted by time desc
+func (r *Resolver) SwapInfos(ctx context.Context, args struct {
+	Filter *swapInfoFilter
+	First  *int32
+	After  *string
+}) (*swapInfoConnection, error) {
+	var filters []orm.Filter
+	if f := args.Filter; f != nil {
+		filters = appendEqual(filters, "address", f.Address)
+		filters = appendEqual(filters, "token_pair_name", f.TokenPairName)
+		filters = appendTimeRange(filters, f.StartTime, f.EndTime)
+	}
+
+	var infos []types.SwapInfo
+	keyColumns := []string{"timestamp", "address", "token_pair_name", "sell_amount", "id"}
+	limit, err := r.fetch(ctx, filters, keyColumns, args.First, args.After, &infos)
+	if err != nil {
+		return nil, err
+	}
+	hasNextPage := len(infos) > limit
+	if hasNextPage {
+		infos = infos[:limit]
+	}
+
+	connection := &swapInfoConnection{edges: []*swapInfoEdge{}}
+	cursors := make([]string, 0, len(infos))
+	for _, info := range infos {
+		cursor := encodeCursor(info.Timestamp, info.Address, info.TokenPairName, info.SellAmount, info.Id)
+		cursors = append(cursors, cursor)
+		connection.edges = append(connection.edges, &swapInfoEdge{cursor: cursor, node: &swapInfoResolver{info}})
+	}
+	connection.pageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
+
+type farmClaimFilter struct {
+	Address   *string
+	PoolName  *string
+	StartTime *Long
+	EndTime   *Long
+}
+
+// FarmClaims resolves the farm claims sorted by time desc
+func (r *Resolver) FarmClaims(ctx context.Context, args struct {
+	Filter *farmClaimFilter
+	First  *int32
+	After  *string
+}) (*farmClaimConnection, error) {
+	var filters []orm.Filter
+	if f := args.Filter; f != nil {
+		filters = appendEqual(filters, "address", f.Address)
+		filters = appendEqual(filters, "pool_name", f.PoolName)
+		filters = appendTimeRange(filters, f.StartTime, f.EndTime)
+	}
+
+	var claims []types.ClaimInfo
+	limit, err := r.fetch(ctx, filters, []string{"timestamp", "id"}, args.First, args.After, &claims)
+	if err != nil {
+		return nil, err
+	}
+	hasNextPage := len(claims) > limit
+	if hasNextPage {
+		claims = claims[:limit]
+	}
+
+	connection := &farmClaimConnection{edges: []*farmClaimEdge{}}
+	cursors := make([]string, 0, len(claims))
+	for _, claim := range claims {
+		cursor := encodeCursor(claim.Timestamp, claim.Id)
+		cursors = append(cursors, cursor)
+		connection.edges = append(connection.edges, &farmClaimEdge{cursor: cursor, node: &farmClaimResolver{claim}})
+	}
+	connection.pageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
+
+// Tickers resolves the latest tickers of the products
+func (r *Resolver) Tickers(ctx context.Context, args struct{ Products *[]string }) ([]*tickerResolver, error) {
+	var products []string
+	cost := tickersCost
+	if args.Products != nil && len(*args.Products) != 0 {
+		products = *args.Products
+		cost = len(products)
+	}
+	if err := charge(ctx, cost); err != nil {
+		return nil, err
+	}
+
+	tickers := r.tickers(products)
+	sort.Slice(tickers, func(i, j int) bool { return tickers[i].Product < tickers[j].Product })
+	resolvers := []*tickerResolver{}
+	for _, ticker := range tickers {
+		resolvers = append(resolvers, &tickerResolver{ticker})
+	}
+	return resolvers, nil
+}
@@ -0,0 +1,243 @@
+package graphql
+
+// schema is the GraphQL schema of the backend data. The lists are cursor connections sorted by time desc, and every
+// connection costs its page size against the complexity limit of a query
+const schema string = `
+    # Long is a 64 bit signed integer, e.g. a unix timestamp in seconds or a block height.
+    scalar Long
+
+    schema {
+        query: Query
+    }
+
+    type PageInfo {
+        hasNextPage: Boolean!
+        endCursor: String
+    }
+
+    type Order {
+        txHash: String!
+        orderId: String!
+        sender: String!
+        product: String!
+        side: String!
+        price: String!
+        quantity: String!
+        # 0: open, 1: filled, 2: cancelled, 3: expired, 4: partially filled and cancelled, 5: partially filled and expired
+        status: Int!
+        filledAvgPrice: String!
+        remainQuantity: String!
+        timestamp: Long!
+    }
+
+    type OrderEdge {
+        cursor: String!
+        node: Order!
+    }
+
+    type OrderConnection {
+        edges: [OrderEdge!]!
+        pageInfo: PageInfo!
+    }
+
+    input OrderFilter {
+        address: String
+        product: String
+        side: String
+        # true for the open orders, false for the closed ones, all orders if it's omitted
+        open: Boolean
+        startTime: Long
+        endTime: Long
+    }
+
+    type Deal {
+        timestamp: Long!
+        blockHeight: Long!
+        orderId: String!
+        sender: String!
+        product: String!
+        side: String!
+        price: Float!
+        volume: Float!
+        fee: String!
+        feeReceiver: String!
+    }
+
+    type DealEdge {
+        cursor: String!
+        node: Deal!
+    }
+
+    type DealConnection {
+        edges: [DealEdge!]!
+        pageInfo: PageInfo!
+    }
+
+    input DealFilter {
+        address: String
+        product: String
+        side: String
+        startTime: Long
+        endTime: Long
+    }
+
+    type MatchResult {
+        timestamp: Long!
+        blockHeight: Long!
+        product: String!
+        price: Float!
+        volume: Float!
+    }
+
+    type MatchResultEdge {
+        cursor: String!
+        node: MatchResult!
+    }
+
+    type MatchResultConnection {
+        edges: [MatchResultEdge!]!
+        pageInfo: PageInfo!
+    }
+
+    input MatchResultFilter {
+        product: String
+        startTime: Long
+        endTime: Long
+    }
+
+    type Transaction {
+        txHash: String!
+        # 1: transfer, 2: new order, 3: cancel order
+        type: Int!
+        address: String!
+        symbol: String!
+        # 1: buy, 2: sell, 3: from, 4: to
+        side: Int!
+        quantity: String!
+        fee: String!
+        timestamp: Long!
+    }
+
+    type TransactionEdge {
+        cursor: String!
+        node: Transaction!
+    }
+
+    type TransactionConnection {
+        edges: [TransactionEdge!]!
+        pageInfo: PageInfo!
+    }
+
+    input TransactionFilter {
+        address: String!
+        type: Int
+        startTime: Long
+        endTime: Long
+    }
+
+    type Kline {
+        product: String!
+        timestamp: Long!
+        open: Float!
+        close: Float!
+        high: Float!
+        low: Float!
+        volume: Float!
+    }
+
+    type KlineEdge {
+        cursor: String!
+        node: Kline!
+    }
+
+    type KlineConnection {
+        edges: [KlineEdge!]!
+        pageInfo: PageInfo!
+    }
+
+    input KlineFilter {
+        product: String!
+        # the seconds of a kline, e.g. 60, 900 or 86400
+        granularity: Int!
+        startTime: Long
+        endTime: Long
+    }
+
+    type Ticker {
+        symbol: String!
+        product: String!
+        timestamp: Long!
+        open: Float!
+        close: Float!
+        high: Float!
+        low: Float!
+        price: Float!
+        volume: Float!
+        change: Float!
+        changePercentage: String!
+    }
+
+    type SwapInfo {
+        address: String!
+        tokenPairName: String!
+        baseTokenAmount: String!
+        quoteTokenAmount: String!
+        sellAmount: String!
+        buysAmount: String!
+        price: String!
+        timestamp: Long!
+    }
+
+    type SwapInfoEdge {
+        cursor: String!
+        node: SwapInfo!
+    }
+
+    type SwapInfoConnection {
+        edges: [SwapInfoEdge!]!
+        pageInfo: PageInfo!
+    }
+
+    input SwapInfoFilter {
+        address: String
+        tokenPairName: String
+        startTime: Long
+        endTime: Long
+    }
+
+    type FarmClaim {
+        poolName: String!
+        address: String!
+        claimed: String!
+        timestamp: Long!
+    }
+
+    type FarmClaimEdge {
+        cursor: String!
+        node: FarmClaim!
+    }
+
+    type FarmClaimConnection {
+        edges: [FarmClaimEdge!]!
+        pageInfo: PageInfo!
+    }
+
+    input FarmClaimFilter {
+        address: String
+        poolName: String
+        startTime: Long
+        endTime: Long
+    }
+
+    type Query {
+        orders(filter: OrderFilter, first: Int, after: String): OrderConnection!
+        deals(filter: DealFilter, first: Int, after: String): DealConnection!
+        matchResults(filter: MatchResultFilter, first: Int, after: String): MatchResultConnection!
+        transactions(filter: TransactionFilter!, first: Int, after: String): TransactionConnection!
+        klines(filter: KlineFilter!, first: Int, after: String): KlineConnection!
+        swapInfos(filter: SwapInfoFilter, first: Int, after: String): SwapInfoConnection!
+        farmClaims(filter: FarmClaimFilter, first: Int, after: String): FarmClaimConnection!
+        # the latest tickers of the products, all products if it's omitted
+        tickers(products: [String!]): [Ticker!]!
+    }
+`
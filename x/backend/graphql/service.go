@@ -0,0 +1,40 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/okex/exchain/x/backend/orm"
+)
+
+const (
+	FlagMaxComplexity = "backend.graphql_max_complexity"
+
+	// DefaultMaxComplexity is the complexity limit of a query if it isn't configured, which is the total page sizes of
+	// the connections queried
+	DefaultMaxComplexity = 1000
+	maxDepth             = 10
+)
+
+// Schema executes the GraphQL queries over the backend data
+type Schema struct {
+	schema *graphql.Schema
+}
+
+// NewSchema creates the schema resolving the data by the orm and the latest tickers by tickers
+func NewSchema(o *orm.ORM, tickers TickersFunc) *Schema {
+	resolver := &Resolver{orm: o, tickers: tickers}
+	return &Schema{schema: graphql.MustParseSchema(schema, resolver, graphql.MaxDepth(maxDepth))}
+}
+
+// Execute executes the query and returns the json of the GraphQL response, the errors of the query are in the
+// response. A query costing more than maxComplexity fails, and a non-positive maxComplexity means the default one
+func (s *Schema) Execute(ctx context.Context, query, operationName string, variables map[string]interface{},
+	maxComplexity int) ([]byte, error) {
+	if maxComplexity <= 0 {
+		maxComplexity = DefaultMaxComplexity
+	}
+	response := s.schema.Exec(withBudget(ctx, maxComplexity), query, operationName, variables)
+	return json.Marshal(response)
+}
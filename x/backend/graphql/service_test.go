@@ -0,0 +1,146 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/okex/exchain/x/backend/orm"
+	"github.com/okex/exchain/x/backend/types"
+	"github.com/stretchr/testify/require"
+)
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func newTestSchema(t *testing.T) (*Schema, func()) {
+	dir, err := ioutil.TempDir("", "graphql")
+	require.Nil(t, err)
+	o, err := orm.NewSqlite3ORM(false, dir, "backend.db", nil)
+	require.Nil(t, err)
+
+	_, err = o.AddOrders([]*types.Order{
+		{OrderID: "ID1-1", Sender: "addr1", Product: types.TestTokenPair, Side: "BUY", Status: 0, Timestamp: 100},
+		{OrderID: "ID2-1", Sender: "addr1", Product: types.TestTokenPair, Side: "SELL", Status: 1, Timestamp: 200},
+		{OrderID: "ID3-1", Sender: "addr1", Product: types.TestTokenPair, Side: "BUY", Status: 0, Timestamp: 300},
+		{OrderID: "ID3-2", Sender: "addr2", Product: types.TestTokenPair, Side: "BUY", Status: 0, Timestamp: 300},
+	})
+	require.Nil(t, err)
+	o.CommitKlines([]interface{}{
+		types.NewKlineM1(&types.BaseKline{Product: types.TestTokenPair, Timestamp: 60, Close: 1}),
+		types.NewKlineM1(&types.BaseKline{Product: types.TestTokenPair, Timestamp: 120, Close: 2}),
+		types.NewKlineM1(&types.BaseKline{Product: types.TestTokenPair, Timestamp: 180, Close: 3}),
+	})
+
+	tickers := func(products []string) []types.Ticker {
+		return []types.Ticker{{Product: "btc_tusdk", Price: 2}, {Product: types.TestTokenPair, Price: 1}}
+	}
+	return NewSchema(o, tickers), func() { os.RemoveAll(dir) }
+}
+
+func execute(t *testing.T, schema *Schema, query string, variables map[string]interface{}, maxComplexity int) response {
+	bz, err := schema.Execute(context.Background(), query, "", variables, maxComplexity)
+	require.Nil(t, err)
+	var res response
+	require.Nil(t, json.Unmarshal(bz, &res))
+	return res
+}
+
+func TestSchema_Orders(t *testing.T) {
+	schema, cleanup := newTestSchema(t)
+	defer cleanup()
+
+	query := `query($after: String) {
+        orders(filter: {address: "addr1", open: true}, first: 1, after: $after) {
+            edges { cursor node { orderId timestamp } }
+            pageInfo { hasNextPage endCursor }
+        }
+    }`
+	type page struct {
+		Orders struct {
+			Edges []struct {
+				Cursor string
+				Node   struct {
+					OrderID   string `json:"orderId"`
+					Timestamp int64
+				}
+			}
+			PageInfo struct {
+				HasNextPage bool
+				EndCursor   *string
+			}
+		}
+	}
+
+	var orderIDs []string
+	variables := map[string]interface{}{}
+	for {
+		res := execute(t, schema, query, variables, 0)
+		require.Empty(t, res.Errors)
+		var p page
+		require.Nil(t, json.Unmarshal(res.Data, &p))
+		for _, edge := range p.Orders.Edges {
+			orderIDs = append(orderIDs, edge.Node.OrderID)
+		}
+		if !p.Orders.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = *p.Orders.PageInfo.EndCursor
+	}
+	require.Equal(t, []string{"ID3-1", "ID1-1"}, orderIDs)
+
+	// the time range accepts the Long in a string
+	res := execute(t, schema, `{ orders(filter: {startTime: "200", endTime: 300}) { edges { node { orderId } } } }`, nil, 0)
+	require.Empty(t, res.Errors)
+	require.JSONEq(t, `{"orders":{"edges":[{"node":{"orderId":"ID2-1"}}]}}`, string(res.Data))
+
+	// invalid cursor and page size
+	res = execute(t, schema, `{ orders(after: "invalid") { edges { cursor } } }`, nil, 0)
+	require.NotEmpty(t, res.Errors)
+	res = execute(t, schema, `{ orders(first: 101) { edges { cursor } } }`, nil, 0)
+	require.NotEmpty(t, res.Errors)
+}
+
+func TestSchema_KlinesAndTickers(t *testing.T) {
+	schema, cleanup := newTestSchema(t)
+	defer cleanup()
+
+	res := execute(t, schema, `{
+        klines(filter: {product: "`+types.TestTokenPair+`", granularity: 60, startTime: 100}) {
+            edges { node { timestamp close } }
+            pageInfo { hasNextPage }
+        }
+        tickers { product price }
+    }`, nil, 0)
+	require.Empty(t, res.Errors)
+	require.JSONEq(t, `{
+        "klines": {
+            "edges": [{"node": {"timestamp": 180, "close": 3}}, {"node": {"timestamp": 120, "close": 2}}],
+            "pageInfo": {"hasNextPage": false}
+        },
+        "tickers": [{"product": "btc_tusdk", "price": 2}, {"product": "`+types.TestTokenPair+`", "price": 1}]
+    }`, string(res.Data))
+
+	res = execute(t, schema, `{ klines(filter: {product: "btc_tusdk", granularity: 7}) { edges { cursor } } }`, nil, 0)
+	require.NotEmpty(t, res.Errors)
+}
+
+func TestSchema_Complexity(t *testing.T) {
+	schema, cleanup := newTestSchema(t)
+	defer cleanup()
+
+	query := `{
+        a: orders(first: 100) { edges { cursor } }
+        b: deals(first: 100) { edges { cursor } }
+    }`
+	res := execute(t, schema, query, nil, 200)
+	require.Empty(t, res.Errors)
+	res = execute(t, schema, query, nil, 150)
+	require.NotEmpty(t, res.Errors)
+}
@@ -0,0 +1,256 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/okex/exchain/x/backend/types"
+)
+
+// Long is the 64 bit integer scalar, which is a number in the responses and a number or a decimal string in the inputs
+type Long int64
+
+// ImplementsGraphQLType maps the type to the Long scalar of the schema
+func (Long) ImplementsGraphQLType(name string) bool {
+	return name == "Long"
+}
+
+// UnmarshalGraphQL parses the input of the Long scalar
+func (l *Long) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case string:
+		value, err := strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid Long %q", input)
+		}
+		*l = Long(value)
+	case int32:
+		*l = Long(input)
+	case int64:
+		*l = Long(input)
+	case float64:
+		if input != float64(int64(input)) {
+			return fmt.Errorf("invalid Long %v", input)
+		}
+		*l = Long(input)
+	default:
+		return fmt.Errorf("unexpected type %T for Long", input)
+	}
+	return nil
+}
+
+type pageInfo struct {
+	hasNextPage bool
+	endCursor   *string
+}
+
+func newPageInfo(cursors []string, hasNextPage bool) *pageInfo {
+	info := &pageInfo{hasNextPage: hasNextPage}
+	if len(cursors) != 0 {
+		info.endCursor = &cursors[len(cursors)-1]
+	}
+	return info
+}
+
+func (p *pageInfo) HasNextPage() bool  { return p.hasNextPage }
+func (p *pageInfo) EndCursor() *string { return p.endCursor }
+
+type orderResolver struct{ o types.Order }
+
+func (r *orderResolver) TxHash() string         { return r.o.TxHash }
+func (r *orderResolver) OrderId() string        { return r.o.OrderID }
+func (r *orderResolver) Sender() string         { return r.o.Sender }
+func (r *orderResolver) Product() string        { return r.o.Product }
+func (r *orderResolver) Side() string           { return r.o.Side }
+func (r *orderResolver) Price() string          { return r.o.Price }
+func (r *orderResolver) Quantity() string       { return r.o.Quantity }
+func (r *orderResolver) Status() int32          { return int32(r.o.Status) }
+func (r *orderResolver) FilledAvgPrice() string { return r.o.FilledAvgPrice }
+func (r *orderResolver) RemainQuantity() string { return r.o.RemainQuantity }
+func (r *orderResolver) Timestamp() Long        { return Long(r.o.Timestamp) }
+
+type orderEdge struct {
+	cursor string
+	node   *orderResolver
+}
+
+func (e *orderEdge) Cursor() string       { return e.cursor }
+func (e *orderEdge) Node() *orderResolver { return e.node }
+
+type orderConnection struct {
+	edges    []*orderEdge
+	pageInfo *pageInfo
+}
+
+func (c *orderConnection) Edges() []*orderEdge { return c.edges }
+func (c *orderConnection) PageInfo() *pageInfo { return c.pageInfo }
+
+type dealResolver struct{ d types.Deal }
+
+func (r *dealResolver) Timestamp() Long     { return Long(r.d.Timestamp) }
+func (r *dealResolver) BlockHeight() Long   { return Long(r.d.BlockHeight) }
+func (r *dealResolver) OrderId() string     { return r.d.OrderID }
+func (r *dealResolver) Sender() string      { return r.d.Sender }
+func (r *dealResolver) Product() string     { return r.d.Product }
+func (r *dealResolver) Side() string        { return r.d.Side }
+func (r *dealResolver) Price() float64      { return r.d.Price }
+func (r *dealResolver) Volume() float64     { return r.d.Quantity }
+func (r *dealResolver) Fee() string         { return r.d.Fee }
+func (r *dealResolver) FeeReceiver() string { return r.d.FeeReceiver }
+
+type dealEdge struct {
+	cursor string
+	node   *dealResolver
+}
+
+func (e *dealEdge) Cursor() string      { return e.cursor }
+func (e *dealEdge) Node() *dealResolver { return e.node }
+
+type dealConnection struct {
+	edges    []*dealEdge
+	pageInfo *pageInfo
+}
+
+func (c *dealConnection) Edges() []*dealEdge  { return c.edges }
+func (c *dealConnection) PageInfo() *pageInfo { return c.pageInfo }
+
+type matchResultResolver struct{ m types.MatchResult }
+
+func (r *matchResultResolver) Timestamp() Long   { return Long(r.m.Timestamp) }
+func (r *matchResultResolver) BlockHeight() Long { return Long(r.m.BlockHeight) }
+func (r *matchResultResolver) Product() string   { return r.m.Product }
+func (r *matchResultResolver) Price() float64    { return r.m.Price }
+func (r *matchResultResolver) Volume() float64   { return r.m.Quantity }
+
+type matchResultEdge struct {
+	cursor string
+	node   *matchResultResolver
+}
+
+func (e *matchResultEdge) Cursor() string             { return e.cursor }
+func (e *matchResultEdge) Node() *matchResultResolver { return e.node }
+
+type matchResultConnection struct {
+	edges    []*matchResultEdge
+	pageInfo *pageInfo
+}
+
+func (c *matchResultConnection) Edges() []*matchResultEdge { return c.edges }
+func (c *matchResultConnection) PageInfo() *pageInfo       { return c.pageInfo }
+
+type transactionResolver struct{ t types.Transaction }
+
+func (r *transactionResolver) TxHash() string   { return r.t.TxHash }
+func (r *transactionResolver) Type() int32      { return int32(r.t.Type) }
+func (r *transactionResolver) Address() string  { return r.t.Address }
+func (r *transactionResolver) Symbol() string   { return r.t.Symbol }
+func (r *transactionResolver) Side() int32      { return int32(r.t.Side) }
+func (r *transactionResolver) Quantity() string { return r.t.Quantity }
+func (r *transactionResolver) Fee() string      { return r.t.Fee }
+func (r *transactionResolver) Timestamp() Long  { return Long(r.t.Timestamp) }
+
+type transactionEdge struct {
+	cursor string
+	node   *transactionResolver
+}
+
+func (e *transactionEdge) Cursor() string             { return e.cursor }
+func (e *transactionEdge) Node() *transactionResolver { return e.node }
+
+type transactionConnection struct {
+	edges    []*transactionEdge
+	pageInfo *pageInfo
+}
+
+func (c *transactionConnection) Edges() []*transactionEdge { return c.edges }
+func (c *transactionConnection) PageInfo() *pageInfo       { return c.pageInfo }
+
+type klineResolver struct{ k *types.BaseKline }
+
+func (r *klineResolver) Product() string { return r.k.Product }
+func (r *klineResolver) Timestamp() Long { return Long(r.k.Timestamp) }
+func (r *klineResolver) Open() float64   { return r.k.Open }
+func (r *klineResolver) Close() float64  { return r.k.Close }
+func (r *klineResolver) High() float64   { return r.k.High }
+func (r *klineResolver) Low() float64    { return r.k.Low }
+func (r *klineResolver) Volume() float64 { return r.k.Volume }
+
+type klineEdge struct {
+	cursor string
+	node   *klineResolver
+}
+
+func (e *klineEdge) Cursor() string       { return e.cursor }
+func (e *klineEdge) Node() *klineResolver { return e.node }
+
+type klineConnection struct {
+	edges    []*klineEdge
+	pageInfo *pageInfo
+}
+
+func (c *klineConnection) Edges() []*klineEdge { return c.edges }
+func (c *klineConnection) PageInfo() *pageInfo { return c.pageInfo }
+
+type tickerResolver struct{ t types.Ticker }
+
+func (r *tickerResolver) Symbol() string           { return r.t.Symbol }
+func (r *tickerResolver) Product() string          { return r.t.Product }
+func (r *tickerResolver) Timestamp() Long          { return Long(r.t.Timestamp) }
+func (r *tickerResolver) Open() float64            { return r.t.Open }
+func (r *tickerResolver) Close() float64           { return r.t.Close }
+func (r *tickerResolver) High() float64            { return r.t.High }
+func (r *tickerResolver) Low() float64             { return r.t.Low }
+func (r *tickerResolver) Price() float64           { return r.t.Price }
+func (r *tickerResolver) Volume() float64          { return r.t.Volume }
+func (r *tickerResolver) Change() float64          { return r.t.Change }
+func (r *tickerResolver) ChangePercentage() string { return r.t.ChangePercentage }
+
+type swapInfoResolver struct{ s types.SwapInfo }
+
+func (r *swapInfoResolver) Address() string          { return r.s.Address }
+func (r *swapInfoResolver) TokenPairName() string    { return r.s.TokenPairName }
+func (r *swapInfoResolver) BaseTokenAmount() string  { return r.s.BaseTokenAmount }
+func (r *swapInfoResolver) QuoteTokenAmount() string { return r.s.QuoteTokenAmount }
+func (r *swapInfoResolver) SellAmount() string       { return r.s.SellAmount }
+func (r *swapInfoResolver) BuysAmount() string       { return r.s.BuysAmount }
+func (r *swapInfoResolver) Price() string            { return r.s.Price }
+func (r *swapInfoResolver) Timestamp() Long          { return Long(r.s.Timestamp) }
+
+type swapInfoEdge struct {
+	cursor string
+	node   *swapInfoResolver
+}
+
+func (e *swapInfoEdge) Cursor() string          { return e.cursor }
+func (e *swapInfoEdge) Node() *swapInfoResolver { return e.node }
+
+type swapInfoConnection struct {
+	edges    []*swapInfoEdge
+	pageInfo *pageInfo
+}
+
+func (c *swapInfoConnection) Edges() []*swapInfoEdge { return c.edges }
+func (c *swapInfoConnection) PageInfo() *pageInfo    { return c.pageInfo }
+
+type farmClaimResolver struct{ c types.ClaimInfo }
+
+func (r *farmClaimResolver) PoolName() string { return r.c.PoolName }
+func (r *farmClaimResolver) Address() string  { return r.c.Address }
+func (r *farmClaimResolver) Claimed() string  { return r.c.Claimed }
+func (r *farmClaimResolver) Timestamp() Long  { return Long(r.c.Timestamp) }
+
+type farmClaimEdge struct {
+	cursor string
+	node   *farmClaimResolver
+}
+
+func (e *farmClaimEdge) Cursor() string           { return e.cursor }
+func (e *farmClaimEdge) Node() *farmClaimResolver { return e.node }
+
+type farmClaimConnection struct {
+	edges    []*farmClaimEdge
+	pageInfo *pageInfo
+}
+
+func (c *farmClaimConnection) Edges() []*farmClaimEdge { return c.edges }
+func (c *farmClaimConnection) PageInfo() *pageInfo     { return c.pageInfo }
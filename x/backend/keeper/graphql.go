@@ -0,0 +1,35 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/exchain/x/backend/graphql"
+	"github.com/okex/exchain/x/backend/types"
+	"github.com/spf13/viper"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// graphQLTickers returns the latest tickers of the products for the GraphQL queries
+func (k Keeper) graphQLTickers(products []string) []types.Ticker {
+	return k.GetTickers(products, len(k.Cache.LatestTicker))
+}
+
+// queryGraphQL executes the GraphQL request, the errors of the request are in the GraphQL response
+func queryGraphQL(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QueryGraphQLParams
+	if err := json.Unmarshal(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	if keeper.graphQLSchema == nil {
+		return nil, types.ErrBackendPluginNotEnabled()
+	}
+
+	res, err := keeper.graphQLSchema.Execute(context.Background(), params.Query, params.OperationName, params.Variables,
+		viper.GetInt(graphql.FlagMaxComplexity))
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+	return res, nil
+}
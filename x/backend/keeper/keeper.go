@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/okex/exchain/x/ammswap"
@@ -14,9 +15,12 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/exchain/x/backend/cache"
 	"github.com/okex/exchain/x/backend/config"
+	"github.com/okex/exchain/x/backend/graphql"
 	"github.com/okex/exchain/x/backend/orm"
 	"github.com/okex/exchain/x/backend/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/token"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 )
 
@@ -37,6 +41,8 @@ type Keeper struct {
 	wsChan       chan types.IWebsocket // Websocket channel, it's only available when websocket config enabled
 	ticker3sChan chan types.IWebsocket // Websocket channel, it's used by tickers merge triggered 3s once
 	Cache        *cache.Cache          // Memory cache
+
+	graphQLSchema *graphql.Schema // GraphQL schema over the orm, it's only available when backend enabled
 }
 
 // NewKeeper creates new instances of the nameservice Keeper
@@ -64,6 +70,7 @@ func NewKeeper(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dex
 		}
 		k.Orm = orm
 		k.stopChan = make(chan struct{})
+		k.graphQLSchema = graphql.NewSchema(k.Orm, k.graphQLTickers)
 
 		if k.Config.EnableMktCompute {
 			// websocket channel
@@ -212,6 +219,22 @@ func (k Keeper) SyncTx(ctx sdk.Context, tx *auth.StdTx, txHash string, timestamp
 	}
 }
 
+// SyncEvmTx generate evm transaction with its token transfers and add them to cache, called at DeliverTx. The sender is
+// taken from the signature cache of the ante handler, which is nil if the tx was rejected before its signature was checked
+func (k Keeper) SyncEvmTx(ctx sdk.Context, msg *evmtypes.MsgEthereumTx, txHash string, timestamp int64,
+	res abci.ResponseDeliverTx, sigCache sdk.SigCache) {
+	if k.Config.EnableBackend && k.Config.EnableMktCompute {
+		k.Logger.Debug(fmt.Sprintf("[backend] get new evm tx, txHash: %s", txHash))
+		if sigCache == nil {
+			k.Logger.Debug(fmt.Sprintf("[backend] skip evm tx %s without the sender", txHash))
+			return
+		}
+		evmTx, transfers := types.GenerateEvmTx(msg, sdk.AccAddress(sigCache.GetFrom().Bytes()), txHash,
+			ctx.BlockHeight(), timestamp, res)
+		k.Cache.AddEvmTransaction(evmTx, transfers)
+	}
+}
+
 func (k Keeper) getMatchResults(ctx sdk.Context, product string, start, end int64, offset, limit int) ([]types.MatchResult, int) {
 	return k.Orm.GetMatchResults(product, start, end, offset, limit)
 }
@@ -237,6 +260,16 @@ func (k Keeper) GetTransactionList(ctx sdk.Context, addr string, txType, startTi
 	return k.Orm.GetTransactionList(addr, txType, startTime, endTime, offset, limit)
 }
 
+// nolint
+func (k Keeper) GetEvmTransactionList(ctx sdk.Context, addr string, startTime, endTime int64, offset, limit int) ([]types.EvmTransaction, int) {
+	return k.Orm.GetEvmTransactionList(addr, startTime, endTime, offset, limit)
+}
+
+// nolint
+func (k Keeper) GetTokenTransferList(ctx sdk.Context, addr, contract string, startTime, endTime int64, offset, limit int) ([]types.TokenTransfer, int) {
+	return k.Orm.GetTokenTransferList(addr, contract, startTime, endTime, offset, limit)
+}
+
 // nolint
 func (k Keeper) GetDexFees(ctx sdk.Context, dexHandlingAddr, product string, offset, limit int) ([]types.DexFees, int) {
 	return k.Orm.GetDexFees(dexHandlingAddr, product, offset, limit)
@@ -416,6 +449,14 @@ func (k Keeper) getTransactionListV2(ctx sdk.Context, addr string, txType int, a
 	return k.Orm.GetTransactionListV2(addr, txType, after, before, limit)
 }
 
+func (k Keeper) getEvmTransactionListV2(ctx sdk.Context, addr string, after string, before string, limit int) []types.EvmTransaction {
+	return k.Orm.GetEvmTransactionListV2(addr, after, before, limit)
+}
+
+func (k Keeper) getTokenTransferListV2(ctx sdk.Context, addr, contract string, after string, before string, limit int) []types.TokenTransfer {
+	return k.Orm.GetTokenTransferListV2(addr, contract, after, before, limit)
+}
+
 func (k Keeper) getAllTickers() []types.Ticker {
 	var tickers []types.Ticker
 	for _, ticker := range k.Cache.LatestTicker {
@@ -472,6 +513,14 @@ func (k Keeper) mergeTicker3SecondEvents() (err error) {
 }
 
 func (k Keeper) OnSwapToken(ctx sdk.Context, address sdk.AccAddress, swapTokenPair ammswap.SwapTokenPair, sellAmount sdk.SysCoin, buyAmount sdk.SysCoin) {
+	price := swapTokenPair.BasePooledCoin.Amount.Quo(swapTokenPair.QuotePooledCoin.Amount)
+	volume := buyAmount.Amount
+	if sellAmount.Denom == swapTokenPair.QuotePooledCoin.Denom {
+		volume = sellAmount.Amount
+	}
+	klinePrice, _ := strconv.ParseFloat(price.String(), 64)
+	klineVolume, _ := strconv.ParseFloat(volume.String(), 64)
+
 	swapInfo := &types.SwapInfo{
 		Address:          address.String(),
 		TokenPairName:    swapTokenPair.TokenPairName(),
@@ -479,8 +528,11 @@ func (k Keeper) OnSwapToken(ctx sdk.Context, address sdk.AccAddress, swapTokenPa
 		QuoteTokenAmount: swapTokenPair.QuotePooledCoin.String(),
 		SellAmount:       sellAmount.String(),
 		BuysAmount:       buyAmount.String(),
-		Price:            swapTokenPair.BasePooledCoin.Amount.Quo(swapTokenPair.QuotePooledCoin.Amount).String(),
+		Price:            price.String(),
 		Timestamp:        ctx.BlockTime().Unix(),
+		Product:          types.GetSwapKlineProduct(swapTokenPair.TokenPairName()),
+		KlinePrice:       klinePrice,
+		KlineVolume:      klineVolume,
 	}
 	k.Cache.AddSwapInfo(swapInfo)
 }
@@ -41,13 +41,21 @@ func generateKline1M(keeper Keeper) {
 	}
 
 	//ds := DealDataSource{orm: orm}
-	ds := orm.MergeResultDataSource{Orm: keeper.Orm}
-	anchorNewStartTS, _, newKline1s, err := keeper.Orm.CreateKline1M(startTS, endTS, &ds)
-	if err != nil {
-		keeper.Logger.Debug(fmt.Sprintf("[backend] generateKline1M go routine error: %+v \n", err))
-	}
+	ds := orm.MarketDataSource{
+		Dex:  &orm.MergeResultDataSource{Orm: keeper.Orm},
+		Swap: &orm.SwapDataSource{Orm: keeper.Orm},
+	}
+	var anchorNewStartTS int64
+	if keeper.Orm.IsTableEnabled(types.ReindexTableKlines) {
+		var newKline1s map[string][]types.KlineM1
+		var err error
+		anchorNewStartTS, _, newKline1s, err = keeper.Orm.CreateKline1M(startTS, endTS, &ds)
+		if err != nil {
+			keeper.Logger.Debug(fmt.Sprintf("[backend] generateKline1M go routine error: %+v \n", err))
+		}
 
-	pushAllKline1M(newKline1s, keeper, anchorNewStartTS)
+		pushAllKline1M(newKline1s, keeper, anchorNewStartTS)
+	}
 
 	waitInSecond := int(60+types.Kline1GoRoutineWaitInSecond-time.Now().Second()) % 60
 	timer := time.NewTimer(time.Duration(waitInSecond * int(time.Second)))
@@ -58,7 +66,7 @@ func generateKline1M(keeper Keeper) {
 	klineNotifyChans := generateSyncKlineMXChans()
 	work := func() {
 		currentBlockTimestamp := keeper.Orm.GetMaxBlockTimestamp()
-		if currentBlockTimestamp == 0 {
+		if currentBlockTimestamp == 0 || !keeper.Orm.IsTableEnabled(types.ReindexTableKlines) {
 			return
 		}
 		keeper.Logger.Debug(fmt.Sprintf("[backend] generateKline1M line1M [%d, %d) [%s, %s)",
@@ -151,11 +159,15 @@ func generateKlinesMX(notifyChan chan int64, refreshInterval int, keeper Keeper)
 
 	//startTS, endTS := int64(0), time.Now().Unix()-int64(destIKline.GetFreqInSecond())
 	startTS, endTS := int64(0), time.Now().Unix()+int64(destIKline.GetFreqInSecond())
-	anchorNewStartTS, _, newKlines, err := keeper.Orm.MergeKlineM1(startTS, endTS, destIKline)
-	if err != nil {
-		keeper.Logger.Debug(fmt.Sprintf("[backend] generateKlineMX-#%d# error: %s", refreshInterval, err.Error()))
-	} else {
-		pushAllKlineXm(newKlines, keeper, destIKline.GetTableName(), anchorNewStartTS)
+	var anchorNewStartTS int64
+	if keeper.Orm.IsTableEnabled(types.ReindexTableKlines) {
+		var newKlines map[string][]interface{}
+		anchorNewStartTS, _, newKlines, err = keeper.Orm.MergeKlineM1(startTS, endTS, destIKline)
+		if err != nil {
+			keeper.Logger.Debug(fmt.Sprintf("[backend] generateKlineMX-#%d# error: %s", refreshInterval, err.Error()))
+		} else {
+			pushAllKlineXm(newKlines, keeper, destIKline.GetTableName(), anchorNewStartTS)
+		}
 	}
 
 	work := func(startTS int64) {
@@ -0,0 +1,211 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/exchain/x/backend/types"
+	"github.com/okex/exchain/x/common"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// UpdatePortfolios rolls the deals, swaps and farm claims of the block into the daily positions and rewards
+func (k Keeper) UpdatePortfolios(ctx sdk.Context, deals []*types.Deal) (int, error) {
+	timestamp := ctx.BlockTime().Unix()
+	positions := make(map[string]*types.AccountPosition)
+	var positionKeys []string
+	getPosition := func(address, product string) (*types.AccountPosition, error) {
+		key := address + "|" + product
+		if position, ok := positions[key]; ok {
+			return position, nil
+		}
+		position, err := k.Orm.GetLatestAccountPosition(address, product)
+		if err != nil {
+			return nil, err
+		}
+		if position == nil {
+			position = types.NewAccountPosition(address, product, timestamp)
+		}
+		positions[key] = position.RollTo(timestamp)
+		positionKeys = append(positionKeys, key)
+		return positions[key], nil
+	}
+
+	for _, deal := range deals {
+		position, err := getPosition(deal.Sender, deal.Product)
+		if err != nil {
+			return 0, err
+		}
+		if deal.Side == types.BuyOrder {
+			position.Buy(deal.Quantity, deal.Price)
+		} else {
+			position.Sell(deal.Quantity, deal.Price)
+		}
+		if fee, err := sdk.ParseDecCoins(deal.Fee); err == nil {
+			position.AddFees(fee)
+		}
+	}
+
+	swapInfos := k.Cache.GetSwapInfos()
+	if len(swapInfos) > 0 {
+		feeRate := k.swapKeeper.GetParams(ctx).FeeRate
+		for _, swapInfo := range swapInfos {
+			position, err := getPosition(swapInfo.Address, swapInfo.Product)
+			if err != nil {
+				return 0, err
+			}
+			if err := applySwap(position, swapInfo, feeRate); err != nil {
+				k.Logger.Error(fmt.Sprintf("[backend] failed to apply swap of %s to portfolio, err: %+v", swapInfo.Address, err))
+			}
+		}
+	}
+
+	rewards := make(map[string]*types.AccountReward)
+	var rewardKeys []string
+	for _, claimInfo := range k.Cache.GetClaimInfos() {
+		claimed, err := sdk.ParseDecCoins(claimInfo.Claimed)
+		if err != nil {
+			k.Logger.Error(fmt.Sprintf("[backend] failed to apply claim of %s to portfolio, err: %+v", claimInfo.Address, err))
+			continue
+		}
+		key := claimInfo.Address + "|" + claimInfo.PoolName
+		reward, ok := rewards[key]
+		if !ok {
+			if reward, err = k.Orm.GetLatestAccountReward(claimInfo.Address, claimInfo.PoolName); err != nil {
+				return 0, err
+			}
+			if reward == nil {
+				reward = types.NewAccountReward(claimInfo.Address, claimInfo.PoolName, timestamp)
+			}
+			reward = reward.RollTo(timestamp)
+			rewards[key] = reward
+			rewardKeys = append(rewardKeys, key)
+		}
+		reward.AddClaimed(claimed)
+	}
+
+	if len(positionKeys) == 0 && len(rewardKeys) == 0 {
+		return 0, nil
+	}
+	positionList := make([]*types.AccountPosition, 0, len(positionKeys))
+	for _, key := range positionKeys {
+		positionList = append(positionList, positions[key])
+	}
+	rewardList := make([]*types.AccountReward, 0, len(rewardKeys))
+	for _, key := range rewardKeys {
+		rewardList = append(rewardList, rewards[key])
+	}
+	return k.Orm.SavePortfolios(positionList, rewardList)
+}
+
+// applySwap applies the swap to the position of the token pair, which holds the quote token priced in the base token.
+// The fee is charged from the token sold
+func applySwap(position *types.AccountPosition, swapInfo *types.SwapInfo, feeRate sdk.Dec) error {
+	sellAmount, err := sdk.ParseDecCoin(swapInfo.SellAmount)
+	if err != nil {
+		return err
+	}
+	buyAmount, err := sdk.ParseDecCoin(swapInfo.BuysAmount)
+	if err != nil {
+		return err
+	}
+	if !sellAmount.IsPositive() || !buyAmount.IsPositive() {
+		return nil
+	}
+
+	quote, err := sdk.ParseDecCoin(swapInfo.QuoteTokenAmount)
+	if err != nil {
+		return err
+	}
+
+	sell, _ := strconv.ParseFloat(sellAmount.Amount.String(), 64)
+	buy, _ := strconv.ParseFloat(buyAmount.Amount.String(), 64)
+	if sellAmount.Denom == quote.Denom {
+		position.Sell(sell, buy/sell)
+	} else {
+		position.Buy(buy, sell/buy)
+	}
+	position.AddFees(sdk.SysCoins{sdk.NewDecCoinFromDec(sellAmount.Denom, sellAmount.Amount.Mul(feeRate))})
+	return nil
+}
+
+func queryPortfolioTimelineV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QueryPortfolioParamsV2
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	positions := keeper.Orm.GetAccountPositionsV2(params.Address, params.Product, params.After, params.Before, params.Limit)
+	if len(positions) == 0 {
+		return nil, nil
+	}
+
+	res, err := common.JSONMarshalV2(positions)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+
+	return res, nil
+}
+
+func queryPortfolioRewardsV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QueryPortfolioRewardsParamsV2
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	rewards := keeper.Orm.GetAccountRewardsV2(params.Address, params.PoolName, params.After, params.Before, params.Limit)
+	if len(rewards) == 0 {
+		return nil, nil
+	}
+
+	res, err := common.JSONMarshalV2(rewards)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+
+	return res, nil
+}
+
+func queryPortfolioPnlV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QueryPortfolioParamsV2
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+
+	positions := keeper.Orm.GetLatestAccountPositions(params.Address, params.Product)
+	if len(positions) == 0 {
+		return nil, nil
+	}
+
+	pnls := make([]types.ProductPnl, 0, len(positions))
+	for _, position := range positions {
+		pnls = append(pnls, types.NewProductPnl(position, keeper.getLastPrice(ctx, position.Product)))
+	}
+
+	res, err := common.JSONMarshalV2(pnls)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+
+	return res, nil
+}
+
+// getLastPrice returns the last price of a dex product or the pool price of a swap token pair, 0 if it's unknown
+func (k Keeper) getLastPrice(ctx sdk.Context, product string) float64 {
+	if !types.IsSwapKlineProduct(product) {
+		if ticker, ok := k.Cache.LatestTicker[product]; ok && ticker != nil {
+			return ticker.Price
+		}
+		return 0
+	}
+
+	swapTokenPair, err := k.swapKeeper.GetSwapTokenPair(ctx, strings.TrimPrefix(product, types.SwapKlineProductPrefix))
+	if err != nil || !swapTokenPair.QuotePooledCoin.Amount.IsPositive() {
+		return 0
+	}
+	price, _ := strconv.ParseFloat(swapTokenPair.BasePooledCoin.Amount.Quo(swapTokenPair.QuotePooledCoin.Amount).String(), 64)
+	return price
+}
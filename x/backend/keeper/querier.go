@@ -57,6 +57,10 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			res, err = queryAccountOrders(ctx, path[1:], req, keeper)
 		case types.QueryTxList:
 			res, err = queryTxList(ctx, path[1:], req, keeper)
+		case types.QueryEvmTxList:
+			res, err = queryEvmTxList(ctx, path[1:], req, keeper)
+		case types.QueryTokenTransferList:
+			res, err = queryTokenTransferList(ctx, path[1:], req, keeper)
 		case types.QueryCandleList:
 			if keeper.Config.EnableMktCompute {
 				res, err = queryCandleList(ctx, path[1:], req, keeper)
@@ -80,6 +84,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			res, err = querySwapTokenPairs(ctx, path[1:], req, keeper)
 		case types.QuerySwapLiquidityHistories:
 			res, err = querySwapLiquidityHistories(ctx, req, keeper)
+		case types.QuerySwapTickers:
+			res, err = querySwapTickers(ctx, keeper)
 		case types.QueryFarmPools:
 			res, err = queryFarmPools(ctx, req, keeper)
 		case types.QueryFarmDashboard:
@@ -122,6 +128,18 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			res, err = queryDealsV2(ctx, path[1:], req, keeper)
 		case types.QueryTxListV2:
 			res, err = queryTxListV2(ctx, path[1:], req, keeper)
+		case types.QueryPortfolioTimelineV2:
+			res, err = queryPortfolioTimelineV2(ctx, path[1:], req, keeper)
+		case types.QueryPortfolioRewardsV2:
+			res, err = queryPortfolioRewardsV2(ctx, path[1:], req, keeper)
+		case types.QueryPortfolioPnlV2:
+			res, err = queryPortfolioPnlV2(ctx, path[1:], req, keeper)
+		case types.QueryEvmTxListV2:
+			res, err = queryEvmTxListV2(ctx, path[1:], req, keeper)
+		case types.QueryTokenTransferListV2:
+			res, err = queryTokenTransferListV2(ctx, path[1:], req, keeper)
+		case types.QueryGraphQL:
+			res, err = queryGraphQL(ctx, req, keeper)
 		default:
 			res, err = nil, types.ErrBackendModuleUnknownQueryType()
 		}
@@ -239,7 +257,12 @@ func queryCandleList(ctx sdk.Context, path []string, req abci.RequestQuery, keep
 	if params.Product == "" {
 		return nil, types.ErrProductIsRequired()
 	}
-	if keeper.dexKeeper.GetTokenPair(ctx, params.Product) == nil {
+	if types.IsSwapKlineProduct(params.Product) {
+		tokenPairName := strings.TrimPrefix(params.Product, types.SwapKlineProductPrefix)
+		if _, err := keeper.swapKeeper.GetSwapTokenPair(ctx, tokenPairName); err != nil {
+			return nil, types.ErrProductDoesNotExist(params.Product)
+		}
+	} else if keeper.dexKeeper.GetTokenPair(ctx, params.Product) == nil {
 		return nil, types.ErrProductDoesNotExist(params.Product)
 	}
 
@@ -537,6 +560,70 @@ func queryTxList(ctx sdk.Context, path []string, req abci.RequestQuery, keeper K
 	return bz, nil
 }
 
+func queryEvmTxList(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QueryEvmTxListParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, common.ErrUnMarshalJSONFailed(err.Error())
+	}
+	address, err := types.EvmAddressFromHexOrBech32(params.Address)
+	if err != nil {
+		return nil, common.ErrCreateAddrFromBech32Failed(params.Address, err.Error())
+	}
+	if params.Page < 0 || params.PerPage < 0 {
+		return nil, common.ErrInvalidPaginateParam(params.Page, params.PerPage)
+	}
+	offset, limit := common.GetPage(params.Page, params.PerPage)
+	evmTxs, total := keeper.GetEvmTransactionList(ctx, address, params.StartTime, params.EndTime, offset, limit)
+
+	var response *common.ListResponse
+	if len(evmTxs) > 0 {
+		response = common.GetListResponse(total, params.Page, params.PerPage, evmTxs)
+	} else {
+		response = common.GetEmptyListResponse(total, params.Page, params.PerPage)
+	}
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+	return bz, nil
+}
+
+func queryTokenTransferList(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QueryTokenTransferListParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, common.ErrUnMarshalJSONFailed(err.Error())
+	}
+	address, err := types.EvmAddressFromHexOrBech32(params.Address)
+	if err != nil {
+		return nil, common.ErrCreateAddrFromBech32Failed(params.Address, err.Error())
+	}
+	var contract string
+	if params.Contract != "" {
+		if contract, err = types.EvmAddressFromHexOrBech32(params.Contract); err != nil {
+			return nil, common.ErrCreateAddrFromBech32Failed(params.Contract, err.Error())
+		}
+	}
+	if params.Page < 0 || params.PerPage < 0 {
+		return nil, common.ErrInvalidPaginateParam(params.Page, params.PerPage)
+	}
+	offset, limit := common.GetPage(params.Page, params.PerPage)
+	transfers, total := keeper.GetTokenTransferList(ctx, address, contract, params.StartTime, params.EndTime, offset, limit)
+
+	var response *common.ListResponse
+	if len(transfers) > 0 {
+		response = common.GetListResponse(total, params.Page, params.PerPage, transfers)
+	} else {
+		response = common.GetEmptyListResponse(total, params.Page, params.PerPage)
+	}
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+	return bz, nil
+}
+
 func queryDexFees(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
 	var params types.QueryDexFeesParams
 	err := keeper.cdc.UnmarshalJSON(req.Data, &params)
@@ -357,3 +357,55 @@ func queryTxListV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 
 	return res, nil
 }
+
+func queryEvmTxListV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QueryEvmTxListParamsV2
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	address, err := types.EvmAddressFromHexOrBech32(params.Address)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("invalid address", err.Error()))
+	}
+
+	evmTxs := keeper.getEvmTransactionListV2(ctx, address, params.After, params.Before, params.Limit)
+	if len(evmTxs) == 0 {
+		return nil, nil
+	}
+
+	res, err := common.JSONMarshalV2(evmTxs)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+
+	return res, nil
+}
+
+func queryTokenTransferListV2(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var params types.QueryTokenTransferListParamsV2
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+	}
+	address, err := types.EvmAddressFromHexOrBech32(params.Address)
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("invalid address", err.Error()))
+	}
+	var contract string
+	if params.Contract != "" {
+		if contract, err = types.EvmAddressFromHexOrBech32(params.Contract); err != nil {
+			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("invalid contract address", err.Error()))
+		}
+	}
+
+	transfers := keeper.getTokenTransferListV2(ctx, address, contract, params.After, params.Before, params.Limit)
+	if len(transfers) == 0 {
+		return nil, nil
+	}
+
+	res, err := common.JSONMarshalV2(transfers)
+	if err != nil {
+		return nil, sdk.ErrInternal(err.Error())
+	}
+
+	return res, nil
+}
@@ -380,6 +380,96 @@ func querySwapTokenPairs(ctx sdk.Context, path []string, req abci.RequestQuery,
 	return bz, nil
 }
 
+// querySwapTickers returns the tickers of all the swap token pairs in the latest 24 hours
+func querySwapTickers(ctx sdk.Context, keeper Keeper) ([]byte, sdk.Error) {
+	swapTokenPairs := keeper.swapKeeper.GetSwapTokenPairs(ctx)
+	feeRate := keeper.swapKeeper.GetParams(ctx).FeeRate
+	tickersMap := make(map[string]*types.SwapTicker, len(swapTokenPairs))
+	quoteDenoms := make(map[string]string, len(swapTokenPairs))
+	for _, swapTokenPair := range swapTokenPairs {
+		tokenPairName := swapTokenPair.TokenPairName()
+		quoteDenoms[tokenPairName] = swapTokenPair.QuotePooledCoin.Denom
+		price := sdk.ZeroDec()
+		if swapTokenPair.QuotePooledCoin.Amount.IsPositive() {
+			price = swapTokenPair.BasePooledCoin.Amount.Quo(swapTokenPair.QuotePooledCoin.Amount)
+		}
+		tickersMap[tokenPairName] = &types.SwapTicker{
+			Product:   types.GetSwapKlineProduct(tokenPairName),
+			TokenPair: tokenPairName,
+			Timestamp: ctx.BlockTime().Unix(),
+			Open:      price,
+			High:      price,
+			Low:       price,
+			Price:     price,
+			Change24h: sdk.ZeroDec(),
+			Volume24h: sdk.ZeroDec(),
+			Tvl:       calculateDollarAmount(ctx, keeper, swapTokenPair.BasePooledCoin, swapTokenPair.QuotePooledCoin),
+			Fees24h:   sdk.ZeroDec(),
+		}
+	}
+
+	// query last 24 hours swap infos in orm db, sorted by timestamp asc
+	startTime := ctx.BlockTime().Add(-24 * time.Hour).Unix()
+	swapInfos := keeper.Orm.GetSwapInfo(startTime)
+	opened := make(map[string]bool, len(swapTokenPairs))
+	for _, swapInfo := range swapInfos {
+		ticker, ok := tickersMap[swapInfo.TokenPairName]
+		if !ok {
+			continue
+		}
+		price, err := sdk.NewDecFromStr(swapInfo.Price)
+		if err != nil {
+			continue
+		}
+		sellAmount, err := sdk.ParseDecCoin(swapInfo.SellAmount)
+		if err != nil {
+			continue
+		}
+		buyAmount, err := sdk.ParseDecCoin(swapInfo.BuysAmount)
+		if err != nil {
+			continue
+		}
+
+		if !opened[swapInfo.TokenPairName] {
+			ticker.Open = price
+			opened[swapInfo.TokenPairName] = true
+		}
+		if price.GT(ticker.High) {
+			ticker.High = price
+		}
+		if price.LT(ticker.Low) {
+			ticker.Low = price
+		}
+
+		// volume is counted in quote token and fee is charged on the token sold
+		if sellAmount.Denom == quoteDenoms[swapInfo.TokenPairName] {
+			ticker.Volume24h = ticker.Volume24h.Add(sellAmount.Amount)
+		} else {
+			ticker.Volume24h = ticker.Volume24h.Add(buyAmount.Amount)
+		}
+		fee := sdk.NewDecCoinFromDec(sellAmount.Denom, sellAmount.Amount.Mul(feeRate))
+		ticker.Fees24h = ticker.Fees24h.Add(calculateDollarAmount(ctx, keeper, fee, sdk.NewDecCoinFromDec(fee.Denom, sdk.ZeroDec())))
+	}
+
+	tickers := make([]types.SwapTicker, 0, len(tickersMap))
+	for _, ticker := range tickersMap {
+		if ticker.Open.IsPositive() {
+			ticker.Change24h = ticker.Price.Sub(ticker.Open).Quo(ticker.Open)
+		}
+		tickers = append(tickers, *ticker)
+	}
+	sort.Slice(tickers, func(i, j int) bool {
+		return tickers[i].TokenPair < tickers[j].TokenPair
+	})
+
+	response := common.GetBaseResponse(tickers)
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+	return bz, nil
+}
+
 // querySwapLiquidityHistories returns liquidity info of the address
 func querySwapLiquidityHistories(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
 	var queryParams types.QuerySwapLiquidityInfoParams
@@ -0,0 +1,59 @@
+package orm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Filter is a where condition of a connection query
+type Filter struct {
+	Query string
+	Args  []interface{}
+}
+
+// NewFilter creates the where condition
+func NewFilter(query string, args ...interface{}) Filter {
+	return Filter{Query: query, Args: args}
+}
+
+// GetConnection finds at most limit rows into out, a pointer to a slice of the table model. The rows match the filters
+// and are sorted by the key columns in descending order, which identify a row. A non-empty cursor is the values of
+// the key columns of the last row of the previous page, and the rows after it are found
+func (orm *ORM) GetConnection(filters []Filter, keyColumns []string, cursor []interface{}, limit int, out interface{}) error {
+	if len(cursor) != 0 && len(cursor) != len(keyColumns) {
+		return fmt.Errorf("invalid cursor of %d values for %d key columns", len(cursor), len(keyColumns))
+	}
+
+	query := orm.db
+	for _, filter := range filters {
+		query = query.Where(filter.Query, filter.Args...)
+	}
+	if len(cursor) != 0 {
+		keysetQuery, keysetArgs := keysetCondition(keyColumns, cursor)
+		query = query.Where(keysetQuery, keysetArgs...)
+	}
+
+	orders := make([]string, 0, len(keyColumns))
+	for _, column := range keyColumns {
+		orders = append(orders, column+" desc")
+	}
+	return query.Order(strings.Join(orders, ", ")).Limit(limit).Find(out).Error
+}
+
+// keysetCondition returns the condition of the rows after the cursor in the descending order of the key columns, e.g.
+// (c1 < v1) or (c1 = v1 and c2 < v2), which works with the sql engines without the row value comparison
+func keysetCondition(keyColumns []string, cursor []interface{}) (string, []interface{}) {
+	var ors []string
+	var args []interface{}
+	for i, column := range keyColumns {
+		var ands []string
+		for j := 0; j < i; j++ {
+			ands = append(ands, keyColumns[j]+" = ?")
+			args = append(args, cursor[j])
+		}
+		ands = append(ands, column+" < ?")
+		args = append(args, cursor[i])
+		ors = append(ors, "("+strings.Join(ands, " and ")+")")
+	}
+	return "(" + strings.Join(ors, " or ") + ")", args
+}
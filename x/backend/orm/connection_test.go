@@ -0,0 +1,88 @@
+package orm
+
+import (
+	"testing"
+
+	"github.com/okex/exchain/x/backend/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestORM_GetConnection(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	_, err := orm.AddDeals([]*types.Deal{
+		{Timestamp: 100, BlockHeight: 1, OrderID: "ID1-1", Sender: "addr1", Product: types.TestTokenPair},
+		{Timestamp: 100, BlockHeight: 1, OrderID: "ID1-2", Sender: "addr2", Product: types.TestTokenPair},
+		{Timestamp: 200, BlockHeight: 2, OrderID: "ID2-1", Sender: "addr1", Product: types.TestTokenPair},
+		{Timestamp: 200, BlockHeight: 2, OrderID: "ID2-2", Sender: "addr1", Product: "btc_tusdk"},
+		{Timestamp: 300, BlockHeight: 3, OrderID: "ID3-1", Sender: "addr1", Product: types.TestTokenPair},
+	})
+	require.Nil(t, err)
+	keyColumns := []string{"timestamp", "block_height", "order_id"}
+
+	// pages of 2 deals sorted by the key columns desc
+	var orderIDs []string
+	var cursor []interface{}
+	for {
+		var deals []types.Deal
+		require.Nil(t, orm.GetConnection(nil, keyColumns, cursor, 2, &deals))
+		for _, deal := range deals {
+			orderIDs = append(orderIDs, deal.OrderID)
+		}
+		if len(deals) < 2 {
+			break
+		}
+		last := deals[len(deals)-1]
+		cursor = []interface{}{last.Timestamp, last.BlockHeight, last.OrderID}
+	}
+	require.Equal(t, []string{"ID3-1", "ID2-2", "ID2-1", "ID1-2", "ID1-1"}, orderIDs)
+
+	// filtered
+	var deals []types.Deal
+	filters := []Filter{NewFilter("sender = ?", "addr1"), NewFilter("product = ?", types.TestTokenPair)}
+	require.Nil(t, orm.GetConnection(filters, keyColumns, []interface{}{300, 3, "ID3-1"}, 10, &deals))
+	require.Equal(t, 2, len(deals))
+	require.Equal(t, "ID2-1", deals[0].OrderID)
+	require.Equal(t, "ID1-1", deals[1].OrderID)
+
+	// invalid cursor
+	require.NotNil(t, orm.GetConnection(nil, keyColumns, []interface{}{300}, 10, &deals))
+}
+
+func TestKeysetCondition(t *testing.T) {
+	query, args := keysetCondition([]string{"c1", "c2"}, []interface{}{1, "a"})
+	require.Equal(t, "((c1 < ?) or (c1 = ? and c2 < ?))", query)
+	require.Equal(t, []interface{}{1, 1, "a"}, args)
+}
+
+func TestORM_GetConnection_SameKeys(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	// the transfers of a tx to the same address in different coins share the key columns but the id
+	var txs []*types.Transaction
+	for _, symbol := range []string{"a", "b", "c"} {
+		txs = append(txs, &types.Transaction{TxHash: "hash1", Address: "addr1", Symbol: symbol, Side: types.TxSideTo,
+			Timestamp: 100})
+	}
+	_, err := orm.AddTransactions(txs)
+	require.Nil(t, err)
+	keyColumns := []string{"timestamp", "tx_hash", "side", "id"}
+
+	var symbols []string
+	var cursor []interface{}
+	for {
+		var page []types.Transaction
+		require.Nil(t, orm.GetConnection(nil, keyColumns, cursor, 2, &page))
+		for _, tx := range page {
+			symbols = append(symbols, tx.Symbol)
+		}
+		if len(page) < 2 {
+			break
+		}
+		last := page[len(page)-1]
+		cursor = []interface{}{last.Timestamp, last.TxHash, last.Side, last.Id}
+	}
+	require.Equal(t, []string{"c", "b", "a"}, symbols)
+}
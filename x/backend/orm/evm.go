@@ -0,0 +1,131 @@
+package orm
+
+import (
+	"github.com/okex/exchain/x/backend/types"
+)
+
+// AddEvmTransactions insert into evm transactions, return count
+func (orm *ORM) AddEvmTransactions(evmTxs []*types.EvmTransaction) (addedCnt int, err error) {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	tx := orm.db.Begin()
+	defer func() { orm.deferRollbackTx(tx, err) }()
+	cnt := 0
+
+	for _, evmTx := range evmTxs {
+		if evmTx != nil {
+			ret := tx.Create(evmTx)
+			if ret.Error != nil {
+				return cnt, ret.Error
+			} else {
+				cnt++
+			}
+		}
+	}
+
+	tx.Commit()
+	return cnt, nil
+}
+
+// AddTokenTransfers insert into token transfers, return count
+func (orm *ORM) AddTokenTransfers(transfers []*types.TokenTransfer) (addedCnt int, err error) {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	tx := orm.db.Begin()
+	defer func() { orm.deferRollbackTx(tx, err) }()
+	cnt := 0
+
+	for _, transfer := range transfers {
+		if transfer != nil {
+			ret := tx.Create(transfer)
+			if ret.Error != nil {
+				return cnt, ret.Error
+			} else {
+				cnt++
+			}
+		}
+	}
+
+	tx.Commit()
+	return cnt, nil
+}
+
+// nolint
+func (orm *ORM) GetEvmTransactionList(address string, startTime, endTime int64, offset, limit int) ([]types.EvmTransaction, int) {
+	var evmTxs []types.EvmTransaction
+	query := orm.db.Model(types.EvmTransaction{}).Where("from_address = ? OR to_address = ?", address, address)
+	if startTime > 0 {
+		query = query.Where("timestamp >= ?", startTime)
+	}
+	if endTime > 0 {
+		query = query.Where("timestamp < ?", endTime)
+	}
+
+	var total int
+	query.Count(&total)
+	if offset >= total {
+		return evmTxs, total
+	}
+
+	query.Order("timestamp desc").Offset(offset).Limit(limit).Find(&evmTxs)
+	return evmTxs, total
+}
+
+// nolint
+func (orm *ORM) GetEvmTransactionListV2(address string, after string, before string, limit int) []types.EvmTransaction {
+	var evmTxs []types.EvmTransaction
+	query := orm.db.Model(types.EvmTransaction{}).Where("from_address = ? OR to_address = ?", address, address)
+	if after != "" {
+		query = query.Where("timestamp > ?", after)
+	}
+	if before != "" {
+		query = query.Where("timestamp < ?", before)
+	}
+
+	query.Order("timestamp desc").Limit(limit).Find(&evmTxs)
+	return evmTxs
+}
+
+// nolint
+func (orm *ORM) GetTokenTransferList(address, contract string, startTime, endTime int64, offset, limit int) ([]types.TokenTransfer, int) {
+	var transfers []types.TokenTransfer
+	query := orm.db.Model(types.TokenTransfer{}).Where("from_address = ? OR to_address = ?", address, address)
+	if contract != "" {
+		query = query.Where("contract = ?", contract)
+	}
+	if startTime > 0 {
+		query = query.Where("timestamp >= ?", startTime)
+	}
+	if endTime > 0 {
+		query = query.Where("timestamp < ?", endTime)
+	}
+
+	var total int
+	query.Count(&total)
+	if offset >= total {
+		return transfers, total
+	}
+
+	query.Order("timestamp desc, log_index desc").Offset(offset).Limit(limit).Find(&transfers)
+	return transfers, total
+}
+
+// nolint
+func (orm *ORM) GetTokenTransferListV2(address, contract string, after string, before string, limit int) []types.TokenTransfer {
+	var transfers []types.TokenTransfer
+	query := orm.db.Model(types.TokenTransfer{}).Where("from_address = ? OR to_address = ?", address, address)
+	if contract != "" {
+		query = query.Where("contract = ?", contract)
+	}
+	if after != "" {
+		query = query.Where("timestamp > ?", after)
+	}
+	if before != "" {
+		query = query.Where("timestamp < ?", before)
+	}
+
+	query.Order("timestamp desc, log_index desc").Limit(limit).Find(&transfers)
+	return transfers
+}
@@ -0,0 +1,127 @@
+package orm
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// migrateAutoIncrementID adds the auto increment id to the table created without it, which is the last key of the
+// keyset pagination telling apart the rows with the same values. It's called before the table is auto migrated
+func (orm *ORM) migrateAutoIncrementID(engineType string, model interface{}) error {
+	scope := orm.db.NewScope(model)
+	table := scope.TableName()
+	if !scope.Dialect().HasTable(table) || scope.Dialect().HasColumn(table, "id") {
+		return nil
+	}
+	orm.Debug(fmt.Sprintf("migrating the auto increment id of %s", table))
+
+	switch engineType {
+	case EngineTypeMysql:
+		return orm.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY",
+			scope.Quote(table))).Error
+	case EngineTypePostgres:
+		return orm.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD id BIGSERIAL PRIMARY KEY", scope.Quote(table))).Error
+	default:
+		return orm.rebuildSqliteTable(model, table)
+	}
+}
+
+// klineProductLength is the length of the product column of the kline tables, which holds the products of the swap
+// token pairs
+const klineProductLength = 64
+
+// migrateKlineProduct widens the product column of the kline table created with a shorter varchar, as AutoMigrate
+// doesn't widen the existing columns. The column type is checked first, so the table is altered only once. It's
+// called after the table is auto migrated
+func (orm *ORM) migrateKlineProduct(engineType string, model interface{}) error {
+	var query string
+	switch engineType {
+	case EngineTypeMysql:
+		query = "SELECT character_maximum_length FROM information_schema.columns " +
+			"WHERE table_schema = DATABASE() AND table_name = ? AND column_name = 'product'"
+	case EngineTypePostgres:
+		query = "SELECT character_maximum_length FROM information_schema.columns " +
+			"WHERE table_schema = CURRENT_SCHEMA() AND table_name = ? AND column_name = 'product'"
+	default:
+		// sqlite doesn't limit the length of a varchar
+		return nil
+	}
+
+	scope := orm.db.NewScope(model)
+	table := scope.TableName()
+	var length sql.NullInt64
+	if err := orm.db.Raw(query, table).Row().Scan(&length); err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+	if !length.Valid || length.Int64 >= klineProductLength {
+		return nil
+	}
+	orm.Debug(fmt.Sprintf("migrating the product column of %s from varchar(%d)", table, length.Int64))
+	return orm.db.Model(model).ModifyColumn("product", fmt.Sprintf("varchar(%d)", klineProductLength)).Error
+}
+
+// rebuildSqliteTable creates the table of the model again and copies the rows in the order they were inserted, as
+// sqlite can't add a primary key to a table
+func (orm *ORM) rebuildSqliteTable(model interface{}, table string) (err error) {
+	tx := orm.db.Begin()
+	defer func() { orm.deferRollbackTx(tx, err) }()
+
+	old := table + "_old"
+	if err = tx.Exec(fmt.Sprintf("ALTER TABLE `%s` RENAME TO `%s`", table, old)).Error; err != nil {
+		return err
+	}
+
+	// the indexes are renamed with the table, whose names are taken again by the new one
+	indexes, err := querySqliteNames(tx.CommonDB(),
+		"SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL", old)
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if err = tx.Exec(fmt.Sprintf("DROP INDEX `%s`", index)).Error; err != nil {
+			return err
+		}
+	}
+	if err = tx.AutoMigrate(model).Error; err != nil {
+		return err
+	}
+
+	columns, err := querySqliteNames(tx.CommonDB(), "SELECT name FROM pragma_table_info(?)", old)
+	if err != nil {
+		return err
+	}
+	columnList := "`" + strings.Join(columns, "`, `") + "`"
+	if err = tx.Exec(fmt.Sprintf("INSERT INTO `%s` (%s) SELECT %s FROM `%s` ORDER BY rowid",
+		table, columnList, columnList, old)).Error; err != nil {
+		return err
+	}
+	if err = tx.Exec(fmt.Sprintf("DROP TABLE `%s`", old)).Error; err != nil {
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// querySqliteNames returns the names selected by the query
+func querySqliteNames(db gorm.SQLCommon, query string, args ...interface{}) ([]string, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
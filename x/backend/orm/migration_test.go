@@ -0,0 +1,69 @@
+package orm
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/okex/exchain/x/backend/types"
+	"github.com/stretchr/testify/require"
+)
+
+// transactionWithoutID is the transaction stored before the auto increment id was added
+type transactionWithoutID struct {
+	TxHash    string `gorm:"type:varchar(80)"`
+	Type      int64  `gorm:"index;"`
+	Address   string `gorm:"index;type:varchar(80)"`
+	Symbol    string `gorm:"type:varchar(20)"`
+	Side      int64  `gorm:""`
+	Quantity  string `gorm:"type:varchar(40)"`
+	Fee       string `gorm:"type:varchar(40)"`
+	Timestamp int64  `gorm:"index"`
+}
+
+func (transactionWithoutID) TableName() string {
+	return "transactions"
+}
+
+func TestORM_MigrateAutoIncrementID(t *testing.T) {
+	dbName := fmt.Sprintf("testdb_migration_%010d.db", time.Now().Unix())
+	dbPath := "/tmp/" + dbName
+	defer DeleteDB(dbPath)
+
+	db, err := gorm.Open(EngineTypeSqlite, dbPath)
+	require.Nil(t, err)
+	require.Nil(t, db.AutoMigrate(&transactionWithoutID{}).Error)
+	for _, hash := range []string{"hash3", "hash1", "hash2"} {
+		require.Nil(t, db.Create(&transactionWithoutID{TxHash: hash, Address: "addr1", Timestamp: 100}).Error)
+	}
+	require.Nil(t, db.Close())
+
+	// the rows are numbered in the order they were inserted
+	orm, err := NewSqlite3ORM(false, "/tmp", dbName, nil)
+	require.Nil(t, err)
+	var txs []types.Transaction
+	require.Nil(t, orm.db.Order("id").Find(&txs).Error)
+	require.Equal(t, 3, len(txs))
+	for i, hash := range []string{"hash3", "hash1", "hash2"} {
+		require.Equal(t, uint64(i+1), txs[i].Id)
+		require.Equal(t, hash, txs[i].TxHash)
+	}
+
+	// the new rows follow them with the indexes kept
+	_, err = orm.AddTransactions([]*types.Transaction{{TxHash: "hash4", Address: "addr1", Timestamp: 200}})
+	require.Nil(t, err)
+	getTxs, total := orm.GetTransactionList("addr1", 0, 0, 0, 0, 10)
+	require.Equal(t, 4, total)
+	require.Equal(t, uint64(4), getTxs[0].Id)
+	require.True(t, orm.db.Dialect().HasIndex("transactions", "idx_transactions_address"))
+	require.False(t, orm.db.HasTable("transactions_old"))
+	require.Nil(t, orm.Close())
+
+	// and migrated once
+	orm, err = NewSqlite3ORM(false, "/tmp", dbName, nil)
+	require.Nil(t, err)
+	require.Nil(t, orm.db.Order("id").Find(&txs).Error)
+	require.Equal(t, 4, len(txs))
+	require.Nil(t, orm.Close())
+}
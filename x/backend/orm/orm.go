@@ -17,6 +17,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
 	"github.com/okex/exchain/x/backend/types"
 	"github.com/okex/exchain/x/token"
@@ -26,8 +27,9 @@ import (
 
 // nolint
 const (
-	EngineTypeSqlite = okexchaincfg.BackendOrmEngineTypeSqlite
-	EngineTypeMysql  = okexchaincfg.BackendOrmEngineTypeMysql
+	EngineTypeSqlite   = okexchaincfg.BackendOrmEngineTypeSqlite
+	EngineTypeMysql    = okexchaincfg.BackendOrmEngineTypeMysql
+	EngineTypePostgres = "postgres"
 )
 
 // nolint
@@ -46,6 +48,8 @@ type ORM struct {
 	klineM1sBuffer         map[string][]types.KlineM1
 	maxBlockTimestampMutex *sync.RWMutex
 	maxBlockTimestamp      int64
+	tableFilterMutex       *sync.RWMutex
+	tableFilter            map[string]bool
 }
 
 func (o *ORM) SetMaxBlockTimestamp(maxBlockTimestamp int64) {
@@ -94,20 +98,35 @@ func New(enableLog bool, engineInfo *OrmEngineInfo, logger *log.Logger) (m *ORM,
 	orm.bufferLock = new(sync.Mutex)
 	orm.singleEntryLock = new(sync.Mutex)
 	orm.maxBlockTimestampMutex = new(sync.RWMutex)
+	orm.tableFilterMutex = new(sync.RWMutex)
+	orm.SetTableFilter(DefaultTableFilter)
 	orm.db.LogMode(enableLog)
 	orm.db.AutoMigrate(&types.MatchResult{})
 	orm.db.AutoMigrate(&types.Deal{})
 	orm.db.AutoMigrate(&token.FeeDetail{})
 	orm.db.AutoMigrate(&types.Order{})
+	for _, model := range []interface{}{&types.Transaction{}, &types.SwapInfo{}} {
+		if err := orm.migrateAutoIncrementID(engineInfo.EngineType, model); err != nil {
+			return nil, err
+		}
+	}
 	orm.db.AutoMigrate(&types.Transaction{})
 	orm.db.AutoMigrate(&types.SwapInfo{})
 	orm.db.AutoMigrate(&types.SwapWhitelist{})
 	orm.db.AutoMigrate(&types.ClaimInfo{})
+	orm.db.AutoMigrate(&types.EvmTransaction{})
+	orm.db.AutoMigrate(&types.TokenTransfer{})
+	orm.db.AutoMigrate(&types.ReindexCheckpoint{})
+	orm.db.AutoMigrate(&types.AccountPosition{})
+	orm.db.AutoMigrate(&types.AccountReward{})
 
 	allKlinesMap := types.GetAllKlineMap()
 	for _, v := range allKlinesMap {
 		k := types.MustNewKlineFactory(v, nil)
 		orm.db.AutoMigrate(k)
+		if err := orm.migrateKlineProduct(engineInfo.EngineType, k); err != nil {
+			return nil, err
+		}
 	}
 	return &orm, nil
 }
@@ -376,6 +395,14 @@ func (orm *ORM) getOpenCloseDeals(startTS, endTS int64, product string) (open *t
 	return nil, nil
 }
 
+func (orm *ORM) getOpenCloseSwapInfos(startTS, endTS int64, product string) (open *types.SwapInfo, close *types.SwapInfo) {
+	var openSwap, closeSwap types.SwapInfo
+	orm.db.Model(types.SwapInfo{}).Where("Timestamp >= ? and Timestamp < ? and Product = ?", startTS, endTS, product).Order("Timestamp desc").Limit(1).First(&closeSwap)
+	orm.db.Model(types.SwapInfo{}).Where("Timestamp >= ? and Timestamp < ? and Product = ?", startTS, endTS, product).Order("Timestamp asc").Limit(1).First(&openSwap)
+
+	return &openSwap, &closeSwap
+}
+
 func (orm *ORM) getOpenCloseKline(startTS, endTS int64, product string, firstK interface{}, lastK interface{}) error {
 	defer types.PrintStackIfPanic()
 
@@ -455,6 +482,10 @@ func (orm *ORM) getKlineMinTimestamp(k types.IKline) int64 {
 	return orm.getMinTimestamp(k.GetTableName())
 }
 
+func (orm *ORM) getSwapInfoMinTimestamp() int64 {
+	return orm.getMinTimestamp("swap_infos")
+}
+
 func (orm *ORM) getMergeResultMinTimestamp() int64 {
 	return orm.getMinTimestamp("match_results")
 }
@@ -511,6 +542,52 @@ func (dm *MergeResultDataSource) getOpenClosePrice(startTS, endTS int64, product
 	return openDeal.Price, closeDeal.Price
 }
 
+// nolint
+type SwapDataSource struct {
+	Orm *ORM
+}
+
+func (dm *SwapDataSource) getDataSourceMinTimestamp() int64 {
+	return dm.Orm.getSwapInfoMinTimestamp()
+}
+
+func (dm *SwapDataSource) getMaxMinSumByGroupSQL(startTS, endTS int64) string {
+	sql := fmt.Sprintf("select product, sum(kline_volume) as quantity, max(kline_price) as high, min(kline_price) as low, count(kline_price) as cnt from swap_infos "+
+		"where Timestamp >= %d and Timestamp < %d and Product <> '' group by product", startTS, endTS)
+	return sql
+}
+
+func (dm *SwapDataSource) getOpenClosePrice(startTS, endTS int64, product string) (float64, float64) {
+	openSwap, closeSwap := dm.Orm.getOpenCloseSwapInfos(startTS, endTS, product)
+	return openSwap.KlinePrice, closeSwap.KlinePrice
+}
+
+// MarketDataSource builds the klines of both the dex products and the swap token pairs in one pass,
+// so that they share the anchor of Kline1M
+type MarketDataSource struct {
+	Dex  IKline1MDataSource
+	Swap *SwapDataSource
+}
+
+func (dm *MarketDataSource) getDataSourceMinTimestamp() int64 {
+	dexTS, swapTS := dm.Dex.getDataSourceMinTimestamp(), dm.Swap.getDataSourceMinTimestamp()
+	if dexTS == -1 || (swapTS != -1 && swapTS < dexTS) {
+		return swapTS
+	}
+	return dexTS
+}
+
+func (dm *MarketDataSource) getMaxMinSumByGroupSQL(startTS, endTS int64) string {
+	return dm.Dex.getMaxMinSumByGroupSQL(startTS, endTS) + " union all " + dm.Swap.getMaxMinSumByGroupSQL(startTS, endTS)
+}
+
+func (dm *MarketDataSource) getOpenClosePrice(startTS, endTS int64, product string) (float64, float64) {
+	if types.IsSwapKlineProduct(product) {
+		return dm.Swap.getOpenClosePrice(startTS, endTS, product)
+	}
+	return dm.Dex.getOpenClosePrice(startTS, endTS, product)
+}
+
 // CreateKline1M batch insert into Kline1M
 func (orm *ORM) CreateKline1M(startTS, endTS int64, dataSource IKline1MDataSource) (
 	anchorEndTS int64, newProductCnt int, newKlineInfo map[string][]types.KlineM1, err error) {
@@ -664,6 +741,10 @@ func (orm *ORM) getAllUpdatedProducts(anchorStartTS, anchorEndTS int64) ([]strin
 
 	tmpMap := map[string]bool{}
 	for _, p := range p1 {
+		// tickers of swap token pairs are built from the pools
+		if types.IsSwapKlineProduct(p) {
+			continue
+		}
 		tmpMap[p] = true
 	}
 
@@ -1237,6 +1318,16 @@ func (orm *ORM) GetTransactionList(address string, txType, startTime, endTime in
 	return txs, total
 }
 
+// batchInsertSQL returns the insert statement of multiple rows, quoting the identifiers by the dialect of the engine
+func (orm *ORM) batchInsertSQL(table string, columns []string, values string) string {
+	dialect := orm.db.Dialect()
+	quotedColumns := make([]string, len(columns))
+	for i, column := range columns {
+		quotedColumns[i] = dialect.Quote(column)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", dialect.Quote(table), strings.Join(quotedColumns, ","), values)
+}
+
 // BatchInsertOrUpdate return map mean success or fail
 func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*types.Order, deals []*types.Deal, mrs []*types.MatchResult,
 	feeDetails []*token.FeeDetail, trxs []*types.Transaction, swapInfos []*types.SwapInfo, claimInfos []*types.ClaimInfo) (resultMap map[string]int, err error) {
@@ -1268,8 +1359,8 @@ func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*t
 	}
 	if len(orderVItems) > 0 {
 		orderValueSQL := strings.Join(orderVItems, ", ")
-		orderSQL := fmt.Sprintf("INSERT INTO `orders` (`tx_hash`,`order_id`,`sender`,`product`,`side`,`price`,"+
-			"`quantity`,`status`,`filled_avg_price`,`remain_quantity`,`timestamp`) VALUES %s", orderValueSQL)
+		orderSQL := orm.batchInsertSQL("orders", []string{"tx_hash", "order_id", "sender", "product", "side", "price",
+			"quantity", "status", "filled_avg_price", "remain_quantity", "timestamp"}, orderValueSQL)
 		ret := trx.Exec(orderSQL)
 		if ret.Error != nil {
 			return resultMap, ret.Error
@@ -1304,8 +1395,8 @@ func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*t
 		dealVItems = append(dealVItems, vItem)
 	}
 	if len(dealVItems) > 0 {
-		dealsSQL := fmt.Sprintf("INSERT INTO `deals` (`timestamp`,`block_height`,`order_id`,`sender`,`product`,`side`,`price`,`quantity`,`fee`,`fee_receiver`) "+
-			"VALUES %s", strings.Join(dealVItems, ","))
+		dealsSQL := orm.batchInsertSQL("deals", []string{"timestamp", "block_height", "order_id", "sender", "product", "side",
+			"price", "quantity", "fee", "fee_receiver"}, strings.Join(dealVItems, ","))
 		ret := trx.Exec(dealsSQL)
 		if ret.Error != nil {
 			return resultMap, ret.Error
@@ -1322,8 +1413,8 @@ func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*t
 		trxVItems = append(trxVItems, vItem)
 	}
 	if len(trxVItems) > 0 {
-		trxSQL := fmt.Sprintf("INSERT INTO `transactions` (`tx_hash`,`type`,`address`,`symbol`,`side`,`quantity`,`fee`,`timestamp`) "+
-			"VALUES %s", strings.Join(trxVItems, ", "))
+		trxSQL := orm.batchInsertSQL("transactions", []string{"tx_hash", "type", "address", "symbol", "side", "quantity",
+			"fee", "timestamp"}, strings.Join(trxVItems, ", "))
 		ret := trx.Exec(trxSQL)
 		if ret.Error != nil {
 			return resultMap, ret.Error
@@ -1340,7 +1431,7 @@ func (orm *ORM) BatchInsertOrUpdate(newOrders []*types.Order, updatedOrders []*t
 		fdVItems = append(fdVItems, vItem)
 	}
 	if len(fdVItems) > 0 {
-		fdSQL := fmt.Sprintf("INSERT INTO `fee_details` (`address`,`fee`,`fee_type`,`timestamp`) VALUES %s", strings.Join(fdVItems, ","))
+		fdSQL := orm.batchInsertSQL("fee_details", []string{"address", "fee", "fee_type", "timestamp"}, strings.Join(fdVItems, ","))
 		ret := trx.Exec(fdSQL)
 		if ret.Error != nil {
 			return resultMap, ret.Error
@@ -0,0 +1,97 @@
+package orm
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/okex/exchain/x/backend/types"
+	"github.com/okex/exchain/x/token"
+	"github.com/stretchr/testify/require"
+)
+
+func (orm *DangrousORM) CleanupAllTablesInTestEvn() (err error) {
+
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	tx := orm.db.Begin()
+	defer func() { orm.deferRollbackTx(tx, err) }()
+
+	models := []interface{}{&types.MatchResult{}, &types.Deal{}, &token.FeeDetail{}, &types.Order{},
+		&types.Transaction{}, &types.SwapInfo{}, &types.SwapWhitelist{}, &types.ClaimInfo{},
+		&types.EvmTransaction{}, &types.TokenTransfer{}}
+	for _, v := range types.GetAllKlineMap() {
+		models = append(models, types.MustNewKlineFactory(v, nil))
+	}
+
+	var errs []error
+	for _, model := range models {
+		errs = append(errs, tx.Delete(model).Error)
+	}
+	if err = types.NewErrorsMerged(errs...); err != nil {
+		return err
+	}
+	tx.Commit()
+
+	return nil
+}
+
+// postgresDSNEnv is the env of the DSN of the postgres for the system tests, e.g.
+// "host=127.0.0.1 port=5432 user=okdexer password=okdex123! dbname=okdex sslmode=disable"
+const postgresDSNEnv = "POSTGRES_TEST_DSN"
+
+func NewPostgresORM(dsn string) (orm *ORM, e error) {
+	engineInfo := OrmEngineInfo{
+		EngineType: EngineTypePostgres,
+		ConnectStr: dsn,
+	}
+	// New panics if the database can't be connected
+	defer func() {
+		if r := recover(); r != nil {
+			orm, e = nil, fmt.Errorf("%v", r)
+		}
+	}()
+	postgresOrm, e := New(false, &engineInfo, nil)
+	if e != nil {
+		return nil, e
+	}
+
+	dorm := DangrousORM{postgresOrm}
+	if err := dorm.CleanupAllTablesInTestEvn(); err != nil {
+		return nil, err
+	}
+
+	return postgresOrm, nil
+}
+
+// TestPostgres runs the shared ORM tests against the postgres of the DSN in POSTGRES_TEST_DSN. It's skipped if the
+// env is unset, and fails if the postgres is unavailable.
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is unset", postgresDSNEnv)
+	}
+
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, orm *ORM)
+	}{
+		{"AllInOne", testORMAllInOne},
+		{"Deals", testORMDeals},
+		{"FeeDetails", testORMFeeDetails},
+		{"Orders", testORMOrders},
+		{"Transactions", testORMTransactions},
+		{"SwapKlines", testORMSwapKlines},
+		{"EvmTransactions", testORMEvmTransactions},
+		{"BatchInsert", testORMBatchInsert},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orm, err := NewPostgresORM(dsn)
+			require.NoError(t, err)
+			defer orm.Close()
+			tt.fn(t, orm)
+		})
+	}
+}
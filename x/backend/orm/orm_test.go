@@ -723,6 +723,203 @@ func TestSqlite3_Transactions(t *testing.T) {
 	testORMTransactions(t, orm)
 }
 
+func TestSqlite3_SwapKlines(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+	testORMSwapKlines(t, orm)
+}
+
+func testORMSwapKlines(t *testing.T, orm *ORM) {
+	ts := time.Now().Unix()/60*60 - 60*10
+	dexProduct, swapProduct := "abc_bcd", types.GetSwapKlineProduct("xxb_okt")
+	deals := []*types.Deal{
+		{BlockHeight: 1, OrderID: "order0", Product: dexProduct, Price: 5, Quantity: 100, Side: types.BuyOrder, Timestamp: ts + 5},
+	}
+	_, err := orm.AddDeals(deals)
+	require.Nil(t, err)
+
+	swapInfos := []*types.SwapInfo{
+		{TokenPairName: "xxb_okt", Price: "1", Timestamp: ts + 1, Product: swapProduct, KlinePrice: 1, KlineVolume: 10},
+		{TokenPairName: "xxb_okt", Price: "3", Timestamp: ts + 10, Product: swapProduct, KlinePrice: 3, KlineVolume: 20},
+		{TokenPairName: "xxb_okt", Price: "2", Timestamp: ts + 20, Product: swapProduct, KlinePrice: 2, KlineVolume: 30},
+		// swap info recorded without kline fields is ignored
+		{TokenPairName: "xxb_okt", Price: "9", Timestamp: ts + 30},
+	}
+	_, err = orm.AddSwapInfo(swapInfos)
+	require.Nil(t, err)
+
+	ds := MarketDataSource{Dex: &DealDataSource{orm: orm}, Swap: &SwapDataSource{Orm: orm}}
+	require.EqualValues(t, ts+1, ds.getDataSourceMinTimestamp())
+
+	_, cnt, newKlinesM1, err := orm.CreateKline1M(0, ts+60, &ds)
+	require.Nil(t, err)
+	require.Equal(t, 2, cnt)
+
+	require.Len(t, newKlinesM1[dexProduct], 1)
+	require.EqualValues(t, 5, newKlinesM1[dexProduct][0].Close)
+
+	require.Len(t, newKlinesM1[swapProduct], 1)
+	swapKline := newKlinesM1[swapProduct][0]
+	require.EqualValues(t, ts, swapKline.Timestamp)
+	require.EqualValues(t, 1, swapKline.Open)
+	require.EqualValues(t, 2, swapKline.Close)
+	require.EqualValues(t, 3, swapKline.High)
+	require.EqualValues(t, 1, swapKline.Low)
+	require.EqualValues(t, 60, swapKline.Volume)
+
+	// tickers of swap token pairs are not built from klines
+	products, err := orm.getAllUpdatedProducts(0, time.Now().Unix()+types.SecondsInADay)
+	require.Nil(t, err)
+	require.NotContains(t, products, swapProduct)
+}
+
+// Evm Transactions
+func testORMEvmTransactions(t *testing.T, orm *ORM) {
+
+	evmTxs := []*types.EvmTransaction{
+		{TxHash: "0xhash1", Height: 1, From: "0xaddr1", To: "0xaddr2", Value: "10", GasUsed: 21000, Status: types.EvmTxStatusSuccess, Timestamp: 100},
+		{TxHash: "0xhash2", Height: 2, From: "0xaddr2", To: "0xaddr1", Value: "20", GasUsed: 21000, Status: types.EvmTxStatusSuccess, Timestamp: 200},
+		{TxHash: "0xhash3", Height: 3, From: "0xaddr1", ContractAddress: "0xtoken", Value: "0", GasUsed: 90000, Status: types.EvmTxStatusFailed, Timestamp: 300},
+		{TxHash: "0xhash4", Height: 4, From: "0xaddr2", To: "0xaddr3", Value: "30", GasUsed: 21000, Status: types.EvmTxStatusSuccess, Timestamp: 400},
+	}
+	// Test AddEvmTransactions
+	cnt, err := orm.AddEvmTransactions(evmTxs)
+	require.Nil(t, err)
+	require.EqualValues(t, 4, cnt)
+
+	// Test GetEvmTransactionList
+	// filtered by from or to, sorted by timestamp desc, and paged by offset and limit
+	getTxs, total := orm.GetEvmTransactionList("0xaddr1", 0, 0, 1, 2)
+	require.EqualValues(t, 3, total)
+	require.EqualValues(t, 2, len(getTxs))
+	require.EqualValues(t, "0xhash2", getTxs[0].TxHash)
+	require.EqualValues(t, "0xhash1", getTxs[1].TxHash)
+
+	// filtered by address & start end time
+	getTxs, total = orm.GetEvmTransactionList("0xaddr1", 200, 300, 0, 10)
+	require.EqualValues(t, 1, total)
+	require.EqualValues(t, "0xhash2", getTxs[0].TxHash)
+
+	// GetEvmTransactionListV2
+	getTxsV2 := orm.GetEvmTransactionListV2("0xaddr1", "100", "400", 1)
+	require.EqualValues(t, 1, len(getTxsV2))
+	require.EqualValues(t, evmTxs[2], &getTxsV2[0])
+
+	transfers := []*types.TokenTransfer{
+		{TxHash: "0xhash1", LogIndex: 0, Height: 1, Standard: types.TokenStandardERC20, Contract: "0xtoken1", From: "0xaddr1", To: "0xaddr2", Value: "100", Timestamp: 100},
+		{TxHash: "0xhash1", LogIndex: 1, Height: 1, Standard: types.TokenStandardERC721, Contract: "0xtoken2", From: "0xaddr2", To: "0xaddr1", Value: "7", Timestamp: 100},
+		{TxHash: "0xhash2", LogIndex: 0, Height: 2, Standard: types.TokenStandardERC20, Contract: "0xtoken1", From: "0xaddr2", To: "0xaddr3", Value: "50", Timestamp: 200},
+	}
+	// Test AddTokenTransfers
+	cnt, err = orm.AddTokenTransfers(transfers)
+	require.Nil(t, err)
+	require.EqualValues(t, 3, cnt)
+
+	// Test GetTokenTransferList
+	getTransfers, total := orm.GetTokenTransferList("0xaddr1", "", 0, 0, 0, 10)
+	require.EqualValues(t, 2, total)
+	require.EqualValues(t, int64(1), getTransfers[0].LogIndex)
+	require.EqualValues(t, int64(0), getTransfers[1].LogIndex)
+
+	// filtered by contract
+	getTransfers, total = orm.GetTokenTransferList("0xaddr2", "0xtoken1", 0, 0, 0, 10)
+	require.EqualValues(t, 2, total)
+	require.EqualValues(t, "0xhash2", getTransfers[0].TxHash)
+
+	// GetTokenTransferListV2
+	getTransfersV2 := orm.GetTokenTransferListV2("0xaddr2", "0xtoken1", "", "200", 10)
+	require.EqualValues(t, 1, len(getTransfersV2))
+	require.EqualValues(t, transfers[0], &getTransfersV2[0])
+
+	// a transfer is indexed once by its tx hash and log index
+	_, err = orm.AddTokenTransfers(transfers[2:])
+	require.NotNil(t, err)
+}
+
+func TestSqlite3_EvmTransactions(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+	testORMEvmTransactions(t, orm)
+}
+
+func TestSqlite3_Reindex(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	// table filter
+	require.True(t, orm.IsTableEnabled(types.ReindexTableDeals))
+	orm.SetTableFilter([]string{})
+	require.False(t, orm.IsTableEnabled(types.ReindexTableDeals))
+	orm.SetTableFilter([]string{types.ReindexTableDeals})
+	require.True(t, orm.IsTableEnabled(types.ReindexTableDeals))
+	require.False(t, orm.IsTableEnabled(types.ReindexTableKlines))
+	orm.SetTableFilter(nil)
+	require.True(t, orm.IsTableEnabled(types.ReindexTableKlines))
+
+	// checkpoint
+	checkpoint, err := orm.GetReindexCheckpoint()
+	require.Nil(t, err)
+	require.Nil(t, checkpoint)
+	require.Nil(t, orm.SaveReindexCheckpoint(10, 1000, []string{types.ReindexTableDeals}))
+	require.Nil(t, orm.SaveReindexCheckpoint(11, 1003, []string{types.ReindexTableDeals}))
+	checkpoint, err = orm.GetReindexCheckpoint()
+	require.Nil(t, err)
+	require.EqualValues(t, 11, checkpoint.Height)
+	require.EqualValues(t, 1003, checkpoint.Timestamp)
+	require.Equal(t, types.ReindexTableDeals, checkpoint.Tables)
+
+	// delete the data of the blocks in [11, 12]
+	ts := time.Now().Unix()/60*60 - 60*10
+	deals := []*types.Deal{
+		{BlockHeight: 10, OrderID: "order0", Product: "abc_bcd", Price: 1, Quantity: 1, Side: types.BuyOrder, Timestamp: ts},
+		{BlockHeight: 11, OrderID: "order1", Product: "abc_bcd", Price: 2, Quantity: 1, Side: types.BuyOrder, Timestamp: ts + 60},
+		{BlockHeight: 13, OrderID: "order2", Product: "abc_bcd", Price: 3, Quantity: 1, Side: types.BuyOrder, Timestamp: ts + 180},
+	}
+	_, err = orm.AddDeals(deals)
+	require.Nil(t, err)
+	txs := []*types.Transaction{
+		{TxHash: "hash0", Address: "addr0", Symbol: "abc", Quantity: "1", Fee: "0", Timestamp: ts},
+		{TxHash: "hash1", Address: "addr0", Symbol: "abc", Quantity: "1", Fee: "0", Timestamp: ts + 60},
+	}
+	_, err = orm.AddTransactions(txs)
+	require.Nil(t, err)
+	matchResults := []*types.MatchResult{
+		{BlockHeight: 10, Product: "abc_bcd", Price: 1, Quantity: 1, Timestamp: ts},
+		{BlockHeight: 11, Product: "abc_bcd", Price: 2, Quantity: 1, Timestamp: ts + 60},
+		{BlockHeight: 13, Product: "abc_bcd", Price: 3, Quantity: 1, Timestamp: ts + 180},
+	}
+	_, err = orm.AddMatchResults(matchResults)
+	require.Nil(t, err)
+	ds := MergeResultDataSource{Orm: orm}
+	_, _, _, err = orm.CreateKline1M(0, ts+240, &ds)
+	require.Nil(t, err)
+
+	err = orm.DeleteReindexData([]string{types.ReindexTableDeals, types.ReindexTableMatchResults,
+		types.ReindexTableTransactions, types.ReindexTableKlines}, 11, 12, ts+60, ts+120)
+	require.Nil(t, err)
+
+	leftDeals, total := orm.GetDeals("", "", "", 0, ts+240, 0, 10)
+	require.Equal(t, 2, total)
+	require.Equal(t, "order2", leftDeals[0].OrderID)
+	require.Equal(t, "order0", leftDeals[1].OrderID)
+	_, total = orm.GetMatchResults("", 0, ts+240, 0, 10)
+	require.Equal(t, 2, total)
+	_, total = orm.GetTransactionList("addr0", 0, 0, ts+240, 0, 10)
+	require.Equal(t, 1, total)
+	// klines covering the blocks or later are deleted
+	require.EqualValues(t, ts, orm.getKlineMaxTimestamp(&types.KlineM1{}))
+
+	// rebuild the klines of the blocks, and the later ones deleted with them
+	require.Nil(t, orm.RebuildKlines(ts+60, ts+120))
+	require.EqualValues(t, ts+180, orm.getKlineMaxTimestamp(&types.KlineM1{}))
+	require.NotEqual(t, int64(-1), orm.getKlineMaxTimestamp(&types.KlineM3{}))
+
+	// unsupported table, and the deals deleted before it are rolled back
+	require.NotNil(t, orm.DeleteReindexData([]string{types.ReindexTableDeals, "kline_m1"}, 0, 100, 0, ts+240))
+	_, total = orm.GetDeals("", "", "", 0, ts+240, 0, 10)
+	require.Equal(t, 2, total)
+}
+
 func Test_Time(t *testing.T) {
 	now := time.Now()
 	time.Sleep(time.Second)
@@ -899,3 +1096,59 @@ func TestORM_DeferRollbackTx(t *testing.T) {
 	panic("orm deferRollbackTx recover will catch the panic")
 
 }
+
+func TestSqlite3_Portfolios(t *testing.T) {
+	orm, dbPath := MockSqlite3ORM()
+	defer DeleteDB(dbPath)
+
+	addr, product := "addr0", "abc_bcd"
+	position, err := orm.GetLatestAccountPosition(addr, product)
+	require.Nil(t, err)
+	require.Nil(t, position)
+
+	day := types.GetDayTimestamp(time.Now().Unix())
+	positions := []*types.AccountPosition{
+		{Address: addr, Product: product, Timestamp: day - types.SecondsInADay, Quantity: 10, AvgCost: 2},
+		{Address: addr, Product: "xyz_bcd", Timestamp: day - types.SecondsInADay, Quantity: 1, AvgCost: 1},
+	}
+	rewards := []*types.AccountReward{
+		{Address: addr, PoolName: "pool0", Timestamp: day - types.SecondsInADay, Claimed: "1.000000000000000000okt"},
+	}
+	cnt, err := orm.SavePortfolios(positions, rewards)
+	require.Nil(t, err)
+	require.Equal(t, 3, cnt)
+
+	// roll forward and update the position of today, twice
+	position, err = orm.GetLatestAccountPosition(addr, product)
+	require.Nil(t, err)
+	position = position.RollTo(day + 10)
+	position.Sell(5, 3)
+	_, err = orm.SavePortfolios([]*types.AccountPosition{position}, nil)
+	require.Nil(t, err)
+	position.Sell(5, 4)
+	_, err = orm.SavePortfolios([]*types.AccountPosition{position}, nil)
+	require.Nil(t, err)
+
+	position, err = orm.GetLatestAccountPosition(addr, product)
+	require.Nil(t, err)
+	require.EqualValues(t, day, position.Timestamp)
+	require.EqualValues(t, 0, position.Quantity)
+	require.EqualValues(t, 15, position.RealizedPnl)
+
+	timeline := orm.GetAccountPositionsV2(addr, "", "", "", 10)
+	require.Equal(t, 3, len(timeline))
+	require.Equal(t, day, timeline[0].Timestamp)
+	require.Equal(t, 1, len(orm.GetAccountPositionsV2(addr, product, "", strconv.FormatInt(day, 10), 10)))
+
+	latest := orm.GetLatestAccountPositions(addr, "")
+	require.Equal(t, 2, len(latest))
+	require.Equal(t, product, latest[0].Product)
+	require.EqualValues(t, day, latest[0].Timestamp)
+	require.Equal(t, "xyz_bcd", latest[1].Product)
+
+	reward, err := orm.GetLatestAccountReward(addr, "pool0")
+	require.Nil(t, err)
+	require.Equal(t, "1.000000000000000000okt", reward.Claimed)
+	require.Equal(t, 1, len(orm.GetAccountRewardsV2(addr, "", "", "", 10)))
+	require.Equal(t, 0, len(orm.GetAccountRewardsV2(addr, "pool1", "", "", 10)))
+}
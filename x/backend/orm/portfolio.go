@@ -0,0 +1,108 @@
+package orm
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/okex/exchain/x/backend/types"
+)
+
+// GetLatestAccountPosition returns the latest daily position of the address in the product, nil if there's none
+func (orm *ORM) GetLatestAccountPosition(address, product string) (*types.AccountPosition, error) {
+	var position types.AccountPosition
+	err := orm.db.Where("address = ? and product = ?", address, product).Order("timestamp desc").First(&position).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &position, nil
+}
+
+// GetLatestAccountReward returns the latest daily reward of the address from the pool, nil if there's none
+func (orm *ORM) GetLatestAccountReward(address, poolName string) (*types.AccountReward, error) {
+	var reward types.AccountReward
+	err := orm.db.Where("address = ? and pool_name = ?", address, poolName).Order("timestamp desc").First(&reward).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reward, nil
+}
+
+// SavePortfolios inserts or updates the daily positions and rewards
+func (orm *ORM) SavePortfolios(positions []*types.AccountPosition, rewards []*types.AccountReward) (savedCnt int, err error) {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	tx := orm.db.Begin()
+	defer func() { orm.deferRollbackTx(tx, err) }()
+	cnt := 0
+
+	for _, position := range positions {
+		if ret := tx.Save(position); ret.Error != nil {
+			return cnt, ret.Error
+		}
+		cnt++
+	}
+	for _, reward := range rewards {
+		if ret := tx.Save(reward); ret.Error != nil {
+			return cnt, ret.Error
+		}
+		cnt++
+	}
+
+	tx.Commit()
+	return cnt, nil
+}
+
+// GetAccountPositionsV2 returns the daily positions of the address, sorted by timestamp desc
+func (orm *ORM) GetAccountPositionsV2(address, product string, after string, before string, limit int) []types.AccountPosition {
+	var positions []types.AccountPosition
+	query := orm.db.Model(types.AccountPosition{}).Where("address = ?", address)
+	if product != "" {
+		query = query.Where("product = ?", product)
+	}
+	if after != "" {
+		query = query.Where("timestamp > ?", after)
+	}
+	if before != "" {
+		query = query.Where("timestamp < ?", before)
+	}
+
+	query.Order("timestamp desc, product asc").Limit(limit).Find(&positions)
+	return positions
+}
+
+// GetAccountRewardsV2 returns the daily rewards of the address, sorted by timestamp desc
+func (orm *ORM) GetAccountRewardsV2(address, poolName string, after string, before string, limit int) []types.AccountReward {
+	var rewards []types.AccountReward
+	query := orm.db.Model(types.AccountReward{}).Where("address = ?", address)
+	if poolName != "" {
+		query = query.Where("pool_name = ?", poolName)
+	}
+	if after != "" {
+		query = query.Where("timestamp > ?", after)
+	}
+	if before != "" {
+		query = query.Where("timestamp < ?", before)
+	}
+
+	query.Order("timestamp desc, pool_name asc").Limit(limit).Find(&rewards)
+	return rewards
+}
+
+// GetLatestAccountPositions returns the latest daily position of the address in every product, sorted by product
+func (orm *ORM) GetLatestAccountPositions(address, product string) []types.AccountPosition {
+	var positions []types.AccountPosition
+	query := orm.db.Model(types.AccountPosition{}).Where("address = ?", address).
+		Where("timestamp = (select max(p.timestamp) from account_positions p " +
+			"where p.address = account_positions.address and p.product = account_positions.product)")
+	if product != "" {
+		query = query.Where("product = ?", product)
+	}
+
+	query.Order("product asc").Find(&positions)
+	return positions
+}
@@ -0,0 +1,143 @@
+package orm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/okex/exchain/x/backend/types"
+	"github.com/okex/exchain/x/token"
+)
+
+// DefaultTableFilter is the table filter of every ORM created, nil enables all the tables.
+// The reindex command sets it before the app is created, so the backend writes nothing until the blocks to re-index
+var DefaultTableFilter []string
+
+// SetTableFilter limits the tables written by the backend to the given ones, nil enables all the tables
+func (orm *ORM) SetTableFilter(tables []string) {
+	var filter map[string]bool
+	if tables != nil {
+		filter = make(map[string]bool, len(tables))
+		for _, table := range tables {
+			filter[table] = true
+		}
+	}
+
+	orm.tableFilterMutex.Lock()
+	defer orm.tableFilterMutex.Unlock()
+	orm.tableFilter = filter
+}
+
+// IsTableEnabled returns whether the backend writes the table
+func (orm *ORM) IsTableEnabled(table string) bool {
+	orm.tableFilterMutex.RLock()
+	defer orm.tableFilterMutex.RUnlock()
+	return orm.tableFilter == nil || orm.tableFilter[table]
+}
+
+// GetReindexCheckpoint returns the checkpoint recorded by the reindex command, nil if there's none
+func (orm *ORM) GetReindexCheckpoint() (*types.ReindexCheckpoint, error) {
+	var checkpoint types.ReindexCheckpoint
+	err := orm.db.Where("name = ?", types.ReindexCheckpointName).First(&checkpoint).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// SaveReindexCheckpoint records the latest block re-indexed
+func (orm *ORM) SaveReindexCheckpoint(height, timestamp int64, tables []string) error {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	checkpoint := types.ReindexCheckpoint{
+		Name:      types.ReindexCheckpointName,
+		Height:    height,
+		Timestamp: timestamp,
+		Tables:    strings.Join(tables, ","),
+	}
+	return orm.db.Save(&checkpoint).Error
+}
+
+// DeleteReindexData deletes the data of the blocks in [startHeight, endHeight] from the tables, so that re-indexing
+// the blocks again won't duplicate them. The tables without heights are matched by the block time, and the klines
+// covering the blocks or later are deleted as they are merged incrementally
+func (orm *ORM) DeleteReindexData(tables []string, startHeight, endHeight, startTS, endTS int64) (err error) {
+	orm.singleEntryLock.Lock()
+	defer orm.singleEntryLock.Unlock()
+
+	tx := orm.db.Begin()
+	// err is evaluated when the deferred function runs, so the errors returned below roll back the tx
+	defer func() { orm.deferRollbackTx(tx, err) }()
+
+	for _, table := range tables {
+		var r *gorm.DB
+		switch table {
+		case types.ReindexTableDeals:
+			r = tx.Delete(&types.Deal{}, "block_height >= ? and block_height <= ?", startHeight, endHeight)
+		case types.ReindexTableMatchResults:
+			r = tx.Delete(&types.MatchResult{}, "block_height >= ? and block_height <= ?", startHeight, endHeight)
+		case types.ReindexTableEvmTransactions:
+			r = tx.Delete(&types.EvmTransaction{}, "height >= ? and height <= ?", startHeight, endHeight)
+		case types.ReindexTableTokenTransfers:
+			r = tx.Delete(&types.TokenTransfer{}, "height >= ? and height <= ?", startHeight, endHeight)
+		case types.ReindexTableOrders:
+			r = tx.Delete(&types.Order{}, "timestamp >= ? and timestamp <= ?", startTS, endTS)
+		case types.ReindexTableFeeDetails:
+			r = tx.Delete(&token.FeeDetail{}, "timestamp >= ? and timestamp <= ?", startTS, endTS)
+		case types.ReindexTableTransactions:
+			r = tx.Delete(&types.Transaction{}, "timestamp >= ? and timestamp <= ?", startTS, endTS)
+		case types.ReindexTableSwapInfos:
+			r = tx.Delete(&types.SwapInfo{}, "timestamp >= ? and timestamp <= ?", startTS, endTS)
+		case types.ReindexTableClaimInfos:
+			r = tx.Delete(&types.ClaimInfo{}, "timestamp >= ? and timestamp <= ?", startTS, endTS)
+		case types.ReindexTableKlines:
+			for freq, name := range types.GetAllKlineMap() {
+				if r = tx.Delete(types.MustNewKlineFactory(name, nil), "timestamp > ?", startTS-int64(freq)); r.Error != nil {
+					break
+				}
+			}
+		default:
+			return fmt.Errorf("unsupported table %s", table)
+		}
+		if r.Error != nil {
+			return r.Error
+		}
+	}
+
+	tx.Commit()
+	return nil
+}
+
+// RebuildKlines builds the klines of all the frequencies from startTS until the block at endTS, or until the latest
+// match result or swap info if later, as all the klines after startTS have been deleted
+func (orm *ORM) RebuildKlines(startTS, endTS int64) error {
+	for _, tbName := range []string{"match_results", "swap_infos"} {
+		// the 1 minute kline of the latest data ends at the next minute
+		if latestTS := orm.getMaxTimestamp(tbName); latestTS >= endTS {
+			endTS = latestTS - latestTS%60 + 60
+		}
+	}
+
+	ds := MarketDataSource{
+		Dex:  &MergeResultDataSource{Orm: orm},
+		Swap: &SwapDataSource{Orm: orm},
+	}
+	if _, _, _, err := orm.CreateKline1M(startTS, endTS, &ds); err != nil {
+		return err
+	}
+
+	for freq, name := range types.GetAllKlineMap() {
+		if freq <= 60 {
+			continue
+		}
+		destKline := types.MustNewKlineFactory(name, nil).(types.IKline)
+		if _, _, _, err := orm.MergeKlineM1(startTS, endTS+int64(freq), destKline); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+const (
+	EvmTxStatusFailed  = 0
+	EvmTxStatusSuccess = 1
+
+	TokenStandardERC20  = "erc20"
+	TokenStandardERC721 = "erc721"
+)
+
+// TransferEventTopic is the topic of the Transfer event shared by ERC-20 and ERC-721 tokens
+var TransferEventTopic = ethcrypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// EvmTransaction is an ethereum tx executed by the evm module
+type EvmTransaction struct {
+	TxHash          string `gorm:"PRIMARY_KEY;type:varchar(80)" json:"txhash" v2:"txhash"`
+	Height          int64  `gorm:"index;" json:"height" v2:"height"`
+	From            string `gorm:"column:from_address;index;type:varchar(42)" json:"from" v2:"from"`
+	To              string `gorm:"column:to_address;index;type:varchar(42)" json:"to" v2:"to"` // empty when a contract is created
+	ContractAddress string `gorm:"type:varchar(42)" json:"contract_address" v2:"contract_address"`
+	Value           string `gorm:"type:varchar(80)" json:"value" v2:"value"`
+	GasUsed         int64  `gorm:"" json:"gas_used" v2:"gas_used"`
+	Status          int64  `gorm:"" json:"status" v2:"status"` // 0:Failed, 1:Success
+	Timestamp       int64  `gorm:"index;" json:"timestamp" v2:"timestamp"`
+}
+
+// TokenTransfer is an ERC-20 or ERC-721 Transfer event emitted by an ethereum tx
+type TokenTransfer struct {
+	TxHash    string `gorm:"unique_index:idx_token_transfer_log;type:varchar(80)" json:"txhash" v2:"txhash"`
+	LogIndex  int64  `gorm:"unique_index:idx_token_transfer_log" json:"log_index" v2:"log_index"`
+	Height    int64  `gorm:"index;" json:"height" v2:"height"`
+	Standard  string `gorm:"type:varchar(10)" json:"standard" v2:"standard"` // erc20 or erc721
+	Contract  string `gorm:"index;type:varchar(42)" json:"contract" v2:"contract"`
+	From      string `gorm:"column:from_address;index;type:varchar(42)" json:"from" v2:"from"`
+	To        string `gorm:"column:to_address;index;type:varchar(42)" json:"to" v2:"to"`
+	Value     string `gorm:"type:varchar(80)" json:"value" v2:"value"` // amount of ERC-20, token id of ERC-721
+	Timestamp int64  `gorm:"index;" json:"timestamp" v2:"timestamp"`
+}
+
+// GenerateEvmTx returns the ethereum tx and the token transfers it emitted, called at DeliverTx
+func GenerateEvmTx(msg *evmtypes.MsgEthereumTx, from sdk.AccAddress, txHash string, height, timestamp int64,
+	res abci.ResponseDeliverTx) (*EvmTransaction, []*TokenTransfer) {
+	evmTx := &EvmTransaction{
+		TxHash:    txHash,
+		Height:    height,
+		From:      ethcmn.BytesToAddress(from).Hex(),
+		Value:     msg.Data.Amount.String(),
+		GasUsed:   res.GasUsed,
+		Status:    EvmTxStatusFailed,
+		Timestamp: timestamp,
+	}
+	if msg.Data.Recipient != nil {
+		evmTx.To = msg.Data.Recipient.Hex()
+	}
+	if !res.IsOK() {
+		return evmTx, nil
+	}
+	evmTx.Status = EvmTxStatusSuccess
+
+	resultData, err := evmtypes.DecodeResultData(res.Data)
+	if err != nil {
+		return evmTx, nil
+	}
+	if msg.Data.Recipient == nil {
+		evmTx.ContractAddress = resultData.ContractAddress.Hex()
+	}
+
+	var transfers []*TokenTransfer
+	for _, log := range resultData.Logs {
+		if transfer := buildTokenTransfer(log, txHash, height, timestamp); transfer != nil {
+			transfers = append(transfers, transfer)
+		}
+	}
+	return evmTx, transfers
+}
+
+// buildTokenTransfer decodes a Transfer log. ERC-20 puts the amount into the data while ERC-721 indexes the token id
+func buildTokenTransfer(log *ethtypes.Log, txHash string, height, timestamp int64) *TokenTransfer {
+	if log == nil || len(log.Topics) < 3 || log.Topics[0] != TransferEventTopic {
+		return nil
+	}
+
+	transfer := &TokenTransfer{
+		TxHash:    txHash,
+		LogIndex:  int64(log.Index),
+		Height:    height,
+		Contract:  log.Address.Hex(),
+		From:      ethcmn.BytesToAddress(log.Topics[1].Bytes()).Hex(),
+		To:        ethcmn.BytesToAddress(log.Topics[2].Bytes()).Hex(),
+		Timestamp: timestamp,
+	}
+	switch {
+	case len(log.Topics) == 3 && len(log.Data) == ethcmn.HashLength:
+		transfer.Standard = TokenStandardERC20
+		transfer.Value = new(big.Int).SetBytes(log.Data).String()
+	case len(log.Topics) == 4 && len(log.Data) == 0:
+		transfer.Standard = TokenStandardERC721
+		transfer.Value = log.Topics[3].Big().String()
+	default:
+		return nil
+	}
+	return transfer
+}
+
+// EvmAddressFromHexOrBech32 parses an address given either in hex or in bech32 format into the hex format stored
+func EvmAddressFromHexOrBech32(address string) (string, error) {
+	if strings.HasPrefix(address, "0x") && ethcmn.IsHexAddress(address) {
+		return ethcmn.HexToAddress(address).Hex(), nil
+	}
+	accAddr, err := sdk.AccAddressFromBech32(address)
+	if err != nil {
+		return "", err
+	}
+	return ethcmn.BytesToAddress(accAddr).Hex(), nil
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestGenerateEvmTx(t *testing.T) {
+	from := ethcmn.HexToAddress("0x1000000000000000000000000000000000000001")
+	to := ethcmn.HexToAddress("0x2000000000000000000000000000000000000002")
+	contract := ethcmn.HexToAddress("0x3000000000000000000000000000000000000003")
+	msg := evmtypes.NewMsgEthereumTx(1, &contract, big.NewInt(10), 100000, big.NewInt(1), nil)
+
+	erc20Log := &ethtypes.Log{
+		Address: contract,
+		Topics:  []ethcmn.Hash{TransferEventTopic, from.Hash(), to.Hash()},
+		Data:    ethcmn.BigToHash(big.NewInt(1000)).Bytes(),
+		Index:   0,
+	}
+	erc721Log := &ethtypes.Log{
+		Address: contract,
+		Topics:  []ethcmn.Hash{TransferEventTopic, from.Hash(), to.Hash(), ethcmn.BigToHash(big.NewInt(7))},
+		Index:   1,
+	}
+	otherLog := &ethtypes.Log{
+		Address: contract,
+		Topics:  []ethcmn.Hash{ethcmn.HexToHash("0x01"), from.Hash(), to.Hash()},
+		Index:   2,
+	}
+	data, err := evmtypes.EncodeResultData(evmtypes.ResultData{Logs: []*ethtypes.Log{erc20Log, erc721Log, otherLog}})
+	require.NoError(t, err)
+
+	// succeeded tx
+	evmTx, transfers := GenerateEvmTx(&msg, sdk.AccAddress(from.Bytes()), "0xhash", 10, 100,
+		abci.ResponseDeliverTx{GasUsed: 50000, Data: data})
+	require.Equal(t, from.Hex(), evmTx.From)
+	require.Equal(t, contract.Hex(), evmTx.To)
+	require.Equal(t, "10", evmTx.Value)
+	require.Equal(t, int64(50000), evmTx.GasUsed)
+	require.Equal(t, int64(EvmTxStatusSuccess), evmTx.Status)
+	require.Len(t, transfers, 2)
+	require.Equal(t, TokenStandardERC20, transfers[0].Standard)
+	require.Equal(t, "1000", transfers[0].Value)
+	require.Equal(t, from.Hex(), transfers[0].From)
+	require.Equal(t, to.Hex(), transfers[0].To)
+	require.Equal(t, contract.Hex(), transfers[0].Contract)
+	require.Equal(t, TokenStandardERC721, transfers[1].Standard)
+	require.Equal(t, "7", transfers[1].Value)
+	require.Equal(t, int64(1), transfers[1].LogIndex)
+
+	// failed tx
+	evmTx, transfers = GenerateEvmTx(&msg, sdk.AccAddress(from.Bytes()), "0xhash", 10, 100,
+		abci.ResponseDeliverTx{Code: 1, GasUsed: 100000})
+	require.Equal(t, int64(EvmTxStatusFailed), evmTx.Status)
+	require.Empty(t, transfers)
+
+	// contract creation
+	msg = evmtypes.NewMsgEthereumTxContract(2, big.NewInt(0), 100000, big.NewInt(1), []byte{0x60})
+	data, err = evmtypes.EncodeResultData(evmtypes.ResultData{ContractAddress: contract})
+	require.NoError(t, err)
+	evmTx, _ = GenerateEvmTx(&msg, sdk.AccAddress(from.Bytes()), "0xhash", 10, 100,
+		abci.ResponseDeliverTx{GasUsed: 50000, Data: data})
+	require.Empty(t, evmTx.To)
+	require.Equal(t, contract.Hex(), evmTx.ContractAddress)
+}
+
+func TestEvmAddressFromHexOrBech32(t *testing.T) {
+	addr := ethcmn.HexToAddress("0x1000000000000000000000000000000000000001")
+	hexAddr, err := EvmAddressFromHexOrBech32("0x1000000000000000000000000000000000000001")
+	require.NoError(t, err)
+	require.Equal(t, addr.Hex(), hexAddr)
+
+	hexAddr, err = EvmAddressFromHexOrBech32(sdk.AccAddress(addr.Bytes()).String())
+	require.NoError(t, err)
+	require.Equal(t, addr.Hex(), hexAddr)
+
+	_, err = EvmAddressFromHexOrBech32("invalid")
+	require.Error(t, err)
+}
@@ -0,0 +1,13 @@
+package types
+
+const (
+	// query key
+	QueryGraphQL = "graphql"
+)
+
+// QueryGraphQLParams is the GraphQL request over the backend data
+type QueryGraphQLParams struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
@@ -33,6 +33,12 @@ const (
 	QueryDealListV2     = "dealsV2"
 	QueryTxListV2       = "txsV2"
 
+	// evm txs and token transfers
+	QueryEvmTxList           = "evmTxs"
+	QueryTokenTransferList   = "tokenTransfers"
+	QueryEvmTxListV2         = "evmTxsV2"
+	QueryTokenTransferListV2 = "tokenTransfersV2"
+
 	// kline const
 
 	Kline1GoRoutineWaitInSecond = 5
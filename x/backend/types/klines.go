@@ -127,13 +127,13 @@ func (klines IKlinesDsc) Less(i, j int) bool {
 
 // BaseKline define the basic data of Kine
 type BaseKline struct {
-	Product   string  `gorm:"PRIMARY_KEY;type:varchar(20)" json:"product"`
+	Product   string  `gorm:"PRIMARY_KEY;type:varchar(64)" json:"product"`
 	Timestamp int64   `gorm:"PRIMARY_KEY;type:bigint;" json:"timestamp"`
-	Open      float64 `gorm:"type:DOUBLE" json:"open"`
-	Close     float64 `gorm:"type:DOUBLE" json:"close"`
-	High      float64 `gorm:"type:DOUBLE" json:"high"`
-	Low       float64 `gorm:"type:DOUBLE" json:"low"`
-	Volume    float64 `gorm:"type:DOUBLE" json:"volume"`
+	Open      float64 `gorm:"type:DOUBLE PRECISION" json:"open"`
+	Close     float64 `gorm:"type:DOUBLE PRECISION" json:"close"`
+	High      float64 `gorm:"type:DOUBLE PRECISION" json:"high"`
+	Low       float64 `gorm:"type:DOUBLE PRECISION" json:"low"`
+	Volume    float64 `gorm:"type:DOUBLE PRECISION" json:"volume"`
 	impl      IKline
 }
 
@@ -187,6 +187,56 @@ func NewQueryTxListParams(addr string, txType, startTime, endTime int64, page, p
 	}
 }
 
+// nolint
+type QueryEvmTxListParams struct {
+	Address   string
+	StartTime int64
+	EndTime   int64
+	Page      int
+	PerPage   int
+}
+
+// NewQueryEvmTxListParams creates a new instance of QueryEvmTxListParams
+func NewQueryEvmTxListParams(addr string, startTime, endTime int64, page, perPage int) QueryEvmTxListParams {
+	if page == 0 && perPage == 0 {
+		page = DefaultPage
+		perPage = DefaultPerPage
+	}
+	return QueryEvmTxListParams{
+		Address:   addr,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Page:      page,
+		PerPage:   perPage,
+	}
+}
+
+// nolint
+type QueryTokenTransferListParams struct {
+	Address   string
+	Contract  string
+	StartTime int64
+	EndTime   int64
+	Page      int
+	PerPage   int
+}
+
+// NewQueryTokenTransferListParams creates a new instance of QueryTokenTransferListParams
+func NewQueryTokenTransferListParams(addr, contract string, startTime, endTime int64, page, perPage int) QueryTokenTransferListParams {
+	if page == 0 && perPage == 0 {
+		page = DefaultPage
+		perPage = DefaultPerPage
+	}
+	return QueryTokenTransferListParams{
+		Address:   addr,
+		Contract:  contract,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Page:      page,
+		PerPage:   perPage,
+	}
+}
+
 // nolint
 type QueryDexFeesParams struct {
 	DexHandlingAddr string
@@ -0,0 +1,173 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// query key
+	QueryPortfolioTimelineV2 = "portfolioTimelineV2"
+	QueryPortfolioRewardsV2  = "portfolioRewardsV2"
+	QueryPortfolioPnlV2      = "portfolioPnlV2"
+
+	// TablePortfolios stands for the account positions and rewards tables. They accumulate from the first block, so
+	// they can't be re-indexed from a block range and are written only when the table filter is off
+	TablePortfolios = "portfolios"
+)
+
+// AccountPosition is the daily snapshot of the position of an address in a dex product or a swap token pair.
+// The swap positions follow the swap klines, which hold the quote token priced in the base token.
+// Quantity and AvgCost are the ones at the end of the day, while RealizedPnl and Fees are accumulated since the
+// position was opened
+type AccountPosition struct {
+	Address     string  `gorm:"PRIMARY_KEY;type:varchar(80)" json:"address" v2:"address"`
+	Product     string  `gorm:"PRIMARY_KEY;type:varchar(64)" json:"product" v2:"product"`
+	Timestamp   int64   `gorm:"PRIMARY_KEY;type:bigint" json:"timestamp" v2:"timestamp"`
+	Quantity    float64 `gorm:"type:DOUBLE PRECISION" json:"quantity" v2:"quantity"`
+	AvgCost     float64 `gorm:"type:DOUBLE PRECISION" json:"avg_cost" v2:"avg_cost"`
+	RealizedPnl float64 `gorm:"type:DOUBLE PRECISION" json:"realized_pnl" v2:"realized_pnl"`
+	Fees        string  `gorm:"type:varchar(256)" json:"fees" v2:"fees"`
+}
+
+// NewAccountPosition creates an empty position of the day
+func NewAccountPosition(address, product string, timestamp int64) *AccountPosition {
+	return &AccountPosition{
+		Address:   address,
+		Product:   product,
+		Timestamp: GetDayTimestamp(timestamp),
+	}
+}
+
+// RollTo returns the position carried forward to the day of timestamp, the position itself if it's of the day
+func (p *AccountPosition) RollTo(timestamp int64) *AccountPosition {
+	day := GetDayTimestamp(timestamp)
+	if p.Timestamp == day {
+		return p
+	}
+	rolled := *p
+	rolled.Timestamp = day
+	return &rolled
+}
+
+// Buy adds quantity to the position and updates the average cost
+func (p *AccountPosition) Buy(quantity, price float64) {
+	if quantity <= 0 {
+		return
+	}
+	p.AvgCost = (p.Quantity*p.AvgCost + quantity*price) / (p.Quantity + quantity)
+	p.Quantity += quantity
+}
+
+// Sell reduces the position and realizes the PnL against the average cost. Selling more than the position only
+// realizes the part held, as the rest was got outside of the dex or swap
+func (p *AccountPosition) Sell(quantity, price float64) {
+	if quantity <= 0 {
+		return
+	}
+	if quantity > p.Quantity {
+		quantity = p.Quantity
+	}
+	p.RealizedPnl += quantity * (price - p.AvgCost)
+	p.Quantity -= quantity
+	if p.Quantity <= 0 {
+		p.Quantity = 0
+		p.AvgCost = 0
+	}
+}
+
+// AddFees accumulates the fees paid
+func (p *AccountPosition) AddFees(fees sdk.SysCoins) {
+	if fees.IsZero() {
+		return
+	}
+	total, err := sdk.ParseDecCoins(p.Fees)
+	if err != nil {
+		total = sdk.SysCoins{}
+	}
+	p.Fees = total.Add(fees...).String()
+}
+
+// AccountReward is the daily snapshot of the farm rewards claimed by an address from a pool, accumulated since the
+// first claim
+type AccountReward struct {
+	Address   string `gorm:"PRIMARY_KEY;type:varchar(80)" json:"address" v2:"address"`
+	PoolName  string `gorm:"PRIMARY_KEY;type:varchar(64)" json:"pool_name" v2:"pool_name"`
+	Timestamp int64  `gorm:"PRIMARY_KEY;type:bigint" json:"timestamp" v2:"timestamp"`
+	Claimed   string `gorm:"type:varchar(256)" json:"claimed" v2:"claimed"`
+}
+
+// NewAccountReward creates an empty reward of the day
+func NewAccountReward(address, poolName string, timestamp int64) *AccountReward {
+	return &AccountReward{
+		Address:   address,
+		PoolName:  poolName,
+		Timestamp: GetDayTimestamp(timestamp),
+	}
+}
+
+// RollTo returns the reward carried forward to the day of timestamp, the reward itself if it's of the day
+func (r *AccountReward) RollTo(timestamp int64) *AccountReward {
+	day := GetDayTimestamp(timestamp)
+	if r.Timestamp == day {
+		return r
+	}
+	rolled := *r
+	rolled.Timestamp = day
+	return &rolled
+}
+
+// AddClaimed accumulates the rewards claimed
+func (r *AccountReward) AddClaimed(claimed sdk.SysCoins) {
+	total, err := sdk.ParseDecCoins(r.Claimed)
+	if err != nil {
+		total = sdk.SysCoins{}
+	}
+	r.Claimed = total.Add(claimed...).String()
+}
+
+// ProductPnl is the PnL of the latest position of an address in a product
+type ProductPnl struct {
+	Product       string  `json:"product" v2:"product"`
+	Quantity      float64 `json:"quantity" v2:"quantity"`
+	AvgCost       float64 `json:"avg_cost" v2:"avg_cost"`
+	LastPrice     float64 `json:"last_price" v2:"last_price"`
+	RealizedPnl   float64 `json:"realized_pnl" v2:"realized_pnl"`
+	UnrealizedPnl float64 `json:"unrealized_pnl" v2:"unrealized_pnl"`
+	Fees          string  `json:"fees" v2:"fees"`
+	Timestamp     int64   `json:"timestamp" v2:"timestamp"`
+}
+
+// NewProductPnl creates the PnL of the position at the last price
+func NewProductPnl(position AccountPosition, lastPrice float64) ProductPnl {
+	return ProductPnl{
+		Product:       position.Product,
+		Quantity:      position.Quantity,
+		AvgCost:       position.AvgCost,
+		LastPrice:     lastPrice,
+		RealizedPnl:   position.RealizedPnl,
+		UnrealizedPnl: position.Quantity * (lastPrice - position.AvgCost),
+		Fees:          position.Fees,
+		Timestamp:     position.Timestamp,
+	}
+}
+
+// GetDayTimestamp returns the start of the UTC day of timestamp
+func GetDayTimestamp(timestamp int64) int64 {
+	return timestamp - timestamp%SecondsInADay
+}
+
+type QueryPortfolioParamsV2 struct {
+	Address string
+	Product string
+	After   string
+	Before  string
+	Limit   int
+}
+
+type QueryPortfolioRewardsParamsV2 struct {
+	Address  string
+	PoolName string
+	After    string
+	Before   string
+	Limit    int
+}
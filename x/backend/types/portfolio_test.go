@@ -0,0 +1,57 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountPosition(t *testing.T) {
+	position := NewAccountPosition("addr0", "abc_bcd", SecondsInADay+100)
+	require.EqualValues(t, SecondsInADay, position.Timestamp)
+
+	// average cost
+	position.Buy(10, 1)
+	position.Buy(30, 3)
+	require.EqualValues(t, 40, position.Quantity)
+	require.EqualValues(t, 2.5, position.AvgCost)
+
+	// realized pnl against the average cost
+	position.Sell(20, 4)
+	require.EqualValues(t, 20, position.Quantity)
+	require.EqualValues(t, 2.5, position.AvgCost)
+	require.EqualValues(t, 30, position.RealizedPnl)
+
+	// selling more than the position only realizes the part held
+	position.Sell(30, 2)
+	require.EqualValues(t, 0, position.Quantity)
+	require.EqualValues(t, 0, position.AvgCost)
+	require.EqualValues(t, 20, position.RealizedPnl)
+
+	// fees
+	position.AddFees(sdk.SysCoins{sdk.NewDecCoinFromDec("okt", sdk.NewDecWithPrec(1, 1))})
+	position.AddFees(sdk.SysCoins{sdk.NewDecCoinFromDec("okt", sdk.NewDecWithPrec(2, 1))})
+	require.Equal(t, "0.300000000000000000okt", position.Fees)
+
+	// roll forward
+	require.True(t, position == position.RollTo(SecondsInADay*2-1))
+	rolled := position.RollTo(SecondsInADay * 2)
+	require.EqualValues(t, SecondsInADay*2, rolled.Timestamp)
+	require.EqualValues(t, 20, rolled.RealizedPnl)
+	require.EqualValues(t, SecondsInADay, position.Timestamp)
+
+	rolled.Buy(10, 3)
+	pnl := NewProductPnl(*rolled, 5)
+	require.EqualValues(t, 20, pnl.UnrealizedPnl)
+	require.EqualValues(t, 20, pnl.RealizedPnl)
+}
+
+func TestAccountReward(t *testing.T) {
+	reward := NewAccountReward("addr0", "pool0", 100)
+	reward.AddClaimed(sdk.SysCoins{sdk.NewDecCoinFromDec("okt", sdk.OneDec())})
+	reward = reward.RollTo(SecondsInADay)
+	reward.AddClaimed(sdk.SysCoins{sdk.NewDecCoinFromDec("okt", sdk.OneDec())})
+	require.Equal(t, "2.000000000000000000okt", reward.Claimed)
+	require.EqualValues(t, SecondsInADay, reward.Timestamp)
+}
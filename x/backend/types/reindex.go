@@ -0,0 +1,66 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tables able to be re-indexed from the blocks
+const (
+	ReindexTableOrders          = "orders"
+	ReindexTableDeals           = "deals"
+	ReindexTableMatchResults    = "match_results"
+	ReindexTableFeeDetails      = "fee_details"
+	ReindexTableTransactions    = "transactions"
+	ReindexTableEvmTransactions = "evm_transactions"
+	ReindexTableTokenTransfers  = "token_transfers"
+	ReindexTableSwapInfos       = "swap_infos"
+	ReindexTableClaimInfos      = "claim_infos"
+	// ReindexTableKlines stands for all the kline tables, which are rebuilt from deals, match results and swap infos
+	ReindexTableKlines = "klines"
+
+	// ReindexCheckpointName is the name of the checkpoint recorded by the reindex command
+	ReindexCheckpointName = "reindex"
+)
+
+// ReindexTables returns all the tables able to be re-indexed
+func ReindexTables() []string {
+	return []string{ReindexTableOrders, ReindexTableDeals, ReindexTableMatchResults, ReindexTableFeeDetails,
+		ReindexTableTransactions, ReindexTableEvmTransactions, ReindexTableTokenTransfers, ReindexTableSwapInfos,
+		ReindexTableClaimInfos, ReindexTableKlines}
+}
+
+// ParseReindexTables parses the comma separated tables to re-index, empty string means all of them
+func ParseReindexTables(tables string) ([]string, error) {
+	if strings.TrimSpace(tables) == "" {
+		return ReindexTables(), nil
+	}
+
+	supported := make(map[string]bool)
+	for _, table := range ReindexTables() {
+		supported[table] = true
+	}
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, table := range strings.Split(tables, ",") {
+		table = strings.TrimSpace(table)
+		if !supported[table] {
+			return nil, fmt.Errorf("unsupported table %s, expected one of %s", table,
+				strings.Join(ReindexTables(), ","))
+		}
+		if !seen[table] {
+			seen[table] = true
+			result = append(result, table)
+		}
+	}
+	return result, nil
+}
+
+// ReindexCheckpoint records the latest block whose data has been re-indexed into the backend tables
+type ReindexCheckpoint struct {
+	Name      string `gorm:"PRIMARY_KEY;type:varchar(64)" json:"name"`
+	Height    int64  `gorm:"" json:"height"`
+	Timestamp int64  `gorm:"" json:"timestamp"`
+	Tables    string `gorm:"type:varchar(256)" json:"tables"`
+}
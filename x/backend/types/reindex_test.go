@@ -0,0 +1,20 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReindexTables(t *testing.T) {
+	tables, err := ParseReindexTables("")
+	require.Nil(t, err)
+	require.Equal(t, ReindexTables(), tables)
+
+	tables, err = ParseReindexTables(" deals, klines,deals")
+	require.Nil(t, err)
+	require.Equal(t, []string{ReindexTableDeals, ReindexTableKlines}, tables)
+
+	_, err = ParseReindexTables("deals,kline_m1")
+	require.NotNil(t, err)
+}
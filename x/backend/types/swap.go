@@ -1,6 +1,8 @@
 package types
 
 import (
+	"strings"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -20,6 +22,10 @@ const (
 	QuerySwapTokens             = "swapTokens"
 	QuerySwapTokenPairs         = "swapTokenPairs"
 	QuerySwapLiquidityHistories = "swapLiquidityHistories"
+	QuerySwapTickers            = "swapTickers"
+
+	// SwapKlineProductPrefix is the namespace of the swap token pairs in klines, it never appears in a dex product
+	SwapKlineProductPrefix = "swap."
 
 	// swap business type
 	SwapBusinessTypeCreate = "create"
@@ -117,6 +123,7 @@ func (s *SwapWatchlistSorter) Swap(i, j int) {
 }
 
 type SwapInfo struct {
+	Id               uint64 `gorm:"primary_key" json:"-"`
 	Address          string `grom:"index;"`
 	TokenPairName    string `gorm:"index;"`
 	BaseTokenAmount  string `gorm:"type:varchar(40)"`
@@ -125,6 +132,10 @@ type SwapInfo struct {
 	BuysAmount       string `gorm:"type:varchar(40)"`
 	Price            string `gorm:"type:varchar(40)"`
 	Timestamp        int64  `gorm:"index;"`
+	// Product, KlinePrice and KlineVolume feed the klines of the swap token pair, the volume is counted in quote token
+	Product     string  `gorm:"index;type:varchar(64)"`
+	KlinePrice  float64 `gorm:"type:DOUBLE PRECISION"`
+	KlineVolume float64 `gorm:"type:DOUBLE PRECISION"`
 }
 
 type SwapWhitelist struct {
@@ -199,3 +210,28 @@ type SwapLiquidityInfo struct {
 	PoolTokenCoin   sdk.SysCoin `json:"pool_token_coin"`
 	PoolTokenRatio  sdk.Dec     `json:"pool_token_ratio"`
 }
+
+// GetSwapKlineProduct returns the kline product of a swap token pair
+func GetSwapKlineProduct(tokenPairName string) string {
+	return SwapKlineProductPrefix + tokenPairName
+}
+
+// IsSwapKlineProduct returns true when the kline product belongs to a swap token pair
+func IsSwapKlineProduct(product string) bool {
+	return strings.HasPrefix(product, SwapKlineProductPrefix)
+}
+
+// SwapTicker is the ticker of a swap token pair in the latest 24 hours
+type SwapTicker struct {
+	Product   string  `json:"product"`
+	TokenPair string  `json:"token_pair"`
+	Timestamp int64   `json:"timestamp"`
+	Open      sdk.Dec `json:"open"`
+	High      sdk.Dec `json:"high"`
+	Low       sdk.Dec `json:"low"`
+	Price     sdk.Dec `json:"price"`
+	Change24h sdk.Dec `json:"change24h"`
+	Volume24h sdk.Dec `json:"volume24h"` // in quote token
+	Tvl       sdk.Dec `json:"tvl"`       // in dollar
+	Fees24h   sdk.Dec `json:"fees24h"`   // in dollar
+}
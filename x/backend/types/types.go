@@ -106,6 +106,7 @@ type Order struct {
 }
 
 type Transaction struct {
+	Id        uint64 `gorm:"primary_key" json:"-" v2:"-"`
 	TxHash    string `gorm:"type:varchar(80)" json:"txhash" v2:"txhash"`
 	Type      int64  `gorm:"index;" json:"type" v2:"type"` // 1:Transfer, 2:NewOrder, 3:CancelOrder
 	Address   string `gorm:"index;type:varchar(80)" json:"address" v2:"address"`
@@ -0,0 +1,37 @@
+package common
+
+import (
+	"strconv"
+)
+
+// MILESTONE_VENUS_HEIGHT is the upgrade height set by the ldflags like the milestones of the sdk, from which the stores
+// added by the Venus upgrade are mounted. A chain built without it has the stores from its genesis.
+var MILESTONE_VENUS_HEIGHT string
+
+// MILESTONE_SLASH_HEIGHT is the upgrade height set by the ldflags, after which the validators punished for double sign
+// or downtime are slashed besides being jailed. They are never slashed if it's not set.
+var MILESTONE_SLASH_HEIGHT string
+
+// GetVenusHeight returns the upgrade height of Venus, or 0 if the stores are there from the genesis
+func GetVenusHeight() int64 {
+	if len(MILESTONE_VENUS_HEIGHT) == 0 {
+		return 0
+	}
+	height, err := strconv.ParseInt(MILESTONE_VENUS_HEIGHT, 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	return height
+}
+
+// HasVenusStores returns whether the stores added by the Venus upgrade are mounted at the height
+func HasVenusStores(height int64) bool {
+	venusHeight := GetVenusHeight()
+	return venusHeight == 0 || height >= venusHeight
+}
+
+// HigherThanSlash returns whether the validators punished at the height are slashed
+func HigherThanSlash(height int64) bool {
+	milestone, err := strconv.ParseInt(MILESTONE_SLASH_HEIGHT, 10, 64)
+	return err == nil && milestone != 0 && height > milestone
+}
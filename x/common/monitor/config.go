@@ -8,6 +8,7 @@ const (
 	stakingSubSystem = "staking"
 	streamSubSystem  = "stream"
 	portSubSystem    = "port"
+	evmSubSystem     = "evm"
 )
 
 type prometheusConfig struct {
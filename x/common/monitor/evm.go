@@ -0,0 +1,73 @@
+package monitor
+
+import (
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// the label of the contracts in the metrics of the evm module
+const contractLabel = "contract"
+
+// EvmMetrics is the struct of metric in evm module. The gauges are labeled by the top contracts of the latest block,
+// and reset every block, so that the cardinality is bounded by the number of the top contracts.
+type EvmMetrics struct {
+	ContractGasUsed       *stdprometheus.GaugeVec
+	ContractCalls         *stdprometheus.GaugeVec
+	ContractStorageWrites *stdprometheus.GaugeVec
+	ContractFailures      *stdprometheus.GaugeVec
+}
+
+// DefaultEvmMetrics returns Metrics build using Prometheus client library if Prometheus is enabled
+// Otherwise, it returns no-op Metrics
+func DefaultEvmMetrics(config *prometheusConfig) *EvmMetrics {
+	if config.Prometheus {
+		return NewEvmMetrics()
+	}
+	return NopEvmMetrics()
+}
+
+// NewEvmMetrics returns a pointer of a new EvmMetrics object
+func NewEvmMetrics() *EvmMetrics {
+	newGaugeVec := func(name, help string) *stdprometheus.GaugeVec {
+		gauge := stdprometheus.NewGaugeVec(stdprometheus.GaugeOpts{
+			Namespace: xNameSpace,
+			Subsystem: evmSubSystem,
+			Name:      name,
+			Help:      help,
+		}, []string{contractLabel})
+		stdprometheus.MustRegister(gauge)
+		return gauge
+	}
+	return &EvmMetrics{
+		ContractGasUsed:       newGaugeVec("contract_gas_used", "the gas used by the txs calling the contract in the latest block"),
+		ContractCalls:         newGaugeVec("contract_calls", "the number of the txs calling the contract in the latest block"),
+		ContractStorageWrites: newGaugeVec("contract_storage_writes", "the number of the storage slots written by the txs calling the contract in the latest block"),
+		ContractFailures:      newGaugeVec("contract_failures", "the number of the failed txs calling the contract in the latest block"),
+	}
+}
+
+// NopEvmMetrics returns a pointer of a no-op Metrics
+func NopEvmMetrics() *EvmMetrics {
+	return &EvmMetrics{}
+}
+
+// ResetContracts drops the contracts of the previous block
+func (m *EvmMetrics) ResetContracts() {
+	if m.ContractGasUsed == nil {
+		return
+	}
+	m.ContractGasUsed.Reset()
+	m.ContractCalls.Reset()
+	m.ContractStorageWrites.Reset()
+	m.ContractFailures.Reset()
+}
+
+// SetContract sets the stats of the contract in the latest block
+func (m *EvmMetrics) SetContract(contract string, gasUsed, calls, storageWrites, failures uint64) {
+	if m.ContractGasUsed == nil {
+		return
+	}
+	m.ContractGasUsed.WithLabelValues(contract).Set(float64(gasUsed))
+	m.ContractCalls.WithLabelValues(contract).Set(float64(calls))
+	m.ContractStorageWrites.WithLabelValues(contract).Set(float64(storageWrites))
+	m.ContractFailures.WithLabelValues(contract).Set(float64(failures))
+}
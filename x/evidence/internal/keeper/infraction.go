@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/evidence/internal/types"
 )
 
@@ -85,24 +86,28 @@ func (k Keeper) HandleDoubleSign(ctx sdk.Context, evidence types.Equivocation) {
 	// -ValidatorUpdateDelay, i.e. at the end of the
 	// pre-genesis block (none) = at the beginning of the genesis block.
 	// That's fine since this is just used to filter unbonding delegations & redelegations.
-	//distributionHeight := infractionHeight - sdk.ValidatorUpdateDelay
+	distributionHeight := infractionHeight - sdk.ValidatorUpdateDelay
 
 	// Slash validator. The `power` is the int64 power of the validator as provided
 	// to/by Tendermint. This value is validator.Tokens as sent to Tendermint via
 	// ABCI, and now received as evidence. The fraction is passed in to separately
-	// to slash unbonding and rebonding delegations.
-	/*
-	k.slashingKeeper.Slash(
-		ctx,
-		consAddr,
-		k.slashingKeeper.SlashFractionDoubleSign(ctx),
-		evidence.GetValidatorPower(), distributionHeight,
-	)
-	*/
+	// to slash unbonding and rebonding delegations. The tokens aren't slashed
+	// before the slash milestone.
+	if common.HigherThanSlash(ctx.BlockHeight()) {
+		if err := k.slashingKeeper.Slash(
+			ctx,
+			consAddr,
+			k.slashingKeeper.SlashFractionDoubleSign(ctx),
+			evidence.GetValidatorPower(), distributionHeight,
+		); err != nil {
+			logger.Error(fmt.Sprintf("failed to slash %s for double sign: %s", consAddr, err))
+		}
+	}
 	k.stakingKeeper.AppendAbandonedValidatorAddrs(ctx, consAddr)
 	// Jail the validator if not already jailed. This will begin unbonding the
-	// validator if not already unbonding (tombstoned).
-	if !validator.IsJailed() {
+	// validator if not already unbonding (tombstoned). A validator in maintenance
+	// is jailed again so that it won't return to the candidates automatically.
+	if !validator.IsJailed() || validator.IsInMaintenance() {
 		k.slashingKeeper.Jail(ctx, consAddr)
 	}
 
@@ -32,6 +32,7 @@ func GetQueryCmd(moduleName string, cdc *codec.Codec) *cobra.Command {
 		GetCmdQueryParams(moduleName, cdc),
 		GetCmdQueryContractDeploymentWhitelist(moduleName, cdc),
 		GetCmdQueryContractBlockedList(moduleName, cdc),
+		GetCmdQueryContractMethodBlockedList(moduleName, cdc),
 	)...)
 	return evmQueryCmd
 }
@@ -79,6 +80,36 @@ $ %s query evm contract-blocked-list
 	}
 }
 
+// GetCmdQueryContractMethodBlockedList gets the contract method blocked list query command.
+func GetCmdQueryContractMethodBlockedList(storeName string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "contract-method-blocked-list",
+		Short: "Query the contract method blocked list",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the current blocked list of contract methods during evm calling.
+
+Example:
+$ %s query evm contract-method-blocked-list
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			route := fmt.Sprintf("custom/%s/%s", storeName, types.QueryContractMethodBlockedList)
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var contractList types.BlockedContractList
+			cdc.MustUnmarshalJSON(bz, &contractList)
+			return cliCtx.PrintOutput(contractList)
+		},
+	}
+}
+
 // GetCmdQueryContractDeploymentWhitelist gets the contract deployment whitelist query command.
 func GetCmdQueryContractDeploymentWhitelist(storeName string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -26,7 +26,8 @@ func GetCmdManageContractDeploymentWhitelistProposal(cdc *codec.Codec) *cobra.Co
 		Short: "Submit an update contract deployment whitelist proposal",
 		Long: strings.TrimSpace(
 			fmt.Sprintf(`Submit an update contract deployment whitelist proposal along with an initial deposit.
-The proposal details must be supplied via a JSON file.
+The proposal details must be supplied via a JSON file. Anyone is allowed to deploy the init code whose keccak256 hash
+is in the code hashes.
 
 Example:
 $ %s tx gov submit-proposal update-contract-deployment-whitelist <path/to/proposal.json> --from=<key_or_address>
@@ -40,6 +41,9 @@ Where proposal.json contains:
     "ex1cftp8q8g4aa65nw9s5trwexe77d9t6cr8ndu02",
     "ex1k0wwsg7xf9tjt3rvxdewz42e74sp286agrf9qc"
   ],
+  "code_hashes": [
+    "0x96e8ac4277198ff8b6f785478aa9a39f403cb768dd02cbee326c3e7da348845f"
+  ],
   "is_added": true,
   "deposit": [
     {
@@ -66,6 +70,7 @@ Where proposal.json contains:
 				proposal.DistributorAddrs,
 				proposal.IsAdded,
 			)
+			content.CodeHashes = proposal.CodeHashes
 
 			err = content.ValidateBasic()
 			if err != nil {
@@ -138,3 +143,134 @@ Where proposal.json contains:
 		},
 	}
 }
+
+// GetCmdManageContractDeploymentFactoryProposal implements a command handler for submitting a manage contract deployment
+// factory proposal transaction
+func GetCmdManageContractDeploymentFactoryProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "update-contract-deployment-factory [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit an update contract deployment factory proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit an update contract deployment factory proposal along with an initial deposit.
+The proposal details must be supplied via a JSON file. The factory contract is allowed to deploy only the init code
+whose keccak256 hash is in the code hashes through CREATE and CREATE2, once both the enable_contract_deployment_whitelist
+and the enable_contract_factory_whitelist params are enabled. The factories already deployed should be granted the code
+hashes they deploy before enable_contract_factory_whitelist is enabled, or their CREATE and CREATE2 will fail.
+
+Example:
+$ %s tx gov submit-proposal update-contract-deployment-factory <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "update contract deployment factory proposal with a code hash list",
+  "description": "allow a factory to deploy the pairs",
+  "factory_address": "ex1cftp8q8g4aa65nw9s5trwexe77d9t6cr8ndu02",
+  "code_hashes": [
+    "0x96e8ac4277198ff8b6f785478aa9a39f403cb768dd02cbee326c3e7da348845f"
+  ],
+  "is_added": true,
+  "deposit": [
+    {
+      "denom": "%s",
+      "amount": "100.000000000000000000"
+    }
+  ]
+}
+`, version.ClientName, sdk.DefaultBondDenom,
+			)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := evmutils.ParseManageContractDeploymentFactoryProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			content := types.NewManageContractDeploymentFactoryProposal(
+				proposal.Title,
+				proposal.Description,
+				proposal.FactoryAddr,
+				proposal.CodeHashes,
+				proposal.IsAdded,
+			)
+
+			err = content.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdManageContractMethodBlockedListProposal implements a command handler for submitting a manage contract method
+// blocked list proposal transaction
+func GetCmdManageContractMethodBlockedListProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "update-contract-method-blocked-list [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit an update contract method blocked list proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit an update contract method blocked list proposal along with an initial deposit.
+The proposal details must be supplied via a JSON file. The methods are the 4-byte selectors of the contract methods,
+and the calls to the other methods of the contracts are still allowed.
+
+Example:
+$ %s tx gov submit-proposal update-contract-method-blocked-list <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "update contract method blocked list proposal with a contract list",
+  "description": "block the mint(address,uint256) of a token",
+  "contract_list": [
+    {
+      "address": "ex1cftp8q8g4aa65nw9s5trwexe77d9t6cr8ndu02",
+      "block_methods": [
+        "0x40c10f19"
+      ]
+    }
+  ],
+  "is_added": true,
+  "deposit": [
+    {
+      "denom": "%s",
+      "amount": "100.000000000000000000"
+    }
+  ]
+}
+`, version.ClientName, sdk.DefaultBondDenom,
+			)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			proposal, err := evmutils.ParseManageContractMethodBlockedListProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			content := types.NewManageContractMethodBlockedListProposal(
+				proposal.Title,
+				proposal.Description,
+				proposal.ContractList,
+				proposal.IsAdded,
+			)
+
+			err = content.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
@@ -18,4 +18,16 @@ var (
 		cli.GetCmdManageContractBlockedListProposal,
 		rest.ManageContractBlockedListProposalRESTHandler,
 	)
+
+	// ManageContractDeploymentFactoryProposalHandler alias gov NewProposalHandler
+	ManageContractDeploymentFactoryProposalHandler = govcli.NewProposalHandler(
+		cli.GetCmdManageContractDeploymentFactoryProposal,
+		rest.ManageContractDeploymentFactoryProposalRESTHandler,
+	)
+
+	// ManageContractMethodBlockedListProposalHandler alias gov NewProposalHandler
+	ManageContractMethodBlockedListProposalHandler = govcli.NewProposalHandler(
+		cli.GetCmdManageContractMethodBlockedListProposal,
+		rest.ManageContractMethodBlockedListProposalRESTHandler,
+	)
 )
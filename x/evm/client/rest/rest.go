@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,6 +35,8 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/txs/decode", authrest.DecodeTxRequestHandlerFn(cliCtx)).Methods("POST")
 	r.HandleFunc("/section", QuerySectionFn(cliCtx)).Methods("GET")
 	r.HandleFunc("/contract/blocked_list", QueryContractBlockedListHandlerFn(cliCtx)).Methods("GET")
+	r.HandleFunc("/contract/method_blocked_list", QueryContractMethodBlockedListHandlerFn(cliCtx)).Methods("GET")
+	r.HandleFunc("/contract/stats", QueryContractStatsHandlerFn(cliCtx)).Methods("GET")
 }
 
 func QueryTxRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
@@ -192,6 +195,16 @@ func ManageContractBlockedListProposalRESTHandler(context.CLIContext) govRest.Pr
 	return govRest.ProposalRESTHandler{}
 }
 
+// ManageContractDeploymentFactoryProposalRESTHandler defines evm proposal handler
+func ManageContractDeploymentFactoryProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
+
+// ManageContractMethodBlockedListProposalRESTHandler defines evm proposal handler
+func ManageContractMethodBlockedListProposalRESTHandler(context.CLIContext) govRest.ProposalRESTHandler {
+	return govRest.ProposalRESTHandler{}
+}
+
 func QuerySectionFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		res, _, err := cliCtx.Query(fmt.Sprintf("custom/%s/%s", evmtypes.RouterKey, evmtypes.QuerySection))
@@ -228,3 +241,67 @@ func QueryContractBlockedListHandlerFn(cliCtx context.CLIContext) http.HandlerFu
 		rest.PostProcessResponse(w, cliCtx, ethAddrs)
 	}
 }
+
+// QueryContractMethodBlockedListHandlerFn defines evm contract method blocked list handler
+func QueryContractMethodBlockedListHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := fmt.Sprintf("custom/%s/%s", evmtypes.ModuleName, evmtypes.QueryContractMethodBlockedList)
+
+		bz, height, err := cliCtx.QueryWithData(path, nil)
+		if err != nil {
+			common.HandleErrorResponseV2(w, http.StatusInternalServerError, common.ErrorABCIQueryFails)
+			return
+		}
+
+		var contractList evmtypes.BlockedContractList
+		cliCtx.Codec.MustUnmarshalJSON(bz, &contractList)
+
+		type blockedContract struct {
+			Address      string              `json:"address"`
+			BlockMethods evmtypes.MethodList `json:"block_methods"`
+		}
+		var contracts []blockedContract
+		for _, contract := range contractList {
+			contracts = append(contracts, blockedContract{
+				Address:      ethcommon.BytesToAddress(contract.Address.Bytes()).Hex(),
+				BlockMethods: contract.BlockMethods,
+			})
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, contracts)
+	}
+}
+
+// QueryContractStatsHandlerFn defines evm contract stats handler, which returns the top contracts by the gas used over
+// the heights from the query parameter from to to
+func QueryContractStatsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		args := []string{"0", "0", "0"}
+		for i, name := range []string{"from", "to", "limit"} {
+			if value := r.URL.Query().Get(name); value != "" {
+				if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+					common.HandleErrorResponseV2(w, http.StatusBadRequest, common.ErrorInvalidParam)
+					return
+				}
+				args[i] = value
+			}
+		}
+		path := fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryContractStats, strings.Join(args, "/"))
+
+		bz, height, err := cliCtx.QueryWithData(path, nil)
+		if err != nil {
+			common.HandleErrorResponseV2(w, http.StatusInternalServerError, common.ErrorABCIQueryFails)
+			return
+		}
+
+		var stats evmtypes.QueryResContractStats
+		if err := json.Unmarshal(bz, &stats); err != nil {
+			common.HandleErrorResponseV2(w, http.StatusInternalServerError, common.ErrorCodecFails)
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, stats)
+	}
+}
@@ -14,9 +14,10 @@ type (
 	ManageContractDeploymentWhitelistProposalJSON struct {
 		Title            string            `json:"title" yaml:"title"`
 		Description      string            `json:"description" yaml:"description"`
-		DistributorAddrs types.AddressList `json:"distributor_addresses" yaml:"distributor_addresses"`
-		IsAdded          bool              `json:"is_added" yaml:"is_added"`
-		Deposit          sdk.SysCoins      `json:"deposit" yaml:"deposit"`
+		DistributorAddrs types.AddressList  `json:"distributor_addresses" yaml:"distributor_addresses"`
+		CodeHashes       types.CodeHashList `json:"code_hashes" yaml:"code_hashes"`
+		IsAdded          bool               `json:"is_added" yaml:"is_added"`
+		Deposit          sdk.SysCoins       `json:"deposit" yaml:"deposit"`
 	}
 	// ManageContractBlockedListProposalJSON defines a ManageContractBlockedListProposal with a deposit used to parse
 	// manage blocked list proposals from a JSON file.
@@ -27,6 +28,25 @@ type (
 		IsAdded       bool              `json:"is_added" yaml:"is_added"`
 		Deposit       sdk.SysCoins      `json:"deposit" yaml:"deposit"`
 	}
+	// ManageContractDeploymentFactoryProposalJSON defines a ManageContractDeploymentFactoryProposal with a deposit used
+	// to parse manage factory proposals from a JSON file.
+	ManageContractDeploymentFactoryProposalJSON struct {
+		Title       string             `json:"title" yaml:"title"`
+		Description string             `json:"description" yaml:"description"`
+		FactoryAddr sdk.AccAddress     `json:"factory_address" yaml:"factory_address"`
+		CodeHashes  types.CodeHashList `json:"code_hashes" yaml:"code_hashes"`
+		IsAdded     bool               `json:"is_added" yaml:"is_added"`
+		Deposit     sdk.SysCoins       `json:"deposit" yaml:"deposit"`
+	}
+	// ManageContractMethodBlockedListProposalJSON defines a ManageContractMethodBlockedListProposal with a deposit used
+	// to parse manage method blocked list proposals from a JSON file.
+	ManageContractMethodBlockedListProposalJSON struct {
+		Title        string                    `json:"title" yaml:"title"`
+		Description  string                    `json:"description" yaml:"description"`
+		ContractList types.BlockedContractList `json:"contract_list" yaml:"contract_list"`
+		IsAdded      bool                      `json:"is_added" yaml:"is_added"`
+		Deposit      sdk.SysCoins              `json:"deposit" yaml:"deposit"`
+	}
 )
 
 // ParseManageContractDeploymentWhitelistProposalJSON parses json from proposal file to ManageContractDeploymentWhitelistProposalJSON
@@ -53,3 +73,29 @@ func ParseManageContractBlockedListProposalJSON(cdc *codec.Codec, proposalFilePa
 	cdc.MustUnmarshalJSON(contents, &proposal)
 	return
 }
+
+// ParseManageContractDeploymentFactoryProposalJSON parses json from proposal file to
+// ManageContractDeploymentFactoryProposalJSON struct
+func ParseManageContractDeploymentFactoryProposalJSON(cdc *codec.Codec, proposalFilePath string) (
+	proposal ManageContractDeploymentFactoryProposalJSON, err error) {
+	contents, err := ioutil.ReadFile(proposalFilePath)
+	if err != nil {
+		return
+	}
+
+	cdc.MustUnmarshalJSON(contents, &proposal)
+	return
+}
+
+// ParseManageContractMethodBlockedListProposalJSON parses json from proposal file to
+// ManageContractMethodBlockedListProposalJSON struct
+func ParseManageContractMethodBlockedListProposalJSON(cdc *codec.Codec, proposalFilePath string) (
+	proposal ManageContractMethodBlockedListProposalJSON, err error) {
+	contents, err := ioutil.ReadFile(proposalFilePath)
+	if err != nil {
+		return
+	}
+
+	cdc.MustUnmarshalJSON(contents, &proposal)
+	return
+}
@@ -80,6 +80,15 @@ func InitGenesis(ctx sdk.Context, k Keeper, accountKeeper types.AccountKeeper, d
 	// set contract blocked list into store
 	csdb.SetContractBlockedList(data.ContractBlockedList)
 
+	// set the code hashes and the factories allowed to deploy into store
+	csdb.SetContractDeploymentCodeHashes(data.ContractDeploymentCodeHashes)
+	for _, factory := range data.ContractDeploymentFactories {
+		csdb.SetContractDeploymentFactory(factory)
+	}
+
+	// set the methods of the contract method blocked list into store
+	csdb.SetContractMethodBlockedList(data.ContractMethodBlockedList)
+
 	logger.Debug("Import finished", "code", codeCount, "storage", storageCount)
 
 	// set state objects and code to store
@@ -167,5 +176,9 @@ func ExportGenesis(ctx sdk.Context, k Keeper, ak types.AccountKeeper) GenesisSta
 		Params:                      k.GetParams(ctx),
 		ContractDeploymentWhitelist: csdb.GetContractDeploymentWhitelist(),
 		ContractBlockedList:         csdb.GetContractBlockedList(),
+
+		ContractDeploymentCodeHashes: csdb.GetContractDeploymentCodeHashes(),
+		ContractDeploymentFactories:  csdb.GetContractDeploymentFactories(),
+		ContractMethodBlockedList:    csdb.GetContractMethodBlockedList(),
 	}
 }
@@ -309,11 +309,22 @@ func (suite *EvmTestSuite) TestExport() {
 		},
 		ContractDeploymentWhitelist: types.AddressList{address.Bytes()},
 		ContractBlockedList:         types.AddressList{address.Bytes()},
+
+		ContractDeploymentCodeHashes: types.CodeHashList{ethcmn.BytesToHash([]byte("code_hash"))},
+		ContractDeploymentFactories: []types.ContractDeploymentFactory{
+			{FactoryAddr: address.Bytes(), CodeHashes: types.CodeHashList{ethcmn.BytesToHash([]byte("code_hash"))}},
+		},
+		ContractMethodBlockedList: types.BlockedContractList{
+			{Address: address.Bytes(), BlockMethods: types.MethodList{{0x40, 0xc1, 0x0f, 0x19}}},
+		},
 	}
 	evm.InitGenesis(suite.ctx, *suite.app.EvmKeeper, &suite.app.AccountKeeper, initGenesis)
 
 	suite.Require().NotPanics(func() {
-		evm.ExportGenesis(suite.ctx, *suite.app.EvmKeeper, &suite.app.AccountKeeper)
+		exportState := evm.ExportGenesis(suite.ctx, *suite.app.EvmKeeper, &suite.app.AccountKeeper)
+		suite.Require().Equal(initGenesis.ContractDeploymentCodeHashes, exportState.ContractDeploymentCodeHashes)
+		suite.Require().Equal(initGenesis.ContractDeploymentFactories, exportState.ContractDeploymentFactories)
+		suite.Require().Equal(initGenesis.ContractMethodBlockedList, exportState.ContractMethodBlockedList)
 	})
 }
 
@@ -4,9 +4,11 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	ethermint "github.com/okex/exchain/app/types"
 	"github.com/okex/exchain/x/analyzer"
 	"github.com/okex/exchain/x/common/perf"
+	"github.com/okex/exchain/x/evm/keeper"
 	"github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/evm/watcher"
 	tmtypes "github.com/tendermint/tendermint/types"
@@ -46,6 +48,27 @@ func NewHandler(k *Keeper) sdk.Handler {
 	}
 }
 
+// addContractStats adds the tx to the stats of the contract it calls or creates, while the transfers to the externally
+// owned accounts are skipped
+func addContractStats(ctx sdk.Context, k *Keeper, st *types.StateTransition, failed bool) {
+	if k.ContractStats == nil {
+		return
+	}
+	gasUsed := ctx.GasMeter().GasConsumed()
+	// the stats are local to the node, so they mustn't consume the gas of the tx
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+	var contract common.Address
+	if st.Recipient == nil {
+		contract = crypto.CreateAddress(st.Sender, st.AccountNonce)
+	} else if len(k.GetCode(ctx, *st.Recipient)) != 0 {
+		contract = *st.Recipient
+	} else {
+		return
+	}
+	k.AddContractStats(contract, gasUsed, st.Csdb.GetStorageWrites(), failed)
+}
+
 // handleMsgEthereumTx handles an Ethereum specific tx
 func handleMsgEthereumTx(ctx sdk.Context, k *Keeper, msg types.MsgEthereumTx) (*sdk.Result, error) {
 	StartTxLog := func(tag string) {
@@ -70,6 +93,18 @@ func handleMsgEthereumTx(ctx sdk.Context, k *Keeper, msg types.MsgEthereumTx) (*
 	}
 	StopTxLog("ParseChainID")
 
+	// each tx of a bundle is metered up to its own gas limit, and the bundle is charged for the sum
+	bundle, inBundle := types.BundleFromContext(ctx)
+	var bundleIndex int
+	if inBundle {
+		bundleIndex = int(msg.Data.AccountNonce - bundle.Txs[0].Data.AccountNonce)
+		bundleGasMeter := ctx.GasMeter()
+		ctx = ctx.WithGasMeter(sdk.NewGasMeter(msg.GetGas()))
+		defer func() {
+			bundleGasMeter.ConsumeGas(ctx.GasMeter().GasConsumedToLimit(), "bundle tx")
+		}()
+	}
+
 	// Verify signature and retrieve sender address
 
 	StartTxLog("VerifySig")
@@ -82,6 +117,10 @@ func handleMsgEthereumTx(ctx sdk.Context, k *Keeper, msg types.MsgEthereumTx) (*
 	StartTxLog("txhash")
 	sender := senderSigCache.GetFrom()
 	txHash := tmtypes.Tx(ctx.TxBytes()).Hash()
+	if inBundle {
+		// a tx of a bundle is known by the hash it has out of the bundle
+		txHash = bundleTxHash(msg).Bytes()
+	}
 	ethHash := common.BytesToHash(txHash)
 	StopTxLog("txhash")
 
@@ -105,6 +144,9 @@ func handleMsgEthereumTx(ctx sdk.Context, k *Keeper, msg types.MsgEthereumTx) (*
 
 	StartTxLog("SaveTx")
 	if !st.Simulate {
+		if inBundle && bundleIndex == 0 {
+			k.StartBundle()
+		}
 		k.Watcher.SaveEthereumTx(msg, common.BytesToHash(txHash), uint64(k.TxCount))
 		// Prepare db for logs
 		st.Csdb.Prepare(ethHash, k.Bhash, k.TxCount)
@@ -140,8 +182,8 @@ func handleMsgEthereumTx(ctx sdk.Context, k *Keeper, msg types.MsgEthereumTx) (*
 		if !st.Simulate {
 			if err != nil {
 				k.Watcher.Reset()
-			} else {
-				//save state and account data into batch
+			} else if !inBundle || bundleIndex == len(bundle.Txs)-1 {
+				//save state and account data into batch, once for a bundle as it's committed all or none
 				k.Watcher.Finalize()
 			}
 		}
@@ -149,9 +191,16 @@ func handleMsgEthereumTx(ctx sdk.Context, k *Keeper, msg types.MsgEthereumTx) (*
 
 	StartTxLog("TransitionDb")
 	executionResult, resultData, err := st.TransitionDb(ctx, config)
+	if !st.Simulate {
+		addContractStats(ctx, k, &st, err != nil)
+	}
 	if err != nil {
 		if !st.Simulate {
-			k.Watcher.SaveTransactionReceipt(watcher.TransactionFailed, msg, common.BytesToHash(txHash), uint64(k.TxCount-1), &types.ResultData{}, ctx.GasMeter().GasConsumed())
+			if inBundle {
+				failBundle(k, bundle, bundleIndex, ctx.GasMeter().GasConsumed())
+			} else {
+				k.Watcher.SaveTransactionReceipt(watcher.TransactionFailed, msg, common.BytesToHash(txHash), uint64(k.TxCount-1), &types.ResultData{}, ctx.GasMeter().GasConsumed())
+			}
 		}
 		return nil, err
 	}
@@ -162,7 +211,23 @@ func handleMsgEthereumTx(ctx sdk.Context, k *Keeper, msg types.MsgEthereumTx) (*
 		// update block bloom filter
 		k.Bloom.Or(k.Bloom, executionResult.Bloom)
 		k.LogSize = st.Csdb.GetLogSize()
-		k.Watcher.SaveTransactionReceipt(watcher.TransactionSuccess, msg, common.BytesToHash(txHash), uint64(k.TxCount-1), resultData, ctx.GasMeter().GasConsumed())
+		if inBundle {
+			k.Bundle.Receipts = append(k.Bundle.Receipts, keeper.BundleReceipt{
+				Msg:     msg,
+				TxHash:  common.BytesToHash(txHash),
+				TxIndex: uint64(k.TxCount - 1),
+				Data:    resultData,
+				GasUsed: ctx.GasMeter().GasConsumed(),
+			})
+			if bundleIndex == len(bundle.Txs)-1 {
+				for _, r := range k.Bundle.Receipts {
+					k.Watcher.SaveTransactionReceipt(watcher.TransactionSuccess, r.Msg, r.TxHash, r.TxIndex, r.Data, r.GasUsed)
+				}
+				k.Bundle = nil
+			}
+		} else {
+			k.Watcher.SaveTransactionReceipt(watcher.TransactionSuccess, msg, common.BytesToHash(txHash), uint64(k.TxCount-1), resultData, ctx.GasMeter().GasConsumed())
+		}
 		if msg.Data.Recipient == nil {
 			st.Csdb.IteratorCode(func(addr common.Address, c types.CacheCode) bool {
 				k.Watcher.SaveContractCode(addr, c.Code)
@@ -204,6 +269,29 @@ func handleMsgEthereumTx(ctx sdk.Context, k *Keeper, msg types.MsgEthereumTx) (*
 	return executionResult.Result, nil
 }
 
+// bundleTxHash returns the hash of the tx of a bundle out of it
+func bundleTxHash(msg types.MsgEthereumTx) common.Hash {
+	return common.BytesToHash(tmtypes.Tx(types.ModuleCdc.MustMarshalBinaryLengthPrefixed(msg)).Hash())
+}
+
+// failBundle records the txs of the bundle failed at the tx of the index as failed, as the state changes of the
+// executed ones are reverted with it. The txs after it aren't executed, but are included in the block all the same.
+func failBundle(k *Keeper, bundle types.BundleTx, index int, gasUsed uint64) {
+	for _, r := range k.Bundle.Receipts {
+		k.Watcher.SaveTransactionReceipt(watcher.TransactionFailed, r.Msg, r.TxHash, r.TxIndex, &types.ResultData{}, r.GasUsed)
+	}
+	k.Watcher.SaveTransactionReceipt(watcher.TransactionFailed, bundle.Txs[index], bundleTxHash(bundle.Txs[index]),
+		uint64(k.TxCount-1), &types.ResultData{}, gasUsed)
+	for _, msg := range bundle.Txs[index+1:] {
+		txHash := bundleTxHash(msg)
+		k.Watcher.SaveEthereumTx(msg, txHash, uint64(k.TxCount))
+		k.Watcher.SaveTransactionReceipt(watcher.TransactionFailed, msg, txHash, uint64(k.TxCount), &types.ResultData{}, 0)
+		k.TxCount++
+	}
+	k.Bloom, k.LogSize = k.Bundle.Bloom, k.Bundle.LogSize
+	k.Bundle = nil
+}
+
 // handleMsgEthermint handles an sdk.StdTx for an Ethereum state transition
 func handleMsgEthermint(ctx sdk.Context, k *Keeper, msg types.MsgEthermint) (*sdk.Result, error) {
 
@@ -393,6 +393,65 @@ func (suite *EvmTestSuite) TestSendTransaction() {
 	suite.Require().EqualValues(expectedGas, suite.ctx.GasMeter().GasConsumed())
 }
 
+func (suite *EvmTestSuite) TestHandleBundleTx() {
+	priv, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	sender := ethcmn.HexToAddress(priv.PubKey().Address().String())
+	recipient := ethcmn.Address{0x1}
+
+	testCases := []struct {
+		msg     string
+		amount  int64
+		expPass bool
+	}{
+		{"all txs executed", 40, true},
+		{"state changes reverted all together", 60, false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest() // reset
+			suite.app.EvmKeeper.SetBalance(suite.ctx, sender, big.NewInt(100))
+			bloom := new(big.Int).Set(suite.app.EvmKeeper.Bloom)
+
+			txs := []types.MsgEthereumTx{
+				types.NewMsgEthereumTx(0, &recipient, big.NewInt(60), 30000, big.NewInt(1), nil),
+				types.NewMsgEthereumTx(1, &recipient, big.NewInt(tc.amount), 30000, big.NewInt(1), nil),
+			}
+			for i := range txs {
+				suite.Require().NoError(txs[i].Sign(big.NewInt(3), priv.ToECDSA()))
+			}
+			bundle := types.NewBundleTx(txs, types.BundleConditions{})
+			suite.Require().NoError(bundle.ValidateBasic())
+
+			// the msgs of the bundle are run in a cache written only if all of them pass, as the baseapp does
+			ctx := types.ContextWithBundle(suite.ctx.WithGasMeter(sdk.NewGasMeter(bundle.GetGas())), bundle)
+			cacheCtx, writeCache := ctx.CacheContext()
+			for _, msg := range bundle.GetMsgs() {
+				if _, err = suite.handler(cacheCtx, msg); err != nil {
+					break
+				}
+			}
+
+			suite.Require().Equal(2, suite.app.EvmKeeper.TxCount)
+			suite.Require().Nil(suite.app.EvmKeeper.Bundle)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				writeCache()
+				// each tx is metered on its own and the bundle is charged for the sum
+				suite.Require().EqualValues(2*21000, ctx.GasMeter().GasConsumed())
+				suite.Require().Equal(big.NewInt(0), suite.app.EvmKeeper.GetBalance(suite.ctx, sender))
+				suite.Require().Equal(big.NewInt(100), suite.app.EvmKeeper.GetBalance(suite.ctx, recipient))
+			} else {
+				suite.Require().Error(err)
+				suite.Require().Equal(bloom, suite.app.EvmKeeper.Bloom)
+				suite.Require().Equal(big.NewInt(100), suite.app.EvmKeeper.GetBalance(suite.ctx, sender))
+				suite.Require().Equal(big.NewInt(0), suite.app.EvmKeeper.GetBalance(suite.ctx, recipient))
+			}
+		})
+	}
+}
+
 func (suite *EvmTestSuite) TestOutOfGasWhenDeployContract() {
 	// Test contract:
 	//http://remix.ethereum.org/#optimize=false&evmVersion=istanbul&version=soljson-v0.5.15+commit.6a57276f.js
@@ -760,6 +819,77 @@ func (suite *EvmTestSuite) TestEvmParamsAndContractDeploymentWhitelistControllin
 	}
 }
 
+func (suite *EvmTestSuite) TestContractDeploymentWhitelist_CodeHashAndFactory() {
+	// the child init code deploying an empty contract: PUSH1 0 PUSH1 0 RETURN
+	childInitCode := hexutils.HexToBytes("60006000F3")
+	childCodeHash := ethcrypto.Keccak256Hash(childInitCode)
+	// the factory creating a child with the init code on every call:
+	// PUSH5 <child init code> PUSH1 0 MSTORE PUSH1 5 PUSH1 27 PUSH1 0 CREATE STOP
+	factoryRuntime := "6460006000F36000526005601B6000F000"
+	// CODECOPY the runtime and RETURN it
+	factoryInitCode := hexutils.HexToBytes("6011600C60003960116000F3" + factoryRuntime)
+
+	privkey, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	sender := ethcmn.BytesToAddress(privkey.PubKey().Address().Bytes())
+	chainID, err := ethermint.ParseChainID(suite.ctx.ChainID())
+	suite.Require().NoError(err)
+	suite.app.EvmKeeper.SetBalance(suite.ctx, sender, sdk.NewDec(1024).BigInt())
+
+	handleTx := func(nonce uint64, to *ethcmn.Address, payload []byte) error {
+		tx := types.NewMsgEthereumTx(nonce, to, nil, 3000000, big.NewInt(1), payload)
+		suite.Require().NoError(tx.Sign(chainID, privkey.ToECDSA()))
+		_, err := suite.handler(suite.ctx, tx)
+		return err
+	}
+
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.EnableContractDeploymentWhitelist = true
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	// the sender isn't in the whitelist, but the code hash of the factory is
+	suite.Require().Error(handleTx(0, nil, factoryInitCode))
+	suite.stateDB.SetContractDeploymentCodeHashes(types.CodeHashList{ethcrypto.Keccak256Hash(factoryInitCode)})
+	suite.Require().NoError(handleTx(0, nil, factoryInitCode))
+	factory := ethcrypto.CreateAddress(sender, 0)
+	suite.Require().Equal(hexutils.HexToBytes(factoryRuntime), suite.stateDB.GetCode(factory))
+
+	// the factory isn't checked until the factory whitelist is enabled
+	suite.Require().NoError(handleTx(1, &factory, nil))
+	csdb := types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), suite.ctx)
+	suite.Require().Equal(uint64(2), csdb.GetNonce(factory))
+	suite.Require().True(csdb.Exist(ethcrypto.CreateAddress(factory, 1)))
+
+	params.EnableContractFactoryWhitelist = true
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	// the factory is allowed to deploy the child only after the code hash is granted to it, while the denied CREATE
+	// fails by itself without failing the tx
+	suite.Require().NoError(handleTx(2, &factory, nil))
+	csdb = types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), suite.ctx)
+	suite.Require().Equal(uint64(2), csdb.GetNonce(factory))
+	suite.Require().False(csdb.Exist(ethcrypto.CreateAddress(factory, 2)))
+
+	suite.stateDB.SetContractDeploymentFactory(types.ContractDeploymentFactory{
+		FactoryAddr: factory.Bytes(),
+		CodeHashes:  types.CodeHashList{childCodeHash},
+	})
+	suite.Require().NoError(handleTx(3, &factory, nil))
+	csdb = types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), suite.ctx)
+	suite.Require().Equal(uint64(3), csdb.GetNonce(factory))
+	suite.Require().True(csdb.Exist(ethcrypto.CreateAddress(factory, 2)))
+	suite.Require().Equal(types.CodeHashList{childCodeHash}, suite.stateDB.GetContractDeploymentFactories()[0].CodeHashes)
+
+	suite.stateDB.DeleteContractDeploymentFactory(types.ContractDeploymentFactory{
+		FactoryAddr: factory.Bytes(),
+		CodeHashes:  types.CodeHashList{childCodeHash},
+	})
+	suite.Require().NoError(handleTx(4, &factory, nil))
+	csdb = types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), suite.ctx)
+	suite.Require().Equal(uint64(3), csdb.GetNonce(factory))
+	suite.Require().False(csdb.Exist(ethcrypto.CreateAddress(factory, 3)))
+}
+
 func (suite *EvmTestSuite) TestEvmParamsAndContractDeploymentWhitelistControlling_MsgEthermint() {
 	params := suite.app.EvmKeeper.GetParams(suite.ctx)
 
@@ -893,6 +1023,10 @@ const (
 	invokeContract1HexPayload = "0x4f2be91f"
 	// invoke Contract2's 'add' function(it will invoke Contract1)
 	invokeContract2HexPayload = "0x4f2be91f"
+	// invoke Contract1's 'num' function
+	invokeContract1NumHexPayload = "0x4e70b1dc"
+	// invoke Contract2's 'number' function(it will invoke Contract1's 'num' function)
+	invokeContract2NumberHexPayload = "0x8381f58a"
 )
 
 type EvmContractBlockedListTestSuite struct {
@@ -1149,3 +1283,81 @@ func (suite *EvmContractBlockedListTestSuite) TestEvmParamsAndContractBlockedLis
 		})
 	}
 }
+
+func (suite *EvmContractBlockedListTestSuite) TestEvmParamsAndContractMethodBlockedListControlling_MsgEthereumTx() {
+	callerPrivKey, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+
+	addMethod := types.MethodSelector{0x4f, 0x2b, 0xe9, 0x1f}
+	numMethod := types.MethodSelector{0x4e, 0x70, 0xb1, 0xdc}
+	testCases := []struct {
+		msg                             string
+		enableContractBlockedList       bool
+		contractMethodBlockedList       types.BlockedContractList
+		expectedErrorForContract1Add    bool
+		expectedErrorForContract1Num    bool
+		expectedErrorForContract2Add    bool
+		expectedErrorForContract2Number bool
+	}{
+		{
+			msg:                       "every method in the blocked list could be invoked when contract blocked list is disabled",
+			enableContractBlockedList: false,
+			contractMethodBlockedList: types.BlockedContractList{
+				{Address: suite.contract1Addr.Bytes(), BlockMethods: types.MethodList{addMethod, numMethod}},
+			},
+		},
+		{
+			msg:                          "only 'add' of Contract1 and Contract2 invoking it couldn't be invoked when 'add' of Contract1 is blocked",
+			enableContractBlockedList:    true,
+			contractMethodBlockedList:    types.BlockedContractList{{Address: suite.contract1Addr.Bytes(), BlockMethods: types.MethodList{addMethod}}},
+			expectedErrorForContract1Add: true,
+			expectedErrorForContract2Add: true,
+		},
+		{
+			msg:                          "only 'add' of Contract2 couldn't be invoked when 'add' of Contract2 is blocked",
+			enableContractBlockedList:    true,
+			contractMethodBlockedList:    types.BlockedContractList{{Address: suite.contract2Addr.Bytes(), BlockMethods: types.MethodList{addMethod}}},
+			expectedErrorForContract2Add: true,
+		},
+		{
+			msg:                             "only 'num' of Contract1 and Contract2 static calling it couldn't be invoked when 'num' of Contract1 is blocked",
+			enableContractBlockedList:       true,
+			contractMethodBlockedList:       types.BlockedContractList{{Address: suite.contract1Addr.Bytes(), BlockMethods: types.MethodList{numMethod}}},
+			expectedErrorForContract1Num:    true,
+			expectedErrorForContract2Number: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			// update params
+			params := suite.app.EvmKeeper.GetParams(suite.ctx)
+			params.EnableContractBlockedList = tc.enableContractBlockedList
+			suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+			// reset contract method blocked list
+			suite.stateDB.DeleteContractMethodBlockedList(suite.stateDB.GetContractMethodBlockedList())
+			suite.stateDB.SetContractMethodBlockedList(tc.contractMethodBlockedList)
+
+			for _, call := range []struct {
+				payload       string
+				to            *ethcmn.Address
+				expectedError bool
+			}{
+				{invokeContract1HexPayload, &suite.contract1Addr, tc.expectedErrorForContract1Add},
+				{invokeContract1NumHexPayload, &suite.contract1Addr, tc.expectedErrorForContract1Num},
+				{invokeContract2HexPayload, &suite.contract2Addr, tc.expectedErrorForContract2Add},
+				{invokeContract2NumberHexPayload, &suite.contract2Addr, tc.expectedErrorForContract2Number},
+			} {
+				// nonce here could be any value
+				err = suite.deployOrInvokeContract(callerPrivKey, call.payload, 1024, call.to)
+				if call.expectedError {
+					suite.Require().Error(err)
+					suite.Require().Contains(err.Error(), "is not allowed to invoke")
+				} else {
+					suite.Require().NoError(err)
+				}
+			}
+		})
+	}
+}
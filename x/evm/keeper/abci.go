@@ -40,6 +40,9 @@ func (k *Keeper) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
 	k.TxCount = 0
 	k.LogSize = 0
 	k.Bhash = common.BytesToHash(currentHash)
+	if k.ContractStats != nil {
+		k.ContractStats = types.NewContractStatsAggregator()
+	}
 
 	//that can make sure latest block has been committed
 	k.Watcher.NewHeight(uint64(req.Header.GetHeight()), common.BytesToHash(currentHash), req.Header)
@@ -58,6 +61,8 @@ func (k Keeper) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.Valid
 	bloom := ethtypes.BytesToBloom(k.Bloom.Bytes())
 	k.SetBlockBloom(ctx, req.Height, bloom)
 
+	k.commitContractStats(ctx, req.Height)
+
 	if types.GetEnableBloomFilter() {
 		// the hash of current block is stored when executing BeginBlock of next block.
 		// so update section in the next block.
@@ -90,6 +95,22 @@ func (k Keeper) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.Valid
 			k.Watcher.SaveContractDeploymentWhitelistItem(iteratorDeploymentWhitelist.Key()[1:])
 		}
 
+		csdb := types.CreateEmptyCommitStateDB(k.GeneratePureCSDBParams(), ctx)
+		for _, codeHash := range csdb.GetContractDeploymentCodeHashes() {
+			k.Watcher.SaveContractDeploymentCodeHashItem(codeHash)
+		}
+		for _, factory := range csdb.GetContractDeploymentFactories() {
+			for _, codeHash := range factory.CodeHashes {
+				k.Watcher.SaveContractDeploymentFactoryItem(factory.FactoryAddr, codeHash)
+			}
+		}
+		for _, contract := range csdb.GetContractMethodBlockedList() {
+			for _, method := range contract.BlockMethods {
+				k.Watcher.SaveContractMethodBlockedListItem(contract.Address, method)
+			}
+		}
+		k.Watcher.SaveContractMethodBlockedListCount(csdb.GetContractMethodBlockedListCount())
+
 		k.Watcher.Used()
 	}
 
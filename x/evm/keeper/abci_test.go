@@ -70,16 +70,27 @@ func (suite *KeeperTestSuite) TestEndBlockWatcher() {
 	store := suite.ctx.KVStore(suite.app.EvmKeeper.GetStoreKey())
 	store.Set(types.GetContractDeploymentWhitelistMemberKey(suite.address.Bytes()), []byte(""))
 	store.Set(types.GetContractBlockedListMemberKey(suite.address.Bytes()), []byte(""))
+	codeHash := ethcrypto.Keccak256Hash([]byte("init code"))
+	store.Set(types.GetContractDeploymentCodeHashKey(codeHash), []byte(""))
+	store.Set(types.GetContractDeploymentFactoryKey(suite.address.Bytes(), codeHash), []byte(""))
+	method := types.MethodSelector{0x40, 0xc1, 0x0f, 0x19}
+	store.Set(types.GetContractMethodBlockedListKey(suite.address.Bytes(), method), []byte(""))
 	viper.Set(watcher.FlagFastQueryLru, 100)
 	_ = suite.app.EvmKeeper.EndBlock(suite.ctx, abci.RequestEndBlock{Height: 10})
 	time.Sleep(time.Millisecond)
 	querier := watcher.NewQuerier()
 	res1 := querier.HasContractDeploymentWhitelist(suite.address.Bytes())
 	res2 := querier.HasContractBlockedList(suite.address.Bytes())
+	res3 := querier.HasContractDeploymentCodeHash(codeHash.Bytes())
+	res4 := querier.HasContractDeploymentFactory(append(suite.address.Bytes(), codeHash.Bytes()...))
+	res5 := querier.HasContractMethodBlockedList(append(suite.address.Bytes(), method[:]...))
 	os.RemoveAll(watcher.WatchDbDir)
 
 	suite.Require().True(res1)
 	suite.Require().True(res2)
+	suite.Require().True(res3)
+	suite.Require().True(res4)
+	suite.Require().True(res5)
 }
 
 func (suite *KeeperTestSuite) TestResetCache() {
@@ -0,0 +1,33 @@
+package keeper
+
+import (
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+// BundleProgress is the progress of the bundle being delivered. The receipts of its executed txs are saved once it
+// ends, and the block bloom and log size are restored if it fails, as its state changes are reverted all together.
+type BundleProgress struct {
+	Bloom    *big.Int
+	LogSize  uint
+	Receipts []BundleReceipt
+}
+
+// BundleReceipt is the receipt of an executed tx of the bundle being delivered
+type BundleReceipt struct {
+	Msg     types.MsgEthereumTx
+	TxHash  ethcmn.Hash
+	TxIndex uint64
+	Data    *types.ResultData
+	GasUsed uint64
+}
+
+// StartBundle starts the progress of a bundle from the current block bloom and log size
+func (k *Keeper) StartBundle() {
+	k.Bundle = &BundleProgress{
+		Bloom:   new(big.Int).Set(k.Bloom),
+		LogSize: k.LogSize,
+	}
+}
@@ -0,0 +1,93 @@
+package keeper
+
+import (
+	"encoding/json"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain/x/common/monitor"
+	"github.com/okex/exchain/x/evm/types"
+	"github.com/spf13/viper"
+)
+
+// the label of the contracts out of the top ones in the metrics
+const otherContractsLabel = "other"
+
+// SetMetrics sets the metrics the contract stats of every block are exported to
+func (k *Keeper) SetMetrics(metrics *monitor.EvmMetrics) {
+	k.metrics = metrics
+}
+
+// AddContractStats adds the tx calling the contract to the contract stats of the block, if the stats are enabled
+func (k *Keeper) AddContractStats(contract ethcmn.Address, gasUsed uint64, storageWrites int, failed bool) {
+	if k.ContractStats != nil {
+		k.ContractStats.Add(contract, gasUsed, storageWrites, failed)
+	}
+}
+
+// commitContractStats exports the contract stats of the block to the metrics, and saves them to the rolling store
+func (k Keeper) commitContractStats(ctx sdk.Context, height int64) {
+	store := types.GetContractStatsStore()
+	if store == nil || k.ContractStats == nil {
+		return
+	}
+
+	if k.metrics != nil {
+		top, other := k.ContractStats.Top(viper.GetInt(types.FlagContractStatsTop))
+		k.metrics.ResetContracts()
+		for _, stats := range top {
+			k.metrics.SetContract(stats.Address.Hex(), stats.GasUsed, stats.Calls, stats.StorageWrites, stats.Failures)
+		}
+		if other.Calls != 0 {
+			k.metrics.SetContract(otherContractsLabel, other.GasUsed, other.Calls, other.StorageWrites, other.Failures)
+		}
+	}
+
+	if err := store.Save(k.ContractStats, height); err != nil {
+		k.Logger(ctx).Error("failed to save the contract stats", "height", height, "error", err)
+	}
+}
+
+// queryContractStats returns the top contracts by the gas used over a range of the latest heights, with the path
+// contract-stats/<from_height>/<to_height>/<limit>. The range ends at the latest height if to_height is 0, and
+// covers all the kept blocks if from_height is 0.
+func queryContractStats(ctx sdk.Context, path []string) ([]byte, error) {
+	store := types.GetContractStatsStore()
+	if store == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "contract stats are disabled")
+	}
+	if len(path) < 4 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 4 parameters is required")
+	}
+
+	var args [3]int64
+	for i := range args {
+		var err error
+		if args[i], err = strconv.ParseInt(path[i+1], 10, 64); err != nil || args[i] < 0 {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid parameter %s", path[i+1])
+		}
+	}
+	fromHeight, toHeight, limit := args[0], args[1], int(args[2])
+	if toHeight == 0 {
+		toHeight = ctx.BlockHeight()
+	}
+	if fromHeight == 0 {
+		fromHeight = 1
+	}
+	if limit == 0 {
+		limit = viper.GetInt(types.FlagContractStatsTop)
+	}
+
+	res, err := store.Query(fromHeight, toHeight, limit)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInternal, err.Error())
+	}
+	bz, err := json.Marshal(res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/okex/exchain/x/common/monitor"
 	"github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/evm/watcher"
 	"github.com/okex/exchain/x/params"
@@ -48,6 +49,13 @@ type Keeper struct {
 	LogSize uint
 	Watcher *watcher.Watcher
 	Ada     types.DbAdapter
+	// the progress of the bundle being delivered, see types.BundleTx
+	Bundle *BundleProgress
+
+	// the stats of the contracts called in a block, which are reset every block on BeginBlock. It's nil if the
+	// contract stats are disabled.
+	ContractStats types.ContractStatsAggregator
+	metrics       *monitor.EvmMetrics
 }
 
 // NewKeeper generates new evm module keeper
@@ -77,6 +85,10 @@ func NewKeeper(
 		LogSize:       0,
 		Watcher:       watcher.NewWatcher(),
 		Ada:           types.DefaultPrefixDb{},
+		metrics:       monitor.NopEvmMetrics(),
+	}
+	if types.GetContractStatsStore() != nil {
+		k.ContractStats = types.NewContractStatsAggregator()
 	}
 	if k.Watcher.Enabled() {
 		ak.SetObserverKeeper(k)
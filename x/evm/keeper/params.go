@@ -5,10 +5,11 @@ import (
 	"github.com/okex/exchain/x/evm/types"
 )
 
-// GetParams returns the total set of evm parameters.
-func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	k.paramSpace.GetParamSet(ctx, &params)
-	return
+// GetParams returns the total set of evm parameters, where the ones not set yet are the defaults.
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	params := types.DefaultParams()
+	types.GetParamSetIfExists(ctx, k.paramSpace, &params)
+	return params
 }
 
 // SetParams sets the evm parameters to the param space.
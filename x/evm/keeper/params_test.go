@@ -1,6 +1,7 @@
 package keeper_test
 
 import (
+	sdkparams "github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/okex/exchain/x/evm/types"
 )
 
@@ -11,3 +12,27 @@ func (suite *KeeperTestSuite) TestParams() {
 	newParams := suite.app.EvmKeeper.GetParams(suite.ctx)
 	suite.Require().Equal(newParams, params)
 }
+
+func (suite *KeeperTestSuite) TestParams_NotSetYet() {
+	params := types.DefaultParams()
+	params.EnableCall = true
+	params.EnabledPrecompiles = []string{"token"}
+	params.EnableContractFactoryWhitelist = true
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	// the params added after the genesis of a running chain aren't in the param store
+	store := suite.ctx.KVStore(suite.app.GetKey(sdkparams.StoreKey))
+	store.Delete(append([]byte(types.DefaultParamspace+"/"), types.ParamStoreKeyEnabledPrecompiles...))
+	store.Delete(append([]byte(types.DefaultParamspace+"/"), types.ParamStoreKeyContractFactoryWhitelist...))
+
+	expected := params
+	expected.EnabledPrecompiles = nil
+	expected.EnableContractFactoryWhitelist = false
+	suite.Require().NotPanics(func() {
+		suite.Require().Equal(expected, suite.app.EvmKeeper.GetParams(suite.ctx))
+	})
+	csdb := types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), suite.ctx)
+	suite.Require().NotPanics(func() {
+		suite.Require().Equal(expected, csdb.GetParams())
+	})
+}
@@ -16,7 +16,7 @@ import (
 
 // NewQuerier is the module level router for state queries
 func NewQuerier(keeper Keeper) sdk.Querier {
-	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, error) {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
 		if len(path) < 1 {
 			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
 				"Insufficient parameters, at least 1 parameter is required")
@@ -53,6 +53,12 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryContractDeploymentWhitelist(ctx, keeper)
 		case types.QueryContractBlockedList:
 			return queryContractBlockedList(ctx, keeper)
+		case types.QueryContractMethodBlockedList:
+			return queryContractMethodBlockedList(ctx, keeper)
+		case types.QuerySimulate:
+			return querySimulate(ctx, req, keeper)
+		case types.QueryContractStats:
+			return queryContractStats(ctx, path)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown query endpoint")
 		}
@@ -69,6 +75,16 @@ func queryContractBlockedList(ctx sdk.Context, keeper Keeper) (res []byte, err s
 	return res, nil
 }
 
+func queryContractMethodBlockedList(ctx sdk.Context, keeper Keeper) (res []byte, err sdk.Error) {
+	contractList := types.CreateEmptyCommitStateDB(keeper.GeneratePureCSDBParams(), ctx).GetContractMethodBlockedList()
+	res, errUnmarshal := codec.MarshalJSONIndent(types.ModuleCdc, contractList)
+	if errUnmarshal != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal result to JSON", errUnmarshal.Error()))
+	}
+
+	return res, nil
+}
+
 func queryContractDeploymentWhitelist(ctx sdk.Context, keeper Keeper) (res []byte, err sdk.Error) {
 	whitelist := types.CreateEmptyCommitStateDB(keeper.GeneratePureCSDBParams(), ctx).GetContractDeploymentWhitelist()
 	res, errUnmarshal := codec.MarshalJSONIndent(types.ModuleCdc, whitelist)
@@ -0,0 +1,92 @@
+package keeper
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethermint "github.com/okex/exchain/app/types"
+	"github.com/okex/exchain/x/evm/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// Simulate executes the msg on the state with the overrides applied, and never commits the state. It's used by the
+// simulated calls overriding the state, e.g. eth_call and eth_estimateGas with the state overrides
+func (k *Keeper) Simulate(ctx sdk.Context, msg types.MsgEthermint, overrides types.StateOverrides) (
+	res *sdk.SimulationResponse, err error) {
+	if err := overrides.Check(); err != nil {
+		return nil, err
+	}
+
+	chainIDEpoch, err := ethermint.ParseChainID(ctx.ChainID())
+	if err != nil {
+		return nil, err
+	}
+	config, found := k.GetChainConfig(ctx)
+	if !found {
+		return nil, types.ErrChainConfigNotFound
+	}
+
+	ctx = ctx.WithGasMeter(sdk.NewGasMeter(msg.GasLimit))
+	defer func() {
+		if r := recover(); r != nil {
+			oog, ok := r.(sdk.ErrorOutOfGas)
+			if !ok {
+				panic(r)
+			}
+			res, err = nil, sdkerrors.Wrapf(sdkerrors.ErrOutOfGas, "out of gas in location: %v", oog.Descriptor)
+		}
+	}()
+
+	txHash := ethcmn.BytesToHash(tmtypes.Tx(ctx.TxBytes()).Hash())
+	st := types.StateTransition{
+		AccountNonce: msg.AccountNonce,
+		Price:        msg.Price.BigInt(),
+		GasLimit:     msg.GasLimit,
+		Amount:       msg.Amount.BigInt(),
+		Payload:      msg.Payload,
+		Csdb:         types.CreateEmptyCommitStateDB(k.GenerateCSDBParams(), ctx),
+		ChainID:      chainIDEpoch,
+		TxHash:       &txHash,
+		Sender:       ethcmn.BytesToAddress(msg.From.Bytes()),
+		Simulate:     true,
+	}
+	if msg.Recipient != nil {
+		to := ethcmn.BytesToAddress(msg.Recipient.Bytes())
+		st.Recipient = &to
+	}
+	if err := overrides.Apply(st.Csdb); err != nil {
+		return nil, err
+	}
+
+	executionResult, _, err := st.TransitionDb(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return &sdk.SimulationResponse{
+		GasInfo: sdk.GasInfo{
+			GasWanted: ctx.GasMeter().Limit(),
+			GasUsed:   ctx.GasMeter().GasConsumed(),
+		},
+		Result: executionResult.Result,
+	}, nil
+}
+
+func querySimulate(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var params types.QuerySimulateParams
+	if err := json.Unmarshal(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, err := keeper.Simulate(ctx, params.Msg, params.Overrides)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := keeper.cdc.MarshalBinaryBare(res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
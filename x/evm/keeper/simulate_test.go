@@ -0,0 +1,84 @@
+package keeper_test
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+func (suite *KeeperTestSuite) TestSimulate() {
+	params := types.DefaultParams()
+	params.EnableCall = true
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	// the contract returns the slot 0 of its storage
+	contract := ethcmn.HexToAddress("0x1000000000000000000000000000000000000001")
+	code := hexutil.Bytes(ethcmn.FromHex("0x60005460005260206000f3"))
+	slot := ethcmn.Hash{}
+	value := ethcmn.BigToHash(big.NewInt(42))
+	recipient := sdk.AccAddress(contract.Bytes())
+	msg := types.NewMsgEthermint(0, &recipient, sdk.ZeroInt(), 100000, sdk.ZeroInt(), nil, sdk.AccAddress(suite.address.Bytes()))
+
+	testCases := []struct {
+		msg       string
+		overrides types.StateOverrides
+		expRet    ethcmn.Hash
+		expPass   bool
+	}{
+		{
+			"state",
+			types.StateOverrides{contract: {Code: &code, State: &map[ethcmn.Hash]ethcmn.Hash{slot: value}}},
+			value,
+			true,
+		},
+		{
+			"state diff",
+			types.StateOverrides{contract: {Code: &code, StateDiff: &map[ethcmn.Hash]ethcmn.Hash{slot: value}}},
+			value,
+			true,
+		},
+		{
+			"empty state",
+			types.StateOverrides{contract: {Code: &code, State: &map[ethcmn.Hash]ethcmn.Hash{}}},
+			ethcmn.Hash{},
+			true,
+		},
+		{
+			"both state and state diff",
+			types.StateOverrides{contract: {
+				Code:      &code,
+				State:     &map[ethcmn.Hash]ethcmn.Hash{slot: value},
+				StateDiff: &map[ethcmn.Hash]ethcmn.Hash{slot: value},
+			}},
+			ethcmn.Hash{},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		res, err := suite.app.EvmKeeper.Simulate(suite.ctx, msg, tc.overrides)
+		if !tc.expPass {
+			suite.Require().Error(err, tc.msg)
+			continue
+		}
+		suite.Require().NoError(err, tc.msg)
+		data, err := types.DecodeResultData(res.Result.Data)
+		suite.Require().NoError(err, tc.msg)
+		suite.Require().Equal(tc.expRet.Bytes(), data.Ret, tc.msg)
+		suite.Require().NotZero(res.GasInfo.GasUsed, tc.msg)
+	}
+
+	// the overrides aren't committed
+	suite.Require().Empty(suite.app.EvmKeeper.GetCode(suite.ctx, contract))
+
+	// the balance override
+	balance := (*hexutil.Big)(big.NewInt(1000))
+	msg = types.NewMsgEthermint(0, &recipient, sdk.NewInt(10), 100000, sdk.ZeroInt(), nil, sdk.AccAddress(suite.address.Bytes()))
+	_, err := suite.app.EvmKeeper.Simulate(suite.ctx, msg, nil)
+	suite.Require().Error(err)
+	_, err = suite.app.EvmKeeper.Simulate(suite.ctx, msg, types.StateOverrides{suite.address: {Balance: &balance}})
+	suite.Require().NoError(err)
+}
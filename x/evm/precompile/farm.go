@@ -0,0 +1,40 @@
+package precompile
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	farmtypes "github.com/okex/exchain/x/farm/types"
+)
+
+const (
+	FarmPrecompileName = "farm"
+
+	farmABI = `[
+	{"type":"function","name":"lock","inputs":[{"name":"poolName","type":"string"},{"name":"denom","type":"string"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"claim","inputs":[{"name":"poolName","type":"string"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+)
+
+// FarmPrecompileAddress is the address of the precompile locking into the farm pools and claiming the rewards of the
+// caller
+var FarmPrecompileAddress = ethcmn.HexToAddress("0x0000000000000000000000000000000000001004")
+
+// NewFarmPrecompile creates the precompile locking and claiming in the pools of x/farm
+func NewFarmPrecompile(router sdk.Router) msgPrecompile {
+	return newMsgPrecompile(FarmPrecompileName, FarmPrecompileAddress, farmABI, map[string]method{
+		"lock":  {gas: 50000, msg: farmLockMsg, outputs: returnTrue},
+		"claim": {gas: 50000, msg: farmClaimMsg, outputs: returnTrue},
+	}, router)
+}
+
+func farmLockMsg(caller sdk.AccAddress, args []interface{}) (sdk.Msg, error) {
+	coin, err := toCoin(args[1], args[2])
+	if err != nil {
+		return nil, err
+	}
+	return farmtypes.NewMsgLock(args[0].(string), caller, coin), nil
+}
+
+func farmClaimMsg(caller sdk.AccAddress, args []interface{}) (sdk.Msg, error) {
+	return farmtypes.NewMsgClaim(args[0].(string), caller), nil
+}
@@ -0,0 +1,69 @@
+package precompile
+
+import (
+	"encoding/json"
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ordertypes "github.com/okex/exchain/x/order/types"
+)
+
+const (
+	OrderPrecompileName = "order"
+
+	orderABI = `[
+	{"type":"function","name":"placeOrder","inputs":[{"name":"product","type":"string"},{"name":"side","type":"string"},{"name":"price","type":"uint256"},{"name":"quantity","type":"uint256"}],"outputs":[{"name":"orderId","type":"string"}]},
+	{"type":"function","name":"cancelOrder","inputs":[{"name":"orderId","type":"string"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+)
+
+// OrderPrecompileAddress is the address of the precompile placing and cancelling the orders of the caller
+var OrderPrecompileAddress = ethcmn.HexToAddress("0x0000000000000000000000000000000000001002")
+
+// NewOrderPrecompile creates the precompile placing and cancelling the orders of x/order
+func NewOrderPrecompile(router sdk.Router) msgPrecompile {
+	return newMsgPrecompile(OrderPrecompileName, OrderPrecompileAddress, orderABI, map[string]method{
+		"placeOrder":  {gas: 60000, msg: placeOrderMsg, outputs: placedOrderID},
+		"cancelOrder": {gas: 40000, msg: cancelOrderMsg, outputs: returnTrue},
+	}, router)
+}
+
+func placeOrderMsg(caller sdk.AccAddress, args []interface{}) (sdk.Msg, error) {
+	price, err := toDec(args[2])
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := toDec(args[3])
+	if err != nil {
+		return nil, err
+	}
+	item := ordertypes.OrderItem{Product: args[0].(string), Side: args[1].(string), Price: price, Quantity: quantity}
+	return ordertypes.NewMsgNewOrders(caller, []ordertypes.OrderItem{item}), nil
+}
+
+func cancelOrderMsg(caller sdk.AccAddress, args []interface{}) (sdk.Msg, error) {
+	orderID := args[0].(string)
+	if len(orderID) == 0 {
+		return nil, errors.New("empty order id")
+	}
+	return ordertypes.NewMsgCancelOrders(caller, []string{orderID}), nil
+}
+
+// placedOrderID returns the id of the order placed, which is in the "orders" attribute of the message event
+func placedOrderID(res *sdk.Result) []interface{} {
+	for _, event := range res.Events {
+		for _, attr := range event.Attributes {
+			if string(attr.Key) != "orders" {
+				continue
+			}
+			var results []struct {
+				OrderID string `json:"orderid"`
+			}
+			if err := json.Unmarshal(attr.Value, &results); err == nil && len(results) != 0 {
+				return []interface{}{results[0].OrderID}
+			}
+		}
+	}
+	return []interface{}{""}
+}
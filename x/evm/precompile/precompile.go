@@ -0,0 +1,138 @@
+package precompile
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+const (
+	// gas of the input of an unknown method, and of every 32 bytes of the input
+	baseGas = 3000
+	wordGas = 3
+)
+
+// Register registers the precompiles of the cosmos modules, whose messages are routed by the router
+func Register(router sdk.Router) {
+	types.RegisterPrecompile(NewTokenPrecompile(router))
+	types.RegisterPrecompile(NewOrderPrecompile(router))
+	types.RegisterPrecompile(NewSwapPrecompile(router))
+	types.RegisterPrecompile(NewFarmPrecompile(router))
+}
+
+// method is a method of a precompile, which builds the message of the module from the arguments and the caller
+type method struct {
+	gas     uint64
+	msg     func(caller sdk.AccAddress, args []interface{}) (sdk.Msg, error)
+	outputs func(res *sdk.Result) []interface{}
+}
+
+// msgPrecompile is a precompile whose methods are executed as the messages of the modules sent by the caller
+type msgPrecompile struct {
+	name    string
+	address ethcmn.Address
+	abi     abi.ABI
+	methods map[string]method
+	router  sdk.Router
+}
+
+var _ types.Precompile = msgPrecompile{}
+
+func newMsgPrecompile(name string, address ethcmn.Address, abiJSON string, methods map[string]method,
+	router sdk.Router) msgPrecompile {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(err)
+	}
+	return msgPrecompile{name: name, address: address, abi: parsed, methods: methods, router: router}
+}
+
+func (p msgPrecompile) Name() string {
+	return p.name
+}
+
+func (p msgPrecompile) Address() ethcmn.Address {
+	return p.address
+}
+
+func (p msgPrecompile) RequiredGas(input []byte) uint64 {
+	gas := uint64(baseGas)
+	if abiMethod, err := p.abi.MethodById(input); err == nil {
+		gas = p.methods[abiMethod.Name].gas
+	}
+	return gas + uint64(len(input)+31)/32*wordGas
+}
+
+func (p msgPrecompile) Run(ctx sdk.Context, caller ethcmn.Address, input []byte) (ret []byte, events sdk.Events, err error) {
+	// the panics of the modules, e.g. the overflows of the amounts, revert the call only
+	defer func() {
+		if e := recover(); e != nil {
+			ret, events, err = nil, nil, fmt.Errorf("%s precompile panics: %v", p.name, e)
+		}
+	}()
+
+	abiMethod, err := p.abi.MethodById(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	args, err := abiMethod.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := p.methods[abiMethod.Name]
+	msg, err := m.msg(caller.Bytes(), args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, nil, err
+	}
+
+	handler := p.router.Route(ctx, msg.Route())
+	if handler == nil {
+		return nil, nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized message route: %s", msg.Route())
+	}
+	res, err := handler(ctx, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ret, err = abiMethod.Outputs.Pack(m.outputs(res)...); err != nil {
+		return nil, nil, err
+	}
+	return ret, res.Events, nil
+}
+
+// returnTrue returns the outputs of the methods returning (bool)
+func returnTrue(*sdk.Result) []interface{} {
+	return []interface{}{true}
+}
+
+// toCoin converts the denom and the uint256 amount arguments into a coin
+func toCoin(denomArg, amountArg interface{}) (sdk.SysCoin, error) {
+	denom := denomArg.(string)
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return sdk.SysCoin{}, err
+	}
+	amount, err := toDec(amountArg)
+	if err != nil {
+		return sdk.SysCoin{}, err
+	}
+	return sdk.NewDecCoinFromDec(denom, amount), nil
+}
+
+// toDec converts the uint256 argument in the precision of sdk.Dec, the same as the amount of wei
+func toDec(arg interface{}) (sdk.Dec, error) {
+	amount, ok := arg.(*big.Int)
+	if !ok {
+		return sdk.Dec{}, fmt.Errorf("invalid amount type: %T", arg)
+	}
+	return sdk.NewDecFromBigIntWithPrec(amount, sdk.Precision), nil
+}
@@ -0,0 +1,49 @@
+package precompile
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	farmtypes "github.com/okex/exchain/x/farm/types"
+	tokentypes "github.com/okex/exchain/x/token/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredGas(t *testing.T) {
+	p := NewFarmPrecompile(nil)
+	require.Equal(t, uint64(baseGas), p.RequiredGas(nil))
+	require.Equal(t, uint64(baseGas+wordGas), p.RequiredGas([]byte{0x1}))
+
+	input, err := p.abi.Pack("claim", "pool")
+	require.NoError(t, err)
+	require.Equal(t, 50000+uint64(len(input)+31)/32*wordGas, p.RequiredGas(input))
+}
+
+func TestMsgs(t *testing.T) {
+	caller := sdk.AccAddress(ethcmn.BytesToAddress([]byte("caller")).Bytes())
+	to := ethcmn.BytesToAddress([]byte("to"))
+
+	msg, err := tokenTransferMsg(caller, []interface{}{to, "okt", sdk.NewDec(2).BigInt()})
+	require.NoError(t, err)
+	require.Equal(t, tokentypes.NewMsgTokenSend(caller, to.Bytes(), sdk.NewDecCoinsFromDec("okt", sdk.NewDec(2))), msg)
+
+	_, err = tokenTransferMsg(caller, []interface{}{to, "OKT!", sdk.NewDec(2).BigInt()})
+	require.Error(t, err)
+
+	msg, err = farmLockMsg(caller, []interface{}{"pool", "okt", big.NewInt(1)})
+	require.NoError(t, err)
+	require.Equal(t, farmtypes.NewMsgLock("pool", caller, sdk.NewDecCoinFromDec("okt", sdk.NewDecWithPrec(1, sdk.Precision))), msg)
+
+	_, err = swapMsg(caller, []interface{}{"okt", big.NewInt(1), "usdt", big.NewInt(1), to, new(big.Int).Lsh(big.NewInt(1), 64)})
+	require.Error(t, err)
+}
+
+func TestPlacedOrderID(t *testing.T) {
+	res := &sdk.Result{Events: sdk.Events{
+		sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute("orders", `[{"orderid":"ID0000000001-1"}]`)),
+	}}
+	require.Equal(t, []interface{}{"ID0000000001-1"}, placedOrderID(res))
+	require.Equal(t, []interface{}{""}, placedOrderID(&sdk.Result{}))
+}
@@ -0,0 +1,46 @@
+package precompile
+
+import (
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ammswaptypes "github.com/okex/exchain/x/ammswap/types"
+)
+
+const (
+	SwapPrecompileName = "swap"
+
+	swapABI = `[
+	{"type":"function","name":"swap","inputs":[{"name":"soldDenom","type":"string"},{"name":"soldAmount","type":"uint256"},{"name":"boughtDenom","type":"string"},{"name":"minBoughtAmount","type":"uint256"},{"name":"recipient","type":"address"},{"name":"deadline","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+)
+
+// SwapPrecompileAddress is the address of the precompile swapping the tokens of the caller
+var SwapPrecompileAddress = ethcmn.HexToAddress("0x0000000000000000000000000000000000001003")
+
+// NewSwapPrecompile creates the precompile swapping the tokens through the pools of x/ammswap
+func NewSwapPrecompile(router sdk.Router) msgPrecompile {
+	return newMsgPrecompile(SwapPrecompileName, SwapPrecompileAddress, swapABI, map[string]method{
+		"swap": {gas: 60000, msg: swapMsg, outputs: returnTrue},
+	}, router)
+}
+
+func swapMsg(caller sdk.AccAddress, args []interface{}) (sdk.Msg, error) {
+	soldToken, err := toCoin(args[0], args[1])
+	if err != nil {
+		return nil, err
+	}
+	minBoughtToken, err := toCoin(args[2], args[3])
+	if err != nil {
+		return nil, err
+	}
+	deadline := args[5].(*big.Int)
+	if !deadline.IsInt64() {
+		return nil, fmt.Errorf("invalid deadline %s", deadline)
+	}
+
+	recipient := args[4].(ethcmn.Address)
+	return ammswaptypes.NewMsgTokenToToken(soldToken, minBoughtToken, deadline.Int64(), recipient.Bytes(), caller), nil
+}
@@ -0,0 +1,34 @@
+package precompile
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	tokentypes "github.com/okex/exchain/x/token/types"
+)
+
+const (
+	TokenPrecompileName = "token"
+
+	tokenABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"denom","type":"string"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+)
+
+// TokenPrecompileAddress is the address of the precompile transferring the coins of the caller
+var TokenPrecompileAddress = ethcmn.HexToAddress("0x0000000000000000000000000000000000001001")
+
+// NewTokenPrecompile creates the precompile transferring the coins of x/token
+func NewTokenPrecompile(router sdk.Router) msgPrecompile {
+	return newMsgPrecompile(TokenPrecompileName, TokenPrecompileAddress, tokenABI, map[string]method{
+		"transfer": {gas: 30000, msg: tokenTransferMsg, outputs: returnTrue},
+	}, router)
+}
+
+func tokenTransferMsg(caller sdk.AccAddress, args []interface{}) (sdk.Msg, error) {
+	coin, err := toCoin(args[1], args[2])
+	if err != nil {
+		return nil, err
+	}
+	to := args[0].(ethcmn.Address)
+	return tokentypes.NewMsgTokenSend(caller, to.Bytes(), sdk.SysCoins{coin}), nil
+}
@@ -15,6 +15,10 @@ func NewManageContractDeploymentWhitelistProposalHandler(k *Keeper) govTypes.Han
 			return handleManageContractDeploymentWhitelistProposal(ctx, k, proposal)
 		case types.ManageContractBlockedListProposal:
 			return handleManageContractBlockedlListProposal(ctx, k, proposal)
+		case types.ManageContractDeploymentFactoryProposal:
+			return handleManageContractDeploymentFactoryProposal(ctx, k, proposal)
+		case types.ManageContractMethodBlockedListProposal:
+			return handleManageContractMethodBlockedListProposal(ctx, k, proposal)
 		default:
 			return common.ErrUnknownProposalType(types.DefaultCodespace, content.ProposalType())
 		}
@@ -30,13 +34,15 @@ func handleManageContractDeploymentWhitelistProposal(ctx sdk.Context, k *Keeper,
 
 	csdb := types.CreateEmptyCommitStateDB(k.GeneratePureCSDBParams(), ctx)
 	if manageContractDeploymentWhitelistProposal.IsAdded {
-		// add deployer addresses into whitelist
+		// add deployer addresses and code hashes into whitelist
 		csdb.SetContractDeploymentWhitelist(manageContractDeploymentWhitelistProposal.DistributorAddrs)
+		csdb.SetContractDeploymentCodeHashes(manageContractDeploymentWhitelistProposal.CodeHashes)
 		return nil
 	}
 
-	// remove deployer addresses from whitelist
+	// remove deployer addresses and code hashes from whitelist
 	csdb.DeleteContractDeploymentWhitelist(manageContractDeploymentWhitelistProposal.DistributorAddrs)
+	csdb.DeleteContractDeploymentCodeHashes(manageContractDeploymentWhitelistProposal.CodeHashes)
 	return nil
 }
 
@@ -58,3 +64,45 @@ func handleManageContractBlockedlListProposal(ctx sdk.Context, k *Keeper, propos
 	csdb.DeleteContractBlockedList(manageContractBlockedListProposal.ContractAddrs)
 	return nil
 }
+
+func handleManageContractDeploymentFactoryProposal(ctx sdk.Context, k *Keeper, proposal *govTypes.Proposal) sdk.Error {
+	// check
+	manageContractDeploymentFactoryProposal, ok := proposal.Content.(types.ManageContractDeploymentFactoryProposal)
+	if !ok {
+		return types.ErrUnexpectedProposalType
+	}
+
+	csdb := types.CreateEmptyCommitStateDB(k.GeneratePureCSDBParams(), ctx)
+	factory := types.ContractDeploymentFactory{
+		FactoryAddr: manageContractDeploymentFactoryProposal.FactoryAddr,
+		CodeHashes:  manageContractDeploymentFactoryProposal.CodeHashes,
+	}
+	if manageContractDeploymentFactoryProposal.IsAdded {
+		// allow the factory to deploy the code hashes
+		csdb.SetContractDeploymentFactory(factory)
+		return nil
+	}
+
+	// disallow the factory to deploy the code hashes
+	csdb.DeleteContractDeploymentFactory(factory)
+	return nil
+}
+
+func handleManageContractMethodBlockedListProposal(ctx sdk.Context, k *Keeper, proposal *govTypes.Proposal) sdk.Error {
+	// check
+	manageContractMethodBlockedListProposal, ok := proposal.Content.(types.ManageContractMethodBlockedListProposal)
+	if !ok {
+		return types.ErrUnexpectedProposalType
+	}
+
+	csdb := types.CreateEmptyCommitStateDB(k.GeneratePureCSDBParams(), ctx)
+	if manageContractMethodBlockedListProposal.IsAdded {
+		// add the methods of the contracts into blocked list
+		csdb.SetContractMethodBlockedList(manageContractMethodBlockedListProposal.ContractList)
+		return nil
+	}
+
+	// remove the methods of the contracts from blocked list
+	csdb.DeleteContractMethodBlockedList(manageContractMethodBlockedListProposal.ContractList)
+	return nil
+}
@@ -170,3 +170,92 @@ func (suite *EvmTestSuite) TestProposalHandler_ManageContractBlockedListProposal
 		})
 	}
 }
+
+func (suite *EvmTestSuite) TestProposalHandler_ManageContractMethodBlockedListProposal() {
+	addr1 := ethcmn.BytesToAddress([]byte{0x0}).Bytes()
+	addr2 := ethcmn.BytesToAddress([]byte{0x1}).Bytes()
+	method1, method2 := types.MethodSelector{0x1}, types.MethodSelector{0x2}
+
+	proposal := types.NewManageContractMethodBlockedListProposal(
+		"default title",
+		"default description",
+		types.BlockedContractList{
+			{Address: addr1, BlockMethods: types.MethodList{method1, method2}},
+			{Address: addr2, BlockMethods: types.MethodList{method1}},
+		},
+		true,
+	)
+
+	suite.govHandler = evm.NewManageContractDeploymentWhitelistProposalHandler(suite.app.EvmKeeper)
+	govProposal := govtypes.Proposal{
+		Content: proposal,
+	}
+
+	testCases := []struct {
+		msg                       string
+		prepare                   func()
+		targetContractListToCheck types.BlockedContractList
+	}{
+		{
+			"add methods into blocked list",
+			func() {},
+			types.BlockedContractList{
+				{Address: addr1, BlockMethods: types.MethodList{method1, method2}},
+				{Address: addr2, BlockMethods: types.MethodList{method1}},
+			},
+		},
+		{
+			"add the methods already in blocked list again",
+			func() {
+				proposal.ContractList = types.BlockedContractList{{Address: addr1, BlockMethods: types.MethodList{method1}}}
+				govProposal.Content = proposal
+			},
+			types.BlockedContractList{
+				{Address: addr1, BlockMethods: types.MethodList{method1, method2}},
+				{Address: addr2, BlockMethods: types.MethodList{method1}},
+			},
+		},
+		{
+			"delete a method of a contract from blocked list",
+			func() {
+				proposal.IsAdded = false
+				proposal.ContractList = types.BlockedContractList{{Address: addr1, BlockMethods: types.MethodList{method1}}}
+				govProposal.Content = proposal
+			},
+			types.BlockedContractList{
+				{Address: addr1, BlockMethods: types.MethodList{method2}},
+				{Address: addr2, BlockMethods: types.MethodList{method1}},
+			},
+		},
+		{
+			"delete the methods of two contracts from blocked list which contains some of them only",
+			func() {
+				proposal.ContractList = types.BlockedContractList{
+					{Address: addr1, BlockMethods: types.MethodList{method1, method2}},
+					{Address: addr2, BlockMethods: types.MethodList{method1, method2}},
+				}
+				govProposal.Content = proposal
+			},
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			tc.prepare()
+
+			err := suite.govHandler(suite.ctx, &govProposal)
+			suite.Require().NoError(err)
+
+			// check the method blocked list with target contract list
+			suite.Require().Equal(tc.targetContractListToCheck, suite.stateDB.GetContractMethodBlockedList())
+
+			// check the count of the methods in the method blocked list
+			var count uint64
+			for _, contract := range tc.targetContractListToCheck {
+				count += uint64(len(contract.BlockMethods))
+			}
+			suite.Require().Equal(count, suite.stateDB.GetContractMethodBlockedListCount())
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package types
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // AddressList is the type alias for []sdk.AccAddress
@@ -20,3 +24,108 @@ func (al AddressList) String() string {
 
 	return strings.TrimSpace(b.String())
 }
+
+// CodeHashList is the type alias for []ethcmn.Hash, which is encoded as the hex strings in json
+type CodeHashList []ethcmn.Hash
+
+// MarshalJSON marshals the code hashes into the hex strings
+func (chl CodeHashList) MarshalJSON() ([]byte, error) {
+	hexes := make([]string, len(chl))
+	for i := 0; i < len(chl); i++ {
+		hexes[i] = chl[i].Hex()
+	}
+	return json.Marshal(hexes)
+}
+
+// UnmarshalJSON unmarshals the code hashes from the hex strings
+func (chl *CodeHashList) UnmarshalJSON(input []byte) error {
+	var hashes []ethcmn.Hash
+	if err := json.Unmarshal(input, &hashes); err != nil {
+		return err
+	}
+	*chl = hashes
+	return nil
+}
+
+// String returns a human readable string representation of CodeHashList
+func (chl CodeHashList) String() string {
+	var b strings.Builder
+	b.WriteString("Code Hash List:\n")
+	for i := 0; i < len(chl); i++ {
+		b.WriteString(chl[i].Hex())
+		b.WriteByte('\n')
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// MethodSelector is the 4-byte selector of a contract method, the first 4 bytes of the keccak256 hash of its signature
+type MethodSelector [4]byte
+
+// String returns the hex string of the MethodSelector
+func (ms MethodSelector) String() string {
+	return hexutil.Encode(ms[:])
+}
+
+// MethodList is the type alias for []MethodSelector, which is encoded as the hex strings in json
+type MethodList []MethodSelector
+
+// MarshalJSON marshals the method selectors into the hex strings
+func (ml MethodList) MarshalJSON() ([]byte, error) {
+	hexes := make([]string, len(ml))
+	for i := 0; i < len(ml); i++ {
+		hexes[i] = ml[i].String()
+	}
+	return json.Marshal(hexes)
+}
+
+// UnmarshalJSON unmarshals the method selectors from the hex strings
+func (ml *MethodList) UnmarshalJSON(input []byte) error {
+	var selectors []hexutil.Bytes
+	if err := json.Unmarshal(input, &selectors); err != nil {
+		return err
+	}
+	methods := make(MethodList, len(selectors))
+	for i := 0; i < len(selectors); i++ {
+		if len(selectors[i]) != len(methods[i]) {
+			return fmt.Errorf("invalid method selector %s, which must be 4 bytes", selectors[i])
+		}
+		copy(methods[i][:], selectors[i])
+	}
+	*ml = methods
+	return nil
+}
+
+// BlockedContract is a contract with the methods blocked from being called
+type BlockedContract struct {
+	Address      sdk.AccAddress `json:"address" yaml:"address"`
+	BlockMethods MethodList     `json:"block_methods" yaml:"block_methods"`
+}
+
+// String returns a human readable string representation of BlockedContract
+func (bc BlockedContract) String() string {
+	var b strings.Builder
+	b.WriteString(ethcmn.BytesToAddress(bc.Address).Hex())
+	b.WriteString(":")
+	for i := 0; i < len(bc.BlockMethods); i++ {
+		b.WriteByte(' ')
+		b.WriteString(bc.BlockMethods[i].String())
+	}
+
+	return b.String()
+}
+
+// BlockedContractList is the type alias for []BlockedContract
+type BlockedContractList []BlockedContract
+
+// String returns a human readable string representation of BlockedContractList
+func (bcl BlockedContractList) String() string {
+	var b strings.Builder
+	b.WriteString("Blocked Contract List:\n")
+	for i := 0; i < len(bcl); i++ {
+		b.WriteString(bcl[i].String())
+		b.WriteByte('\n')
+	}
+
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,186 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/tendermint/tendermint/mempool"
+)
+
+// MaxBundleSize is the max number of the txs in a bundle
+const MaxBundleSize = 16
+
+var (
+	_ sdk.Tx     = BundleTx{}
+	_ ante.FeeTx = BundleTx{}
+)
+
+// BundleTx is a bundle of the ethereum txs of a single sender with consecutive nonces and the same gas price. The txs
+// are executed in order in the same block, and their state changes are committed all or none, only if the conditions
+// of the bundle hold in that block.
+type BundleTx struct {
+	Txs        []MsgEthereumTx  `json:"txs"`
+	Conditions BundleConditions `json:"conditions"`
+}
+
+// BundleConditions are the preconditions of a bundle checked against the block it's executed in. The zero values of
+// the ranges are unset.
+type BundleConditions struct {
+	BlockNumberMin int64              `json:"block_number_min"`
+	BlockNumberMax int64              `json:"block_number_max"`
+	TimestampMin   int64              `json:"timestamp_min"`
+	TimestampMax   int64              `json:"timestamp_max"`
+	StorageSlots   []StorageCondition `json:"storage_slots"`
+}
+
+// StorageCondition is the expected value of a storage slot of a contract
+type StorageCondition struct {
+	Address ethcmn.Address `json:"address"`
+	Key     ethcmn.Hash    `json:"key"`
+	Value   ethcmn.Hash    `json:"value"`
+}
+
+// NewBundleTx creates a new BundleTx
+func NewBundleTx(txs []MsgEthereumTx, conditions BundleConditions) BundleTx {
+	return BundleTx{
+		Txs:        txs,
+		Conditions: conditions,
+	}
+}
+
+// GetMsgs returns the txs of the bundle, which are executed atomically as the msgs of a tx
+func (tx BundleTx) GetMsgs() []sdk.Msg {
+	msgs := make([]sdk.Msg, len(tx.Txs))
+	for i, msg := range tx.Txs {
+		msgs[i] = msg
+	}
+	return msgs
+}
+
+// ValidateBasic validates the size of the bundle, its txs and the ranges of its conditions. The txs sharing the
+// sender is checked by the ante handler, as it needs to recover the signers.
+func (tx BundleTx) ValidateBasic() error {
+	if len(tx.Txs) == 0 || len(tx.Txs) > MaxBundleSize {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "the size of the bundle must be in the range of [1, %d]", MaxBundleSize)
+	}
+	for i, msg := range tx.Txs {
+		if err := msg.ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "invalid tx %d of the bundle", i)
+		}
+		if i == 0 {
+			continue
+		}
+		// the nonces order the txs of the bundle, and the gas fees are refunded at the single gas price
+		if msg.Data.AccountNonce != tx.Txs[i-1].Data.AccountNonce+1 {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidSequence, "the txs of the bundle must have consecutive nonces")
+		}
+		if msg.Data.Price.Cmp(tx.Txs[0].Data.Price) != 0 {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "the txs of the bundle must have the same gas price")
+		}
+	}
+	return tx.Conditions.ValidateBasic()
+}
+
+// GetTxInfo returns the sender, the nonce of the first tx and the gas price of the bundle, which takes the nonce slot
+// of its first tx in the mempool
+func (tx BundleTx) GetTxInfo(ctx sdk.Context) mempool.ExTxInfo {
+	if len(tx.Txs) == 0 {
+		return mempool.ExTxInfo{GasPrice: big.NewInt(0)}
+	}
+	return tx.Txs[0].GetTxInfo(ctx)
+}
+
+// GetGasPrice returns the gas price of the txs of the bundle
+func (tx BundleTx) GetGasPrice() *big.Int {
+	if len(tx.Txs) == 0 {
+		return big.NewInt(0)
+	}
+	return tx.Txs[0].GetGasPrice()
+}
+
+// GetGas returns the sum of the gas limits of the txs of the bundle
+func (tx BundleTx) GetGas() uint64 {
+	var gas uint64
+	for _, msg := range tx.Txs {
+		gas += msg.GetGas()
+	}
+	return gas
+}
+
+// GetFee returns the sum of the fees of the txs of the bundle
+func (tx BundleTx) GetFee() sdk.Coins {
+	fee := new(big.Int)
+	for _, msg := range tx.Txs {
+		fee.Add(fee, msg.Fee())
+	}
+	return sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewDecFromBigIntWithPrec(fee, sdk.Precision)))
+}
+
+// FeePayer returns the sender of the txs of the bundle
+func (tx BundleTx) FeePayer(ctx sdk.Context) sdk.AccAddress {
+	if len(tx.Txs) == 0 {
+		return nil
+	}
+	return tx.Txs[0].FeePayer(ctx)
+}
+
+// ValidateBasic validates the ranges of the conditions
+func (c BundleConditions) ValidateBasic() error {
+	if c.BlockNumberMin < 0 || c.BlockNumberMax < 0 || c.TimestampMin < 0 || c.TimestampMax < 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "the ranges of the bundle conditions can't be negative")
+	}
+	if c.BlockNumberMax != 0 && c.BlockNumberMin > c.BlockNumberMax {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid block number range of the bundle conditions")
+	}
+	if c.TimestampMax != 0 && c.TimestampMin > c.TimestampMax {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid timestamp range of the bundle conditions")
+	}
+	return nil
+}
+
+// Check checks the conditions against the height and the timestamp of the block and the storage of the contracts
+func (c BundleConditions) Check(height, timestamp int64, getState func(ethcmn.Address, ethcmn.Hash) ethcmn.Hash) error {
+	if height < c.BlockNumberMin || (c.BlockNumberMax != 0 && height > c.BlockNumberMax) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "block number %d out of the range of the bundle conditions", height)
+	}
+	if timestamp < c.TimestampMin || (c.TimestampMax != 0 && timestamp > c.TimestampMax) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "timestamp %d out of the range of the bundle conditions", timestamp)
+	}
+	for _, slot := range c.StorageSlots {
+		if getState(slot.Address, slot.Key) != slot.Value {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest,
+				"storage slot %s of %s mismatches the bundle conditions", slot.Key.Hex(), slot.Address.Hex())
+		}
+	}
+	return nil
+}
+
+// String implements the fmt.Stringer interface
+func (tx BundleTx) String() string {
+	return fmt.Sprintf("BundleTx{txs: %d, conditions: %+v}", len(tx.Txs), tx.Conditions)
+}
+
+type bundleKey struct{}
+
+// ContextWithBundle records the bundle being executed in the context
+func ContextWithBundle(ctx sdk.Context, bundle BundleTx) sdk.Context {
+	parent := ctx.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+	return ctx.WithContext(context.WithValue(parent, bundleKey{}, bundle))
+}
+
+// BundleFromContext returns the bundle being executed recorded in the context, if any
+func BundleFromContext(ctx sdk.Context) (BundleTx, bool) {
+	if ctx.Context() == nil {
+		return BundleTx{}, false
+	}
+	bundle, ok := ctx.Context().Value(bundleKey{}).(BundleTx)
+	return bundle, ok
+}
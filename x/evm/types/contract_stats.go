@@ -0,0 +1,211 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	// FlagContractStatsBlocks is the number of the latest blocks whose contract stats are kept, 0 disables the stats
+	FlagContractStatsBlocks = "evm-contract-stats-blocks"
+	// FlagContractStatsTop is the number of the contracts exported to the metrics, the others are summed as "other"
+	FlagContractStatsTop = "evm-contract-stats-top"
+
+	contractStatsDir = "contract_stats"
+	// the number of the contracts kept per block in the rolling store, the others are summed into the other bucket
+	contractStatsStoreLimit = 100
+)
+
+var (
+	contractStatsStore     *ContractStatsStore
+	initContractStatsStore sync.Once
+)
+
+// ContractStats is the gas used, the calls, the storage writes and the failures of the txs calling a contract
+type ContractStats struct {
+	Address       ethcmn.Address `json:"address"`
+	GasUsed       uint64         `json:"gas_used"`
+	Calls         uint64         `json:"calls"`
+	StorageWrites uint64         `json:"storage_writes"`
+	Failures      uint64         `json:"failures"`
+}
+
+func (s *ContractStats) merge(other ContractStats) {
+	s.GasUsed += other.GasUsed
+	s.Calls += other.Calls
+	s.StorageWrites += other.StorageWrites
+	s.Failures += other.Failures
+}
+
+// BlockContractStats is the top contracts called in a block by the gas used, with the rest summed into Other
+type BlockContractStats struct {
+	Height    int64           `json:"height"`
+	Contracts []ContractStats `json:"contracts"`
+	Other     ContractStats   `json:"other"`
+}
+
+// QueryResContractStats is the response of the contract stats over a range of heights
+type QueryResContractStats struct {
+	FromHeight int64           `json:"from_height"`
+	ToHeight   int64           `json:"to_height"`
+	Contracts  []ContractStats `json:"contracts"`
+	Other      ContractStats   `json:"other"`
+}
+
+// ContractStatsAggregator aggregates the stats of the contracts called by the txs of a block
+type ContractStatsAggregator map[ethcmn.Address]*ContractStats
+
+// NewContractStatsAggregator creates a new ContractStatsAggregator
+func NewContractStatsAggregator() ContractStatsAggregator {
+	return make(ContractStatsAggregator)
+}
+
+// Add adds a tx calling the contract
+func (a ContractStatsAggregator) Add(contract ethcmn.Address, gasUsed uint64, storageWrites int, failed bool) {
+	stats, ok := a[contract]
+	if !ok {
+		stats = &ContractStats{Address: contract}
+		a[contract] = stats
+	}
+	stats.GasUsed += gasUsed
+	stats.Calls++
+	stats.StorageWrites += uint64(storageWrites)
+	if failed {
+		stats.Failures++
+	}
+}
+
+// Top returns the top n contracts by the gas used, and the sum of the others
+func (a ContractStatsAggregator) Top(n int) ([]ContractStats, ContractStats) {
+	stats := make([]ContractStats, 0, len(a))
+	for _, s := range a {
+		stats = append(stats, *s)
+	}
+	return topContractStats(stats, n)
+}
+
+// topContractStats sorts the stats by the gas used in descending order, and sums the ones after the top n
+func topContractStats(stats []ContractStats, n int) ([]ContractStats, ContractStats) {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].GasUsed != stats[j].GasUsed {
+			return stats[i].GasUsed > stats[j].GasUsed
+		}
+		return bytes.Compare(stats[i].Address.Bytes(), stats[j].Address.Bytes()) < 0
+	})
+
+	var other ContractStats
+	if n < 0 {
+		n = 0
+	}
+	if len(stats) > n {
+		for _, s := range stats[n:] {
+			other.merge(s)
+		}
+		stats = stats[:n]
+	}
+	return stats, other
+}
+
+// ContractStatsStore keeps the contract stats of the latest blocks in the local db of the node
+type ContractStatsStore struct {
+	db     dbm.DB
+	blocks int64
+}
+
+// NewContractStatsStore creates a new ContractStatsStore keeping the stats of the latest blocks
+func NewContractStatsStore(db dbm.DB, blocks int64) *ContractStatsStore {
+	return &ContractStatsStore{
+		db:     db,
+		blocks: blocks,
+	}
+}
+
+// GetContractStatsStore returns the global ContractStatsStore, which is nil if the contract stats are disabled
+func GetContractStatsStore() *ContractStatsStore {
+	initContractStatsStore.Do(func() {
+		blocks := viper.GetInt64(FlagContractStatsBlocks)
+		if blocks <= 0 {
+			return
+		}
+		db, err := sdk.NewLevelDB(contractStatsDir, filepath.Join(viper.GetString("home"), "data"))
+		if err != nil {
+			panic(err)
+		}
+		contractStatsStore = NewContractStatsStore(db, blocks)
+	})
+	return contractStatsStore
+}
+
+func contractStatsKey(height int64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(height))
+	return key
+}
+
+// Save saves the stats of the block, and deletes the ones out of the latest blocks
+func (s *ContractStatsStore) Save(aggregator ContractStatsAggregator, height int64) error {
+	stats := BlockContractStats{Height: height}
+	stats.Contracts, stats.Other = aggregator.Top(contractStatsStoreLimit)
+	bz, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	batch.Set(contractStatsKey(height), bz)
+	if expired := height - s.blocks; expired > 0 {
+		batch.Delete(contractStatsKey(expired))
+	}
+	return batch.Write()
+}
+
+// Query returns the top n contracts by the gas used over the heights from fromHeight to toHeight. As only the top
+// contracts of each block are kept, a contract out of them in a block is counted into the other bucket of the block.
+func (s *ContractStatsStore) Query(fromHeight, toHeight int64, n int) (QueryResContractStats, error) {
+	res := QueryResContractStats{FromHeight: fromHeight, ToHeight: toHeight}
+	if fromHeight > toHeight {
+		return res, nil
+	}
+
+	it, err := s.db.Iterator(contractStatsKey(fromHeight), contractStatsKey(toHeight+1))
+	if err != nil {
+		return res, err
+	}
+	defer it.Close()
+
+	merged := make(map[ethcmn.Address]*ContractStats)
+	for ; it.Valid(); it.Next() {
+		var block BlockContractStats
+		if err := json.Unmarshal(it.Value(), &block); err != nil {
+			return res, err
+		}
+		for _, contract := range block.Contracts {
+			if stats, ok := merged[contract.Address]; ok {
+				stats.merge(contract)
+			} else {
+				contract := contract
+				merged[contract.Address] = &contract
+			}
+		}
+		res.Other.merge(block.Other)
+	}
+
+	stats := make([]ContractStats, 0, len(merged))
+	for _, s := range merged {
+		stats = append(stats, *s)
+	}
+	var other ContractStats
+	res.Contracts, other = topContractStats(stats, n)
+	res.Other.merge(other)
+	return res, nil
+}
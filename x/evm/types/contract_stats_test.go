@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestContractStatsAggregator_Top(t *testing.T) {
+	addr1, addr2, addr3 := common.BytesToAddress([]byte{1}), common.BytesToAddress([]byte{2}), common.BytesToAddress([]byte{3})
+
+	aggregator := NewContractStatsAggregator()
+	aggregator.Add(addr1, 100, 1, false)
+	aggregator.Add(addr2, 300, 2, false)
+	aggregator.Add(addr1, 50, 0, true)
+	aggregator.Add(addr3, 10, 3, true)
+
+	top, other := aggregator.Top(2)
+	require.Equal(t, []ContractStats{
+		{Address: addr2, GasUsed: 300, Calls: 1, StorageWrites: 2},
+		{Address: addr1, GasUsed: 150, Calls: 2, StorageWrites: 1, Failures: 1},
+	}, top)
+	require.Equal(t, ContractStats{GasUsed: 10, Calls: 1, StorageWrites: 3, Failures: 1}, other)
+
+	top, other = aggregator.Top(5)
+	require.Len(t, top, 3)
+	require.Equal(t, ContractStats{}, other)
+}
+
+func TestContractStatsStore(t *testing.T) {
+	addr1, addr2 := common.BytesToAddress([]byte{1}), common.BytesToAddress([]byte{2})
+	store := NewContractStatsStore(dbm.NewMemDB(), 3)
+
+	for height := int64(1); height <= 5; height++ {
+		aggregator := NewContractStatsAggregator()
+		aggregator.Add(addr1, uint64(height*100), 1, false)
+		aggregator.Add(addr2, 250, 0, height%2 == 0)
+		require.NoError(t, store.Save(aggregator, height))
+	}
+
+	// the stats of the heights 1 and 2 are out of the latest 3 blocks
+	res, err := store.Query(1, 5, 10)
+	require.NoError(t, err)
+	require.Equal(t, []ContractStats{
+		{Address: addr1, GasUsed: 1200, Calls: 3, StorageWrites: 3},
+		{Address: addr2, GasUsed: 750, Calls: 3, Failures: 1},
+	}, res.Contracts)
+
+	res, err = store.Query(4, 5, 1)
+	require.NoError(t, err)
+	require.Equal(t, []ContractStats{{Address: addr1, GasUsed: 900, Calls: 2, StorageWrites: 2}}, res.Contracts)
+	require.Equal(t, ContractStats{GasUsed: 500, Calls: 2, Failures: 1}, res.Other)
+}
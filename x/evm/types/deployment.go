@@ -0,0 +1,134 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ContractDeploymentFactory is a factory contract with the code hashes of the init code it's allowed to deploy
+type ContractDeploymentFactory struct {
+	FactoryAddr sdk.AccAddress `json:"factory_address" yaml:"factory_address"`
+	CodeHashes  CodeHashList   `json:"code_hashes" yaml:"code_hashes"`
+}
+
+// SetContractDeploymentCodeHashes sets the code hashes of the init code anyone is allowed to deploy into the whitelist
+// store
+func (csdb *CommitStateDB) SetContractDeploymentCodeHashes(codeHashes CodeHashList) {
+	if csdb.Watcher.Enabled() {
+		for i := 0; i < len(codeHashes); i++ {
+			csdb.Watcher.SaveContractDeploymentCodeHashItem(codeHashes[i])
+		}
+	}
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	for i := 0; i < len(codeHashes); i++ {
+		store.Set(GetContractDeploymentCodeHashKey(codeHashes[i]), []byte(""))
+	}
+}
+
+// DeleteContractDeploymentCodeHashes deletes the code hashes from the whitelist store
+func (csdb *CommitStateDB) DeleteContractDeploymentCodeHashes(codeHashes CodeHashList) {
+	if csdb.Watcher.Enabled() {
+		for i := 0; i < len(codeHashes); i++ {
+			csdb.Watcher.DeleteContractDeploymentCodeHash(codeHashes[i])
+		}
+	}
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	for i := 0; i < len(codeHashes); i++ {
+		store.Delete(GetContractDeploymentCodeHashKey(codeHashes[i]))
+	}
+}
+
+// GetContractDeploymentCodeHashes gets all the code hashes in the whitelist currently
+func (csdb *CommitStateDB) GetContractDeploymentCodeHashes() (codeHashes CodeHashList) {
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, KeyPrefixContractDeploymentCodeHash)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		codeHashes = append(codeHashes, splitApprovedCodeHash(iterator.Key()))
+	}
+
+	return
+}
+
+// IsCodeHashInWhitelist checks whether the code hash of the init code is in the whitelist
+func (csdb *CommitStateDB) IsCodeHashInWhitelist(codeHash ethcmn.Hash) bool {
+	bs := csdb.dbAdapter.NewStore(csdb.ctx.KVStore(csdb.storeKey), KeyPrefixContractDeploymentCodeHash)
+	return bs.Has(codeHash.Bytes())
+}
+
+// SetContractDeploymentFactory allows the factory to deploy the contracts with the code hashes of the init code
+func (csdb *CommitStateDB) SetContractDeploymentFactory(factory ContractDeploymentFactory) {
+	if csdb.Watcher.Enabled() {
+		for i := 0; i < len(factory.CodeHashes); i++ {
+			csdb.Watcher.SaveContractDeploymentFactoryItem(factory.FactoryAddr, factory.CodeHashes[i])
+		}
+	}
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	for i := 0; i < len(factory.CodeHashes); i++ {
+		store.Set(GetContractDeploymentFactoryKey(factory.FactoryAddr, factory.CodeHashes[i]), []byte(""))
+	}
+}
+
+// DeleteContractDeploymentFactory disallows the factory to deploy the contracts with the code hashes of the init code
+func (csdb *CommitStateDB) DeleteContractDeploymentFactory(factory ContractDeploymentFactory) {
+	if csdb.Watcher.Enabled() {
+		for i := 0; i < len(factory.CodeHashes); i++ {
+			csdb.Watcher.DeleteContractDeploymentFactory(factory.FactoryAddr, factory.CodeHashes[i])
+		}
+	}
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	for i := 0; i < len(factory.CodeHashes); i++ {
+		store.Delete(GetContractDeploymentFactoryKey(factory.FactoryAddr, factory.CodeHashes[i]))
+	}
+}
+
+// GetContractDeploymentFactories gets all the factories with the code hashes they are allowed to deploy currently
+func (csdb *CommitStateDB) GetContractDeploymentFactories() (factories []ContractDeploymentFactory) {
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, KeyPrefixContractDeploymentFactory)
+	defer iterator.Close()
+
+	// the keys of a factory are adjacent in the iteration
+	for ; iterator.Valid(); iterator.Next() {
+		factoryAddr, codeHash := splitFactoryCodeHash(iterator.Key())
+		if n := len(factories); n != 0 && factories[n-1].FactoryAddr.Equals(factoryAddr) {
+			factories[n-1].CodeHashes = append(factories[n-1].CodeHashes, codeHash)
+		} else {
+			factories = append(factories, ContractDeploymentFactory{
+				FactoryAddr: factoryAddr,
+				CodeHashes:  CodeHashList{codeHash},
+			})
+		}
+	}
+
+	return
+}
+
+// IsFactoryAllowedToDeploy checks whether the factory is allowed to deploy the init code with the code hash
+func (csdb *CommitStateDB) IsFactoryAllowedToDeploy(factoryAddr sdk.AccAddress, codeHash ethcmn.Hash) bool {
+	bs := csdb.dbAdapter.NewStore(csdb.ctx.KVStore(csdb.storeKey), KeyPrefixContractDeploymentFactory)
+	return bs.Has(GetContractDeploymentFactoryKey(factoryAddr, codeHash)[len(KeyPrefixContractDeploymentFactory):])
+}
+
+// IsDeploymentAllowed checks whether the deployer is allowed to deploy the init code with the code hash, which is true
+// if the deployer is in the whitelist as a distributor, the code hash is in the whitelist, or the deployer is a factory
+// allowed to deploy the code hash
+func (csdb *CommitStateDB) IsDeploymentAllowed(deployerAddr sdk.AccAddress, codeHash ethcmn.Hash) bool {
+	return csdb.IsDeployerInWhitelist(deployerAddr) || csdb.IsCodeHashInWhitelist(codeHash) ||
+		csdb.IsFactoryAllowedToDeploy(deployerAddr, codeHash)
+}
+
+// checkDeployment checks the init code of a CREATE or CREATE2 before it runs, whose memory has already been expanded.
+// A denied deployment fails its create frame only, and pushes zero as the address like any failed CREATE or CREATE2.
+func (g *evmGuard) checkDeployment(scope *vm.ScopeContext) {
+	// the stack of both is value, offset, size from the top
+	offset, size := scope.Stack.Back(1), scope.Stack.Back(2)
+	initCode := scope.Memory.GetPtr(int64(offset.Uint64()), int64(size.Uint64()))
+	deployer := scope.Contract.Address()
+	if !g.csdb.IsDeploymentAllowed(deployer.Bytes(), crypto.Keccak256Hash(initCode)) {
+		g.deniedDeployer = &deployer
+	}
+}
@@ -44,6 +44,18 @@ var (
 	// ErrDuplicatedAddr returns an error if the address is duplicated in address list
 	ErrDuplicatedAddr = sdkerrors.Register(ModuleName, 12, "Duplicated address in address list")
 
+	// ErrEmptyCodeHashList returns an error if the code hash list is empty
+	ErrEmptyCodeHashList = sdkerrors.Register(ModuleName, 18, "Empty code hash list")
+
+	// ErrDuplicatedCodeHash returns an error if the code hash is duplicated in code hash list
+	ErrDuplicatedCodeHash = sdkerrors.Register(ModuleName, 19, "Duplicated code hash in code hash list")
+
+	// ErrEmptyMethodList returns an error if the method list of a contract is empty
+	ErrEmptyMethodList = sdkerrors.Register(ModuleName, 21, "Empty method list")
+
+	// ErrDuplicatedMethod returns an error if the method is duplicated in method list
+	ErrDuplicatedMethod = sdkerrors.Register(ModuleName, 22, "Duplicated method in method list")
+
 	CodeSpaceEvmCallFailed = uint32(7)
 
 	ErrorHexData = "HexData"
@@ -79,3 +91,25 @@ func ErrCallBlockedContract(contractAddr ethcmn.Address) sdk.EnvelopedErr {
 		),
 	}
 }
+
+// ErrPrecompileUnavailable returns an error when the stateful precompile is called out of a state transition
+func ErrPrecompileUnavailable(addr ethcmn.Address) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{
+		Err: sdkerrors.New(
+			DefaultParamspace,
+			16,
+			fmt.Sprintf("failed. the precompile %s is unavailable out of a state transition", addr.Hex()),
+		),
+	}
+}
+
+// ErrCallBlockedContractMethod returns an error when the blocked method of a contract is invoked
+func ErrCallBlockedContractMethod(contractAddr ethcmn.Address, method MethodSelector) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{
+		Err: sdkerrors.New(
+			DefaultParamspace,
+			20,
+			fmt.Sprintf("failed. the method %s of the contract %s is not allowed to invoke", method, contractAddr.Hex()),
+		),
+	}
+}
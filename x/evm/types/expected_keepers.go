@@ -24,6 +24,7 @@ type SupplyKeeper interface {
 
 type Subspace interface {
 	GetParamSet(ctx sdk.Context, ps params.ParamSet)
+	GetIfExists(ctx sdk.Context, key []byte, ptr interface{})
 	SetParamSet(ctx sdk.Context, ps params.ParamSet)
 }
 
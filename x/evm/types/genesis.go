@@ -18,6 +18,12 @@ type (
 		ContractBlockedList         AddressList       `json:"contract_blocked_list"`
 		ChainConfig                 ChainConfig       `json:"chain_config"`
 		Params                      Params            `json:"params"`
+		// the code hashes of the init code anyone is allowed to deploy, and the factories with the code hashes they
+		// are allowed to deploy
+		ContractDeploymentCodeHashes CodeHashList                `json:"contract_deployment_code_hashes,omitempty"`
+		ContractDeploymentFactories  []ContractDeploymentFactory `json:"contract_deployment_factories,omitempty"`
+		// the contracts with the methods blocked from being called
+		ContractMethodBlockedList BlockedContractList `json:"contract_method_blocked_list,omitempty"`
 	}
 
 	// GenesisAccount defines an account to be initialized in the genesis state.
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"math/big"
+	"time"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// evmGuard is the tracer checking the call frames of a tx against the contract method blocked list, and the contracts
+// created by the CREATE and CREATE2 of the other contracts against the contract deployment whitelist. It's only
+// attached to the evm if either is enabled, as tracing every opcode slows down the interpreter.
+type evmGuard struct {
+	csdb *CommitStateDB
+	// whether the methods called and the contracts created are checked
+	checkMethods     bool
+	checkDeployments bool
+	// the deployer of the CREATE or CREATE2 denied right before, whose balance check fails the create frame only
+	deniedDeployer *ethcmn.Address
+}
+
+var _ vm.Tracer = (*evmGuard)(nil)
+
+// newEVMGuard creates the evmGuard with the checks enabled by the params, and returns nil if there's nothing to check.
+// The methods are only checked when the method blocked list isn't empty, as the blocked contracts are checked before.
+func newEVMGuard(csdb *CommitStateDB, params Params) *evmGuard {
+	checkMethods := params.EnableContractBlockedList && csdb.GetContractMethodBlockedListCount() != 0
+	checkDeployments := params.EnableContractDeploymentWhitelist && params.EnableContractFactoryWhitelist
+	if !checkMethods && !checkDeployments {
+		return nil
+	}
+	return &evmGuard{
+		csdb:             csdb,
+		checkMethods:     checkMethods,
+		checkDeployments: checkDeployments,
+	}
+}
+
+// CaptureState runs the checks before the opcode executes. A call frame is checked on its first opcode, and a frame
+// without any code never calls a method.
+func (g *evmGuard) CaptureState(_ *vm.EVM, pc uint64, op vm.OpCode, _, _ uint64, scope *vm.ScopeContext, _ []byte, _ int, err error) {
+	// the denial only applies to the CREATE or CREATE2 it's captured on
+	g.deniedDeployer = nil
+	if err != nil {
+		return
+	}
+	if g.checkMethods && pc == 0 {
+		g.checkMethod(scope.Contract)
+	}
+	if g.checkDeployments && (op == vm.CREATE || op == vm.CREATE2) {
+		g.checkDeployment(scope)
+	}
+}
+
+// canTransfer is the CanTransfer of the evm, which is called by a CREATE or CREATE2 before the nonce of the deployer
+// increases. The create frame of a denied deployment fails as if the deployer were short of balance, while the frame
+// of the deployer goes on.
+func (g *evmGuard) canTransfer(db vm.StateDB, addr ethcmn.Address, amount *big.Int) bool {
+	if g.deniedDeployer != nil && *g.deniedDeployer == addr {
+		g.deniedDeployer = nil
+		return false
+	}
+	return core.CanTransfer(db, addr, amount)
+}
+
+// nolint
+func (g *evmGuard) CaptureStart(*vm.EVM, ethcmn.Address, ethcmn.Address, bool, []byte, uint64, *big.Int) {
+}
+func (g *evmGuard) CaptureFault(*vm.EVM, uint64, vm.OpCode, uint64, uint64, *vm.ScopeContext, int, error) {
+}
+func (g *evmGuard) CaptureEnd([]byte, uint64, time.Duration, error) {}
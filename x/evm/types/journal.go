@@ -192,6 +192,17 @@ type (
 		address *ethcmn.Address
 		slot    *ethcmn.Hash
 	}
+
+	// Changes of the stateful precompiles.
+	precompileChange struct {
+		prev   sdk.Context                 // context of the state db before the precompile branched it
+		cached map[ethcmn.Address]struct{} // live objects before the precompile
+	}
+
+	coinsChange struct {
+		account *ethcmn.Address
+		prev    sdk.Coins
+	}
 )
 
 func (ch createObjectChange) revert(s *CommitStateDB) {
@@ -372,3 +383,33 @@ func (ch accessListAddSlotChange) revert(s *CommitStateDB) {
 func (ch accessListAddSlotChange) dirtied() *ethcmn.Address {
 	return nil
 }
+
+func (ch precompileChange) revert(s *CommitStateDB) {
+	s.ctx = ch.prev
+	s.precompileWrites = s.precompileWrites[:len(s.precompileWrites)-1]
+
+	// the objects loaded from the branch are loaded from the previous context again
+	var loaded []ethcmn.Address
+	for _, entry := range s.stateObjects {
+		if _, ok := ch.cached[entry.address]; !ok {
+			loaded = append(loaded, entry.address)
+		}
+	}
+	for _, addr := range loaded {
+		s.dropStateObject(addr)
+	}
+}
+
+func (ch precompileChange) dirtied() *ethcmn.Address {
+	return nil
+}
+
+func (ch coinsChange) revert(s *CommitStateDB) {
+	if err := s.getStateObject(*ch.account).account.SetCoins(ch.prev); err != nil {
+		panic(err)
+	}
+}
+
+func (ch coinsChange) dirtied() *ethcmn.Address {
+	return ch.account
+}
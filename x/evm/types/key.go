@@ -28,6 +28,12 @@ var (
 	KeyPrefixHeightHash                  = []byte{0x07}
 	KeyPrefixContractDeploymentWhitelist = []byte{0x08}
 	KeyPrefixContractBlockedList         = []byte{0x09}
+	KeyPrefixContractDeploymentCodeHash  = []byte{0x0A}
+	KeyPrefixContractDeploymentFactory   = []byte{0x0B}
+	KeyPrefixContractMethodBlockedList   = []byte{0x0C}
+
+	// KeyContractMethodBlockedListCount is the key of the count of the methods in the method blocked list
+	KeyContractMethodBlockedListCount = []byte{0x0D}
 )
 
 // HeightHashKey returns the key for the given chain epoch and height.
@@ -59,6 +65,31 @@ func splitApprovedDeployerAddress(key []byte) sdk.AccAddress {
 	return key[1:]
 }
 
+// GetContractDeploymentCodeHashKey builds the key for an approved code hash of the init code of the contracts
+func GetContractDeploymentCodeHashKey(codeHash ethcmn.Hash) []byte {
+	return append(KeyPrefixContractDeploymentCodeHash, codeHash.Bytes()...)
+}
+
+// splitApprovedCodeHash splits the code hash from a ContractDeploymentCodeHashKey
+func splitApprovedCodeHash(key []byte) ethcmn.Hash {
+	return ethcmn.BytesToHash(key[1:])
+}
+
+// GetContractDeploymentFactoryPrefix returns a prefix to iterate over the code hashes a factory is allowed to deploy
+func GetContractDeploymentFactoryPrefix(factoryAddr sdk.AccAddress) []byte {
+	return append(append([]byte{}, KeyPrefixContractDeploymentFactory...), factoryAddr...)
+}
+
+// GetContractDeploymentFactoryKey builds the key for a code hash a factory is allowed to deploy
+func GetContractDeploymentFactoryKey(factoryAddr sdk.AccAddress, codeHash ethcmn.Hash) []byte {
+	return append(GetContractDeploymentFactoryPrefix(factoryAddr), codeHash.Bytes()...)
+}
+
+// splitFactoryCodeHash splits the factory address and the code hash from a ContractDeploymentFactoryKey
+func splitFactoryCodeHash(key []byte) (sdk.AccAddress, ethcmn.Hash) {
+	return key[1 : len(key)-ethcmn.HashLength], ethcmn.BytesToHash(key[len(key)-ethcmn.HashLength:])
+}
+
 // getContractBlockedListMemberKey builds the key for a blocked contract address
 func GetContractBlockedListMemberKey(contractAddr sdk.AccAddress) []byte {
 	return append(KeyPrefixContractBlockedList, contractAddr...)
@@ -67,4 +98,20 @@ func GetContractBlockedListMemberKey(contractAddr sdk.AccAddress) []byte {
 // splitBlockedContractAddress splits the blocked contract address from a ContractBlockedListMemberKey
 func splitBlockedContractAddress(key []byte) sdk.AccAddress {
 	return key[1:]
-}
\ No newline at end of file
+}
+
+// GetContractMethodBlockedListPrefix returns a prefix to iterate over the blocked methods of a contract
+func GetContractMethodBlockedListPrefix(contractAddr sdk.AccAddress) []byte {
+	return append(append([]byte{}, KeyPrefixContractMethodBlockedList...), contractAddr...)
+}
+
+// GetContractMethodBlockedListKey builds the key for a blocked method of a contract
+func GetContractMethodBlockedListKey(contractAddr sdk.AccAddress, method MethodSelector) []byte {
+	return append(GetContractMethodBlockedListPrefix(contractAddr), method[:]...)
+}
+
+// splitBlockedContractMethod splits the contract address and the method selector from a ContractMethodBlockedListKey
+func splitBlockedContractMethod(key []byte) (contractAddr sdk.AccAddress, method MethodSelector) {
+	copy(method[:], key[len(key)-len(method):])
+	return key[1 : len(key)-len(method)], method
+}
@@ -0,0 +1,130 @@
+package types
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// SetContractMethodBlockedList sets the methods of the contracts into the method blocked list store
+func (csdb *CommitStateDB) SetContractMethodBlockedList(contractList BlockedContractList) {
+	if csdb.Watcher.Enabled() {
+		for i := 0; i < len(contractList); i++ {
+			for _, method := range contractList[i].BlockMethods {
+				csdb.Watcher.SaveContractMethodBlockedListItem(contractList[i].Address, method)
+			}
+		}
+	}
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	count := csdb.GetContractMethodBlockedListCount()
+	for i := 0; i < len(contractList); i++ {
+		for _, method := range contractList[i].BlockMethods {
+			key := GetContractMethodBlockedListKey(contractList[i].Address, method)
+			if !store.Has(key) {
+				store.Set(key, []byte(""))
+				count++
+			}
+		}
+	}
+	csdb.setContractMethodBlockedListCount(count)
+}
+
+// DeleteContractMethodBlockedList deletes the methods of the contracts from the method blocked list store
+func (csdb *CommitStateDB) DeleteContractMethodBlockedList(contractList BlockedContractList) {
+	if csdb.Watcher.Enabled() {
+		for i := 0; i < len(contractList); i++ {
+			for _, method := range contractList[i].BlockMethods {
+				csdb.Watcher.DeleteContractMethodBlockedList(contractList[i].Address, method)
+			}
+		}
+	}
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	count := csdb.GetContractMethodBlockedListCount()
+	for i := 0; i < len(contractList); i++ {
+		for _, method := range contractList[i].BlockMethods {
+			key := GetContractMethodBlockedListKey(contractList[i].Address, method)
+			if store.Has(key) {
+				store.Delete(key)
+				count--
+			}
+		}
+	}
+	csdb.setContractMethodBlockedListCount(count)
+}
+
+// GetContractMethodBlockedListCount gets the count of the methods in the method blocked list, which tells whether the
+// call frames need to be checked without iterating the store
+func (csdb *CommitStateDB) GetContractMethodBlockedListCount() uint64 {
+	bs := csdb.dbAdapter.NewStore(csdb.ctx.KVStore(csdb.storeKey), KeyContractMethodBlockedListCount)
+	bz := bs.Get([]byte{})
+	if len(bz) == 0 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (csdb *CommitStateDB) setContractMethodBlockedListCount(count uint64) {
+	if csdb.Watcher.Enabled() {
+		csdb.Watcher.SaveContractMethodBlockedListCount(count)
+	}
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	if count == 0 {
+		store.Delete(KeyContractMethodBlockedListCount)
+		return
+	}
+	store.Set(KeyContractMethodBlockedListCount, sdk.Uint64ToBigEndian(count))
+}
+
+// GetContractMethodBlockedList gets all the contracts with their blocked methods currently
+func (csdb *CommitStateDB) GetContractMethodBlockedList() (contractList BlockedContractList) {
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, KeyPrefixContractMethodBlockedList)
+	defer iterator.Close()
+
+	// the keys of a contract are adjacent in the iteration
+	for ; iterator.Valid(); iterator.Next() {
+		contractAddr, method := splitBlockedContractMethod(iterator.Key())
+		if n := len(contractList); n != 0 && contractList[n-1].Address.Equals(contractAddr) {
+			contractList[n-1].BlockMethods = append(contractList[n-1].BlockMethods, method)
+		} else {
+			contractList = append(contractList, BlockedContract{
+				Address:      contractAddr,
+				BlockMethods: MethodList{method},
+			})
+		}
+	}
+
+	return
+}
+
+// IsContractMethodBlocked checks whether the method of the contract is in the method blocked list
+func (csdb *CommitStateDB) IsContractMethodBlocked(contractAddr sdk.AccAddress, method MethodSelector) bool {
+	bs := csdb.dbAdapter.NewStore(csdb.ctx.KVStore(csdb.storeKey), KeyPrefixContractMethodBlockedList)
+	return bs.Has(GetContractMethodBlockedListKey(contractAddr, method)[len(KeyPrefixContractMethodBlockedList):])
+}
+
+// blockedContractMethod is the panic of a call to a blocked method, which is recovered by the state transition
+type blockedContractMethod struct {
+	contract ethcmn.Address
+	method   MethodSelector
+}
+
+// checkMethod checks the method called by the call frame of the contract. The code address is checked, which is the
+// contract whose method runs in the context of the caller for a DELEGATECALL or CALLCODE.
+func (g *evmGuard) checkMethod(contract *vm.Contract) {
+	var method MethodSelector
+	if len(contract.Input) < len(method) {
+		return
+	}
+	copy(method[:], contract.Input)
+
+	codeAddr := contract.Address()
+	if contract.CodeAddr != nil {
+		codeAddr = *contract.CodeAddr
+	}
+	if g.csdb.IsContractMethodBlocked(codeAddr.Bytes(), method) {
+		panic(blockedContractMethod{contract: codeAddr, method: method})
+	}
+}
@@ -1,10 +1,12 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 
 	"gopkg.in/yaml.v2"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/okex/exchain/x/params"
 )
@@ -23,6 +25,8 @@ var (
 	ParamStoreKeyContractDeploymentWhitelist = []byte("EnableContractDeploymentWhitelist")
 	ParamStoreKeyContractBlockedList         = []byte("EnableContractBlockedList")
 	ParamStoreKeyMaxGasLimitPerTx            = []byte("MaxGasLimitPerTx")
+	ParamStoreKeyEnabledPrecompiles          = []byte("EnabledPrecompiles")
+	ParamStoreKeyContractFactoryWhitelist    = []byte("EnableContractFactoryWhitelist")
 )
 
 // ParamKeyTable returns the parameter key table.
@@ -44,6 +48,12 @@ type Params struct {
 	EnableContractBlockedList bool `json:"enable_contract_blocked_list" yaml:"enable_contract_blocked_list"`
 	// MaxGasLimit defines the max gas limit in transaction
 	MaxGasLimitPerTx uint64 `json:"max_gas_limit_per_tx" yaml:"max_gas_limit_per_tx"`
+	// EnabledPrecompiles defines the names of the stateful precompiles which can be called
+	EnabledPrecompiles []string `json:"enabled_precompiles" yaml:"enabled_precompiles"`
+	// EnableContractFactoryWhitelist controls the authorization of the contracts created by the other contracts when
+	// the contract deployment whitelist is enabled. The factories deployed before have to be granted the code hashes
+	// they deploy by the ManageContractDeploymentFactoryProposal before it's enabled.
+	EnableContractFactoryWhitelist bool `json:"enable_contract_factory_whitelist" yaml:"enable_contract_factory_whitelist"`
 }
 
 // NewParams creates a new Params instance
@@ -68,6 +78,16 @@ func DefaultParams() Params {
 		EnableContractDeploymentWhitelist: false,
 		EnableContractBlockedList:         false,
 		MaxGasLimitPerTx:                  DefaultMaxGasLimitPerTx,
+		EnabledPrecompiles:                []string(nil),
+		EnableContractFactoryWhitelist:    false,
+	}
+}
+
+// GetParamSetIfExists gets the params from the subspace, where the params never set keep their values in ps. The params
+// added after the genesis of a running chain aren't in the param store until a proposal changes them.
+func GetParamSetIfExists(ctx sdk.Context, subspace Subspace, ps params.ParamSet) {
+	for _, pair := range ps.ParamSetPairs() {
+		subspace.GetIfExists(ctx, pair.Key, pair.Value)
 	}
 }
 
@@ -86,12 +106,27 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		params.NewParamSetPair(ParamStoreKeyContractDeploymentWhitelist, &p.EnableContractDeploymentWhitelist, validateBool),
 		params.NewParamSetPair(ParamStoreKeyContractBlockedList, &p.EnableContractBlockedList, validateBool),
 		params.NewParamSetPair(ParamStoreKeyMaxGasLimitPerTx, &p.MaxGasLimitPerTx, validateUint64),
+		params.NewParamSetPair(ParamStoreKeyEnabledPrecompiles, &p.EnabledPrecompiles, validatePrecompiles),
+		params.NewParamSetPair(ParamStoreKeyContractFactoryWhitelist, &p.EnableContractFactoryWhitelist, validateBool),
 	}
 }
 
 // Validate performs basic validation on evm parameters.
 func (p Params) Validate() error {
-	return validateEIPs(p.ExtraEIPs)
+	if err := validateEIPs(p.ExtraEIPs); err != nil {
+		return err
+	}
+	return validatePrecompiles(p.EnabledPrecompiles)
+}
+
+// IsPrecompileEnabled returns whether the stateful precompile with the name is enabled
+func (p Params) IsPrecompileEnabled(name string) bool {
+	for _, enabled := range p.EnabledPrecompiles {
+		if enabled == name {
+			return true
+		}
+	}
+	return false
 }
 
 func validateBool(i interface{}) error {
@@ -124,3 +159,23 @@ func validateUint64(i interface{}) error {
 	}
 	return nil
 }
+
+func validatePrecompiles(i interface{}) error {
+	names, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid precompile slice type: %T", i)
+	}
+
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if len(name) == 0 {
+			return errors.New("empty precompile name")
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("duplicated precompile %s", name)
+		}
+		seen[name] = struct{}{}
+	}
+
+	return nil
+}
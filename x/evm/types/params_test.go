@@ -26,6 +26,13 @@ func TestParamsValidate(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"duplicated precompile",
+			Params{
+				EnabledPrecompiles: []string{"token", "token"},
+			},
+			true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -46,6 +53,18 @@ func TestParamsValidatePriv(t *testing.T) {
 	require.NoError(t, validateEIPs([]int{1884}))
 	require.NoError(t, validateUint64(uint64(30000000)))
 	require.Error(t, validateUint64("test"))
+	require.Error(t, validatePrecompiles(""))
+	require.Error(t, validatePrecompiles([]string{""}))
+	require.NoError(t, validatePrecompiles([]string{"token", "farm"}))
+}
+
+func TestParams_IsPrecompileEnabled(t *testing.T) {
+	params := DefaultParams()
+	require.False(t, params.IsPrecompileEnabled("token"))
+
+	params.EnabledPrecompiles = []string{"token"}
+	require.True(t, params.IsPrecompileEnabled("token"))
+	require.False(t, params.IsPrecompileEnabled("farm"))
 }
 
 func TestParams_String(t *testing.T) {
@@ -55,6 +74,8 @@ extra_eips: []
 enable_contract_deployment_whitelist: false
 enable_contract_blocked_list: false
 max_gas_limit_per_tx: 30000000
+enabled_precompiles: []
+enable_contract_factory_whitelist: false
 `
 	require.True(t, strings.EqualFold(expectedParamsStr, DefaultParams().String()))
 }
@@ -0,0 +1,298 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// Precompile is a stateful precompiled contract at a fixed address, which runs against the states of the cosmos
+// modules on behalf of its caller
+type Precompile interface {
+	// Name is the name enabling the precompile in the params
+	Name() string
+	Address() ethcmn.Address
+	// RequiredGas returns the gas of the input, which must be deterministic
+	RequiredGas(input []byte) uint64
+	// Run executes the input on a branch of the state. The events returned are emitted as the EVM logs of the
+	// precompile as well as the events of the tx
+	Run(ctx sdk.Context, caller ethcmn.Address, input []byte) ([]byte, sdk.Events, error)
+}
+
+var (
+	// MILESTONE_PRECOMPILE_HEIGHT is the upgrade height set by the ldflags like the milestones of the sdk, after which the
+	// precompiles enabled by the params are active. The precompiles are never active if it's not set.
+	MILESTONE_PRECOMPILE_HEIGHT string
+
+	precompilesMtx sync.RWMutex
+	precompiles    = make(map[ethcmn.Address]Precompile)
+
+	// geth looks the precompiled contracts up in its package maps and runs them without the evm, so the evm with the
+	// precompiles active runs alone and binds its state db, while the others run concurrently and see none bound
+	evmMtx       sync.RWMutex
+	boundStateDB *CommitStateDB
+
+	precompileLogArgs abi.Arguments
+	revertArgs        abi.Arguments
+	revertSelector    = ethcrypto.Keccak256([]byte("Error(string)"))[:4]
+)
+
+func init() {
+	stringType, _ := abi.NewType("string", "", nil)
+	stringsType, _ := abi.NewType("string[]", "", nil)
+	precompileLogArgs = abi.Arguments{{Name: "keys", Type: stringsType}, {Name: "values", Type: stringsType}}
+	revertArgs = abi.Arguments{{Type: stringType}}
+}
+
+// HigherThanPrecompileHeight returns whether the height is after the upgrade height of the precompiles
+func HigherThanPrecompileHeight(height int64) bool {
+	milestone, err := strconv.ParseInt(MILESTONE_PRECOMPILE_HEIGHT, 10, 64)
+	return err == nil && milestone != 0 && height > milestone
+}
+
+// RegisterPrecompile registers the stateful precompile, whose address is reserved in the precompiled contracts of geth
+// for the evms it's active in. A precompile registered again replaces the former one at its address
+func RegisterPrecompile(p Precompile) {
+	precompilesMtx.Lock()
+	defer precompilesMtx.Unlock()
+
+	addr := p.Address()
+	if _, ok := precompiles[addr]; !ok {
+		// the active precompiles of the access lists are left as they are, as they are the same as an empty account
+		// in the evms the precompile isn't active in
+		contract := precompiledContract{address: addr}
+		vm.PrecompiledContractsHomestead[addr] = contract
+		vm.PrecompiledContractsByzantium[addr] = contract
+		vm.PrecompiledContractsIstanbul[addr] = contract
+		vm.PrecompiledContractsBerlin[addr] = contract
+	}
+	precompiles[addr] = p
+}
+
+// GetPrecompile returns the stateful precompile at the address
+func GetPrecompile(addr ethcmn.Address) (Precompile, bool) {
+	precompilesMtx.RLock()
+	defer precompilesMtx.RUnlock()
+	p, ok := precompiles[addr]
+	return p, ok
+}
+
+// activePrecompiles returns the precompiles enabled by the params, if the height is after the upgrade height
+func activePrecompiles(height int64, params Params) map[ethcmn.Address]Precompile {
+	if len(params.EnabledPrecompiles) == 0 || !HigherThanPrecompileHeight(height) {
+		return nil
+	}
+
+	precompilesMtx.RLock()
+	defer precompilesMtx.RUnlock()
+	active := make(map[ethcmn.Address]Precompile)
+	for addr, p := range precompiles {
+		if params.IsPrecompileEnabled(p.Name()) {
+			active[addr] = p
+		}
+	}
+	return active
+}
+
+// precompiledContract dispatches the calls of the EVM to the precompile active in the evm running. It's the same as an
+// empty account in the other evms, whose calls cost no gas and return nothing
+type precompiledContract struct {
+	address ethcmn.Address
+}
+
+func (c precompiledContract) RequiredGas(input []byte) uint64 {
+	p, ok := boundPrecompile(c.address)
+	if !ok {
+		return 0
+	}
+	return p.RequiredGas(input)
+}
+
+func (c precompiledContract) Run(input []byte) ([]byte, error) {
+	p, ok := boundPrecompile(c.address)
+	if !ok {
+		return nil, nil
+	}
+	return boundStateDB.runPrecompile(p, input)
+}
+
+// boundPrecompile returns the precompile at the address if it's active in the evm running. It's called by the evm
+// holding the lock of bindPrecompiles only.
+func boundPrecompile(addr ethcmn.Address) (Precompile, bool) {
+	if boundStateDB == nil {
+		return nil, false
+	}
+	p, ok := boundStateDB.precompiles[addr]
+	return p, ok
+}
+
+// bindPrecompiles adds the precompiles active at the height of the state db to it, which binds it to the precompiled
+// contracts of geth until the returned function runs. The function also drops the precompile writes not committed.
+func bindPrecompiles(csdb *CommitStateDB, params Params) func() {
+	active := activePrecompiles(csdb.ctx.BlockHeight(), params)
+	if len(active) == 0 {
+		evmMtx.RLock()
+		return evmMtx.RUnlock
+	}
+
+	evmMtx.Lock()
+	csdb.precompiles = active
+	boundStateDB = csdb
+	return func() {
+		boundStateDB = nil
+		csdb.precompiles = nil
+		csdb.discardPrecompiles()
+		evmMtx.Unlock()
+	}
+}
+
+// precompileCall is the CALL into a precompile whose value has been transferred and which is about to run
+type precompileCall struct {
+	caller  ethcmn.Address
+	address ethcmn.Address
+	value   *big.Int
+}
+
+// precompileWrite is the branch of the state written by a precompile, which is written into its parent when the
+// state transition commits
+type precompileWrite struct {
+	parent sdk.Context
+	write  func()
+	events sdk.Events
+}
+
+// recordPrecompileCall records the transfer of a CALL if it goes to a precompile. Every CALL, STATICCALL,
+// DELEGATECALL and CALLCODE takes a snapshot before the run, which clears the record, so only a CALL reaches a
+// precompile with its record
+func (csdb *CommitStateDB) recordPrecompileCall(from, to ethcmn.Address, amount *big.Int) {
+	if _, ok := csdb.precompiles[to]; !ok {
+		csdb.precompileCall = nil
+		return
+	}
+	csdb.precompileCall = &precompileCall{caller: from, address: to, value: amount}
+}
+
+func (csdb *CommitStateDB) runPrecompile(p Precompile, input []byte) ([]byte, error) {
+	call := csdb.precompileCall
+	csdb.precompileCall = nil
+
+	switch {
+	case call == nil || call.address != p.Address():
+		return revertPrecompile(errors.New("precompile can only be called by CALL"))
+	case call.value != nil && call.value.Sign() != 0:
+		return revertPrecompile(fmt.Errorf("precompile %s is not payable", p.Name()))
+	}
+
+	parent := csdb.ctx
+	cacheCtx, write := parent.CacheContext()
+	// the accounts modified by the evm are seen by the modules
+	for _, dirty := range csdb.journal.dirties {
+		if idx, ok := csdb.addressToObjectIndex[dirty.address]; ok {
+			if so := csdb.stateObjects[idx].stateObject; !so.deleted && !so.suicided {
+				csdb.accountKeeper.SetAccount(cacheCtx, so.account)
+			}
+		}
+	}
+
+	ret, events, err := p.Run(cacheCtx, call.caller, input)
+	if err != nil {
+		return revertPrecompile(err)
+	}
+
+	cached := make(map[ethcmn.Address]struct{}, len(csdb.stateObjects))
+	for _, entry := range csdb.stateObjects {
+		cached[entry.address] = struct{}{}
+	}
+	csdb.journal.append(precompileChange{prev: parent, cached: cached})
+	csdb.precompileWrites = append(csdb.precompileWrites, precompileWrite{parent: parent, write: write, events: events})
+	csdb.ctx = cacheCtx
+
+	// and the accounts modified by the modules are seen by the evm
+	for _, entry := range csdb.stateObjects {
+		so := entry.stateObject
+		if so.deleted {
+			continue
+		}
+		acc := csdb.accountKeeper.GetAccount(cacheCtx, so.account.GetAddress())
+		if acc == nil || acc.GetCoins().IsEqual(so.account.GetCoins()) {
+			continue
+		}
+		csdb.journal.append(coinsChange{account: &so.address, prev: so.account.GetCoins()})
+		if err := so.account.SetCoins(acc.GetCoins()); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, event := range events {
+		csdb.AddLog(newPrecompileLog(p.Address(), event))
+	}
+	return ret, nil
+}
+
+// commitPrecompiles writes the branches of the precompiles down to the context of the state transition and emits
+// their events
+func (csdb *CommitStateDB) commitPrecompiles() {
+	if len(csdb.precompileWrites) == 0 {
+		return
+	}
+
+	for i := len(csdb.precompileWrites) - 1; i >= 0; i-- {
+		csdb.precompileWrites[i].write()
+	}
+	base := csdb.precompileWrites[0].parent
+	for _, w := range csdb.precompileWrites {
+		base.EventManager().EmitEvents(w.events)
+	}
+	csdb.ctx = base
+	csdb.precompileWrites = nil
+}
+
+// discardPrecompiles drops the branches of the precompiles not committed
+func (csdb *CommitStateDB) discardPrecompiles() {
+	csdb.precompileCall = nil
+	if len(csdb.precompileWrites) == 0 {
+		return
+	}
+	csdb.ctx = csdb.precompileWrites[0].parent
+	csdb.precompileWrites = nil
+}
+
+// dropStateObject removes the live object of the address, which is loaded from the store again when needed
+func (csdb *CommitStateDB) dropStateObject(addr ethcmn.Address) {
+	createObjectChange{account: &addr}.revert(csdb)
+}
+
+// newPrecompileLog mirrors the module event as an EVM log of the precompile, whose topic is the hash of the event
+// type and whose data is the abi encoding of the keys and values of the attributes
+func newPrecompileLog(addr ethcmn.Address, event sdk.Event) *ethtypes.Log {
+	keys := make([]string, len(event.Attributes))
+	values := make([]string, len(event.Attributes))
+	for i, attr := range event.Attributes {
+		keys[i], values[i] = string(attr.Key), string(attr.Value)
+	}
+	data, _ := precompileLogArgs.Pack(keys, values)
+
+	return &ethtypes.Log{
+		Address: addr,
+		Topics:  []ethcmn.Hash{ethcrypto.Keccak256Hash([]byte(event.Type))},
+		Data:    data,
+	}
+}
+
+// revertPrecompile reverts the precompile call with the error as the solidity revert reason
+func revertPrecompile(err error) ([]byte, error) {
+	reason, packErr := revertArgs.Pack(err.Error())
+	if packErr != nil {
+		return nil, vm.ErrExecutionReverted
+	}
+	return append(append([]byte{}, revertSelector...), reason...), vm.ErrExecutionReverted
+}
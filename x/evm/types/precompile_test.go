@@ -0,0 +1,127 @@
+package types_test
+
+import (
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	ethermint "github.com/okex/exchain/app/types"
+	"github.com/okex/exchain/x/evm/precompile"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+const transferABI = `[{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"denom","type":"string"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}]`
+
+// callerCode copies the calldata, calls the token precompile with it and then stops or reverts
+func callerCode(revert bool) []byte {
+	code := []byte{0x36, 0x60, 0x00, 0x60, 0x00, 0x37, 0x60, 0x00, 0x60, 0x00, 0x36, 0x60, 0x00, 0x60, 0x00, 0x73}
+	code = append(code, precompile.TokenPrecompileAddress.Bytes()...)
+	code = append(code, 0x5a, 0xf1)
+	if revert {
+		return append(code, 0x60, 0x00, 0x60, 0x00, 0xfd)
+	}
+	return append(code, 0x00)
+}
+
+func (suite *StateDBTestSuite) TestPrecompile() {
+	parsed, err := abi.JSON(strings.NewReader(transferABI))
+	suite.Require().NoError(err)
+
+	priv, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	recipient := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
+	payload, err := parsed.Pack("transfer", recipient, sdk.DefaultBondDenom, sdk.NewDec(10).BigInt())
+	suite.Require().NoError(err)
+
+	contract := ethcmn.BytesToAddress([]byte("precompile caller"))
+
+	// the precompiles are active after the height 1
+	defer func(milestone string) { types.MILESTONE_PRECOMPILE_HEIGHT = milestone }(types.MILESTONE_PRECOMPILE_HEIGHT)
+	types.MILESTONE_PRECOMPILE_HEIGHT = "1"
+
+	testCases := []struct {
+		name         string
+		height       int64
+		enabled      bool
+		to           ethcmn.Address
+		code         []byte
+		expPass      bool
+		expRecipient sdk.Dec
+	}{
+		// a precompile not active is the same as an empty account
+		{"disabled", 2, false, precompile.TokenPrecompileAddress, nil, true, sdk.ZeroDec()},
+		{"before the upgrade height", 1, true, precompile.TokenPrecompileAddress, nil, true, sdk.ZeroDec()},
+		{"called by the sender", 2, true, precompile.TokenPrecompileAddress, nil, true, sdk.NewDec(10)},
+		{"called by a contract", 2, true, contract, callerCode(false), true, sdk.NewDec(10)},
+		{"reverted by the contract", 2, true, contract, callerCode(true), false, sdk.ZeroDec()},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter()).WithBlockHeight(tc.height)
+			addr := sdk.AccAddress(suite.address.Bytes())
+			acc := suite.app.AccountKeeper.GetAccount(suite.ctx, addr)
+			suite.Require().NoError(acc.SetCoins(sdk.NewCoins(ethermint.NewPhotonCoin(sdk.NewInt(100)))))
+			suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+			params := suite.stateDB.GetParams()
+			if tc.enabled {
+				params.EnabledPrecompiles = []string{precompile.TokenPrecompileName}
+			}
+			suite.stateDB.SetParams(params)
+
+			sender := tc.to
+			if tc.code != nil {
+				suite.stateDB.SetCode(contract, tc.code)
+				suite.stateDB.SetBalance(contract, sdk.NewDec(100).BigInt())
+				_, err := suite.stateDB.Commit(false)
+				suite.Require().NoError(err)
+			} else {
+				sender = suite.address
+			}
+
+			csdb := types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), ctx)
+			st := types.StateTransition{
+				AccountNonce: 0,
+				Price:        big.NewInt(1),
+				GasLimit:     1000000,
+				Recipient:    &tc.to,
+				Amount:       big.NewInt(0),
+				Payload:      payload,
+				ChainID:      big.NewInt(1),
+				Csdb:         csdb,
+				TxHash:       &ethcmn.Hash{},
+				Sender:       suite.address,
+			}
+			res, _, err := st.TransitionDb(ctx, types.DefaultChainConfig())
+
+			recipientAcc := suite.app.AccountKeeper.GetAccount(ctx, recipient.Bytes())
+			recipientBalance := sdk.ZeroDec()
+			if recipientAcc != nil {
+				recipientBalance = recipientAcc.GetCoins().AmountOf(sdk.DefaultBondDenom)
+			}
+			suite.Require().True(tc.expRecipient.Equal(recipientBalance), recipientBalance.String())
+			senderBalance := suite.app.AccountKeeper.GetAccount(ctx, sender.Bytes()).GetCoins().AmountOf(sdk.DefaultBondDenom)
+			suite.Require().True(sdk.NewDec(100).Sub(tc.expRecipient).Equal(senderBalance), senderBalance.String())
+
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			if tc.expRecipient.IsZero() {
+				suite.Require().Empty(res.Logs)
+				return
+			}
+			suite.Require().NotEmpty(res.Logs)
+			for _, log := range res.Logs {
+				suite.Require().Equal(precompile.TokenPrecompileAddress, log.Address)
+			}
+		})
+	}
+}
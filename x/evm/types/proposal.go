@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
 	govtypes "github.com/okex/exchain/x/gov/types"
 )
 
@@ -14,26 +15,39 @@ const (
 	proposalTypeManageContractDeploymentWhitelist = "ManageContractDeploymentWhitelist"
 	// proposalTypeManageContractBlockedList defines the type for a ManageContractBlockedListProposal
 	proposalTypeManageContractBlockedList = "ManageContractBlockedList"
+	// proposalTypeManageContractDeploymentFactory defines the type for a ManageContractDeploymentFactoryProposal
+	proposalTypeManageContractDeploymentFactory = "ManageContractDeploymentFactory"
+	// proposalTypeManageContractMethodBlockedList defines the type for a ManageContractMethodBlockedListProposal
+	proposalTypeManageContractMethodBlockedList = "ManageContractMethodBlockedList"
 )
 
 func init() {
 	govtypes.RegisterProposalType(proposalTypeManageContractDeploymentWhitelist)
 	govtypes.RegisterProposalType(proposalTypeManageContractBlockedList)
+	govtypes.RegisterProposalType(proposalTypeManageContractDeploymentFactory)
+	govtypes.RegisterProposalType(proposalTypeManageContractMethodBlockedList)
 	govtypes.RegisterProposalTypeCodec(ManageContractDeploymentWhitelistProposal{}, "okexchain/evm/ManageContractDeploymentWhitelistProposal")
 	govtypes.RegisterProposalTypeCodec(ManageContractBlockedListProposal{}, "okexchain/evm/ManageContractBlockedListProposal")
+	govtypes.RegisterProposalTypeCodec(ManageContractDeploymentFactoryProposal{}, "okexchain/evm/ManageContractDeploymentFactoryProposal")
+	govtypes.RegisterProposalTypeCodec(ManageContractMethodBlockedListProposal{}, "okexchain/evm/ManageContractMethodBlockedListProposal")
 }
 
 var (
 	_ govtypes.Content = (*ManageContractDeploymentWhitelistProposal)(nil)
 	_ govtypes.Content = (*ManageContractBlockedListProposal)(nil)
+	_ govtypes.Content = (*ManageContractDeploymentFactoryProposal)(nil)
+	_ govtypes.Content = (*ManageContractMethodBlockedListProposal)(nil)
 )
 
-// ManageContractDeploymentWhitelistProposal - structure for the proposal to add or delete deployer addresses from whitelist
+// ManageContractDeploymentWhitelistProposal - structure for the proposal to add or delete deployer addresses and code
+// hashes of the init code from whitelist
 type ManageContractDeploymentWhitelistProposal struct {
 	Title            string      `json:"title" yaml:"title"`
 	Description      string      `json:"description" yaml:"description"`
 	DistributorAddrs AddressList `json:"distributor_addresses" yaml:"distributor_addresses"`
 	IsAdded          bool        `json:"is_added" yaml:"is_added"`
+	// the code hashes of the init code anyone is allowed to deploy
+	CodeHashes CodeHashList `json:"code_hashes,omitempty" yaml:"code_hashes,omitempty"`
 }
 
 // NewManageContractDeploymentWhitelistProposal creates a new instance of ManageContractDeploymentWhitelistProposal
@@ -89,7 +103,7 @@ func (mp ManageContractDeploymentWhitelistProposal) ValidateBasic() sdk.Error {
 	}
 
 	distributorAddrLen := len(mp.DistributorAddrs)
-	if distributorAddrLen == 0 {
+	if distributorAddrLen == 0 && len(mp.CodeHashes) == 0 {
 		return ErrEmptyAddressList
 	}
 
@@ -101,7 +115,7 @@ func (mp ManageContractDeploymentWhitelistProposal) ValidateBasic() sdk.Error {
 		return ErrDuplicatedAddr
 	}
 
-	return nil
+	return validateCodeHashes(mp.CodeHashes)
 }
 
 // String returns a human readable string representation of a ManageContractDeploymentWhitelistProposal
@@ -124,6 +138,15 @@ func (mp ManageContractDeploymentWhitelistProposal) String() string {
 		builder.Write([]byte{'\n'})
 	}
 
+	if len(mp.CodeHashes) != 0 {
+		builder.WriteString(" CodeHashes:\n")
+		for i := 0; i < len(mp.CodeHashes); i++ {
+			builder.WriteString("\t\t\t\t\t\t")
+			builder.WriteString(mp.CodeHashes[i].Hex())
+			builder.Write([]byte{'\n'})
+		}
+	}
+
 	return strings.TrimSpace(builder.String())
 }
 
@@ -239,3 +262,248 @@ func (mp ManageContractBlockedListProposal) String() string {
 
 	return strings.TrimSpace(builder.String())
 }
+
+// ManageContractDeploymentFactoryProposal - structure for the proposal to add or delete the code hashes of the init code
+// a factory contract is allowed to deploy, without allowing the factory to deploy any contract as a distributor
+type ManageContractDeploymentFactoryProposal struct {
+	Title       string         `json:"title" yaml:"title"`
+	Description string         `json:"description" yaml:"description"`
+	FactoryAddr sdk.AccAddress `json:"factory_address" yaml:"factory_address"`
+	CodeHashes  CodeHashList   `json:"code_hashes" yaml:"code_hashes"`
+	IsAdded     bool           `json:"is_added" yaml:"is_added"`
+}
+
+// NewManageContractDeploymentFactoryProposal creates a new instance of ManageContractDeploymentFactoryProposal
+func NewManageContractDeploymentFactoryProposal(title, description string, factoryAddr sdk.AccAddress,
+	codeHashes CodeHashList, isAdded bool,
+) ManageContractDeploymentFactoryProposal {
+	return ManageContractDeploymentFactoryProposal{
+		Title:       title,
+		Description: description,
+		FactoryAddr: factoryAddr,
+		CodeHashes:  codeHashes,
+		IsAdded:     isAdded,
+	}
+}
+
+// GetTitle returns title of a manage contract deployment factory proposal object
+func (mp ManageContractDeploymentFactoryProposal) GetTitle() string {
+	return mp.Title
+}
+
+// GetDescription returns description of a manage contract deployment factory proposal object
+func (mp ManageContractDeploymentFactoryProposal) GetDescription() string {
+	return mp.Description
+}
+
+// ProposalRoute returns route key of a manage contract deployment factory proposal object
+func (mp ManageContractDeploymentFactoryProposal) ProposalRoute() string {
+	return RouterKey
+}
+
+// ProposalType returns type of a manage contract deployment factory proposal object
+func (mp ManageContractDeploymentFactoryProposal) ProposalType() string {
+	return proposalTypeManageContractDeploymentFactory
+}
+
+// ValidateBasic validates a manage contract deployment factory proposal
+func (mp ManageContractDeploymentFactoryProposal) ValidateBasic() sdk.Error {
+	if len(strings.TrimSpace(mp.Title)) == 0 {
+		return govtypes.ErrInvalidProposalContent("title is required")
+	}
+	if len(mp.Title) > govtypes.MaxTitleLength {
+		return govtypes.ErrInvalidProposalContent("title length is longer than the maximum title length")
+	}
+
+	if len(mp.Description) == 0 {
+		return govtypes.ErrInvalidProposalContent("description is required")
+	}
+
+	if len(mp.Description) > govtypes.MaxDescriptionLength {
+		return govtypes.ErrInvalidProposalContent("description length is longer than the maximum description length")
+	}
+
+	if mp.ProposalType() != proposalTypeManageContractDeploymentFactory {
+		return govtypes.ErrInvalidProposalType(mp.ProposalType())
+	}
+
+	if len(mp.FactoryAddr) != ethcmn.AddressLength {
+		return govtypes.ErrInvalidProposalContent("factory address is required")
+	}
+
+	if len(mp.CodeHashes) == 0 {
+		return ErrEmptyCodeHashList
+	}
+
+	return validateCodeHashes(mp.CodeHashes)
+}
+
+// String returns a human readable string representation of a ManageContractDeploymentFactoryProposal
+func (mp ManageContractDeploymentFactoryProposal) String() string {
+	var builder strings.Builder
+	builder.WriteString(
+		fmt.Sprintf(`ManageContractDeploymentFactoryProposal:
+ Title:					%s
+ Description:        	%s
+ Type:                	%s
+ IsAdded:				%t
+ FactoryAddr:			%s
+ CodeHashes:
+`,
+			mp.Title, mp.Description, mp.ProposalType(), mp.IsAdded, mp.FactoryAddr.String()),
+	)
+
+	for i := 0; i < len(mp.CodeHashes); i++ {
+		builder.WriteString("\t\t\t\t\t\t")
+		builder.WriteString(mp.CodeHashes[i].Hex())
+		builder.Write([]byte{'\n'})
+	}
+
+	return strings.TrimSpace(builder.String())
+}
+
+// ManageContractMethodBlockedListProposal - structure for the proposal to add or delete the methods of contracts from
+// blocked list, which blocks the calls to the methods only instead of the whole contracts
+type ManageContractMethodBlockedListProposal struct {
+	Title        string              `json:"title" yaml:"title"`
+	Description  string              `json:"description" yaml:"description"`
+	ContractList BlockedContractList `json:"contract_list" yaml:"contract_list"`
+	IsAdded      bool                `json:"is_added" yaml:"is_added"`
+}
+
+// NewManageContractMethodBlockedListProposal creates a new instance of ManageContractMethodBlockedListProposal
+func NewManageContractMethodBlockedListProposal(title, description string, contractList BlockedContractList,
+	isAdded bool,
+) ManageContractMethodBlockedListProposal {
+	return ManageContractMethodBlockedListProposal{
+		Title:        title,
+		Description:  description,
+		ContractList: contractList,
+		IsAdded:      isAdded,
+	}
+}
+
+// GetTitle returns title of a manage contract method blocked list proposal object
+func (mp ManageContractMethodBlockedListProposal) GetTitle() string {
+	return mp.Title
+}
+
+// GetDescription returns description of a manage contract method blocked list proposal object
+func (mp ManageContractMethodBlockedListProposal) GetDescription() string {
+	return mp.Description
+}
+
+// ProposalRoute returns route key of a manage contract method blocked list proposal object
+func (mp ManageContractMethodBlockedListProposal) ProposalRoute() string {
+	return RouterKey
+}
+
+// ProposalType returns type of a manage contract method blocked list proposal object
+func (mp ManageContractMethodBlockedListProposal) ProposalType() string {
+	return proposalTypeManageContractMethodBlockedList
+}
+
+// ValidateBasic validates a manage contract method blocked list proposal
+func (mp ManageContractMethodBlockedListProposal) ValidateBasic() sdk.Error {
+	if len(strings.TrimSpace(mp.Title)) == 0 {
+		return govtypes.ErrInvalidProposalContent("title is required")
+	}
+	if len(mp.Title) > govtypes.MaxTitleLength {
+		return govtypes.ErrInvalidProposalContent("title length is longer than the maximum title length")
+	}
+
+	if len(mp.Description) == 0 {
+		return govtypes.ErrInvalidProposalContent("description is required")
+	}
+
+	if len(mp.Description) > govtypes.MaxDescriptionLength {
+		return govtypes.ErrInvalidProposalContent("description length is longer than the maximum description length")
+	}
+
+	if mp.ProposalType() != proposalTypeManageContractMethodBlockedList {
+		return govtypes.ErrInvalidProposalType(mp.ProposalType())
+	}
+
+	contractLen := len(mp.ContractList)
+	if contractLen == 0 {
+		return ErrEmptyAddressList
+	}
+
+	if contractLen > maxAddressListLength {
+		return ErrOversizeAddrList(contractLen)
+	}
+
+	contractAddrs := make(AddressList, contractLen)
+	for i := 0; i < contractLen; i++ {
+		if len(mp.ContractList[i].Address) != ethcmn.AddressLength {
+			return govtypes.ErrInvalidProposalContent("contract address is required")
+		}
+		contractAddrs[i] = mp.ContractList[i].Address
+		if err := validateMethods(mp.ContractList[i].BlockMethods); err != nil {
+			return err
+		}
+	}
+
+	if isAddrDuplicated(contractAddrs) {
+		return ErrDuplicatedAddr
+	}
+
+	return nil
+}
+
+// String returns a human readable string representation of a ManageContractMethodBlockedListProposal
+func (mp ManageContractMethodBlockedListProposal) String() string {
+	var builder strings.Builder
+	builder.WriteString(
+		fmt.Sprintf(`ManageContractMethodBlockedListProposal:
+ Title:					%s
+ Description:        	%s
+ Type:                	%s
+ IsAdded:				%t
+ ContractList:
+`,
+			mp.Title, mp.Description, mp.ProposalType(), mp.IsAdded),
+	)
+
+	for i := 0; i < len(mp.ContractList); i++ {
+		builder.WriteString("\t\t\t\t\t\t")
+		builder.WriteString(mp.ContractList[i].String())
+		builder.Write([]byte{'\n'})
+	}
+
+	return strings.TrimSpace(builder.String())
+}
+
+func validateMethods(methods MethodList) sdk.Error {
+	methodLen := len(methods)
+	if methodLen == 0 {
+		return ErrEmptyMethodList
+	}
+
+	filter := make(map[MethodSelector]struct{}, methodLen)
+	for i := 0; i < methodLen; i++ {
+		if _, ok := filter[methods[i]]; ok {
+			return ErrDuplicatedMethod
+		}
+		filter[methods[i]] = struct{}{}
+	}
+
+	return nil
+}
+
+func validateCodeHashes(codeHashes CodeHashList) sdk.Error {
+	codeHashLen := len(codeHashes)
+	if codeHashLen > maxAddressListLength {
+		return ErrOversizeAddrList(codeHashLen)
+	}
+
+	filter := make(map[ethcmn.Hash]struct{}, codeHashLen)
+	for i := 0; i < codeHashLen; i++ {
+		if _, ok := filter[codeHashes[i]]; ok {
+			return ErrDuplicatedCodeHash
+		}
+		filter[codeHashes[i]] = struct{}{}
+	}
+
+	return nil
+}
@@ -252,3 +252,152 @@ func (suite *ProposalTestSuite) TestProposal_ManageContractBlockedListProposal()
 		})
 	}
 }
+
+func (suite *ProposalTestSuite) TestProposal_ManageContractDeploymentFactoryProposal() {
+	codeHashes := CodeHashList{ethcmn.BytesToHash([]byte{0x1}), ethcmn.BytesToHash([]byte{0x2})}
+	proposal := NewManageContractDeploymentFactoryProposal(
+		expectedTitle,
+		expectedDescription,
+		suite.addrs[1],
+		codeHashes,
+		true,
+	)
+
+	suite.Require().Equal(expectedTitle, proposal.GetTitle())
+	suite.Require().Equal(expectedDescription, proposal.GetDescription())
+	suite.Require().Equal(RouterKey, proposal.ProposalRoute())
+	suite.Require().Equal(proposalTypeManageContractDeploymentFactory, proposal.ProposalType())
+
+	testCases := []struct {
+		msg           string
+		prepare       func()
+		expectedError bool
+	}{
+		{
+			"pass",
+			func() {},
+			false,
+		},
+		{
+			"duplicated code hashes",
+			func() {
+				proposal.CodeHashes = append(proposal.CodeHashes, proposal.CodeHashes[0])
+			},
+			true,
+		},
+		{
+			"empty code hashes",
+			func() {
+				proposal.CodeHashes = nil
+			},
+			true,
+		},
+		{
+			"empty factory address",
+			func() {
+				proposal.CodeHashes = codeHashes
+				proposal.FactoryAddr = nil
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			tc.prepare()
+
+			err := proposal.ValidateBasic()
+
+			if tc.expectedError {
+				suite.Require().Error(err)
+			} else {
+				suite.Require().NoError(err)
+			}
+		})
+	}
+}
+
+func (suite *ProposalTestSuite) TestProposal_ManageContractMethodBlockedListProposal() {
+	methods := MethodList{{0x40, 0xc1, 0x0f, 0x19}, {0xa9, 0x05, 0x9c, 0xbb}}
+	proposal := NewManageContractMethodBlockedListProposal(
+		expectedTitle,
+		expectedDescription,
+		BlockedContractList{{Address: suite.addrs[0], BlockMethods: methods}, {Address: suite.addrs[1], BlockMethods: methods}},
+		true,
+	)
+
+	suite.Require().Equal(expectedTitle, proposal.GetTitle())
+	suite.Require().Equal(expectedDescription, proposal.GetDescription())
+	suite.Require().Equal(RouterKey, proposal.ProposalRoute())
+	suite.Require().Equal(proposalTypeManageContractMethodBlockedList, proposal.ProposalType())
+
+	// the method selectors are encoded as the hex strings in json
+	bz := ModuleCdc.MustMarshalJSON(proposal)
+	suite.Require().Contains(string(bz), `"block_methods":["0x40c10f19","0xa9059cbb"]`)
+	var decoded ManageContractMethodBlockedListProposal
+	ModuleCdc.MustUnmarshalJSON(bz, &decoded)
+	suite.Require().Equal(proposal, decoded)
+	suite.Require().Error(ModuleCdc.UnmarshalJSON([]byte(`["0x40c10f"]`), &MethodList{}))
+
+	testCases := []struct {
+		msg           string
+		prepare       func()
+		expectedError bool
+	}{
+		{
+			"pass",
+			func() {},
+			false,
+		},
+		{
+			"duplicated methods",
+			func() {
+				proposal.ContractList[0].BlockMethods = append(methods, methods[0])
+			},
+			true,
+		},
+		{
+			"empty methods",
+			func() {
+				proposal.ContractList[0].BlockMethods = nil
+			},
+			true,
+		},
+		{
+			"duplicated contract addresses",
+			func() {
+				proposal.ContractList[0].BlockMethods = methods
+				proposal.ContractList[1].Address = suite.addrs[0]
+			},
+			true,
+		},
+		{
+			"empty contract address",
+			func() {
+				proposal.ContractList[1].Address = nil
+			},
+			true,
+		},
+		{
+			"empty contract list",
+			func() {
+				proposal.ContractList = nil
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			tc.prepare()
+
+			err := proposal.ValidateBasic()
+
+			if tc.expectedError {
+				suite.Require().Error(err)
+			} else {
+				suite.Require().NoError(err)
+			}
+		})
+	}
+}
@@ -25,8 +25,17 @@ const (
 	QuerySection                     = "section"
 	QueryContractDeploymentWhitelist = "contract-deployment-whitelist"
 	QueryContractBlockedList         = "contract-blocked-list"
+	QueryContractMethodBlockedList   = "contract-method-blocked-list"
+	QuerySimulate                    = "simulate"
+	QueryContractStats               = "contract-stats"
 )
 
+// QuerySimulateParams is the request of simulating a msg on the state with the overrides
+type QuerySimulateParams struct {
+	Msg       MsgEthermint   `json:"msg"`
+	Overrides StateOverrides `json:"overrides"`
+}
+
 // QueryResBalance is response type for balance query
 type QueryResBalance struct {
 	Balance string `json:"balance"`
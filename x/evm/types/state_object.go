@@ -75,6 +75,10 @@ type stateObject struct {
 	dirtyCode bool // true if the code was updated
 	suicided  bool
 	deleted   bool
+
+	// storageOverridden is true if the storage is replaced by a state override of the simulated calls, then the keys
+	// out of the origin storage read empty instead of the KVStore
+	storageOverridden bool
 }
 
 func newStateObject(db *CommitStateDB, accProto authexported.Account) *stateObject {
@@ -158,6 +162,20 @@ func (so *stateObject) setCode(codeHash ethcmn.Hash, code []byte) {
 	so.dirtyCode = true
 }
 
+// setStorage replaces the whole storage with the given one as the committed storage, which isn't journaled.
+func (so *stateObject) setStorage(storage map[ethcmn.Hash]ethcmn.Hash) {
+	so.originStorage = Storage{}
+	so.keyToOriginStorageIndex = make(map[ethcmn.Hash]int)
+	so.dirtyStorage = Storage{}
+	so.keyToDirtyStorageIndex = make(map[ethcmn.Hash]int)
+	for key, value := range storage {
+		prefixKey := so.GetStorageByAddressKey(key.Bytes())
+		so.originStorage = append(so.originStorage, NewState(prefixKey, value))
+		so.keyToOriginStorageIndex[prefixKey] = len(so.originStorage) - 1
+	}
+	so.storageOverridden = true
+}
+
 // AddBalance adds an amount to a state object's balance. It is used to add
 // funds to the destination account of a transfer.
 func (so *stateObject) AddBalance(amount *big.Int) {
@@ -244,6 +262,7 @@ func (so *stateObject) commitState() {
 		// delete empty values from the store
 		if (state.Value == ethcmn.Hash{}) {
 			store.Delete(state.Key.Bytes())
+			so.stateDB.storageWrites++
 			if !so.stateDB.ctx.IsCheckTx() {
 				if so.stateDB.Watcher.Enabled() {
 					so.stateDB.Watcher.SaveState(so.Address(), state.Key.Bytes(), ethcmn.Hash{}.Bytes())
@@ -270,6 +289,7 @@ func (so *stateObject) commitState() {
 
 		so.originStorage[idx].Value = state.Value
 		store.Set(state.Key.Bytes(), state.Value.Bytes())
+		so.stateDB.storageWrites++
 		if !so.stateDB.ctx.IsCheckTx() {
 			if so.stateDB.Watcher.Enabled() {
 				so.stateDB.Watcher.SaveState(so.Address(), state.Key.Bytes(), state.Value.Bytes())
@@ -371,6 +391,9 @@ func (so *stateObject) GetCommittedState(_ ethstate.Database, key ethcmn.Hash) e
 	if cached {
 		return so.originStorage[idx].Value
 	}
+	if so.storageOverridden {
+		return ethcmn.Hash{}
+	}
 
 	// otherwise load the value from the KVStore
 	state := NewState(prefixKey, ethcmn.Hash{})
@@ -415,6 +438,7 @@ func (so *stateObject) deepCopy(db *CommitStateDB) *stateObject {
 	newStateObj.suicided = so.suicided
 	newStateObj.dirtyCode = so.dirtyCode
 	newStateObj.deleted = so.deleted
+	newStateObj.storageOverridden = so.storageOverridden
 
 	return newStateObj
 }
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// StateOverrideAccount is the geth-style override of an account for the simulated calls, the fields which aren't nil
+// override the ones of the account. State replaces the whole storage while StateDiff overrides the given keys only
+type StateOverrideAccount struct {
+	Nonce     *hexutil.Uint64              `json:"nonce"`
+	Code      *hexutil.Bytes               `json:"code"`
+	Balance   **hexutil.Big                `json:"balance"`
+	State     *map[ethcmn.Hash]ethcmn.Hash `json:"state"`
+	StateDiff *map[ethcmn.Hash]ethcmn.Hash `json:"stateDiff"`
+}
+
+// StateOverrides is the set of the accounts overridden before a simulated call
+type StateOverrides map[ethcmn.Address]StateOverrideAccount
+
+// Check validates the overrides with the errors of geth
+func (overrides StateOverrides) Check() error {
+	for addr, account := range overrides {
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+	}
+	return nil
+}
+
+// Apply applies the overrides to the state db of a simulated call
+func (overrides StateOverrides) Apply(csdb *CommitStateDB) error {
+	if err := overrides.Check(); err != nil {
+		return err
+	}
+
+	for addr, account := range overrides {
+		if account.Nonce != nil {
+			csdb.SetNonce(addr, uint64(*account.Nonce))
+		}
+		if account.Code != nil {
+			csdb.SetCode(addr, *account.Code)
+		}
+		if account.Balance != nil {
+			balance := new(big.Int)
+			if *account.Balance != nil {
+				balance = (*account.Balance).ToInt()
+			}
+			csdb.SetBalance(addr, balance)
+		}
+		if account.State != nil {
+			csdb.SetStorage(addr, *account.State)
+		}
+		if account.StateDiff != nil {
+			for key, value := range *account.StateDiff {
+				csdb.SetState(addr, key, value)
+			}
+		}
+	}
+	return nil
+}
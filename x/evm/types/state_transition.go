@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/okex/exchain/x/analyzer"
 )
 
@@ -80,12 +81,22 @@ func (st StateTransition) newEVM(
 	gasLimit uint64,
 	gasPrice *big.Int,
 	config ChainConfig,
-	extraEIPs []int,
+	params Params,
 ) *vm.EVM {
+	// the methods called by every call frame and the contracts created by the contracts are checked by the guard
+	guard := newEVMGuard(csdb, params)
+	canTransfer := core.CanTransfer
+	if guard != nil {
+		canTransfer = guard.canTransfer
+	}
+
 	// Create context for evm
 	blockCtx := vm.BlockContext{
-		CanTransfer: core.CanTransfer,
-		Transfer:    core.Transfer,
+		CanTransfer: canTransfer,
+		Transfer: func(db vm.StateDB, sender, recipient common.Address, amount *big.Int) {
+			csdb.recordPrecompileCall(sender, recipient, amount)
+			core.Transfer(db, sender, recipient, amount)
+		},
 		GetHash:     GetHashFn(ctx, csdb),
 		Coinbase:    common.BytesToAddress(ctx.BlockHeader().ProposerAddress),
 		BlockNumber: big.NewInt(ctx.BlockHeight()),
@@ -100,7 +111,11 @@ func (st StateTransition) newEVM(
 	}
 
 	vmConfig := vm.Config{
-		ExtraEips: extraEIPs,
+		ExtraEips: params.ExtraEIPs,
+	}
+	if guard != nil {
+		vmConfig.Debug = true
+		vmConfig.Tracer = guard
 	}
 
 	return vm.NewEVM(blockCtx, txCtx, csdb, config.EthereumConfig(st.ChainID), vmConfig)
@@ -117,6 +132,9 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 			// contract calling
 			if blockedContractAddr, ok := e.(common.Address); ok {
 				err = ErrCallBlockedContract(blockedContractAddr)
+			} else if call, ok := e.(blockedContractMethod); ok {
+				// captured by the panics of the method checks of the guard
+				err = ErrCallBlockedContractMethod(call.contract, call.method)
 			} else {
 				// unexpected and unknown panic from lower part
 				panic(e)
@@ -158,10 +176,10 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		}
 	}
 
-
 	params := csdb.GetParams()
 
-	evm := st.newEVM(ctx, csdb, gasLimit, st.Price, config, params.ExtraEIPs)
+	defer bindPrecompiles(csdb, params)()
+	evm := st.newEVM(ctx, csdb, gasLimit, st.Price, config, params)
 
 	var (
 		ret             []byte
@@ -183,9 +201,10 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 			return exeRes, resData, ErrCreateDisabled
 		}
 
-		// check whether the deployer address is in the whitelist if the whitelist is enabled
+		// check whether the deployer address or the code hash of the init code is in the whitelist if the whitelist is
+		// enabled
 		senderAccAddr := st.Sender.Bytes()
-		if params.EnableContractDeploymentWhitelist && !csdb.IsDeployerInWhitelist(senderAccAddr) {
+		if params.EnableContractDeploymentWhitelist && !csdb.IsDeploymentAllowed(senderAccAddr, crypto.Keccak256Hash(st.Payload)) {
 			return exeRes, resData, ErrUnauthorizedAccount(senderAccAddr)
 		}
 
@@ -250,6 +269,7 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		if _, err = csdb.Commit(true); err != nil {
 			return
 		}
+		csdb.commitPrecompiles()
 	}
 
 	// Encode all necessary data into slice of bytes to return in sdk result
@@ -52,6 +52,13 @@ type Watcher interface {
 	SaveContractDeploymentWhitelistItem(addr sdk.AccAddress)
 	DeleteContractBlockedList(addr sdk.AccAddress)
 	DeleteContractDeploymentWhitelist(addr sdk.AccAddress)
+	SaveContractDeploymentCodeHashItem(codeHash ethcmn.Hash)
+	DeleteContractDeploymentCodeHash(codeHash ethcmn.Hash)
+	SaveContractDeploymentFactoryItem(factoryAddr sdk.AccAddress, codeHash ethcmn.Hash)
+	DeleteContractDeploymentFactory(factoryAddr sdk.AccAddress, codeHash ethcmn.Hash)
+	SaveContractMethodBlockedListItem(contractAddr sdk.AccAddress, method MethodSelector)
+	DeleteContractMethodBlockedList(contractAddr sdk.AccAddress, method MethodSelector)
+	SaveContractMethodBlockedListCount(count uint64)
 }
 
 type CacheCode struct {
@@ -122,6 +129,15 @@ type CommitStateDB struct {
 	codeCache map[ethcmn.Address]CacheCode
 
 	dbAdapter DbAdapter
+
+	// the stateful precompiles active in the state transition, the CALL into one about to run, and the branches of the
+	// state written by them
+	precompiles      map[ethcmn.Address]Precompile
+	precompileCall   *precompileCall
+	precompileWrites []precompileWrite
+
+	// the number of the storage slots written by the commits
+	storageWrites int
 }
 
 type StoreProxy interface {
@@ -335,6 +351,16 @@ func (csdb *CommitStateDB) SetCode(addr ethcmn.Address, code []byte) {
 	}
 }
 
+// SetStorage replaces the whole storage of an account with the given one, the keys out of it read empty. It's used
+// by the state overrides of the simulated calls.
+func (csdb *CommitStateDB) SetStorage(addr ethcmn.Address, storage map[ethcmn.Hash]ethcmn.Hash) {
+	so := csdb.getStateObject(addr)
+	if so == nil {
+		so, _ = csdb.createObject(addr)
+	}
+	so.setStorage(storage)
+}
+
 // ----------------------------------------------------------------------------
 // Transaction logs
 // Required for upgrade logic or ease of querying.
@@ -517,11 +543,11 @@ func (csdb *CommitStateDB) GetHeightHash(height uint64) ethcmn.Hash {
 	return ethcmn.BytesToHash(bz)
 }
 
-// GetParams returns the total set of evm parameters.
+// GetParams returns the total set of evm parameters, where the ones not set yet are the defaults.
 func (csdb *CommitStateDB) GetParams() Params {
 	if csdb.params == nil {
-		var params Params
-		csdb.paramSpace.GetParamSet(csdb.ctx, &params)
+		params := DefaultParams()
+		GetParamSetIfExists(csdb.ctx, csdb.paramSpace, &params)
 		csdb.params = &params
 	}
 	return *csdb.params
@@ -885,6 +911,9 @@ func (csdb *CommitStateDB) Snapshot() int {
 		defer analyzer.StopTxLog(funcName)
 	}
 
+	// a precompile reached after a snapshot isn't called by the recorded CALL
+	csdb.precompileCall = nil
+
 	id := csdb.nextRevisionID
 	csdb.nextRevisionID++
 
@@ -1221,6 +1250,11 @@ func (csdb *CommitStateDB) GetLogSize() uint {
 	return csdb.logSize
 }
 
+// GetStorageWrites returns the number of the storage slots written by the commits
+func (csdb *CommitStateDB) GetStorageWrites() int {
+	return csdb.storageWrites
+}
+
 // SetContractDeploymentWhitelistMember sets the target address list into whitelist store
 func (csdb *CommitStateDB) SetContractDeploymentWhitelist(addrList AddressList) {
 	if csdb.Watcher.Enabled() {
@@ -20,7 +20,8 @@ const (
 )
 
 type WatchStore struct {
-	db dbm.DB
+	db     dbm.DB
+	pruner historyPruner
 }
 
 var gWatchStore *WatchStore = nil
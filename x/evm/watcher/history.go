@@ -0,0 +1,172 @@
+package watcher
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/okex/exchain/app/types"
+	"github.com/spf13/viper"
+)
+
+const (
+	// FlagFastQueryHistory is the number of the latest blocks whose account and storage changes are kept for the
+	// historical queries, and 0 disables the history
+	FlagFastQueryHistory = "fast-query-history"
+
+	// the history older than the retention window is pruned every historyPruneInterval blocks
+	historyPruneInterval = 100
+)
+
+var (
+	// the versions of the account and state keys, prefixHistory | key | big endian height
+	prefixHistory = []byte{0x15}
+	// the lowest height served from the history
+	prefixHistoryBase = []byte{0x16}
+
+	errHistoryNotFound = errors.New("history not found")
+
+	historyRetention uint64
+	onceHistory      sync.Once
+)
+
+// GetHistoryRetention returns the number of the latest blocks kept in the history
+func GetHistoryRetention() uint64 {
+	onceHistory.Do(func() {
+		historyRetention = viper.GetUint64(FlagFastQueryHistory)
+	})
+	return historyRetention
+}
+
+func historyKey(key []byte, height uint64) []byte {
+	hKey := make([]byte, 0, len(prefixHistory)+len(key)+8)
+	hKey = append(hKey, prefixHistory...)
+	hKey = append(hKey, key...)
+	return append(hKey, sdk.Uint64ToBigEndian(height)...)
+}
+
+// historyPruner prunes the history in the background, one pass at a time
+type historyPruner struct {
+	running int32
+}
+
+// saveHistory records the versions of the accounts and states changed at the height
+func (w *WatchStore) saveHistory(batch []WatchMessage, height uint64) {
+	if GetHistoryRetention() == 0 {
+		return
+	}
+	if !w.Has(prefixHistoryBase) {
+		w.Set(prefixHistoryBase, sdk.Uint64ToBigEndian(height))
+	}
+	for _, b := range batch {
+		switch b.(type) {
+		case *MsgAccount, *MsgState:
+			w.Set(historyKey(b.GetKey(), height), []byte(b.GetValue()))
+		}
+	}
+}
+
+// deleteHistory records the deletion of the key at the height
+func (w *WatchStore) deleteHistory(key []byte, height uint64) {
+	if GetHistoryRetention() == 0 {
+		return
+	}
+	w.Set(historyKey(key, height), []byte{})
+}
+
+// pruneHistory prunes the versions out of the retention window in the background. The latest version of every key
+// below the window is kept, as it's the value of the key at the bottom of the window
+func (w *WatchStore) pruneHistory(height uint64) {
+	retention := GetHistoryRetention()
+	if retention == 0 || height <= retention || height%historyPruneInterval != 0 {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&w.pruner.running, 0, 1) {
+		return
+	}
+
+	base := height - retention
+	go func() {
+		defer atomic.StoreInt32(&w.pruner.running, 0)
+
+		it, err := w.db.Iterator(prefixHistory, sdk.PrefixEndBytes(prefixHistory))
+		if err != nil {
+			log.Println("watchdb error: ", err.Error())
+			return
+		}
+		var stale [][]byte
+		var prevKey []byte
+		for ; it.Valid(); it.Next() {
+			hKey := it.Key()
+			key, version := hKey[:len(hKey)-8], binary.BigEndian.Uint64(hKey[len(hKey)-8:])
+			if version > base {
+				continue
+			}
+			// the versions of a key are in ascending order, so the former one below the window is stale
+			if prevKey != nil && bytes.Equal(key, prevKey[:len(prevKey)-8]) {
+				stale = append(stale, prevKey)
+			}
+			prevKey = append([]byte{}, hKey...)
+		}
+		it.Close()
+
+		for _, key := range stale {
+			w.Delete(key)
+		}
+		w.Set(prefixHistoryBase, sdk.Uint64ToBigEndian(base))
+	}()
+}
+
+// getHistory returns the value of the key at the height, which is its latest version not above the height
+func (w *WatchStore) getHistory(key []byte, height uint64) ([]byte, error) {
+	if GetHistoryRetention() == 0 {
+		return nil, errHistoryNotFound
+	}
+	base, err := w.Get(prefixHistoryBase)
+	if err != nil {
+		return nil, err
+	}
+	if len(base) == 0 || height < binary.BigEndian.Uint64(base) {
+		return nil, errHistoryNotFound
+	}
+
+	it, err := w.db.ReverseIterator(historyKey(key, 0), historyKey(key, height+1))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	if !it.Valid() || len(it.Value()) == 0 {
+		return nil, errHistoryNotFound
+	}
+	return it.Value(), nil
+}
+
+// GetAccountAtHeight returns the account at the height from the history
+func (q Querier) GetAccountAtHeight(addr sdk.AccAddress, height uint64) (*types.EthAccount, error) {
+	if !q.enabled() {
+		return nil, errors.New(MsgFunctionDisable)
+	}
+	b, e := q.store.getHistory(GetMsgAccountKey(addr.Bytes()), height)
+	if e != nil {
+		return nil, e
+	}
+	var acc types.EthAccount
+	if e = json.Unmarshal(b, &acc); e != nil {
+		return nil, e
+	}
+	return &acc, nil
+}
+
+// GetStateAtHeight returns the value of the storage key of the contract at the height from the history
+func (q Querier) GetStateAtHeight(addr common.Address, key []byte, height uint64) ([]byte, error) {
+	if !q.enabled() {
+		return nil, errors.New(MsgFunctionDisable)
+	}
+	return q.store.getHistory(GetMsgStateKey(addr, key), height)
+}
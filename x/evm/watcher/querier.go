@@ -61,6 +61,30 @@ func (q Querier) GetTransactionReceipt(hash common.Hash) (*TransactionReceipt, e
 	return &receipt, nil
 }
 
+// GetBlockReceipts returns the receipts of all the txs of the block of the number
+func (q Querier) GetBlockReceipts(number uint64) ([]TransactionReceipt, error) {
+	if !q.enabled() {
+		return nil, errors.New(MsgFunctionDisable)
+	}
+	b, e := q.store.Get(append(prefixBlockReceipt, []byte(strconv.Itoa(int(number)))...))
+	if e != nil {
+		return nil, e
+	}
+	if b == nil {
+		return nil, errors.New("block receipts not found")
+	}
+	var receipts []TransactionReceipt
+	if e = json.Unmarshal(b, &receipts); e != nil {
+		return nil, e
+	}
+	for i := range receipts {
+		if receipts[i].Logs == nil {
+			receipts[i].Logs = []*ethtypes.Log{}
+		}
+	}
+	return receipts, nil
+}
+
 func (q Querier) GetBlockByHash(hash common.Hash, fullTx bool) (*EthBlock, error) {
 	if !q.enabled() {
 		return nil, errors.New(MsgFunctionDisable)
@@ -360,9 +384,41 @@ func (q Querier) HasContractBlockedList(key []byte) bool {
 	return q.store.Has(append(prefixBlackList, key...))
 }
 
+func (q Querier) HasContractMethodBlockedList(key []byte) bool {
+	if !q.enabled() {
+		return false
+	}
+	return q.store.Has(append(prefixBlackListMethod, key...))
+}
+
+func (q Querier) GetContractMethodBlockedListCount() []byte {
+	if !q.enabled() {
+		return nil
+	}
+	b, e := q.store.Get(prefixBlackListMethodCount)
+	if e != nil {
+		return nil
+	}
+	return b
+}
+
 func (q Querier) HasContractDeploymentWhitelist(key []byte) bool {
 	if !q.enabled() {
 		return false
 	}
 	return q.store.Has(append(prefixWhiteList, key...))
 }
+
+func (q Querier) HasContractDeploymentCodeHash(key []byte) bool {
+	if !q.enabled() {
+		return false
+	}
+	return q.store.Has(append(prefixWhiteListCodeHash, key...))
+}
+
+func (q Querier) HasContractDeploymentFactory(key []byte) bool {
+	if !q.enabled() {
+		return false
+	}
+	return q.store.Has(append(prefixWhiteListFactory, key...))
+}
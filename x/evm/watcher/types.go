@@ -10,6 +10,7 @@ import (
 
 	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/ethereum/go-ethereum/common"
@@ -35,6 +36,12 @@ var (
 	prefixWhiteList    = []byte{0x11}
 	prefixBlackList    = []byte{0x12}
 	prefixRpcDb        = []byte{0x13}
+	prefixBlockReceipt = []byte{0x14}
+	// 0x15 and 0x16 are the prefixes of the history
+	prefixWhiteListCodeHash    = []byte{0x17}
+	prefixWhiteListFactory     = []byte{0x18}
+	prefixBlackListMethod      = []byte{0x19}
+	prefixBlackListMethodCount = []byte{0x1A}
 
 	KeyLatestHeight = "LatestHeight"
 
@@ -208,6 +215,46 @@ func (m MsgTransactionReceipt) GetValue() string {
 	return m.receipt
 }
 
+// CumulativeGas is the cumulative gas used by the txs of a block, indexed by the tx index. The txs must be updated in
+// their order in the block
+type CumulativeGas map[uint64]uint64
+
+// Update adds the gas used by the tx to the cumulative gas and returns it
+func (c CumulativeGas) Update(txIndex, gasUsed uint64) uint64 {
+	if len(c) == 0 {
+		c[txIndex] = gasUsed
+	} else {
+		c[txIndex] = c[txIndex-1] + gasUsed
+	}
+	return c[txIndex]
+}
+
+// MsgBlockReceipts is the receipts of all the txs of a block, in the order of the txs
+type MsgBlockReceipts struct {
+	height   []byte
+	receipts string
+}
+
+func (m MsgBlockReceipts) GetType() uint32 {
+	return TypeOthers
+}
+
+// NewMsgBlockReceipts groups the json receipts of the txs of the block into a json array
+func NewMsgBlockReceipts(height uint64, receipts []string) *MsgBlockReceipts {
+	return &MsgBlockReceipts{
+		height:   []byte(strconv.Itoa(int(height))),
+		receipts: "[" + strings.Join(receipts, ",") + "]",
+	}
+}
+
+func (m MsgBlockReceipts) GetKey() []byte {
+	return append(prefixBlockReceipt, m.height...)
+}
+
+func (m MsgBlockReceipts) GetValue() string {
+	return m.receipts
+}
+
 type MsgBlock struct {
 	blockHash []byte
 	block     string
@@ -488,3 +535,97 @@ func (msgItem *MsgContractDeploymentWhitelistItem) GetKey() []byte {
 func (msgItem *MsgContractDeploymentWhitelistItem) GetValue() string {
 	return ""
 }
+
+type MsgContractDeploymentCodeHashItem struct {
+	codeHash common.Hash
+}
+
+func (msgItem *MsgContractDeploymentCodeHashItem) GetType() uint32 {
+	return TypeOthers
+}
+
+func NewMsgContractDeploymentCodeHashItem(codeHash common.Hash) *MsgContractDeploymentCodeHashItem {
+	return &MsgContractDeploymentCodeHashItem{
+		codeHash: codeHash,
+	}
+}
+
+func (msgItem *MsgContractDeploymentCodeHashItem) GetKey() []byte {
+	return append(prefixWhiteListCodeHash, msgItem.codeHash.Bytes()...)
+}
+
+func (msgItem *MsgContractDeploymentCodeHashItem) GetValue() string {
+	return ""
+}
+
+type MsgContractDeploymentFactoryItem struct {
+	factoryAddr sdk.AccAddress
+	codeHash    common.Hash
+}
+
+func (msgItem *MsgContractDeploymentFactoryItem) GetType() uint32 {
+	return TypeOthers
+}
+
+func NewMsgContractDeploymentFactoryItem(factoryAddr sdk.AccAddress, codeHash common.Hash) *MsgContractDeploymentFactoryItem {
+	return &MsgContractDeploymentFactoryItem{
+		factoryAddr: factoryAddr,
+		codeHash:    codeHash,
+	}
+}
+
+func (msgItem *MsgContractDeploymentFactoryItem) GetKey() []byte {
+	key := append(append([]byte{}, prefixWhiteListFactory...), msgItem.factoryAddr.Bytes()...)
+	return append(key, msgItem.codeHash.Bytes()...)
+}
+
+func (msgItem *MsgContractDeploymentFactoryItem) GetValue() string {
+	return ""
+}
+
+type MsgContractMethodBlockedListItem struct {
+	addr   sdk.AccAddress
+	method types.MethodSelector
+}
+
+func (msgItem *MsgContractMethodBlockedListItem) GetType() uint32 {
+	return TypeOthers
+}
+
+func NewMsgContractMethodBlockedListItem(addr sdk.AccAddress, method types.MethodSelector) *MsgContractMethodBlockedListItem {
+	return &MsgContractMethodBlockedListItem{
+		addr:   addr,
+		method: method,
+	}
+}
+
+func (msgItem *MsgContractMethodBlockedListItem) GetKey() []byte {
+	key := append(append([]byte{}, prefixBlackListMethod...), msgItem.addr.Bytes()...)
+	return append(key, msgItem.method[:]...)
+}
+
+func (msgItem *MsgContractMethodBlockedListItem) GetValue() string {
+	return ""
+}
+
+type MsgContractMethodBlockedListCount struct {
+	count uint64
+}
+
+func (msgItem *MsgContractMethodBlockedListCount) GetType() uint32 {
+	return TypeOthers
+}
+
+func NewMsgContractMethodBlockedListCount(count uint64) *MsgContractMethodBlockedListCount {
+	return &MsgContractMethodBlockedListCount{
+		count: count,
+	}
+}
+
+func (msgItem *MsgContractMethodBlockedListCount) GetKey() []byte {
+	return prefixBlackListMethodCount
+}
+
+func (msgItem *MsgContractMethodBlockedListCount) GetValue() string {
+	return string(sdk.Uint64ToBigEndian(msgItem.count))
+}
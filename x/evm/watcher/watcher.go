@@ -23,9 +23,10 @@ type Watcher struct {
 	header        types.Header
 	batch         []WatchMessage
 	staleBatch    []WatchMessage
-	cumulativeGas map[uint64]uint64
+	cumulativeGas CumulativeGas
 	gasUsed       uint64
 	blockTxs      []common.Hash
+	blockReceipts []string
 	sw            bool
 	firstUse      bool
 	delayEraseKey [][]byte
@@ -80,9 +81,10 @@ func (w *Watcher) NewHeight(height uint64, blockHash common.Hash, header types.H
 	w.header = header
 	w.height = height
 	w.blockHash = blockHash
-	w.cumulativeGas = make(map[uint64]uint64)
+	w.cumulativeGas = make(CumulativeGas)
 	w.gasUsed = 0
 	w.blockTxs = []common.Hash{}
+	w.blockReceipts = []string{}
 }
 
 func (w *Watcher) SaveEthereumTx(msg evmtypes.MsgEthereumTx, txHash common.Hash, index uint64) {
@@ -124,6 +126,7 @@ func (w *Watcher) SaveTransactionReceipt(status uint32, msg evmtypes.MsgEthereum
 	wMsg := NewMsgTransactionReceipt(status, &msg, txHash, w.blockHash, txIndex, w.height, data, w.cumulativeGas[txIndex], gasUsed)
 	if wMsg != nil {
 		w.batch = append(w.batch, wMsg)
+		w.blockReceipts = append(w.blockReceipts, wMsg.receipt)
 	}
 }
 
@@ -131,11 +134,7 @@ func (w *Watcher) UpdateCumulativeGas(txIndex, gasUsed uint64) {
 	if !w.Enabled() {
 		return
 	}
-	if len(w.cumulativeGas) == 0 {
-		w.cumulativeGas[txIndex] = gasUsed
-	} else {
-		w.cumulativeGas[txIndex] = w.cumulativeGas[txIndex-1] + gasUsed
-	}
+	w.cumulativeGas.Update(txIndex, gasUsed)
 	w.gasUsed += gasUsed
 }
 
@@ -166,6 +165,7 @@ func (w *Watcher) DeleteAccount(addr sdk.AccAddress) {
 		return
 	}
 	w.store.Delete(GetMsgAccountKey(addr.Bytes()))
+	w.store.deleteHistory(GetMsgAccountKey(addr.Bytes()), w.height)
 	key := append(prefixRpcDb, GetMsgAccountKey(addr.Bytes())...)
 	w.delayEraseKey = append(w.delayEraseKey, key)
 }
@@ -206,6 +206,11 @@ func (w *Watcher) SaveBlock(bloom ethtypes.Bloom) {
 	if wInfo != nil {
 		w.batch = append(w.batch, wInfo)
 	}
+
+	wReceipts := NewMsgBlockReceipts(w.height, w.blockReceipts)
+	if wReceipts != nil {
+		w.batch = append(w.batch, wReceipts)
+	}
 	w.SaveLatestHeight(w.height)
 }
 
@@ -269,6 +274,76 @@ func (w *Watcher) DeleteContractDeploymentWhitelist(addr sdk.AccAddress) {
 	}
 }
 
+func (w *Watcher) SaveContractDeploymentCodeHashItem(codeHash common.Hash) {
+	if !w.Enabled() {
+		return
+	}
+	wMsg := NewMsgContractDeploymentCodeHashItem(codeHash)
+	if wMsg != nil {
+		w.batch = append(w.batch, wMsg)
+	}
+}
+
+func (w *Watcher) DeleteContractDeploymentCodeHash(codeHash common.Hash) {
+	if !w.Enabled() {
+		return
+	}
+	wMsg := NewMsgContractDeploymentCodeHashItem(codeHash)
+	if wMsg != nil {
+		w.store.Delete(wMsg.GetKey())
+	}
+}
+
+func (w *Watcher) SaveContractDeploymentFactoryItem(factoryAddr sdk.AccAddress, codeHash common.Hash) {
+	if !w.Enabled() {
+		return
+	}
+	wMsg := NewMsgContractDeploymentFactoryItem(factoryAddr, codeHash)
+	if wMsg != nil {
+		w.batch = append(w.batch, wMsg)
+	}
+}
+
+func (w *Watcher) DeleteContractDeploymentFactory(factoryAddr sdk.AccAddress, codeHash common.Hash) {
+	if !w.Enabled() {
+		return
+	}
+	wMsg := NewMsgContractDeploymentFactoryItem(factoryAddr, codeHash)
+	if wMsg != nil {
+		w.store.Delete(wMsg.GetKey())
+	}
+}
+
+func (w *Watcher) SaveContractMethodBlockedListItem(addr sdk.AccAddress, method evmtypes.MethodSelector) {
+	if !w.Enabled() {
+		return
+	}
+	wMsg := NewMsgContractMethodBlockedListItem(addr, method)
+	if wMsg != nil {
+		w.batch = append(w.batch, wMsg)
+	}
+}
+
+func (w *Watcher) DeleteContractMethodBlockedList(addr sdk.AccAddress, method evmtypes.MethodSelector) {
+	if !w.Enabled() {
+		return
+	}
+	wMsg := NewMsgContractMethodBlockedListItem(addr, method)
+	if wMsg != nil {
+		w.store.Delete(wMsg.GetKey())
+	}
+}
+
+func (w *Watcher) SaveContractMethodBlockedListCount(count uint64) {
+	if !w.Enabled() {
+		return
+	}
+	wMsg := NewMsgContractMethodBlockedListCount(count)
+	if wMsg != nil {
+		w.batch = append(w.batch, wMsg)
+	}
+}
+
 func (w *Watcher) Finalize() {
 	if !w.Enabled() {
 		return
@@ -321,6 +396,7 @@ func (w *Watcher) Commit() {
 	}
 	//hold it in temp
 	batch := w.batch
+	height := w.height
 	go func() {
 		for _, b := range batch {
 			w.store.Set(b.GetKey(), []byte(b.GetValue()))
@@ -328,5 +404,7 @@ func (w *Watcher) Commit() {
 				state.SetStateToLru(common.BytesToHash(b.GetKey()), []byte(b.GetValue()))
 			}
 		}
+		w.store.saveHistory(batch, height)
 	}()
+	w.store.pruneHistory(height)
 }
@@ -1 +1,67 @@
 package watcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestCumulativeGas(t *testing.T) {
+	cumulativeGas := make(CumulativeGas)
+	require.Equal(t, uint64(100), cumulativeGas.Update(0, 100))
+	require.Equal(t, uint64(150), cumulativeGas.Update(1, 50))
+	require.Equal(t, uint64(150), cumulativeGas.Update(2, 0))
+	require.Equal(t, uint64(160), cumulativeGas.Update(3, 10))
+}
+
+func TestMsgBlockReceipts(t *testing.T) {
+	msg := NewMsgBlockReceipts(10, nil)
+	require.Equal(t, append(prefixBlockReceipt, "10"...), msg.GetKey())
+	require.Equal(t, "[]", msg.GetValue())
+
+	msg = NewMsgBlockReceipts(10, []string{`{"gasUsed":"0x1"}`, `{"gasUsed":"0x2"}`})
+	var receipts []TransactionReceipt
+	require.NoError(t, json.Unmarshal([]byte(msg.GetValue()), &receipts))
+	require.Len(t, receipts, 2)
+	require.Equal(t, uint64(2), uint64(receipts[1].GasUsed))
+}
+
+func TestHistory(t *testing.T) {
+	onceHistory.Do(func() {})
+	historyRetention = 150
+	defer func() { historyRetention = 0 }()
+
+	store := &WatchStore{db: dbm.NewMemDB()}
+	addr := common.BytesToAddress([]byte("contract"))
+	key := GetMsgStateKey(addr, []byte("key"))
+	for _, height := range []uint64{10, 20, 120} {
+		store.saveHistory([]WatchMessage{NewMsgState(addr, []byte("key"), []byte(fmt.Sprint(height)))}, height)
+	}
+	store.deleteHistory(key, 180)
+
+	_, err := store.getHistory(key, 9)
+	require.Error(t, err)
+	for height, expValue := range map[uint64]string{10: "10", 19: "10", 20: "20", 179: "120"} {
+		value, err := store.getHistory(key, height)
+		require.NoError(t, err)
+		require.Equal(t, expValue, string(value))
+	}
+	_, err = store.getHistory(key, 180)
+	require.Error(t, err)
+
+	// the versions below 200-150 are pruned except the latest one
+	store.pruneHistory(200)
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&store.pruner.running) == 0 }, time.Second, time.Millisecond)
+	_, err = store.getHistory(key, 49)
+	require.Error(t, err)
+	value, err := store.getHistory(key, 50)
+	require.NoError(t, err)
+	require.Equal(t, "20", string(value))
+	require.False(t, store.Has(historyKey(key, 10)))
+}
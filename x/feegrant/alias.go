@@ -0,0 +1,43 @@
+package feegrant
+
+import (
+	"github.com/okex/exchain/x/feegrant/keeper"
+	"github.com/okex/exchain/x/feegrant/types"
+)
+
+const (
+	// nolint
+	ModuleName   = types.ModuleName
+	RouterKey    = types.RouterKey
+	StoreKey     = types.StoreKey
+	QuerierRoute = types.QuerierRoute
+)
+
+var (
+	// functions aliases
+	// nolint
+	NewKeeper                       = keeper.NewKeeper
+	NewQuerier                      = keeper.NewQuerier
+	RegisterCodec                   = types.RegisterCodec
+	NewFeeAllowance                 = types.NewFeeAllowance
+	NewFeeGrantTx                   = types.NewFeeGrantTx
+	NewMsgGrantFeeAllowance         = types.NewMsgGrantFeeAllowance
+	NewMsgRevokeFeeAllowance        = types.NewMsgRevokeFeeAllowance
+	NewMsgSponsorContract           = types.NewMsgSponsorContract
+	NewMsgRevokeContractSponsorship = types.NewMsgRevokeContractSponsorship
+
+	// variable aliases
+	// nolint
+	ModuleCdc = types.ModuleCdc
+)
+
+type (
+	// nolint
+	Keeper = keeper.Keeper
+
+	// nolint
+	FeeAllowance        = types.FeeAllowance
+	FeeAllowanceGrant   = types.FeeAllowanceGrant
+	ContractSponsorship = types.ContractSponsorship
+	FeeGrantTx          = types.FeeGrantTx
+)
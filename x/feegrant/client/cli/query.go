@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/spf13/cobra"
+
+	"github.com/okex/exchain/x/feegrant/types"
+)
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	queryCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Querying commands for the feegrant module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	queryCmd.AddCommand(flags.GetCommands(
+		GetCmdQueryFeeAllowance(queryRoute, cdc),
+		GetCmdQueryFeeAllowances(queryRoute, cdc),
+		GetCmdQueryContractSponsorships(queryRoute, cdc),
+	)...)
+
+	return queryCmd
+}
+
+// GetCmdQueryFeeAllowance gets the fee allowance query command.
+func GetCmdQueryFeeAllowance(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "allowance [granter] [grantee]",
+		Short: "Query the fee allowance granted by granter to grantee",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the fee allowance granted by granter to grantee.
+
+Example:
+$ %s query feegrant allowance ex1... ex1...
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			route := fmt.Sprintf("custom/%s/%s/%s/%s", queryRoute, types.QueryFeeAllowance, args[0], args[1])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var grant types.FeeAllowanceGrant
+			cdc.MustUnmarshalJSON(bz, &grant)
+			return cliCtx.PrintOutput(grant)
+		},
+	}
+}
+
+// GetCmdQueryFeeAllowances gets the query command of all the fee allowances of a grantee.
+func GetCmdQueryFeeAllowances(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "allowances [grantee]",
+		Short: "Query all the fee allowances granted to grantee",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query all the fee allowances granted to grantee.
+
+Example:
+$ %s query feegrant allowances ex1...
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryFeeAllowances, args[0])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var grants []types.FeeAllowanceGrant
+			cdc.MustUnmarshalJSON(bz, &grants)
+			return cliCtx.PrintOutput(grants)
+		},
+	}
+}
+
+// GetCmdQueryContractSponsorships gets the query command of all the sponsorships of a contract.
+func GetCmdQueryContractSponsorships(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "sponsorships [contract]",
+		Short: "Query all the sponsorships paying the gas of calls to a contract",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query all the sponsorships paying the gas of calls to a contract. The contract address may be in hex or bech32 format.
+
+Example:
+$ %s query feegrant sponsorships 0x...
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryContractSponsorships, args[0])
+			bz, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var sponsorships []types.ContractSponsorship
+			cdc.MustUnmarshalJSON(bz, &sponsorships)
+			return cliCtx.PrintOutput(sponsorships)
+		},
+	}
+}
@@ -0,0 +1,299 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	apptypes "github.com/okex/exchain/app/types"
+	"github.com/okex/exchain/x/feegrant/types"
+)
+
+// flags
+const (
+	flagSpendLimit  = "spend-limit"
+	flagExpiration  = "expiration"
+	flagAllowedMsgs = "allowed-msgs"
+	flagMaxGasPrice = "max-gas-price"
+	flagFeeAccount  = "fee-account"
+)
+
+// defaultMaxGasPrice is the default gas price of evm transactions
+var defaultMaxGasPrice = sdk.NewDecWithPrec(apptypes.DefaultGasPrice, sdk.Precision/2+1)
+
+// GetTxCmd returns the transaction commands for this module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	txCmd := &cobra.Command{
+		Use:   types.ModuleName,
+		Short: "Fee grant transactions subcommands",
+	}
+
+	txCmd.AddCommand(flags.PostCommands(
+		GetCmdGrantFeeAllowance(cdc),
+		GetCmdRevokeFeeAllowance(cdc),
+		GetCmdSponsorContract(cdc),
+		GetCmdRevokeContractSponsorship(cdc),
+		GetCmdExec(cdc),
+	)...)
+
+	return txCmd
+}
+
+// GetCmdGrantFeeAllowance returns the command to grant a fee allowance.
+func GetCmdGrantFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee]",
+		Short: "Grant a fee allowance to an account",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Grant a fee allowance to an account, replacing any previous one. The grantee may then send
+txs whose fees are paid by the granter, within the spend limit and before the expiration, with the exec command.
+
+Example:
+$ %s tx feegrant grant ex1... --spend-limit 10okt --expiration 2022-01-01T00:00:00Z --allowed-msgs token/send --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			allowance, err := allowanceFromFlags()
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgGrantFeeAllowance(cliCtx.GetFromAddress(), grantee, allowance)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	addAllowanceFlags(cmd)
+	cmd.Flags().StringSlice(flagAllowedMsgs, nil, "Messages the allowance may pay for, as route/type (default all)")
+	return cmd
+}
+
+// GetCmdRevokeFeeAllowance returns the command to revoke a fee allowance.
+func GetCmdRevokeFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke [grantee]",
+		Short: "Revoke the fee allowance granted to an account",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Revoke the fee allowance granted to an account.
+
+Example:
+$ %s tx feegrant revoke ex1... --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevokeFeeAllowance(cliCtx.GetFromAddress(), grantee)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSponsorContract returns the command to sponsor the gas of calls to a contract.
+func GetCmdSponsorContract(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sponsor-contract [contract]",
+		Short: "Pay the gas of every EVM call to a contract",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Pay the gas of every EVM call to a contract, within the spend limit and before the expiration.
+Calls with a gas price above the max gas price, in okt per gas, are paid by their sender. Sponsoring the
+contract again replaces the previous sponsorship of the account. A contract may have several sponsors, a call
+is paid by the first of them, in the order of their addresses, able to pay it.
+The contract address may be in hex or bech32 format.
+
+Example:
+$ %s tx feegrant sponsor-contract 0x... --spend-limit 100okt --max-gas-price 0.0000000001 --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			contract, err := types.AccAddressFromHexOrBech32(args[0])
+			if err != nil {
+				return err
+			}
+			allowance, err := allowanceFromFlags()
+			if err != nil {
+				return err
+			}
+			maxGasPrice, err := sdk.NewDecFromStr(viper.GetString(flagMaxGasPrice))
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSponsorContract(cliCtx.GetFromAddress(), contract, allowance, maxGasPrice)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	addAllowanceFlags(cmd)
+	cmd.Flags().String(flagMaxGasPrice, defaultMaxGasPrice.String(), "The maximum gas price of the calls paid for, in okt per gas")
+	return cmd
+}
+
+// GetCmdRevokeContractSponsorship returns the command to stop sponsoring a contract.
+func GetCmdRevokeContractSponsorship(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-sponsorship [contract]",
+		Short: "Stop paying the gas of EVM calls to a contract",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Stop paying the gas of EVM calls to a contract.
+
+Example:
+$ %s tx feegrant revoke-sponsorship 0x... --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			contract, err := types.AccAddressFromHexOrBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevokeContractSponsorship(cliCtx.GetFromAddress(), contract)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdExec returns the command to send a generated tx whose fees are paid out of a fee allowance.
+func GetCmdExec(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec [tx-json-file]",
+		Short: "Sign and broadcast a generated tx whose fees are paid out of a fee allowance",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Sign and broadcast a tx generated with --generate-only, whose fees are paid by the fee account
+out of the fee allowance it granted to you. The fee and memo of the generated tx are kept.
+
+Example:
+$ %s tx token send mykey ex1... 1okt --fees 0.01okt --from mykey --generate-only > tx.json
+$ %s tx feegrant exec tx.json --fee-account ex1... --from mykey
+`,
+				version.ClientName, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			stdTx, err := utils.ReadStdTxFromFile(cdc, args[0])
+			if err != nil {
+				return err
+			}
+			feeAccount, err := sdk.AccAddressFromBech32(viper.GetString(flagFeeAccount))
+			if err != nil {
+				return err
+			}
+
+			tx := types.NewFeeGrantTx(stdTx.Msgs, stdTx.Fee, feeAccount, nil, stdTx.Memo)
+			if cliCtx.GenerateOnly {
+				return cliCtx.PrintOutput(tx)
+			}
+
+			from := cliCtx.GetFromAddress()
+			if signers := tx.GetSigners(); len(signers) != 1 || !signers[0].Equals(from) {
+				return fmt.Errorf("the messages of the tx must be signed by %s only", from)
+			}
+			if txBldr.ChainID() == "" {
+				return fmt.Errorf("chain ID required but not specified")
+			}
+			txBldr, err = utils.PrepareTxBuilder(txBldr, cliCtx)
+			if err != nil {
+				return err
+			}
+
+			signBytes := types.FeeGrantSignBytes(txBldr.ChainID(), txBldr.AccountNumber(), txBldr.Sequence(),
+				tx.Fee, tx.FeeAccount, tx.Msgs, tx.Memo)
+			sig, pubKey, err := txBldr.Keybase().Sign(cliCtx.GetFromName(), keys.DefaultKeyPass, signBytes)
+			if err != nil {
+				return err
+			}
+			tx.Signatures = []auth.StdSignature{{PubKey: pubKey, Signature: sig}}
+
+			txBytes, err := txBldr.TxEncoder()(tx)
+			if err != nil {
+				return err
+			}
+			res, err := cliCtx.BroadcastTx(txBytes)
+			if err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(res)
+		},
+	}
+
+	cmd.Flags().String(flagFeeAccount, "", "The account paying the fees out of the allowance it granted to you")
+	_ = cmd.MarkFlagRequired(flagFeeAccount)
+	return cmd
+}
+
+func addAllowanceFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagSpendLimit, "", "The maximum amount of fees that can be paid (default no limit)")
+	cmd.Flags().String(flagExpiration, "", "The RFC3339 time after which the allowance expires (default never)")
+}
+
+func allowanceFromFlags() (types.FeeAllowance, error) {
+	var allowance types.FeeAllowance
+	if spendLimit := viper.GetString(flagSpendLimit); spendLimit != "" {
+		coins, err := sdk.ParseDecCoins(spendLimit)
+		if err != nil {
+			return allowance, err
+		}
+		allowance.SpendLimit = coins
+	}
+	if expiration := viper.GetString(flagExpiration); expiration != "" {
+		t, err := time.Parse(time.RFC3339, expiration)
+		if err != nil {
+			return allowance, err
+		}
+		allowance.Expiration = t
+	}
+	allowance.AllowedMsgs = viper.GetStringSlice(flagAllowedMsgs)
+	return allowance, allowance.ValidateBasic()
+}
@@ -0,0 +1,38 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/gorilla/mux"
+
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/feegrant/types"
+)
+
+func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	r = r.PathPrefix("/feegrant").Subrouter()
+	r.HandleFunc("/allowance/{granter}/{grantee}", queryHandler(cliCtx, types.QueryFeeAllowance, "granter", "grantee")).Methods("GET")
+	r.HandleFunc("/allowances/{grantee}", queryHandler(cliCtx, types.QueryFeeAllowances, "grantee")).Methods("GET")
+	r.HandleFunc("/sponsorships/{contract}", queryHandler(cliCtx, types.QueryContractSponsorships, "contract")).Methods("GET")
+}
+
+func queryHandler(cliCtx context.CLIContext, endpoint string, pathVars ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, endpoint)
+		for _, pathVar := range pathVars {
+			route = fmt.Sprintf("%s/%s", route, vars[pathVar])
+		}
+
+		res, _, err := cliCtx.QueryWithData(route, nil)
+		if err != nil {
+			sdkErr := common.ParseSDKError(err.Error())
+			common.HandleErrorMsg(w, cliCtx, sdkErr.Code, sdkErr.Message)
+			return
+		}
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
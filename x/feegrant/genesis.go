@@ -0,0 +1,69 @@
+package feegrant
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/feegrant/types"
+)
+
+// GenesisState stores the fee allowances and contract sponsorships at genesis
+type GenesisState struct {
+	FeeAllowances        []types.FeeAllowanceGrant   `json:"fee_allowances"`
+	ContractSponsorships []types.ContractSponsorship `json:"contract_sponsorships"`
+}
+
+// DefaultGenesisState returns an empty genesis state
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// ValidateGenesis validates the format of the specified genesisState
+func ValidateGenesis(data GenesisState) error {
+	for _, grant := range data.FeeAllowances {
+		if err := grant.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid fee allowance from %s to %s: %s", grant.Granter, grant.Grantee, err)
+		}
+	}
+	for _, sponsorship := range data.ContractSponsorships {
+		if err := sponsorship.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid sponsorship of contract %s by %s: %s", sponsorship.Contract, sponsorship.Granter, err)
+		}
+	}
+	return nil
+}
+
+// InitGenesis init genesis data to keeper. A chain whose Venus upgrade comes after the genesis can't have any.
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	if !common.HasVenusStores(ctx.BlockHeight()) {
+		if len(data.FeeAllowances) != 0 || len(data.ContractSponsorships) != 0 {
+			panic(types.ErrFeeGrantUnavailable)
+		}
+		return
+	}
+	for _, grant := range data.FeeAllowances {
+		k.GrantFeeAllowance(ctx, grant)
+	}
+	for _, sponsorship := range data.ContractSponsorships {
+		k.SetContractSponsorship(ctx, sponsorship)
+	}
+}
+
+// ExportGenesis exports genesis from keeper
+func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	var data GenesisState
+	if !common.HasVenusStores(ctx.BlockHeight()) {
+		return data
+	}
+	k.IterateFeeAllowances(ctx, func(grant types.FeeAllowanceGrant) bool {
+		data.FeeAllowances = append(data.FeeAllowances, grant)
+		return false
+	})
+	k.IterateContractSponsorships(ctx, func(sponsorship types.ContractSponsorship) bool {
+		data.ContractSponsorships = append(data.ContractSponsorships, sponsorship)
+		return false
+	})
+	return data
+}
@@ -0,0 +1,110 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/common/perf"
+	"github.com/okex/exchain/x/feegrant/types"
+)
+
+// NewHandler creates an sdk.Handler for all the feegrant type messages
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+		if !common.HasVenusStores(ctx.BlockHeight()) {
+			return nil, types.ErrFeeGrantUnavailable
+		}
+		var handlerFun func() (*sdk.Result, error)
+		var name string
+		switch msg := msg.(type) {
+		case types.MsgGrantFeeAllowance:
+			name = "handleMsgGrantFeeAllowance"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgGrantFeeAllowance(ctx, k, msg)
+			}
+		case types.MsgRevokeFeeAllowance:
+			name = "handleMsgRevokeFeeAllowance"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgRevokeFeeAllowance(ctx, k, msg)
+			}
+		case types.MsgSponsorContract:
+			name = "handleMsgSponsorContract"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgSponsorContract(ctx, k, msg)
+			}
+		case types.MsgRevokeContractSponsorship:
+			name = "handleMsgRevokeContractSponsorship"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgRevokeContractSponsorship(ctx, k, msg)
+			}
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
+		}
+		seq := perf.GetPerf().OnDeliverTxEnter(ctx, types.ModuleName, name)
+		defer perf.GetPerf().OnDeliverTxExit(ctx, types.ModuleName, name, seq)
+
+		res, err := handlerFun()
+		common.SanityCheckHandler(res, err)
+		return res, err
+	}
+}
+
+func handleMsgGrantFeeAllowance(ctx sdk.Context, k Keeper, msg types.MsgGrantFeeAllowance) (*sdk.Result, error) {
+	if msg.Allowance.IsExpired(ctx.BlockTime()) {
+		return nil, types.ErrFeeAllowanceExpired
+	}
+
+	k.GrantFeeAllowance(ctx, types.NewFeeAllowanceGrant(msg.Granter, msg.Grantee, msg.Allowance))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeGrantFeeAllowance,
+		sdk.NewAttribute(types.AttributeKeyGranter, msg.Granter.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantee, msg.Grantee.String()),
+	))
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgRevokeFeeAllowance(ctx sdk.Context, k Keeper, msg types.MsgRevokeFeeAllowance) (*sdk.Result, error) {
+	if err := k.RevokeFeeAllowance(ctx, msg.Granter, msg.Grantee); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRevokeFeeAllowance,
+		sdk.NewAttribute(types.AttributeKeyGranter, msg.Granter.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantee, msg.Grantee.String()),
+	))
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgSponsorContract(ctx sdk.Context, k Keeper, msg types.MsgSponsorContract) (*sdk.Result, error) {
+	if msg.Allowance.IsExpired(ctx.BlockTime()) {
+		return nil, types.ErrFeeAllowanceExpired
+	}
+
+	k.SetContractSponsorship(ctx, types.NewContractSponsorship(msg.Granter, msg.Contract, msg.Allowance, msg.MaxGasPrice))
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSponsorContract,
+		sdk.NewAttribute(types.AttributeKeyGranter, msg.Granter.String()),
+		sdk.NewAttribute(types.AttributeKeyContract, msg.Contract.String()),
+	))
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgRevokeContractSponsorship(ctx sdk.Context, k Keeper, msg types.MsgRevokeContractSponsorship) (*sdk.Result, error) {
+	if _, found := k.GetContractSponsorship(ctx, msg.Granter, msg.Contract); !found {
+		return nil, sdkerrors.Wrapf(types.ErrSponsorshipNotFound, "contract %s is not sponsored by %s", msg.Contract, msg.Granter)
+	}
+
+	k.DeleteContractSponsorship(ctx, msg.Granter, msg.Contract)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRevokeContractSponsorship,
+		sdk.NewAttribute(types.AttributeKeyGranter, msg.Granter.String()),
+		sdk.NewAttribute(types.AttributeKeyContract, msg.Contract.String()),
+	))
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -0,0 +1,245 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/feegrant/types"
+)
+
+// Keeper of the feegrant store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+}
+
+// NewKeeper creates a feegrant keeper
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey) Keeper {
+	return Keeper{
+		storeKey: key,
+		cdc:      cdc,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// GrantFeeAllowance stores the allowance granted by granter to grantee, replacing any previous one
+func (k Keeper) GrantFeeAllowance(ctx sdk.Context, grant types.FeeAllowanceGrant) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetFeeAllowanceKey(grant.Granter, grant.Grantee), k.cdc.MustMarshalBinaryLengthPrefixed(grant))
+}
+
+// RevokeFeeAllowance removes the allowance granted by granter to grantee
+func (k Keeper) RevokeFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress) error {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetFeeAllowanceKey(granter, grantee)
+	if !store.Has(key) {
+		return sdkerrors.Wrapf(types.ErrFeeAllowanceNotFound, "granter %s, grantee %s", granter, grantee)
+	}
+	store.Delete(key)
+	return nil
+}
+
+// GetFeeAllowance returns the allowance granted by granter to grantee
+func (k Keeper) GetFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress) (grant types.FeeAllowanceGrant, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetFeeAllowanceKey(granter, grantee))
+	if bz == nil {
+		return grant, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &grant)
+	return grant, true
+}
+
+// IterateFeeAllowances iterates over all fee allowances until the callback returns true
+func (k Keeper) IterateFeeAllowances(ctx sdk.Context, cb func(grant types.FeeAllowanceGrant) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.FeeAllowanceKeyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var grant types.FeeAllowanceGrant
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &grant)
+		if cb(grant) {
+			break
+		}
+	}
+}
+
+// UseGrantedFees charges the fee of a tx with msgs to the allowance granted by granter to grantee and returns
+// the payment. The allowance is removed once it is used up or expired.
+func (k Keeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins, msgs []sdk.Msg) (types.FeePayment, error) {
+	payment := types.FeePayment{Granter: granter, Grantee: grantee}
+	if !common.HasVenusStores(ctx.BlockHeight()) {
+		return payment, types.ErrFeeGrantUnavailable
+	}
+	grant, found := k.GetFeeAllowance(ctx, granter, grantee)
+	if !found {
+		return payment, sdkerrors.Wrapf(types.ErrFeeAllowanceNotFound, "granter %s, grantee %s", granter, grantee)
+	}
+
+	if !grant.Allowance.AllowsMsgs(msgs) {
+		return payment, types.ErrMessageNotAllowed
+	}
+
+	left, remove, err := grant.Allowance.Accept(fee, ctx.BlockTime())
+	if remove {
+		store := ctx.KVStore(k.storeKey)
+		store.Delete(types.GetFeeAllowanceKey(granter, grantee))
+	}
+	if err != nil {
+		return payment, err
+	}
+	grant.Allowance = left
+	if remove {
+		payment.UsedUpGrant = &grant
+	} else {
+		k.GrantFeeAllowance(ctx, grant)
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUseFeeGrant,
+		sdk.NewAttribute(types.AttributeKeyGranter, granter.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, fee.String()),
+	))
+	return payment, nil
+}
+
+// SetContractSponsorship stores the sponsorship of a contract by its granter, replacing any previous one
+func (k Keeper) SetContractSponsorship(ctx sdk.Context, sponsorship types.ContractSponsorship) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetContractSponsorshipKey(sponsorship.Granter, sponsorship.Contract),
+		k.cdc.MustMarshalBinaryLengthPrefixed(sponsorship))
+}
+
+// DeleteContractSponsorship removes the sponsorship of a contract by granter
+func (k Keeper) DeleteContractSponsorship(ctx sdk.Context, granter, contract sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetContractSponsorshipKey(granter, contract))
+}
+
+// GetContractSponsorship returns the sponsorship of a contract by granter
+func (k Keeper) GetContractSponsorship(ctx sdk.Context, granter, contract sdk.AccAddress) (sponsorship types.ContractSponsorship, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetContractSponsorshipKey(granter, contract))
+	if bz == nil {
+		return sponsorship, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &sponsorship)
+	return sponsorship, true
+}
+
+// GetContractSponsorships returns all sponsorships of a contract, ordered by granter. There's none before the Venus
+// upgrade adds the store.
+func (k Keeper) GetContractSponsorships(ctx sdk.Context, contract sdk.AccAddress) []types.ContractSponsorship {
+	if !common.HasVenusStores(ctx.BlockHeight()) {
+		return []types.ContractSponsorship{}
+	}
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.GetContractSponsorshipsKey(contract))
+	defer iterator.Close()
+
+	sponsorships := make([]types.ContractSponsorship, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var sponsorship types.ContractSponsorship
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &sponsorship)
+		sponsorships = append(sponsorships, sponsorship)
+	}
+	return sponsorships
+}
+
+// IterateContractSponsorships iterates over all contract sponsorships until the callback returns true
+func (k Keeper) IterateContractSponsorships(ctx sdk.Context, cb func(sponsorship types.ContractSponsorship) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.ContractSponsorshipKeyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var sponsorship types.ContractSponsorship
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &sponsorship)
+		if cb(sponsorship) {
+			break
+		}
+	}
+}
+
+// GetSponsoredFeePayer returns the first sponsor of the contract, in the order of their addresses, which covers
+// the gas price and whose allowance can pay the fee
+func (k Keeper) GetSponsoredFeePayer(ctx sdk.Context, contract sdk.AccAddress, gasPrice sdk.Dec, fee sdk.Coins) (sdk.AccAddress, bool) {
+	for _, sponsorship := range k.GetContractSponsorships(ctx, contract) {
+		if !sponsorship.CoversGasPrice(gasPrice) {
+			continue
+		}
+		if _, _, err := sponsorship.Allowance.Accept(fee, ctx.BlockTime()); err == nil {
+			return sponsorship.Granter, true
+		}
+	}
+	return nil, false
+}
+
+// UseSponsoredFees charges the fee of an EVM call from sender to the allowance of the first sponsor of the
+// contract, in the order of their addresses, which covers the gas price and can pay the fee, and returns the
+// payment. It returns false if no sponsor can pay the fee, in which case the sender pays as usual.
+func (k Keeper) UseSponsoredFees(ctx sdk.Context, sender, contract sdk.AccAddress, gasPrice sdk.Dec, fee sdk.Coins) (types.FeePayment, bool) {
+	for _, sponsorship := range k.GetContractSponsorships(ctx, contract) {
+		if !sponsorship.CoversGasPrice(gasPrice) {
+			continue
+		}
+
+		left, remove, err := sponsorship.Allowance.Accept(fee, ctx.BlockTime())
+		if remove {
+			k.DeleteContractSponsorship(ctx, sponsorship.Granter, contract)
+		}
+		if err != nil {
+			continue
+		}
+		payment := types.FeePayment{Granter: sponsorship.Granter, Grantee: sender, Contract: contract}
+		sponsorship.Allowance = left
+		if remove {
+			payment.UsedUpSponsorship = &sponsorship
+		} else {
+			k.SetContractSponsorship(ctx, sponsorship)
+		}
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeUseContractSponsorship,
+			sdk.NewAttribute(types.AttributeKeyGranter, sponsorship.Granter.String()),
+			sdk.NewAttribute(types.AttributeKeyContract, contract.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, fee.String()),
+		))
+		return payment, true
+	}
+	return types.FeePayment{}, false
+}
+
+// RestoreFeeAllowance gives back the refunded fees to the allowance they were paid from. An allowance the fees
+// used up is set again with the refund as spend limit.
+func (k Keeper) RestoreFeeAllowance(ctx sdk.Context, payment types.FeePayment, refund sdk.Coins) {
+	if !payment.Contract.Empty() {
+		sponsorship, found := k.GetContractSponsorship(ctx, payment.Granter, payment.Contract)
+		if found {
+			sponsorship.Allowance = sponsorship.Allowance.Restore(refund)
+			k.SetContractSponsorship(ctx, sponsorship)
+		} else if payment.UsedUpSponsorship != nil && !refund.IsZero() {
+			sponsorship = *payment.UsedUpSponsorship
+			sponsorship.Allowance.SpendLimit = sdk.NewCoins(refund...)
+			k.SetContractSponsorship(ctx, sponsorship)
+		}
+		return
+	}
+
+	grant, found := k.GetFeeAllowance(ctx, payment.Granter, payment.Grantee)
+	if found {
+		grant.Allowance = grant.Allowance.Restore(refund)
+		k.GrantFeeAllowance(ctx, grant)
+	} else if payment.UsedUpGrant != nil && !refund.IsZero() {
+		grant = *payment.UsedUpGrant
+		grant.Allowance.SpendLimit = sdk.NewCoins(refund...)
+		k.GrantFeeAllowance(ctx, grant)
+	}
+}
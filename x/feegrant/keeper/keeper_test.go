@@ -0,0 +1,256 @@
+package keeper_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/okex/exchain/app"
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/feegrant/types"
+)
+
+type KeeperTestSuite struct {
+	suite.Suite
+
+	ctx sdk.Context
+	app *app.OKExChainApp
+
+	granter  sdk.AccAddress
+	grantee  sdk.AccAddress
+	contract sdk.AccAddress
+}
+
+func (suite *KeeperTestSuite) SetupTest() {
+	suite.app = app.Setup(false)
+	suite.ctx = suite.app.BaseApp.NewContext(false, abci.Header{Height: 1, ChainID: "okexchain-3", Time: time.Now().UTC()})
+
+	suite.granter = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	suite.grantee = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	suite.contract = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+}
+
+func TestKeeperTestSuite(t *testing.T) {
+	suite.Run(t, new(KeeperTestSuite))
+}
+
+func newFee(amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewDecCoin(sdk.DefaultBondDenom, sdk.NewInt(amount)))
+}
+
+func (suite *KeeperTestSuite) TestUseGrantedFees() {
+	msgs := []sdk.Msg{sdk.NewTestMsg(suite.grantee)}
+
+	testCases := []struct {
+		msg       string
+		allowance types.FeeAllowance
+		fee       sdk.Coins
+		expPass   bool
+		expFound  bool
+		expLeft   sdk.Coins
+	}{
+		{
+			"unlimited allowance",
+			types.NewFeeAllowance(nil, time.Time{}, nil),
+			newFee(100), true, true, nil,
+		},
+		{
+			"spend limit decreases",
+			types.NewFeeAllowance(newFee(100), time.Time{}, nil),
+			newFee(40), true, true, newFee(60),
+		},
+		{
+			"spend limit used up removes the grant",
+			types.NewFeeAllowance(newFee(100), time.Time{}, nil),
+			newFee(100), true, false, nil,
+		},
+		{
+			"spend limit exceeded",
+			types.NewFeeAllowance(newFee(100), time.Time{}, nil),
+			newFee(101), false, true, newFee(100),
+		},
+		{
+			"expired allowance",
+			types.NewFeeAllowance(nil, suite.ctx.BlockTime().Add(-time.Hour), nil),
+			newFee(1), false, false, nil,
+		},
+		{
+			"msg not allowed",
+			types.NewFeeAllowance(nil, time.Time{}, []string{"token/send"}),
+			newFee(1), false, true, nil,
+		},
+		{
+			"msg allowed",
+			types.NewFeeAllowance(nil, time.Time{}, []string{types.MsgTypeURL(msgs[0])}),
+			newFee(1), true, true, nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest()
+			keeper := suite.app.FeeGrantKeeper
+			keeper.GrantFeeAllowance(suite.ctx, types.NewFeeAllowanceGrant(suite.granter, suite.grantee, tc.allowance))
+
+			_, err := keeper.UseGrantedFees(suite.ctx, suite.granter, suite.grantee, tc.fee, msgs)
+			if tc.expPass {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().Error(err)
+			}
+
+			grant, found := keeper.GetFeeAllowance(suite.ctx, suite.granter, suite.grantee)
+			suite.Require().Equal(tc.expFound, found)
+			if found {
+				suite.Require().True(tc.expLeft.IsEqual(grant.Allowance.SpendLimit), grant.Allowance.SpendLimit.String())
+			}
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestUseGrantedFeesNotFound() {
+	_, err := suite.app.FeeGrantKeeper.UseGrantedFees(suite.ctx, suite.granter, suite.grantee, newFee(1), nil)
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestFeeGrantsBeforeVenus() {
+	keeper := suite.app.FeeGrantKeeper
+	allowance := types.NewFeeAllowance(nil, time.Time{}, nil)
+	keeper.GrantFeeAllowance(suite.ctx, types.NewFeeAllowanceGrant(suite.granter, suite.grantee, allowance))
+	keeper.SetContractSponsorship(suite.ctx, types.NewContractSponsorship(suite.granter, suite.contract, allowance, sdk.OneDec()))
+
+	common.MILESTONE_VENUS_HEIGHT = "10"
+	defer func() { common.MILESTONE_VENUS_HEIGHT = "" }()
+	ctx := suite.ctx.WithBlockHeight(9)
+	_, err := keeper.UseGrantedFees(ctx, suite.granter, suite.grantee, newFee(1), nil)
+	suite.Require().True(types.ErrFeeGrantUnavailable.Is(err))
+	_, ok := keeper.GetSponsoredFeePayer(ctx, suite.contract, sdk.OneDec(), newFee(1))
+	suite.Require().False(ok)
+
+	_, err = keeper.UseGrantedFees(ctx.WithBlockHeight(10), suite.granter, suite.grantee, newFee(1), nil)
+	suite.Require().NoError(err)
+}
+
+func (suite *KeeperTestSuite) TestContractSponsorship() {
+	keeper := suite.app.FeeGrantKeeper
+	gasPrice := sdk.OneDec()
+
+	_, ok := keeper.GetSponsoredFeePayer(suite.ctx, suite.contract, gasPrice, newFee(10))
+	suite.Require().False(ok)
+
+	keeper.SetContractSponsorship(suite.ctx, types.NewContractSponsorship(suite.granter, suite.contract,
+		types.NewFeeAllowance(newFee(100), time.Time{}, nil), gasPrice))
+
+	// a fee above the spend limit falls back to the sender
+	_, ok = keeper.GetSponsoredFeePayer(suite.ctx, suite.contract, gasPrice, newFee(101))
+	suite.Require().False(ok)
+	_, ok = keeper.UseSponsoredFees(suite.ctx, suite.grantee, suite.contract, gasPrice, newFee(101))
+	suite.Require().False(ok)
+
+	// so does a gas price above the max gas price of the sponsor
+	_, ok = keeper.GetSponsoredFeePayer(suite.ctx, suite.contract, sdk.NewDec(2), newFee(60))
+	suite.Require().False(ok)
+	_, ok = keeper.UseSponsoredFees(suite.ctx, suite.grantee, suite.contract, sdk.NewDec(2), newFee(60))
+	suite.Require().False(ok)
+
+	sponsor, ok := keeper.GetSponsoredFeePayer(suite.ctx, suite.contract, gasPrice, newFee(60))
+	suite.Require().True(ok)
+	suite.Require().Equal(suite.granter, sponsor)
+
+	payment, ok := keeper.UseSponsoredFees(suite.ctx, suite.grantee, suite.contract, gasPrice, newFee(60))
+	suite.Require().True(ok)
+	suite.Require().Equal(suite.granter, payment.Granter)
+	suite.Require().Nil(payment.UsedUpSponsorship)
+
+	sponsorship, found := keeper.GetContractSponsorship(suite.ctx, suite.granter, suite.contract)
+	suite.Require().True(found)
+	suite.Require().True(newFee(40).IsEqual(sponsorship.Allowance.SpendLimit))
+
+	// unused gas goes back to the sponsorship
+	keeper.RestoreFeeAllowance(suite.ctx, payment, newFee(20))
+	sponsorship, found = keeper.GetContractSponsorship(suite.ctx, suite.granter, suite.contract)
+	suite.Require().True(found)
+	suite.Require().True(newFee(60).IsEqual(sponsorship.Allowance.SpendLimit))
+
+	// a used up sponsorship is removed, and set again by a refund
+	payment, ok = keeper.UseSponsoredFees(suite.ctx, suite.grantee, suite.contract, gasPrice, newFee(60))
+	suite.Require().True(ok)
+	suite.Require().NotNil(payment.UsedUpSponsorship)
+	_, found = keeper.GetContractSponsorship(suite.ctx, suite.granter, suite.contract)
+	suite.Require().False(found)
+
+	keeper.RestoreFeeAllowance(suite.ctx, payment, newFee(20))
+	sponsorship, found = keeper.GetContractSponsorship(suite.ctx, suite.granter, suite.contract)
+	suite.Require().True(found)
+	suite.Require().True(newFee(20).IsEqual(sponsorship.Allowance.SpendLimit))
+	suite.Require().Equal(gasPrice, sponsorship.MaxGasPrice)
+}
+
+func (suite *KeeperTestSuite) TestRestoreUsedUpFeeAllowance() {
+	keeper := suite.app.FeeGrantKeeper
+	expiration := suite.ctx.BlockTime().Add(time.Hour)
+	keeper.GrantFeeAllowance(suite.ctx, types.NewFeeAllowanceGrant(suite.granter, suite.grantee,
+		types.NewFeeAllowance(newFee(100), expiration, nil)))
+
+	payment, err := keeper.UseGrantedFees(suite.ctx, suite.granter, suite.grantee, newFee(100), nil)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(payment.UsedUpGrant)
+	_, found := keeper.GetFeeAllowance(suite.ctx, suite.granter, suite.grantee)
+	suite.Require().False(found)
+
+	// nothing refunded leaves the allowance removed
+	keeper.RestoreFeeAllowance(suite.ctx, payment, sdk.NewCoins())
+	_, found = keeper.GetFeeAllowance(suite.ctx, suite.granter, suite.grantee)
+	suite.Require().False(found)
+
+	keeper.RestoreFeeAllowance(suite.ctx, payment, newFee(30))
+	grant, found := keeper.GetFeeAllowance(suite.ctx, suite.granter, suite.grantee)
+	suite.Require().True(found)
+	suite.Require().True(newFee(30).IsEqual(grant.Allowance.SpendLimit))
+	suite.Require().True(expiration.Equal(grant.Allowance.Expiration))
+}
+
+func (suite *KeeperTestSuite) TestContractSponsorships() {
+	keeper := suite.app.FeeGrantKeeper
+	gasPrice := sdk.OneDec()
+
+	// any account may sponsor a contract besides its other sponsors
+	sponsors := []sdk.AccAddress{suite.granter, suite.grantee}
+	if bytes.Compare(sponsors[0], sponsors[1]) > 0 {
+		sponsors[0], sponsors[1] = sponsors[1], sponsors[0]
+	}
+	keeper.SetContractSponsorship(suite.ctx, types.NewContractSponsorship(sponsors[0], suite.contract,
+		types.NewFeeAllowance(newFee(50), time.Time{}, nil), gasPrice))
+	keeper.SetContractSponsorship(suite.ctx, types.NewContractSponsorship(sponsors[1], suite.contract,
+		types.NewFeeAllowance(newFee(100), time.Time{}, nil), gasPrice))
+	suite.Require().Len(keeper.GetContractSponsorships(suite.ctx, suite.contract), 2)
+
+	// the sponsors pay in the order of their addresses, while their allowance lasts
+	payment, ok := keeper.UseSponsoredFees(suite.ctx, suite.grantee, suite.contract, gasPrice, newFee(40))
+	suite.Require().True(ok)
+	suite.Require().Equal(sponsors[0], payment.Granter)
+
+	sponsor, ok := keeper.GetSponsoredFeePayer(suite.ctx, suite.contract, gasPrice, newFee(40))
+	suite.Require().True(ok)
+	suite.Require().Equal(sponsors[1], sponsor)
+	payment, ok = keeper.UseSponsoredFees(suite.ctx, suite.grantee, suite.contract, gasPrice, newFee(40))
+	suite.Require().True(ok)
+	suite.Require().Equal(sponsors[1], payment.Granter)
+
+	sponsorship, found := keeper.GetContractSponsorship(suite.ctx, sponsors[0], suite.contract)
+	suite.Require().True(found)
+	suite.Require().True(newFee(10).IsEqual(sponsorship.Allowance.SpendLimit))
+	sponsorship, found = keeper.GetContractSponsorship(suite.ctx, sponsors[1], suite.contract)
+	suite.Require().True(found)
+	suite.Require().True(newFee(60).IsEqual(sponsorship.Allowance.SpendLimit))
+
+	keeper.DeleteContractSponsorship(suite.ctx, sponsors[1], suite.contract)
+	_, ok = keeper.UseSponsoredFees(suite.ctx, suite.grantee, suite.contract, gasPrice, newFee(40))
+	suite.Require().False(ok)
+}
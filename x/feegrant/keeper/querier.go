@@ -0,0 +1,104 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/okex/exchain/x/common"
+	"github.com/okex/exchain/x/feegrant/types"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, error) {
+		if len(path) < 1 {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+				"Insufficient parameters, at least 1 parameter is required")
+		}
+		if !common.HasVenusStores(ctx.BlockHeight()) {
+			return nil, types.ErrFeeGrantUnavailable
+		}
+
+		switch path[0] {
+		case types.QueryFeeAllowance:
+			return queryFeeAllowance(ctx, path, keeper)
+		case types.QueryFeeAllowances:
+			return queryFeeAllowances(ctx, path, keeper)
+		case types.QueryContractSponsorships:
+			return queryContractSponsorships(ctx, path, keeper)
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown query endpoint")
+		}
+	}
+}
+
+func queryFeeAllowance(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 3 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 3 parameters is required")
+	}
+
+	granter, err := sdk.AccAddressFromBech32(path[1])
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+	grantee, err := sdk.AccAddressFromBech32(path[2])
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	grant, found := keeper.GetFeeAllowance(ctx, granter, grantee)
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrFeeAllowanceNotFound, "granter %s, grantee %s", granter, grantee)
+	}
+
+	return marshalJSONIndent(grant)
+}
+
+// queryFeeAllowances returns all the allowances granted to a grantee
+func queryFeeAllowances(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 2 parameters is required")
+	}
+
+	grantee, err := sdk.AccAddressFromBech32(path[1])
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	grants := make([]types.FeeAllowanceGrant, 0)
+	keeper.IterateFeeAllowances(ctx, func(grant types.FeeAllowanceGrant) bool {
+		if grant.Grantee.Equals(grantee) {
+			grants = append(grants, grant)
+		}
+		return false
+	})
+
+	return marshalJSONIndent(grants)
+}
+
+// queryContractSponsorships returns all the sponsorships of a contract
+func queryContractSponsorships(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 2 parameters is required")
+	}
+
+	contract, err := types.AccAddressFromHexOrBech32(path[1])
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+
+	return marshalJSONIndent(keeper.GetContractSponsorships(ctx, contract))
+}
+
+func marshalJSONIndent(v interface{}) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, v)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("failed to marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// FeeAllowance limits the fees a granter pays on behalf of someone else
+type FeeAllowance struct {
+	// SpendLimit is the maximum amount of fees that can be paid. An empty limit means no limit
+	SpendLimit sdk.SysCoins `json:"spend_limit"`
+	// Expiration is the time after which the allowance can't be used. A zero time never expires
+	Expiration time.Time `json:"expiration"`
+	// AllowedMsgs lists the "route/type" of the messages the allowance may pay for. Empty allows all
+	AllowedMsgs []string `json:"allowed_msgs"`
+}
+
+// NewFeeAllowance creates a new FeeAllowance object
+func NewFeeAllowance(spendLimit sdk.SysCoins, expiration time.Time, allowedMsgs []string) FeeAllowance {
+	return FeeAllowance{
+		SpendLimit:  spendLimit,
+		Expiration:  expiration,
+		AllowedMsgs: allowedMsgs,
+	}
+}
+
+// ValidateBasic runs stateless checks on the allowance
+func (a FeeAllowance) ValidateBasic() error {
+	if !a.SpendLimit.IsValid() {
+		return sdkerrors.Wrapf(ErrInvalidAllowance, "invalid spend limit: %s", a.SpendLimit)
+	}
+	for _, msgType := range a.AllowedMsgs {
+		if len(strings.Split(msgType, "/")) != 2 {
+			return sdkerrors.Wrapf(ErrInvalidAllowance, "allowed msg %q must be in route/type format", msgType)
+		}
+	}
+	return nil
+}
+
+// IsExpired returns true if the allowance can no longer be used at the given block time
+func (a FeeAllowance) IsExpired(blockTime time.Time) bool {
+	return !a.Expiration.IsZero() && !blockTime.Before(a.Expiration)
+}
+
+// AllowsMsgs returns true if every message is covered by the allowance
+func (a FeeAllowance) AllowsMsgs(msgs []sdk.Msg) bool {
+	if len(a.AllowedMsgs) == 0 {
+		return true
+	}
+
+	allowed := make(map[string]struct{}, len(a.AllowedMsgs))
+	for _, msgType := range a.AllowedMsgs {
+		allowed[msgType] = struct{}{}
+	}
+	for _, msg := range msgs {
+		if _, ok := allowed[MsgTypeURL(msg)]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Accept checks whether the fee can be paid from the allowance at the given block time and returns
+// the allowance left after paying it. remove is true when nothing is left of the allowance.
+func (a FeeAllowance) Accept(fee sdk.SysCoins, blockTime time.Time) (left FeeAllowance, remove bool, err error) {
+	if a.IsExpired(blockTime) {
+		return a, true, ErrFeeAllowanceExpired
+	}
+
+	if a.SpendLimit.Empty() {
+		return a, false, nil
+	}
+
+	remaining, isNeg := a.SpendLimit.SafeSub(fee)
+	if isNeg {
+		return a, false, sdkerrors.Wrapf(ErrFeeLimitExceeded, "fee %s, spend limit %s", fee, a.SpendLimit)
+	}
+
+	a.SpendLimit = remaining
+	return a, remaining.IsZero(), nil
+}
+
+// Restore gives back unused fees to the allowance. It is a no-op for unlimited allowances
+func (a FeeAllowance) Restore(refund sdk.SysCoins) FeeAllowance {
+	if a.SpendLimit.Empty() {
+		return a
+	}
+	a.SpendLimit = a.SpendLimit.Add(refund...)
+	return a
+}
+
+// String implements the Stringer interface
+func (a FeeAllowance) String() string {
+	return fmt.Sprintf(`SpendLimit:  %s
+Expiration:  %s
+AllowedMsgs: %s`, a.SpendLimit, a.Expiration, strings.Join(a.AllowedMsgs, ","))
+}
+
+// MsgTypeURL returns the "route/type" identifier of a message used by FeeAllowance.AllowedMsgs
+func MsgTypeURL(msg sdk.Msg) string {
+	return msg.Route() + "/" + msg.Type()
+}
+
+// FeeAllowanceGrant is a fee allowance granted by granter to grantee
+type FeeAllowanceGrant struct {
+	Granter   sdk.AccAddress `json:"granter"`
+	Grantee   sdk.AccAddress `json:"grantee"`
+	Allowance FeeAllowance   `json:"allowance"`
+}
+
+// NewFeeAllowanceGrant creates a new FeeAllowanceGrant object
+func NewFeeAllowanceGrant(granter, grantee sdk.AccAddress, allowance FeeAllowance) FeeAllowanceGrant {
+	return FeeAllowanceGrant{
+		Granter:   granter,
+		Grantee:   grantee,
+		Allowance: allowance,
+	}
+}
+
+// ValidateBasic runs stateless checks on the grant
+func (g FeeAllowanceGrant) ValidateBasic() error {
+	if g.Granter.Empty() || g.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "granter and grantee are required")
+	}
+	if g.Granter.Equals(g.Grantee) {
+		return ErrSelfGrant
+	}
+	return g.Allowance.ValidateBasic()
+}
+
+// ContractSponsorship is an allowance paying the gas of every EVM call to a contract whose gas price
+// doesn't exceed the max gas price set by the sponsor
+type ContractSponsorship struct {
+	Granter     sdk.AccAddress `json:"granter"`
+	Contract    sdk.AccAddress `json:"contract"`
+	Allowance   FeeAllowance   `json:"allowance"`
+	MaxGasPrice sdk.Dec        `json:"max_gas_price"`
+}
+
+// NewContractSponsorship creates a new ContractSponsorship object
+func NewContractSponsorship(granter, contract sdk.AccAddress, allowance FeeAllowance, maxGasPrice sdk.Dec) ContractSponsorship {
+	return ContractSponsorship{
+		Granter:     granter,
+		Contract:    contract,
+		Allowance:   allowance,
+		MaxGasPrice: maxGasPrice,
+	}
+}
+
+// ValidateBasic runs stateless checks on the sponsorship
+func (s ContractSponsorship) ValidateBasic() error {
+	if s.Granter.Empty() || s.Contract.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "granter and contract are required")
+	}
+	if len(s.Allowance.AllowedMsgs) != 0 {
+		return sdkerrors.Wrap(ErrInvalidAllowance, "allowed msgs are not supported by contract sponsorships")
+	}
+	if s.MaxGasPrice.IsNil() || !s.MaxGasPrice.IsPositive() {
+		return sdkerrors.Wrap(ErrInvalidAllowance, "max gas price must be positive")
+	}
+	return s.Allowance.ValidateBasic()
+}
+
+// CoversGasPrice returns true if the sponsor pays for calls with the given gas price
+func (s ContractSponsorship) CoversGasPrice(gasPrice sdk.Dec) bool {
+	return !s.MaxGasPrice.IsNil() && gasPrice.LTE(s.MaxGasPrice)
+}
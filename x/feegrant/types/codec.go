@@ -0,0 +1,24 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers concrete types on codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgGrantFeeAllowance{}, "okexchain/feegrant/MsgGrantFeeAllowance", nil)
+	cdc.RegisterConcrete(MsgRevokeFeeAllowance{}, "okexchain/feegrant/MsgRevokeFeeAllowance", nil)
+	cdc.RegisterConcrete(MsgSponsorContract{}, "okexchain/feegrant/MsgSponsorContract", nil)
+	cdc.RegisterConcrete(MsgRevokeContractSponsorship{}, "okexchain/feegrant/MsgRevokeContractSponsorship", nil)
+	cdc.RegisterConcrete(FeeGrantTx{}, "okexchain/feegrant/FeeGrantTx", nil)
+}
+
+// ModuleCdc defines the module codec
+var ModuleCdc *codec.Codec
+
+func init() {
+	ModuleCdc = codec.New()
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
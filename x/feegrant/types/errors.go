@@ -0,0 +1,31 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+var (
+	// ErrFeeLimitExceeded returns an error when the fee exceeds the spend limit of an allowance
+	ErrFeeLimitExceeded = sdkerrors.Register(ModuleName, 2, "fee limit exceeded")
+
+	// ErrFeeAllowanceExpired returns an error when the allowance has expired
+	ErrFeeAllowanceExpired = sdkerrors.Register(ModuleName, 3, "fee allowance expired")
+
+	// ErrFeeAllowanceNotFound returns an error when no allowance exists between granter and grantee
+	ErrFeeAllowanceNotFound = sdkerrors.Register(ModuleName, 4, "fee allowance not found")
+
+	// ErrMessageNotAllowed returns an error when the allowance does not cover a message of the tx
+	ErrMessageNotAllowed = sdkerrors.Register(ModuleName, 5, "message not allowed by fee allowance")
+
+	// ErrInvalidAllowance returns an error when the allowance is malformed
+	ErrInvalidAllowance = sdkerrors.Register(ModuleName, 6, "invalid fee allowance")
+
+	// ErrSponsorshipNotFound returns an error when the contract is not sponsored by the granter
+	ErrSponsorshipNotFound = sdkerrors.Register(ModuleName, 7, "contract sponsorship not found")
+
+	// ErrSelfGrant returns an error when the granter and the grantee are the same account
+	ErrSelfGrant = sdkerrors.Register(ModuleName, 9, "cannot grant fee allowance to self")
+
+	// ErrFeeGrantUnavailable returns an error when the fee grants are used before the Venus upgrade adds their store
+	ErrFeeGrantUnavailable = sdkerrors.Register(ModuleName, 10, "fee grants are not available before the Venus upgrade")
+)
@@ -0,0 +1,15 @@
+package types
+
+// feegrant module event types
+const (
+	EventTypeGrantFeeAllowance         = "grant_fee_allowance"
+	EventTypeRevokeFeeAllowance        = "revoke_fee_allowance"
+	EventTypeUseFeeGrant               = "use_fee_grant"
+	EventTypeSponsorContract           = "sponsor_contract"
+	EventTypeRevokeContractSponsorship = "revoke_contract_sponsorship"
+	EventTypeUseContractSponsorship    = "use_contract_sponsorship"
+
+	AttributeKeyGranter  = "granter"
+	AttributeKeyGrantee  = "grantee"
+	AttributeKeyContract = "contract"
+)
@@ -0,0 +1,51 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// ModuleName is the name of the module
+	ModuleName = "feegrant"
+
+	// StoreKey to be used when creating the KVStore
+	StoreKey = ModuleName
+
+	// RouterKey to be used for routing msgs
+	RouterKey = ModuleName
+
+	// QuerierRoute to be used for querier msgs
+	QuerierRoute = ModuleName
+
+	// query endpoints supported by the feegrant Querier
+	QueryFeeAllowance         = "allowance"
+	QueryFeeAllowances        = "allowances"
+	QueryContractSponsorships = "sponsorships"
+)
+
+var (
+	// FeeAllowanceKeyPrefix is the prefix of the fee allowances keyed by granter and grantee
+	FeeAllowanceKeyPrefix = []byte{0x01}
+	// ContractSponsorshipKeyPrefix is the prefix of the contract sponsorships keyed by contract and granter
+	ContractSponsorshipKeyPrefix = []byte{0x02}
+)
+
+// GetFeeAllowanceKey returns the store key of the fee allowance granted by granter to grantee
+func GetFeeAllowanceKey(granter, grantee sdk.AccAddress) []byte {
+	return append(GetFeeAllowancesByGranterKey(granter), grantee.Bytes()...)
+}
+
+// GetFeeAllowancesByGranterKey returns the prefix of all fee allowances granted by granter
+func GetFeeAllowancesByGranterKey(granter sdk.AccAddress) []byte {
+	return append(append(FeeAllowanceKeyPrefix, byte(len(granter))), granter.Bytes()...)
+}
+
+// GetContractSponsorshipKey returns the store key of the sponsorship of a contract by granter
+func GetContractSponsorshipKey(granter, contract sdk.AccAddress) []byte {
+	return append(GetContractSponsorshipsKey(contract), granter.Bytes()...)
+}
+
+// GetContractSponsorshipsKey returns the prefix of all sponsorships of a contract
+func GetContractSponsorshipsKey(contract sdk.AccAddress) []byte {
+	return append(append(ContractSponsorshipKeyPrefix, byte(len(contract))), contract.Bytes()...)
+}
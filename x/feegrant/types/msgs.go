@@ -0,0 +1,173 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// feegrant message types
+const (
+	TypeMsgGrantFeeAllowance         = "grant_fee_allowance"
+	TypeMsgRevokeFeeAllowance        = "revoke_fee_allowance"
+	TypeMsgSponsorContract           = "sponsor_contract"
+	TypeMsgRevokeContractSponsorship = "revoke_contract_sponsorship"
+)
+
+var (
+	_ sdk.Msg = MsgGrantFeeAllowance{}
+	_ sdk.Msg = MsgRevokeFeeAllowance{}
+	_ sdk.Msg = MsgSponsorContract{}
+	_ sdk.Msg = MsgRevokeContractSponsorship{}
+)
+
+// MsgGrantFeeAllowance grants a fee allowance to the grantee, replacing any previous one
+type MsgGrantFeeAllowance struct {
+	Granter   sdk.AccAddress `json:"granter"`
+	Grantee   sdk.AccAddress `json:"grantee"`
+	Allowance FeeAllowance   `json:"allowance"`
+}
+
+// NewMsgGrantFeeAllowance is a constructor function for MsgGrantFeeAllowance
+func NewMsgGrantFeeAllowance(granter, grantee sdk.AccAddress, allowance FeeAllowance) MsgGrantFeeAllowance {
+	return MsgGrantFeeAllowance{
+		Granter:   granter,
+		Grantee:   grantee,
+		Allowance: allowance,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgGrantFeeAllowance) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgGrantFeeAllowance) Type() string { return TypeMsgGrantFeeAllowance }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgGrantFeeAllowance) ValidateBasic() error {
+	return NewFeeAllowanceGrant(msg.Granter, msg.Grantee, msg.Allowance).ValidateBasic()
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgGrantFeeAllowance) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgGrantFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgRevokeFeeAllowance removes the fee allowance granted to the grantee
+type MsgRevokeFeeAllowance struct {
+	Granter sdk.AccAddress `json:"granter"`
+	Grantee sdk.AccAddress `json:"grantee"`
+}
+
+// NewMsgRevokeFeeAllowance is a constructor function for MsgRevokeFeeAllowance
+func NewMsgRevokeFeeAllowance(granter, grantee sdk.AccAddress) MsgRevokeFeeAllowance {
+	return MsgRevokeFeeAllowance{
+		Granter: granter,
+		Grantee: grantee,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRevokeFeeAllowance) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRevokeFeeAllowance) Type() string { return TypeMsgRevokeFeeAllowance }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRevokeFeeAllowance) ValidateBasic() error {
+	if msg.Granter.Empty() || msg.Grantee.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "granter and grantee are required")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRevokeFeeAllowance) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRevokeFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgSponsorContract makes the granter pay the gas of EVM calls to the contract, up to a max gas price
+type MsgSponsorContract struct {
+	Granter     sdk.AccAddress `json:"granter"`
+	Contract    sdk.AccAddress `json:"contract"`
+	Allowance   FeeAllowance   `json:"allowance"`
+	MaxGasPrice sdk.Dec        `json:"max_gas_price"`
+}
+
+// NewMsgSponsorContract is a constructor function for MsgSponsorContract
+func NewMsgSponsorContract(granter, contract sdk.AccAddress, allowance FeeAllowance, maxGasPrice sdk.Dec) MsgSponsorContract {
+	return MsgSponsorContract{
+		Granter:     granter,
+		Contract:    contract,
+		Allowance:   allowance,
+		MaxGasPrice: maxGasPrice,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSponsorContract) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSponsorContract) Type() string { return TypeMsgSponsorContract }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSponsorContract) ValidateBasic() error {
+	return NewContractSponsorship(msg.Granter, msg.Contract, msg.Allowance, msg.MaxGasPrice).ValidateBasic()
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSponsorContract) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSponsorContract) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgRevokeContractSponsorship stops the granter from paying the gas of EVM calls to the contract
+type MsgRevokeContractSponsorship struct {
+	Granter  sdk.AccAddress `json:"granter"`
+	Contract sdk.AccAddress `json:"contract"`
+}
+
+// NewMsgRevokeContractSponsorship is a constructor function for MsgRevokeContractSponsorship
+func NewMsgRevokeContractSponsorship(granter, contract sdk.AccAddress) MsgRevokeContractSponsorship {
+	return MsgRevokeContractSponsorship{
+		Granter:  granter,
+		Contract: contract,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRevokeContractSponsorship) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRevokeContractSponsorship) Type() string { return TypeMsgRevokeContractSponsorship }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRevokeContractSponsorship) ValidateBasic() error {
+	if msg.Granter.Empty() || msg.Contract.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "granter and contract are required")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRevokeContractSponsorship) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRevokeContractSponsorship) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
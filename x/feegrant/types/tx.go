@@ -0,0 +1,162 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/mempool"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/exported"
+)
+
+var _ sdk.Tx = (*FeeGrantTx)(nil)
+
+// FeeGrantTx is a StdTx whose fee is paid by FeeAccount out of the fee allowance it granted
+// to the first signer. An empty FeeAccount makes the first signer pay, as with StdTx.
+type FeeGrantTx struct {
+	Msgs       []sdk.Msg           `json:"msg" yaml:"msg"`
+	Fee        auth.StdFee         `json:"fee" yaml:"fee"`
+	FeeAccount sdk.AccAddress      `json:"fee_account" yaml:"fee_account"`
+	Signatures []auth.StdSignature `json:"signatures" yaml:"signatures"`
+	Memo       string              `json:"memo" yaml:"memo"`
+}
+
+// NewFeeGrantTx creates a new FeeGrantTx object
+func NewFeeGrantTx(msgs []sdk.Msg, fee auth.StdFee, feeAccount sdk.AccAddress, sigs []auth.StdSignature, memo string) FeeGrantTx {
+	return FeeGrantTx{
+		Msgs:       msgs,
+		Fee:        fee,
+		FeeAccount: feeAccount,
+		Signatures: sigs,
+		Memo:       memo,
+	}
+}
+
+func (tx FeeGrantTx) stdTx() auth.StdTx {
+	return auth.NewStdTx(tx.Msgs, tx.Fee, tx.Signatures, tx.Memo)
+}
+
+// GetMsgs returns the all the transaction's messages.
+func (tx FeeGrantTx) GetMsgs() []sdk.Msg { return tx.Msgs }
+
+// ValidateBasic does a simple and lightweight validation check that doesn't
+// require access to any other information.
+func (tx FeeGrantTx) ValidateBasic() error {
+	if err := tx.stdTx().ValidateBasic(); err != nil {
+		return err
+	}
+	if signers := tx.GetSigners(); len(signers) > 0 && tx.FeeAccount.Equals(signers[0]) {
+		return sdkerrors.Wrap(ErrSelfGrant, "fee account must differ from the first signer")
+	}
+	return nil
+}
+
+// GetSigners returns the addresses that must sign the transaction.
+func (tx FeeGrantTx) GetSigners() []sdk.AccAddress { return tx.stdTx().GetSigners() }
+
+// GetMemo returns the memo
+func (tx FeeGrantTx) GetMemo() string { return tx.Memo }
+
+// GetSignatures returns the signature of signers who signed the Msg.
+func (tx FeeGrantTx) GetSignatures() [][]byte { return tx.stdTx().GetSignatures() }
+
+// GetPubKeys returns the pubkeys of signers if the pubkey is included in the signature
+func (tx FeeGrantTx) GetPubKeys() []crypto.PubKey { return tx.stdTx().GetPubKeys() }
+
+// GetSignBytes returns the signBytes of the tx for a given signer. Unlike StdTx, the fee
+// account is signed over so that it can't be swapped by a relayer.
+func (tx FeeGrantTx) GetSignBytes(ctx sdk.Context, acc exported.Account) []byte {
+	var accNum uint64
+	if ctx.BlockHeight() != 0 {
+		accNum = acc.GetAccountNumber()
+	}
+
+	return FeeGrantSignBytes(ctx.ChainID(), accNum, acc.GetSequence(), tx.Fee, tx.FeeAccount, tx.Msgs, tx.Memo)
+}
+
+// GetGas returns the Gas in StdFee
+func (tx FeeGrantTx) GetGas() uint64 { return tx.Fee.Gas }
+
+// GetFee returns the FeeAmount in StdFee
+func (tx FeeGrantTx) GetFee() sdk.Coins { return tx.Fee.Amount }
+
+// FeePayer returns the first signer, who is the grantee of the fee allowance
+func (tx FeeGrantTx) FeePayer(ctx sdk.Context) sdk.AccAddress { return tx.stdTx().FeePayer(ctx) }
+
+// FeeGranter returns the account paying the fee out of its fee allowance
+func (tx FeeGrantTx) FeeGranter() sdk.AccAddress { return tx.FeeAccount }
+
+// GetTxInfo return tx sender and gas price
+func (tx FeeGrantTx) GetTxInfo(ctx sdk.Context) mempool.ExTxInfo { return tx.stdTx().GetTxInfo(ctx) }
+
+// GetGasPrice return gas price
+func (tx FeeGrantTx) GetGasPrice() *big.Int { return tx.stdTx().GetGasPrice() }
+
+// FeeGrantSignDoc is replay-prevention structure for FeeGrantTx
+type FeeGrantSignDoc struct {
+	AccountNumber uint64            `json:"account_number" yaml:"account_number"`
+	ChainID       string            `json:"chain_id" yaml:"chain_id"`
+	Fee           json.RawMessage   `json:"fee" yaml:"fee"`
+	FeeAccount    sdk.AccAddress    `json:"fee_account" yaml:"fee_account"`
+	Memo          string            `json:"memo" yaml:"memo"`
+	Msgs          []json.RawMessage `json:"msgs" yaml:"msgs"`
+	Sequence      uint64            `json:"sequence" yaml:"sequence"`
+}
+
+// FeeGrantSignBytes returns the bytes to sign for a FeeGrantTx
+func FeeGrantSignBytes(chainID string, accnum, sequence uint64, fee auth.StdFee, feeAccount sdk.AccAddress,
+	msgs []sdk.Msg, memo string) []byte {
+	msgsBytes := make([]json.RawMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		msgsBytes = append(msgsBytes, json.RawMessage(msg.GetSignBytes()))
+	}
+
+	bz := ModuleCdc.MustMarshalJSON(FeeGrantSignDoc{
+		AccountNumber: accnum,
+		ChainID:       chainID,
+		Fee:           json.RawMessage(fee.Bytes()),
+		FeeAccount:    feeAccount,
+		Memo:          memo,
+		Msgs:          msgsBytes,
+		Sequence:      sequence,
+	})
+	return sdk.MustSortJSON(bz)
+}
+
+// FeePayment records who paid the fee of a tx out of which allowance, so that unused
+// gas is refunded to the same account and allowance
+type FeePayment struct {
+	Granter  sdk.AccAddress
+	Grantee  sdk.AccAddress
+	Contract sdk.AccAddress
+
+	// UsedUpGrant is the grant removed from the store as the fee used it up, if any
+	UsedUpGrant *FeeAllowanceGrant
+	// UsedUpSponsorship is the sponsorship removed from the store as the fee used it up, if any
+	UsedUpSponsorship *ContractSponsorship
+}
+
+type feePaymentKey struct{}
+
+// ContextWithFeePayment records the fee payment in the context
+func ContextWithFeePayment(ctx sdk.Context, payment FeePayment) sdk.Context {
+	parent := ctx.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+	return ctx.WithContext(context.WithValue(parent, feePaymentKey{}, payment))
+}
+
+// FeePaymentFromContext returns the fee payment recorded in the context, if any
+func FeePaymentFromContext(ctx sdk.Context) (FeePayment, bool) {
+	if ctx.Context() == nil {
+		return FeePayment{}, false
+	}
+	payment, ok := ctx.Context().Value(feePaymentKey{}).(FeePayment)
+	return payment, ok
+}
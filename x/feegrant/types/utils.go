@@ -0,0 +1,16 @@
+package types
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// AccAddressFromHexOrBech32 parses a contract address given either in hex or in bech32 format
+func AccAddressFromHexOrBech32(address string) (sdk.AccAddress, error) {
+	if strings.HasPrefix(address, "0x") && ethcmn.IsHexAddress(address) {
+		return ethcmn.HexToAddress(address).Bytes(), nil
+	}
+	return sdk.AccAddressFromBech32(address)
+}
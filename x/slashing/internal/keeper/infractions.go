@@ -6,6 +6,7 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/slashing/internal/types"
 )
 
@@ -20,6 +21,11 @@ func (k Keeper) HandleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 		panic(fmt.Sprintf("Validator consensus-address %s not found", consAddr))
 	}
 
+	// the liveness of a validator in maintenance isn't tracked
+	if validator := k.sk.ValidatorByConsAddr(ctx, consAddr); validator != nil && validator.IsInMaintenance() {
+		return
+	}
+
 	// fetch signing info
 	signInfo, found := k.GetValidatorSigningInfo(ctx, consAddr)
 	if !found {
@@ -80,7 +86,7 @@ func (k Keeper) HandleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 			// Note that this *can* result in a negative "distributionHeight" up to -ValidatorUpdateDelay-1,
 			// i.e. at the end of the pre-genesis block (none) = at the beginning of the genesis block.
 			// That's fine since this is just used to filter unbonding delegations & redelegations.
-			//distributionHeight := height - sdk.ValidatorUpdateDelay - 1
+			distributionHeight := height - sdk.ValidatorUpdateDelay - 1
 
 			ctx.EventManager().EmitEvent(
 				sdk.NewEvent(
@@ -91,8 +97,12 @@ func (k Keeper) HandleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 					sdk.NewAttribute(types.AttributeKeyJailed, consAddr.String()),
 				),
 			)
-			//don't slashing tokens, just jail the validator
-			//k.sk.Slash(ctx, consAddr, distributionHeight, power, k.SlashFractionDowntime(ctx))
+			// the tokens aren't slashed before the slash milestone, when the validator is just jailed
+			if common.HigherThanSlash(height) {
+				if err := k.sk.Slash(ctx, consAddr, distributionHeight, power, k.SlashFractionDowntime(ctx)); err != nil {
+					logger.Error(fmt.Sprintf("failed to slash %s for downtime: %s", consAddr, err))
+				}
+			}
 			k.sk.Jail(ctx, consAddr)
 			k.GetStakingKeeper().AppendAbandonedValidatorAddrs(ctx, consAddr)
 
@@ -46,8 +46,10 @@ var (
 	NewValidator                       = types.NewValidator
 	NewDescription                     = types.NewDescription
 	NewMsgAddShares                    = types.NewMsgAddShares
+	NewMsgEnterMaintenance             = types.NewMsgEnterMaintenance
 	NewGenesisState                    = types.NewGenesisState
 	DelegatorAddSharesInvariant        = keeper.DelegatorAddSharesInvariant
+	MaintenanceInvariant               = keeper.MaintenanceInvariant
 
 	// variable aliases
 	ModuleCdc     = types.ModuleCdc
@@ -32,6 +32,8 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		GetCmdQueryValidator(queryRoute, cdc),
 		GetCmdQueryValidators(queryRoute, cdc),
 		GetCmdQueryProxy(queryRoute, cdc),
+		GetCmdQueryValidatorSlashes(queryRoute, cdc),
+		GetCmdQueryDelegatorSlashes(queryRoute, cdc),
 		GetCmdQueryParams(queryRoute, cdc),
 		GetCmdQueryPool(queryRoute, cdc))...)
 
@@ -386,3 +388,91 @@ $ %s query staking shares-added-to exvaloper1alq9na49n9yycysh889rl90g9nhe58lcqkf
 		},
 	}
 }
+
+// GetCmdQueryValidatorSlashes gets command for querying the slash history of a validator
+func GetCmdQueryValidatorSlashes(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "slashes [validator-addr]",
+		Short: "query the slash history of a validator",
+		Args:  cobra.ExactArgs(1),
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the slash history of a validator and the tokens each delegator lost in every slash.
+
+Example:
+$ %s query staking slashes exvaloper1alq9na49n9yycysh889rl90g9nhe58lcqkfpfg
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			valAddr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bytes, err := cdc.MarshalJSON(types.NewQueryValidatorParams(valAddr))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryValidatorSlashes)
+			resp, _, err := cliCtx.QueryWithData(route, bytes)
+			if err != nil {
+				return err
+			}
+
+			var events types.SlashEvents
+			if err := cdc.UnmarshalJSON(resp, &events); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(events)
+		},
+	}
+}
+
+// GetCmdQueryDelegatorSlashes gets command for querying the slashes suffered by a delegator
+func GetCmdQueryDelegatorSlashes(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delegator-slashes [delegator-addr]",
+		Short: "query the slashes suffered by a delegator",
+		Args:  cobra.ExactArgs(1),
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the slashes suffered by a delegator, including the ones through its proxy.
+
+Example:
+$ %s query staking delegator-slashes ex1cftp8q8g4aa65nw9s5trwexe77d9t6cr8ndu02
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			delAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			bytes, err := cdc.MarshalJSON(types.NewQueryDelegatorParams(delAddr))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDelegatorSlashes)
+			resp, _, err := cliCtx.QueryWithData(route, bytes)
+			if err != nil {
+				return err
+			}
+
+			var slashes types.DelegatorSlashes
+			if err := cdc.UnmarshalJSON(resp, &slashes); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(slashes)
+		},
+	}
+}
@@ -39,6 +39,7 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		flags.PostCommands(
 			GetCmdCreateValidator(cdc),
 			GetCmdDestroyValidator(cdc),
+			GetCmdEnterMaintenance(cdc),
 			GetCmdEditValidator(cdc),
 			GetCmdDeposit(cdc),
 			GetCmdWithdraw(cdc),
@@ -3,6 +3,7 @@ package cli
 import (
 	"bufio"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -45,6 +46,38 @@ $ %s tx staking destroy-validator --from mykey
 	}
 }
 
+// GetCmdEnterMaintenance gets command for taking a validator offline for maintenance
+func GetCmdEnterMaintenance(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "enter-maintenance [blocks]",
+		Args:  cobra.ExactArgs(1),
+		Short: "take the validator offline for maintenance without being punished for downtime",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Take the validator offline for maintenance for a number of blocks (at most %d).
+The validator leaves the validator-set and keeps all the shares added to it. It returns to the candidates
+automatically when the maintenance ends, or in advance by unjailing.
+
+Example:
+$ %s tx staking enter-maintenance 1000 --from mykey
+`,
+				types.MaxMaintenanceBlocks, version.ClientName),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			blocks, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid blocks %s: %s", args[0], err)
+			}
+
+			msg := types.NewMsgEnterMaintenance(cliCtx.GetFromAddress(), blocks)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
 // GetCmdDeposit gets command for deposit
 func GetCmdDeposit(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -32,6 +32,18 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		delegatorProxyHandlerFn(cliCtx),
 	).Methods("GET")
 
+	// query the slashes suffered by a delegator
+	r.HandleFunc(
+		"/staking/delegators/{delegatorAddr}/slashes",
+		delegatorSlashesHandlerFn(cliCtx),
+	).Methods("GET")
+
+	// query the slash history of a validator
+	r.HandleFunc(
+		"/staking/validators/{validatorAddr}/slashes",
+		validatorSlashesHandlerFn(cliCtx),
+	).Methods("GET")
+
 	// query the all shares on a validator
 	r.HandleFunc(
 		"/staking/validators/{validatorAddr}/shares",
@@ -97,6 +109,16 @@ func delegatorHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return queryDelegator(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryDelegator))
 }
 
+// HTTP request handler to query the slashes suffered by a delegator
+func delegatorSlashesHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryDelegator(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryDelegatorSlashes))
+}
+
+// HTTP request handler to query the slash history of a validator
+func validatorSlashesHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return queryValidator(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryValidatorSlashes))
+}
+
 // HTTP request handler to query the all shares added to a validator
 func validatorAllSharesHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return queryValidator(cliCtx, fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryValidatorAllShares))
@@ -14,6 +14,7 @@ type DelegatorI interface {
 // ValidatorI expected validator functions
 type ValidatorI interface {
 	IsJailed() bool                                         // whether the validator is jailed
+	IsInMaintenance() bool                                  // whether the validator is jailed for maintenance
 	GetMoniker() string                                     // moniker of the validator
 	GetStatus() sdk.BondStatus                              // status of the validator
 	IsBonded() bool                                         // check if has a bonded status
@@ -126,6 +126,9 @@ func initValidator(ctx sdk.Context, valExported ValidatorExport, keeper Keeper,
 	if validator.IsUnbonding() {
 		keeper.InsertValidatorQueue(ctx, validator)
 	}
+	if validator.IsInMaintenance() {
+		keeper.InsertMaintenanceQueue(ctx, validator)
+	}
 	// all the msd on validator should be added into bonded pool
 	*pBondedTokens = pBondedTokens.Add(validator.MinSelfDelegation)
 }
@@ -34,6 +34,8 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleRegProxy(ctx, msg, k)
 		case types.MsgDestroyValidator:
 			return handleMsgDestroyValidator(ctx, msg, k)
+		case types.MsgEnterMaintenance:
+			return handleMsgEnterMaintenance(ctx, msg, k)
 		default:
 			errMsg := fmt.Sprintf("unrecognized staking message type: %T", msg)
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -66,6 +68,9 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper) []abci.ValidatorUpdate {
 	// Unbond all mature validators from the unbonding queue.
 	k.UnbondAllMatureValidatorQueue(ctx)
 
+	// Return all the validators whose maintenance ends to the candidates
+	k.ReturnMatureMaintenanceValidators(ctx)
+
 	k.IterateKeysBeforeCurrentTime(ctx, ctx.BlockHeader().Time,
 		func(index int64, key []byte) (stop bool) {
 			oldTime, delAddr := types.SplitCompleteTimeWithAddrKey(key)
@@ -1,6 +1,7 @@
 package staking
 
 import (
+	"fmt"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -325,3 +326,30 @@ func handleMsgDestroyValidator(ctx sdk.Context, msg types.MsgDestroyValidator, k
 	return &sdk.Result{Data: completionTimeBytes, Events: ctx.EventManager().Events()}, nil
 
 }
+
+func handleMsgEnterMaintenance(ctx sdk.Context, msg types.MsgEnterMaintenance, k keeper.Keeper) (*sdk.Result, error) {
+	valAddr := sdk.ValAddress(msg.DelAddr)
+	validator, found := k.GetValidator(ctx, valAddr)
+	if !found {
+		return ErrNoValidatorFound(valAddr.String()).Result()
+	}
+
+	if err := k.EnterMaintenance(ctx, validator, msg.Blocks); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeEnterMaintenance,
+			sdk.NewAttribute(types.AttributeKeyValidator, valAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyMaintenanceEndHeight, fmt.Sprintf("%d", ctx.BlockHeight()+msg.Blocks)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.DelAddr.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -41,6 +41,74 @@ func TestHandlerDestroyValidator(t *testing.T) {
 	require.Equal(t, 1, len(updates2))
 }
 
+func TestHandlerEnterMaintenance(t *testing.T) {
+	validatorAddr := sdk.ValAddress(Addrs[0])
+	ctx, _, mockKeeper := CreateTestInput(t, false, SufficientInitPower)
+	ctx = ctx.WithBlockHeight(1)
+	keeper := mockKeeper.Keeper
+	handler := NewHandler(keeper)
+
+	// 0.enter maintenance on a nonexistent validator
+	_, err := handler(ctx, types.NewMsgEnterMaintenance([]byte(validatorAddr), 10))
+	require.NotNil(t, err)
+
+	// 1.create a bonded validator
+	_, err = handler(ctx, NewTestMsgCreateValidator(validatorAddr, PKs[0], DefaultMSD))
+	require.Nil(t, err)
+	require.Equal(t, 1, len(keeper.ApplyAndReturnValidatorSetUpdates(ctx)))
+
+	// 2.enter maintenance and leave the validator-set at the end block
+	_, err = handler(ctx, types.NewMsgEnterMaintenance([]byte(validatorAddr), 10))
+	require.Nil(t, err)
+	validator, found := keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.True(t, validator.Jailed)
+	require.Equal(t, int64(11), validator.MaintenanceEndHeight)
+	_, err = handler(ctx, types.NewMsgEnterMaintenance([]byte(validatorAddr), 10))
+	require.NotNil(t, err)
+
+	updates := EndBlocker(ctx, keeper)
+	require.Equal(t, 1, len(updates))
+	require.Equal(t, int64(0), updates[0].Power)
+	_, broken := MaintenanceInvariant(keeper)(ctx)
+	require.False(t, broken)
+	_, broken = DelegatorAddSharesInvariant(keeper)(ctx)
+	require.False(t, broken)
+
+	// 3.return to the candidates automatically when the maintenance ends
+	ctx = ctx.WithBlockHeight(11)
+	EndBlocker(ctx, keeper)
+	validator, found = keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.False(t, validator.Jailed)
+	require.False(t, validator.IsInMaintenance())
+	_, broken = MaintenanceInvariant(keeper)(ctx)
+	require.False(t, broken)
+	require.Equal(t, 1, len(keeper.ApplyAndReturnValidatorSetUpdates(ctx)))
+
+	// 4.unjailing ends the maintenance in advance
+	_, err = handler(ctx, types.NewMsgEnterMaintenance([]byte(validatorAddr), 10))
+	require.Nil(t, err)
+	keeper.Unjail(ctx, validator.ConsAddress())
+	validator, found = keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.False(t, validator.Jailed)
+	require.False(t, validator.IsInMaintenance())
+	_, broken = MaintenanceInvariant(keeper)(ctx)
+	require.False(t, broken)
+
+	// 5.the validator punished during maintenance stays in jail
+	_, err = handler(ctx, types.NewMsgEnterMaintenance([]byte(validatorAddr), 10))
+	require.Nil(t, err)
+	keeper.Jail(ctx, validator.ConsAddress())
+	ctx = ctx.WithBlockHeight(21)
+	EndBlocker(ctx, keeper)
+	validator, found = keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.True(t, validator.Jailed)
+	require.False(t, validator.IsInMaintenance())
+}
+
 type MsgFaked struct {
 	Fakeid int
 }
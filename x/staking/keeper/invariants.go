@@ -20,6 +20,8 @@ func RegisterInvariantsCustom(ir sdk.InvariantRegistry, k Keeper) {
 		PositiveDelegatorInvariant(k))
 	ir.RegisterRoute(types.ModuleName, "delegator-add-shares",
 		DelegatorAddSharesInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "maintenance",
+		MaintenanceInvariant(k))
 }
 
 // DelegatorAddSharesInvariant checks whether all the shares which persist
@@ -118,6 +120,46 @@ func NonNegativePowerInvariantCustom(k Keeper) sdk.Invariant {
 	}
 }
 
+// MaintenanceInvariant checks that all the validators in maintenance are jailed and out of the power store, and
+// that the maintenance queue matches the end heights stored on the validators
+func MaintenanceInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var broken bool
+
+		store := ctx.KVStore(k.storeKey)
+		for _, validator := range k.GetAllValidators(ctx) {
+			if !validator.IsInMaintenance() {
+				continue
+			}
+
+			if !validator.Jailed || store.Has(types.GetValidatorsByPowerIndexKey(validator)) {
+				broken = true
+				msg += fmt.Sprintf("	validator %s in maintenance is still a candidate\n", validator.OperatorAddress)
+			}
+
+			if !store.Has(types.GetMaintenanceQueueKey(validator.MaintenanceEndHeight, validator.OperatorAddress)) {
+				broken = true
+				msg += fmt.Sprintf("	validator %s in maintenance is missing from the maintenance queue\n",
+					validator.OperatorAddress)
+			}
+		}
+
+		k.IterateMaintenanceQueue(ctx, func(_ int64, endHeight int64, valAddr sdk.ValAddress) (stop bool) {
+			validator, found := k.GetValidator(ctx, valAddr)
+			if found && validator.MaintenanceEndHeight != endHeight {
+				broken = true
+				msg += fmt.Sprintf("	maintenance queue ends validator %s at height %d instead of %d\n",
+					valAddr, endHeight, validator.MaintenanceEndHeight)
+			}
+			return false
+		})
+
+		return sdk.FormatInvariant(types.ModuleName, "maintenance",
+			fmt.Sprintf("found invalid validator maintenance\n%s", msg)), broken
+	}
+}
+
 // ModuleAccountInvariantsCustom check invariants for module account
 func ModuleAccountInvariantsCustom(k Keeper) sdk.Invariant {
 	return func(ctx sdk.Context) (string, bool) {
@@ -0,0 +1,108 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/exchain/x/staking/types"
+)
+
+// EnterMaintenance jails a validator voluntarily for a number of blocks. The shares on the validator are kept and
+// the validator returns to the candidates when the maintenance ends or when it's unjailed
+func (k Keeper) EnterMaintenance(ctx sdk.Context, validator types.Validator, blocks int64) error {
+	// 0.check the validator status
+	if validator.MinSelfDelegation.IsZero() {
+		return types.ErrNoMinSelfDelegation(validator.OperatorAddress.String())
+	}
+	if validator.IsInMaintenance() {
+		return types.ErrAlreadyInMaintenance(validator.OperatorAddress.String(), validator.MaintenanceEndHeight)
+	}
+	if validator.Jailed {
+		return types.ErrValidatorJailed(validator.OperatorAddress.String())
+	}
+
+	// 1.jail the validator and kick it out of the power store
+	k.jailValidator(ctx, validator)
+	validator.Jailed = true
+
+	// 2.record the end of maintenance
+	validator.MaintenanceEndHeight = ctx.BlockHeight() + blocks
+	k.SetValidator(ctx, validator)
+	k.InsertMaintenanceQueue(ctx, validator)
+
+	// 3.set the validator info to enforce the update of validator-set
+	if validator.IsBonded() {
+		k.AppendAbandonedValidatorAddrs(ctx, validator.ConsAddress())
+	}
+
+	return nil
+}
+
+// leaveMaintenance clears the maintenance of a validator without changing its jailed status
+func (k Keeper) leaveMaintenance(ctx sdk.Context, validator types.Validator) types.Validator {
+	k.DeleteMaintenanceQueue(ctx, validator)
+	validator.MaintenanceEndHeight = 0
+	k.SetValidator(ctx, validator)
+	return validator
+}
+
+// ReturnMatureMaintenanceValidators unjails all the validators whose maintenance ends before the current height
+func (k Keeper) ReturnMatureMaintenanceValidators(ctx sdk.Context) {
+	logger := k.Logger(ctx)
+	iterator := k.MaintenanceQueueIterator(ctx, ctx.BlockHeight())
+	defer iterator.Close()
+
+	var valAddrs []sdk.ValAddress
+	for ; iterator.Valid(); iterator.Next() {
+		_, valAddr := types.SplitMaintenanceQueueKey(iterator.Key())
+		valAddrs = append(valAddrs, valAddr)
+	}
+
+	for _, valAddr := range valAddrs {
+		validator, found := k.GetValidator(ctx, valAddr)
+		if !found || !validator.IsInMaintenance() {
+			continue
+		}
+
+		validator = k.leaveMaintenance(ctx, validator)
+		// the validator destroyed during maintenance stays in jail
+		if validator.Jailed && !validator.MinSelfDelegation.IsZero() {
+			k.unjailValidator(ctx, validator)
+			logger.Info(fmt.Sprintf("validator %s returned from maintenance", valAddr))
+		}
+	}
+}
+
+// InsertMaintenanceQueue inserts a validator into the maintenance queue
+func (k Keeper) InsertMaintenanceQueue(ctx sdk.Context, validator types.Validator) {
+	ctx.KVStore(k.storeKey).Set(
+		types.GetMaintenanceQueueKey(validator.MaintenanceEndHeight, validator.OperatorAddress), []byte{})
+}
+
+// DeleteMaintenanceQueue deletes a validator from the maintenance queue
+func (k Keeper) DeleteMaintenanceQueue(ctx sdk.Context, validator types.Validator) {
+	ctx.KVStore(k.storeKey).Delete(
+		types.GetMaintenanceQueueKey(validator.MaintenanceEndHeight, validator.OperatorAddress))
+}
+
+// MaintenanceQueueIterator returns all the maintenance queue keys from height 0 until endHeight
+func (k Keeper) MaintenanceQueueIterator(ctx sdk.Context, endHeight int64) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(types.MaintenanceQueueKey, sdk.PrefixEndBytes(types.GetMaintenanceQueueHeightKey(endHeight)))
+}
+
+// IterateMaintenanceQueue iterates through all the validators in the maintenance queue
+func (k Keeper) IterateMaintenanceQueue(ctx sdk.Context,
+	fn func(index int64, endHeight int64, valAddr sdk.ValAddress) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.MaintenanceQueueKey)
+	defer iterator.Close()
+
+	for i := int64(0); iterator.Valid(); iterator.Next() {
+		endHeight, valAddr := types.SplitMaintenanceQueueKey(iterator.Key())
+		if stop := fn(i, endHeight, valAddr); stop {
+			break
+		}
+		i++
+	}
+}
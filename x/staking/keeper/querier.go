@@ -39,6 +39,10 @@ func NewQuerier(k Keeper) sdk.Querier {
 			return queryProxy(ctx, req, k)
 		case types.QueryDelegator:
 			return queryDelegator(ctx, req, k)
+		case types.QueryValidatorSlashes:
+			return queryValidatorSlashes(ctx, req, k)
+		case types.QueryDelegatorSlashes:
+			return queryDelegatorSlashes(ctx, req, k)
 		default:
 			return nil, types.ErrUnknownStakingQueryType()
 		}
@@ -185,6 +189,46 @@ func queryValidatorAllShares(ctx sdk.Context, req abci.RequestQuery, k Keeper) (
 	return resp, nil
 }
 
+func queryValidatorSlashes(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryValidatorParams
+
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, common.ErrUnMarshalJSONFailed(err.Error())
+	}
+
+	events := k.GetValidatorSlashEvents(ctx, params.ValidatorAddr)
+	if events == nil {
+		events = types.SlashEvents{}
+	}
+
+	resp, err := codec.MarshalJSONIndent(types.ModuleCdc, events)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+
+	return resp, nil
+}
+
+func queryDelegatorSlashes(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryDelegatorParams
+
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, common.ErrUnMarshalJSONFailed(err.Error())
+	}
+
+	slashes := k.GetDelegatorSlashes(ctx, params.DelegatorAddr)
+	if slashes == nil {
+		slashes = types.DelegatorSlashes{}
+	}
+
+	resp, err := codec.MarshalJSONIndent(types.ModuleCdc, slashes)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+
+	return resp, nil
+}
+
 func queryUndelegation(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
 	var params types.QueryDelegatorParams
 	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
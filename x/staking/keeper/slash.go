@@ -4,16 +4,188 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/exchain/x/staking/types"
 )
 
-func (k Keeper) Slash(ctx sdk.Context, consAddr sdk.ConsAddress, infractionHeight int64, power int64, slashFactor sdk.Dec) {
+// Slash burns a fraction of the tokens backing a validator
+//
+// In okexchain the voting power of a validator comes from the shares added by delegators instead of the tokens
+// bonded to it, so the slash is applied to the deposits behind those shares:
+//  1. the min self delegation of the validator
+//  2. the tokens of every delegator who added shares to the validator
+//  3. the tokens of every delegator bound to a proxy who added shares to the validator
+//
+// Each of them loses slashFactor of its tokens and the shares are recalculated with the tokens left.
+// Tokens that are already being withdrawn are not slashed because the undelegation doesn't record the height it
+// started at. The power at the infraction height is ignored since the deposits are the source of power here.
+// Nothing is slashed if the shares of a delegator fail to be recalculated.
+func (k Keeper) Slash(ctx sdk.Context, consAddr sdk.ConsAddress, infractionHeight int64, power int64,
+	slashFactor sdk.Dec) error {
+	logger := k.Logger(ctx)
+	if slashFactor.IsNegative() {
+		panic(fmt.Errorf("attempted to slash with a negative slash factor: %v", slashFactor))
+	}
+
+	validator, found := k.GetValidatorByConsAddr(ctx, consAddr)
+	if !found {
+		// the validator might have been removed after the infraction
+		logger.Error(fmt.Sprintf("WARNING: ignored attempt to slash a nonexistent validator with address %s", consAddr))
+		return nil
+	}
+	cacheCtx, writeCache := ctx.CacheContext()
+
+	event := types.SlashEvent{
+		ValidatorAddress:         validator.OperatorAddress,
+		Height:                   ctx.BlockHeight(),
+		InfractionHeight:         infractionHeight,
+		Time:                     ctx.BlockTime(),
+		SlashFactor:              slashFactor,
+		SlashedMinSelfDelegation: validator.MinSelfDelegation.Mul(slashFactor),
+		TotalSlashed:             sdk.ZeroDec(),
+	}
+
+	// 1.slash the min self delegation
+	if event.SlashedMinSelfDelegation.IsPositive() {
+		validator.MinSelfDelegation = validator.MinSelfDelegation.Sub(event.SlashedMinSelfDelegation)
+		k.SetValidator(cacheCtx, validator)
+		event.TotalSlashed = event.SlashedMinSelfDelegation
+	}
+
+	// 2.slash the delegators who added shares to the validator
+	for _, sharesResp := range k.GetValidatorAllShares(cacheCtx, validator.OperatorAddress) {
+		delegator, found := k.GetDelegator(cacheCtx, sharesResp.DelAddr)
+		if !found {
+			continue
+		}
+
+		slashes, err := k.slashDelegator(cacheCtx, delegator, validator.OperatorAddress, slashFactor)
+		if err != nil {
+			return err
+		}
+		for _, slash := range slashes {
+			event.TotalSlashed = event.TotalSlashed.Add(slash.Slashed)
+		}
+		event.Delegators = append(event.Delegators, slashes...)
+	}
+
+	// 3.burn the slashed tokens from the bonded pool
+	if event.TotalSlashed.IsPositive() {
+		coins := sdk.NewDecCoinFromDec(k.BondDenom(ctx), event.TotalSlashed).ToCoins()
+		if err := k.supplyKeeper.BurnCoins(cacheCtx, types.BondedPoolName, coins); err != nil {
+			return err
+		}
+	}
+
+	// 4.record the slash history
+	k.SetSlashEvent(cacheCtx, event)
+	writeCache()
+
+	logger.Info(fmt.Sprintf("validator %s slashed by slash factor of %s; burned %s tokens",
+		validator.OperatorAddress, slashFactor, event.TotalSlashed))
+	return nil
+}
+
+// slashDelegator slashes the tokens of a delegator who added shares to a validator. If the delegator is a proxy,
+// the delegators bound to it are slashed as well
+func (k Keeper) slashDelegator(ctx sdk.Context, delegator types.Delegator, valAddr sdk.ValAddress,
+	slashFactor sdk.Dec) (slashes types.DelegatorSlashes, err error) {
+	height := ctx.BlockHeight()
+	if slashed := delegator.Tokens.Mul(slashFactor); slashed.IsPositive() {
+		slashes = append(slashes, types.NewDelegatorSlash(delegator.DelegatorAddress, valAddr, nil, height,
+			delegator.Tokens, slashed))
+		delegator.Tokens = delegator.Tokens.Sub(slashed)
+	}
+
+	if delegator.IsProxy {
+		for _, delAddr := range k.GetDelegatorsByProxy(ctx, delegator.DelegatorAddress) {
+			proxied, found := k.GetDelegator(ctx, delAddr)
+			if !found {
+				continue
+			}
+
+			slashed := proxied.Tokens.Mul(slashFactor)
+			if !slashed.IsPositive() {
+				continue
+			}
+			slashes = append(slashes, types.NewDelegatorSlash(delAddr, valAddr, delegator.DelegatorAddress, height,
+				proxied.Tokens, slashed))
+			proxied.Tokens = proxied.Tokens.Sub(slashed)
+			k.SetDelegator(ctx, proxied)
+
+			delegator.TotalDelegatedTokens = delegator.TotalDelegatedTokens.Sub(slashed)
+		}
+	}
+
+	if len(slashes) == 0 {
+		// nothing is slashed, so the shares aren't recalculated at the current time
+		return nil, nil
+	}
+	for _, slash := range slashes {
+		k.SetDelegatorSlash(ctx, slash)
+	}
+
+	k.SetDelegator(ctx, delegator)
+	finalTokens := delegator.Tokens
+	if delegator.IsProxy {
+		finalTokens = finalTokens.Add(delegator.TotalDelegatedTokens)
+	}
+	if err = k.UpdateShares(ctx, delegator.DelegatorAddress, finalTokens); err != nil {
+		return nil, fmt.Errorf("failed to update the shares of %s after slashing: %s", delegator.DelegatorAddress, err)
+	}
+
+	return
+}
+
+// SetSlashEvent sets the slash event of a validator into the store
+func (k Keeper) SetSlashEvent(ctx sdk.Context, event types.SlashEvent) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(event)
+	store.Set(types.GetValidatorSlashEventKey(event.ValidatorAddress, event.Height), bz)
+}
+
+// GetValidatorSlashEvents gets all the slash events of a validator in ascending order of height
+func (k Keeper) GetValidatorSlashEvents(ctx sdk.Context, valAddr sdk.ValAddress) (events types.SlashEvents) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetValidatorSlashEventsKey(valAddr))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		events = append(events, types.MustUnmarshalSlashEvent(k.cdc, iterator.Value()))
+	}
+
+	return
+}
+
+// SetDelegatorSlash sets the slash suffered by a delegator into the store
+func (k Keeper) SetDelegatorSlash(ctx sdk.Context, slash types.DelegatorSlash) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(slash)
+	store.Set(types.GetDelegatorSlashKey(slash.DelegatorAddress, slash.Height, slash.ValidatorAddress), bz)
+}
+
+// GetDelegatorSlashes gets all the slashes suffered by a delegator in ascending order of height
+func (k Keeper) GetDelegatorSlashes(ctx sdk.Context, delAddr sdk.AccAddress) (slashes types.DelegatorSlashes) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetDelegatorSlashesKey(delAddr))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		slashes = append(slashes, types.MustUnmarshalDelegatorSlash(k.cdc, iterator.Value()))
+	}
 
+	return
 }
 
 // Jail sents a validator to jail
 func (k Keeper) Jail(ctx sdk.Context, consAddr sdk.ConsAddress) {
 	validator := k.mustGetValidatorByConsAddr(ctx, consAddr)
-	k.jailValidator(ctx, validator)
+	if validator.IsInMaintenance() {
+		// the validator punished during maintenance stays in jail instead of returning automatically
+		validator = k.leaveMaintenance(ctx, validator)
+	}
+	if !validator.Jailed {
+		k.jailValidator(ctx, validator)
+	}
 	logger := k.Logger(ctx)
 	logger.Info(fmt.Sprintf("validator %s jailed", consAddr))
 	// TODO Return event(s), blocked on https://github.com/tendermint/tendermint/pull/1803
@@ -22,6 +194,10 @@ func (k Keeper) Jail(ctx sdk.Context, consAddr sdk.ConsAddress) {
 // Unjail discharges a validator by unjailing
 func (k Keeper) Unjail(ctx sdk.Context, consAddr sdk.ConsAddress) {
 	validator := k.mustGetValidatorByConsAddr(ctx, consAddr)
+	if validator.IsInMaintenance() {
+		// unjailing ends the maintenance in advance
+		validator = k.leaveMaintenance(ctx, validator)
+	}
 	k.unjailValidator(ctx, validator)
 	logger := k.Logger(ctx)
 	logger.Info(fmt.Sprintf("validator %s unjailed", consAddr))
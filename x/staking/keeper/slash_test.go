@@ -1 +1,175 @@
 package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/okex/exchain/x/staking/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestSlashSharesAndProxy(t *testing.T) {
+	ctx, _, mKeeper := CreateTestInput(t, false, SufficientInitBalance)
+	ctx = ctx.WithBlockHeight(10)
+	k := mKeeper.Keeper
+	delAddr, proxyAddr, proxiedAddr := addrDels[0], addrDels[1], addrDels[2]
+	valAddr := addrVals[0]
+
+	// create validator
+	validator := types.NewValidator(valAddr, PKs[0], types.Description{}, InitMsd2000)
+	k.SetValidator(ctx, validator)
+	k.SetValidatorByConsAddr(ctx, validator)
+	k.SetNewValidatorByPowerIndex(ctx, validator)
+	msdToken := sdk.NewDecCoinFromDec(k.BondDenom(ctx), validator.MinSelfDelegation)
+	require.Nil(t, k.AddSharesAsMinSelfDelegation(ctx, sdk.AccAddress(valAddr), &validator, msdToken))
+
+	// deposit
+	depositToken := sdk.NewDecCoinFromDec(k.BondDenom(ctx), sdk.NewDec(100))
+	for _, addr := range []sdk.AccAddress{delAddr, proxyAddr, proxiedAddr} {
+		require.Nil(t, k.Delegate(ctx, addr, depositToken))
+	}
+
+	// register a proxy and bind a delegator to it
+	proxy, found := k.GetDelegator(ctx, proxyAddr)
+	require.True(t, found)
+	proxy.RegProxy(true)
+	proxied, found := k.GetDelegator(ctx, proxiedAddr)
+	require.True(t, found)
+	proxied.BindProxy(proxyAddr)
+	proxy.TotalDelegatedTokens = proxy.TotalDelegatedTokens.Add(proxied.Tokens)
+	k.SetDelegator(ctx, proxied)
+	k.SetDelegator(ctx, proxy)
+	k.SetProxyBinding(ctx, proxyAddr, proxiedAddr, false)
+
+	// add shares
+	for _, addr := range []sdk.AccAddress{delAddr, proxyAddr} {
+		vals, err := k.GetValidatorsToAddShares(ctx, []sdk.ValAddress{valAddr})
+		require.Nil(t, err)
+		delegator, found := k.GetDelegator(ctx, addr)
+		require.True(t, found)
+		shares, err := k.AddSharesToValidators(ctx, addr, vals, delegator.Tokens.Add(delegator.TotalDelegatedTokens))
+		require.Nil(t, err)
+		delegator.ValidatorAddresses = []sdk.ValAddress{valAddr}
+		delegator.Shares = shares
+		k.SetDelegator(ctx, delegator)
+	}
+
+	require.Nil(t, k.Slash(ctx, validator.ConsAddress(), 8, 0, sdk.NewDecWithPrec(1, 1)))
+
+	// deposits are slashed by 10%
+	for _, addr := range []sdk.AccAddress{delAddr, proxyAddr, proxiedAddr} {
+		delegator, found := k.GetDelegator(ctx, addr)
+		require.True(t, found)
+		require.Equal(t, sdk.NewDec(90), delegator.Tokens)
+	}
+	proxy, found = k.GetDelegator(ctx, proxyAddr)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDec(90), proxy.TotalDelegatedTokens)
+	validator, found = k.GetValidator(ctx, valAddr)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDec(1800), validator.MinSelfDelegation)
+
+	// shares follow the tokens left
+	expShares, err := calculateWeight(ctx.BlockTime().Unix(), sdk.NewDec(90))
+	require.Nil(t, err)
+	shares, found := k.GetShares(ctx, delAddr, valAddr)
+	require.True(t, found)
+	require.Equal(t, expShares, shares)
+	expShares, err = calculateWeight(ctx.BlockTime().Unix(), sdk.NewDec(180))
+	require.Nil(t, err)
+	shares, found = k.GetShares(ctx, proxyAddr, valAddr)
+	require.True(t, found)
+	require.Equal(t, expShares, shares)
+
+	// the slashed tokens are burned
+	_, broken := ModuleAccountInvariantsCustom(k)(ctx)
+	require.False(t, broken)
+	_, broken = DelegatorAddSharesInvariant(k)(ctx)
+	require.False(t, broken)
+
+	// slash history
+	events := k.GetValidatorSlashEvents(ctx, valAddr)
+	require.Len(t, events, 1)
+	require.Equal(t, int64(10), events[0].Height)
+	require.Equal(t, int64(8), events[0].InfractionHeight)
+	require.Equal(t, sdk.NewDec(200), events[0].SlashedMinSelfDelegation)
+	require.Equal(t, sdk.NewDec(230), events[0].TotalSlashed)
+	require.Len(t, events[0].Delegators, 3)
+
+	slashes := k.GetDelegatorSlashes(ctx, proxiedAddr)
+	require.Len(t, slashes, 1)
+	require.Equal(t, proxyAddr, slashes[0].ProxyAddress)
+	require.Equal(t, sdk.NewDec(100), slashes[0].TokensBefore)
+	require.Equal(t, sdk.NewDec(10), slashes[0].Slashed)
+
+	slashes = k.GetDelegatorSlashes(ctx, delAddr)
+	require.Len(t, slashes, 1)
+	require.Nil(t, slashes[0].ProxyAddress)
+
+	// querier
+	querier := NewQuerier(k)
+	bz, err := types.ModuleCdc.MarshalJSON(types.NewQueryValidatorParams(valAddr))
+	require.Nil(t, err)
+	res, err := querier(ctx, []string{types.QueryValidatorSlashes}, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	var queriedEvents types.SlashEvents
+	require.Nil(t, types.ModuleCdc.UnmarshalJSON(res, &queriedEvents))
+	require.Len(t, queriedEvents, 1)
+
+	bz, err = types.ModuleCdc.MarshalJSON(types.NewQueryDelegatorParams(proxiedAddr))
+	require.Nil(t, err)
+	res, err = querier(ctx, []string{types.QueryDelegatorSlashes}, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	var queriedSlashes types.DelegatorSlashes
+	require.Nil(t, types.ModuleCdc.UnmarshalJSON(res, &queriedSlashes))
+	require.Len(t, queriedSlashes, 1)
+}
+
+func TestSlashNonexistentValidator(t *testing.T) {
+	ctx, _, mKeeper := CreateTestInput(t, false, SufficientInitBalance)
+	k := mKeeper.Keeper
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{}, InitMsd2000)
+	require.NotPanics(t, func() {
+		require.Nil(t, k.Slash(ctx, validator.ConsAddress(), 1, 0, sdk.NewDecWithPrec(1, 1)))
+	})
+	require.Empty(t, k.GetValidatorSlashEvents(ctx, addrVals[0]))
+}
+
+func TestSlashFailedToUpdateShares(t *testing.T) {
+	ctx, _, mKeeper := CreateTestInput(t, false, SufficientInitBalance)
+	k := mKeeper.Keeper
+	delAddr, valAddr := addrDels[0], addrVals[0]
+
+	validator := types.NewValidator(valAddr, PKs[0], types.Description{}, InitMsd2000)
+	k.SetValidator(ctx, validator)
+	k.SetValidatorByConsAddr(ctx, validator)
+	k.SetNewValidatorByPowerIndex(ctx, validator)
+	msdToken := sdk.NewDecCoinFromDec(k.BondDenom(ctx), validator.MinSelfDelegation)
+	require.Nil(t, k.AddSharesAsMinSelfDelegation(ctx, sdk.AccAddress(valAddr), &validator, msdToken))
+
+	require.Nil(t, k.Delegate(ctx, delAddr, sdk.NewDecCoinFromDec(k.BondDenom(ctx), sdk.NewDec(100))))
+	vals, err := k.GetValidatorsToAddShares(ctx, []sdk.ValAddress{valAddr})
+	require.Nil(t, err)
+	delegator, found := k.GetDelegator(ctx, delAddr)
+	require.True(t, found)
+	shares, err := k.AddSharesToValidators(ctx, delAddr, vals, delegator.Tokens)
+	require.Nil(t, err)
+	delegator.ValidatorAddresses = []sdk.ValAddress{valAddr}
+	delegator.Shares = shares
+	k.SetDelegator(ctx, delegator)
+
+	// the whole min self delegation slashed dismisses the validator, whose shares can't be updated any more
+	require.NotNil(t, k.Slash(ctx, validator.ConsAddress(), 1, 0, sdk.OneDec()))
+
+	// nothing is slashed
+	delegator, found = k.GetDelegator(ctx, delAddr)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDec(100), delegator.Tokens)
+	validator, found = k.GetValidator(ctx, valAddr)
+	require.True(t, found)
+	require.Equal(t, InitMsd2000, validator.MinSelfDelegation)
+	require.Empty(t, k.GetValidatorSlashEvents(ctx, valAddr))
+	require.Empty(t, k.GetDelegatorSlashes(ctx, delAddr))
+}
@@ -58,6 +58,9 @@ const (
 	CodeNoDelegatorExisted              uint32 = 67044
 	CodeTargetValsDuplicate             uint32 = 67045
 	CodeAlreadyBound                    uint32 = 67046
+	CodeInvalidMaintenanceBlocks        uint32 = 67047
+	CodeAlreadyInMaintenance            uint32 = 67048
+	CodeValidatorJailed                 uint32 = 67049
 )
 
 // ErrNoValidatorFound returns an error when a validator doesn't exist
@@ -312,3 +315,21 @@ func ErrAlreadyBound(delAddr string) sdk.EnvelopedErr {
 		fmt.Sprintf("failed. %s has already bound a proxy. it's necessary to unbind before proxy register",
 			delAddr))}
 }
+
+// ErrInvalidMaintenanceBlocks returns an error when the blocks of maintenance are out of range
+func ErrInvalidMaintenanceBlocks(blocks int64) sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeInvalidMaintenanceBlocks,
+		fmt.Sprintf("failed. maintenance blocks %d should be in range [1, %d]", blocks, MaxMaintenanceBlocks))
+}
+
+// ErrAlreadyInMaintenance returns an error when a validator enters maintenance twice
+func ErrAlreadyInMaintenance(valAddr string, endHeight int64) sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeAlreadyInMaintenance,
+		fmt.Sprintf("failed. validator %s is already in maintenance until height %d", valAddr, endHeight))
+}
+
+// ErrValidatorJailed returns an error when a jailed validator tries to enter maintenance
+func ErrValidatorJailed(valAddr string) sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeValidatorJailed,
+		fmt.Sprintf("failed. validator %s is jailed", valAddr))
+}
@@ -7,16 +7,18 @@ const (
 	EventTypeEditValidator     = "edit_validator"
 	EventTypeDelegate          = "delegate"
 	EventTypeUnbond            = "unbond"
+	EventTypeEnterMaintenance  = "enter_maintenance"
 
-	AttributeKeyValidator         = "validator"
-	AttributeKeyCommissionRate    = "commission_rate"
-	AttributeKeyMinSelfDelegation = "min_self_delegation"
-	AttributeKeyDelegator         = "delegator"
-	AttributeKeyCompletionTime    = "completion_time"
-	AttributeValueCategory        = ModuleName
+	AttributeKeyValidator            = "validator"
+	AttributeKeyCommissionRate       = "commission_rate"
+	AttributeKeyMinSelfDelegation    = "min_self_delegation"
+	AttributeKeyDelegator            = "delegator"
+	AttributeKeyCompletionTime       = "completion_time"
+	AttributeKeyMaintenanceEndHeight = "maintenance_end_height"
+	AttributeValueCategory           = ModuleName
 
 	EventTypeAddShares = "add_shares"
 
 	AttributeKeyValidatorToAddShares = "validator_to_add_shares"
-	AttributeKeyShares              = "shares"
+	AttributeKeyShares               = "shares"
 )
@@ -61,6 +61,7 @@ type ValidatorExported struct {
 	UnbondingHeight         int64          `json:"unbonding_height"`
 	UnbondingCompletionTime time.Time      `json:"unbonding_time"`
 	MinSelfDelegation       sdk.Dec        `json:"min_self_delegation"`
+	MaintenanceEndHeight    int64          `json:"maintenance_end_height"`
 }
 
 // Import converts validator exported format to inner one by filling the zero-value of Tokens and Commission
@@ -82,6 +83,7 @@ func (ve ValidatorExported) Import() Validator {
 		ve.UnbondingCompletionTime,
 		NewCommission(sdk.NewDec(1), sdk.NewDec(1), sdk.NewDec(0)),
 		ve.MinSelfDelegation,
+		ve.MaintenanceEndHeight,
 	}
 }
 
@@ -41,7 +41,8 @@ var (
 	ValidatorsByConsAddrKey   = []byte{0x22} // prefix for each key to a validator index, by pubkey
 	ValidatorsByPowerIndexKey = []byte{0x23} // prefix for each key to a validator index, sorted by power
 
-	ValidatorQueueKey = []byte{0x43} // prefix for the timestamps in validator queue
+	ValidatorQueueKey   = []byte{0x43} // prefix for the timestamps in validator queue
+	MaintenanceQueueKey = []byte{0x44} // prefix for the heights in maintenance queue
 
 	SharesKey           = []byte{0x51}
 	DelegatorKey        = []byte{0x52}
@@ -52,6 +53,10 @@ var (
 	// prefix key for vals info to enforce the update of validator-set
 	ValidatorAbandonedKey = []byte{0x60}
 
+	// prefix keys for the slash history
+	ValidatorSlashEventKey = []byte{0x71}
+	DelegatorSlashKey      = []byte{0x72}
+
 	lenTime = len(sdk.FormatTimeBytes(time.Now()))
 )
 
@@ -91,6 +96,24 @@ func GetValidatorQueueTimeKey(timestamp time.Time) []byte {
 	return append(ValidatorQueueKey, bz...)
 }
 
+// GetMaintenanceQueueHeightKey gets the prefix for all the validators whose maintenance ends at a height
+func GetMaintenanceQueueHeightKey(height int64) []byte {
+	return append(MaintenanceQueueKey, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetMaintenanceQueueKey gets the key for a validator whose maintenance ends at a height
+func GetMaintenanceQueueKey(height int64, valAddr sdk.ValAddress) []byte {
+	return append(GetMaintenanceQueueHeightKey(height), valAddr.Bytes()...)
+}
+
+// SplitMaintenanceQueueKey splits the key and returns the end height and validator address
+func SplitMaintenanceQueueKey(key []byte) (int64, sdk.ValAddress) {
+	if len(key[1:]) != 8+sdk.AddrLen {
+		panic(fmt.Sprintf("unexpected key length (%d ≠ %d)", len(key[1:]), 8+sdk.AddrLen))
+	}
+	return int64(binary.BigEndian.Uint64(key[1:9])), sdk.ValAddress(key[9:])
+}
+
 // getValidatorPowerRank gets the power ranking of a validator by okexchain's rule
 // just according to the shares instead of tokens on a validator
 func getValidatorPowerRank(validator Validator) []byte {
@@ -136,6 +159,29 @@ func GetSharesToValidatorsKey(valAddr sdk.ValAddress) []byte {
 	return append(SharesKey, valAddr.Bytes()...)
 }
 
+// GetValidatorSlashEventsKey gets the prefix for all the slash events of a validator
+func GetValidatorSlashEventsKey(valAddr sdk.ValAddress) []byte {
+	return append(ValidatorSlashEventKey, valAddr.Bytes()...)
+}
+
+// GetValidatorSlashEventKey gets the key for the slash event of a validator at a specific height
+// VALUE: staking/SlashEvent
+func GetValidatorSlashEventKey(valAddr sdk.ValAddress, height int64) []byte {
+	return append(GetValidatorSlashEventsKey(valAddr), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetDelegatorSlashesKey gets the prefix for all the slashes suffered by a delegator
+func GetDelegatorSlashesKey(delAddr sdk.AccAddress) []byte {
+	return append(DelegatorSlashKey, delAddr.Bytes()...)
+}
+
+// GetDelegatorSlashKey gets the key for the slash suffered by a delegator from a validator at a specific height
+// VALUE: staking/DelegatorSlash
+func GetDelegatorSlashKey(delAddr sdk.AccAddress, height int64, valAddr sdk.ValAddress) []byte {
+	key := append(GetDelegatorSlashesKey(delAddr), sdk.Uint64ToBigEndian(uint64(height))...)
+	return append(key, valAddr.Bytes()...)
+}
+
 // GetUndelegationInfoKey gets the key for UndelegationInfo
 func GetUndelegationInfoKey(delAddr sdk.AccAddress) []byte {
 	return append(UnDelegationInfoKey, delAddr.Bytes()...)
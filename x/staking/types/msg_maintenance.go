@@ -0,0 +1,50 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxMaintenanceBlocks is the longest maintenance a validator is allowed to take, about a week with 3s blocks
+const MaxMaintenanceBlocks int64 = 201600
+
+// ensure Msg interface compliance at compile time
+var _ sdk.Msg = (*MsgEnterMaintenance)(nil)
+
+// MsgEnterMaintenance - struct for transactions to take a validator offline for maintenance
+type MsgEnterMaintenance struct {
+	DelAddr sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+	Blocks  int64          `json:"blocks" yaml:"blocks"`
+}
+
+// NewMsgEnterMaintenance creates a msg of enter-maintenance
+func NewMsgEnterMaintenance(delAddr sdk.AccAddress, blocks int64) MsgEnterMaintenance {
+	return MsgEnterMaintenance{
+		DelAddr: delAddr,
+		Blocks:  blocks,
+	}
+}
+
+// nolint
+func (MsgEnterMaintenance) Route() string { return RouterKey }
+func (MsgEnterMaintenance) Type() string  { return "enter_maintenance" }
+func (msg MsgEnterMaintenance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelAddr}
+}
+
+// ValidateBasic gives a quick validity check
+func (msg MsgEnterMaintenance) ValidateBasic() error {
+	if msg.DelAddr.Empty() {
+		return ErrNilDelegatorAddr()
+	}
+	if msg.Blocks <= 0 || msg.Blocks > MaxMaintenanceBlocks {
+		return ErrInvalidMaintenanceBlocks(msg.Blocks)
+	}
+
+	return nil
+}
+
+// GetSignBytes returns the message bytes to sign over
+func (msg MsgEnterMaintenance) GetSignBytes() []byte {
+	bytes := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bytes)
+}
@@ -81,6 +81,32 @@ func TestMsgDestroyValidator(t *testing.T) {
 	}
 }
 
+func TestMsgEnterMaintenance(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		valAddr    sdk.AccAddress
+		blocks     int64
+		expectPass bool
+	}{
+		{"basic good", dlgAddr1, 100, true},
+		{"max blocks", dlgAddr1, MaxMaintenanceBlocks, true},
+		{"empty validator", sdk.AccAddress(emptyAddr), 100, false},
+		{"zero blocks", dlgAddr1, 0, false},
+		{"too many blocks", dlgAddr1, MaxMaintenanceBlocks + 1, false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgEnterMaintenance(tc.valAddr, tc.blocks)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+			checkMsg(t, msg, "enter_maintenance")
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
 func TestMsgCreateValidator_Smoke(t *testing.T) {
 
 	msd := sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdk.NewDec(2000))
@@ -17,6 +17,8 @@ const (
 	QueryProxy               = "proxy"
 	QueryValidatorAllShares  = "validatorAllShares"
 	QueryDelegator           = "delegator"
+	QueryValidatorSlashes    = "validatorSlashes"
+	QueryDelegatorSlashes    = "delegatorSlashes"
 )
 
 // QueryDelegatorParams defines the params for the following queries:
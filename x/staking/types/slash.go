@@ -0,0 +1,114 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DelegatorSlash records how much of a delegator's deposit was burned by a slash of a validator
+type DelegatorSlash struct {
+	DelegatorAddress sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+	ValidatorAddress sdk.ValAddress `json:"validator_address" yaml:"validator_address"`
+	// ProxyAddress is set when the delegator was slashed through the proxy it bound to
+	ProxyAddress sdk.AccAddress `json:"proxy_address" yaml:"proxy_address"`
+	Height       int64          `json:"height" yaml:"height"`
+	TokensBefore sdk.Dec        `json:"tokens_before" yaml:"tokens_before"`
+	Slashed      sdk.Dec        `json:"slashed" yaml:"slashed"`
+}
+
+// NewDelegatorSlash creates a new instance of DelegatorSlash
+func NewDelegatorSlash(delAddr sdk.AccAddress, valAddr sdk.ValAddress, proxyAddr sdk.AccAddress, height int64,
+	tokensBefore, slashed sdk.Dec) DelegatorSlash {
+	return DelegatorSlash{
+		DelegatorAddress: delAddr,
+		ValidatorAddress: valAddr,
+		ProxyAddress:     proxyAddr,
+		Height:           height,
+		TokensBefore:     tokensBefore,
+		Slashed:          slashed,
+	}
+}
+
+// String returns a human readable string representation of DelegatorSlash
+func (ds DelegatorSlash) String() string {
+	proxy := ""
+	if ds.ProxyAddress != nil {
+		proxy = ds.ProxyAddress.String()
+	}
+	return fmt.Sprintf(`Delegator Slash:
+  Delegator:     %s
+  Validator:     %s
+  Proxy:         %s
+  Height:        %d
+  Tokens Before: %s
+  Slashed:       %s`,
+		ds.DelegatorAddress, ds.ValidatorAddress, proxy, ds.Height, ds.TokensBefore, ds.Slashed)
+}
+
+// DelegatorSlashes is the type alias of DelegatorSlash slice
+type DelegatorSlashes []DelegatorSlash
+
+// String returns a human readable string representation of DelegatorSlashes
+func (dss DelegatorSlashes) String() string {
+	out := make([]string, len(dss))
+	for i, ds := range dss {
+		out[i] = ds.String()
+	}
+	return strings.Join(out, "\n")
+}
+
+// SlashEvent records a slash of a validator together with its impact on every delegator that backed it
+type SlashEvent struct {
+	ValidatorAddress         sdk.ValAddress   `json:"validator_address" yaml:"validator_address"`
+	Height                   int64            `json:"height" yaml:"height"`
+	InfractionHeight         int64            `json:"infraction_height" yaml:"infraction_height"`
+	Time                     time.Time        `json:"time" yaml:"time"`
+	SlashFactor              sdk.Dec          `json:"slash_factor" yaml:"slash_factor"`
+	SlashedMinSelfDelegation sdk.Dec          `json:"slashed_min_self_delegation" yaml:"slashed_min_self_delegation"`
+	TotalSlashed             sdk.Dec          `json:"total_slashed" yaml:"total_slashed"`
+	Delegators               DelegatorSlashes `json:"delegators" yaml:"delegators"`
+}
+
+// String returns a human readable string representation of SlashEvent
+func (se SlashEvent) String() string {
+	return fmt.Sprintf(`Slash Event:
+  Validator:                   %s
+  Height:                      %d
+  Infraction Height:           %d
+  Time:                        %s
+  Slash Factor:                %s
+  Slashed Min Self Delegation: %s
+  Total Slashed:               %s
+  Delegators:
+%s`,
+		se.ValidatorAddress, se.Height, se.InfractionHeight, se.Time, se.SlashFactor,
+		se.SlashedMinSelfDelegation, se.TotalSlashed, se.Delegators)
+}
+
+// SlashEvents is the type alias of SlashEvent slice
+type SlashEvents []SlashEvent
+
+// String returns a human readable string representation of SlashEvents
+func (ses SlashEvents) String() string {
+	out := make([]string, len(ses))
+	for i, se := range ses {
+		out[i] = se.String()
+	}
+	return strings.Join(out, "\n")
+}
+
+// MustUnmarshalSlashEvent must return a slash event by unmarshalling
+func MustUnmarshalSlashEvent(cdc *codec.Codec, value []byte) (event SlashEvent) {
+	cdc.MustUnmarshalBinaryLengthPrefixed(value, &event)
+	return
+}
+
+// MustUnmarshalDelegatorSlash must return a delegator slash by unmarshalling
+func MustUnmarshalDelegatorSlash(cdc *codec.Codec, value []byte) (slash DelegatorSlash) {
+	cdc.MustUnmarshalBinaryLengthPrefixed(value, &slash)
+	return
+}
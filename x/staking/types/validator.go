@@ -58,6 +58,8 @@ type Validator struct {
 	Commission Commission `json:"commission" yaml:"commission"`
 	// validator's self declared minimum self delegation
 	MinSelfDelegation sdk.Dec `json:"min_self_delegation" yaml:"min_self_delegation"`
+	// if in maintenance, height at which the validator returns to the candidates automatically
+	MaintenanceEndHeight int64 `json:"maintenance_end_height" yaml:"maintenance_end_height"`
 }
 
 // MarshalYAML implements the text format for yaml marshaling due to consensus pubkey
@@ -74,6 +76,7 @@ func (v Validator) MarshalYAML() (interface{}, error) {
 		UnbondingCompletionTime time.Time
 		Commission              Commission
 		MinSelfDelegation       sdk.Dec
+		MaintenanceEndHeight    int64
 	}{
 		OperatorAddress:         v.OperatorAddress,
 		ConsPubKey:              MustBech32ifyConsPub(v.ConsPubKey),
@@ -86,6 +89,7 @@ func (v Validator) MarshalYAML() (interface{}, error) {
 		UnbondingCompletionTime: v.UnbondingCompletionTime,
 		Commission:              v.Commission,
 		MinSelfDelegation:       v.MinSelfDelegation,
+		MaintenanceEndHeight:    v.MaintenanceEndHeight,
 	})
 	if err != nil {
 		return nil, err
@@ -167,12 +171,13 @@ func (v Validator) String() string {
   Unbonding Height:           %d
   Unbonding Completion Time:  %v
   Minimum Self Delegation:    %v
-  Commission:                 %s`,
+  Commission:                 %s
+  Maintenance End Height:     %d`,
 		v.OperatorAddress, bechConsPubKey,
 		v.Jailed, v.Status, v.Tokens,
 		v.DelegatorShares, v.Description,
 		v.UnbondingHeight, v.UnbondingCompletionTime, v.MinSelfDelegation,
-		v.Commission)
+		v.Commission, v.MaintenanceEndHeight)
 }
 
 // this is a helper struct used for JSON de- and encoding only
@@ -199,6 +204,8 @@ type bechValidator struct {
 	Commission Commission `json:"commission" yaml:"commission"`
 	// minimum self delegation
 	MinSelfDelegation sdk.Dec `json:"min_self_delegation" yaml:"min_self_delegation"`
+	// if in maintenance, height at which the validator returns to the candidates automatically
+	MaintenanceEndHeight int64 `json:"maintenance_end_height" yaml:"maintenance_end_height"`
 }
 
 // MarshalJSON marshals the validator to JSON using Bech32
@@ -220,6 +227,7 @@ func (v Validator) MarshalJSON() ([]byte, error) {
 		UnbondingCompletionTime: v.UnbondingCompletionTime,
 		MinSelfDelegation:       v.MinSelfDelegation,
 		Commission:              v.Commission,
+		MaintenanceEndHeight:    v.MaintenanceEndHeight,
 	})
 }
 
@@ -245,6 +253,7 @@ func (v *Validator) UnmarshalJSON(data []byte) error {
 		UnbondingCompletionTime: bv.UnbondingCompletionTime,
 		Commission:              bv.Commission,
 		MinSelfDelegation:       bv.MinSelfDelegation,
+		MaintenanceEndHeight:    bv.MaintenanceEndHeight,
 	}
 	return nil
 }
@@ -440,6 +449,7 @@ func (v Validator) UpdateStatus(newStatus sdk.BondStatus) Validator {
 
 // nolint - for ValidatorI
 func (v Validator) IsJailed() bool                { return v.Jailed }
+func (v Validator) IsInMaintenance() bool         { return v.MaintenanceEndHeight > 0 }
 func (v Validator) GetMoniker() string            { return v.Description.Moniker }
 func (v Validator) GetStatus() sdk.BondStatus     { return v.Status }
 func (v Validator) GetOperator() sdk.ValAddress   { return v.OperatorAddress }
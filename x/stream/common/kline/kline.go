@@ -1,12 +1,14 @@
 package kline
 
 import (
+	"encoding/json"
 	"sync"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/exchain/x/backend"
 	"github.com/okex/exchain/x/dex"
 	"github.com/okex/exchain/x/stream/common"
+	"github.com/okex/exchain/x/stream/outbox"
 	"github.com/okex/exchain/x/stream/types"
 )
 
@@ -52,6 +54,8 @@ type KlineData struct {
 	Height        int64
 	matchResults  []*backend.MatchResult
 	newTokenPairs []*dex.TokenPair
+	// the market ids of the products matched, kept with the data so that it can be replayed without the app
+	marketIDs map[string]int64
 }
 
 func NewKlineData() *KlineData {
@@ -68,10 +72,25 @@ func (kd KlineData) DataType() types.StreamDataKind {
 	return types.StreamDataKlineKind
 }
 
-func (kd *KlineData) SetData(ctx sdk.Context, orderKeeper types.OrderKeeper, cache *common.Cache) {
+func (kd *KlineData) SetData(ctx sdk.Context, orderKeeper types.OrderKeeper, dexKeeper types.DexKeeper,
+	cache *common.Cache) {
 	kd.Height = ctx.BlockHeight()
 	kd.matchResults = common.GetMatchResults(ctx, orderKeeper)
 	kd.newTokenPairs = cache.GetNewTokenPairs()
+	if len(kd.matchResults) == 0 {
+		return
+	}
+
+	products := make(map[string]bool, len(kd.matchResults))
+	for _, matchResult := range kd.matchResults {
+		products[matchResult.Product] = true
+	}
+	kd.marketIDs = make(map[string]int64, len(products))
+	for _, tokenPair := range dexKeeper.GetTokenPairs(ctx) {
+		if name := tokenPair.Name(); products[name] {
+			kd.marketIDs[name] = int64(tokenPair.ID)
+		}
+	}
 }
 
 func (kd *KlineData) GetNewTokenPairs() []*dex.TokenPair {
@@ -85,3 +104,60 @@ func (kd *KlineData) GetMatchResults() []*backend.MatchResult {
 func (kd *KlineData) SetMatchResults(matchResults []*backend.MatchResult) {
 	kd.matchResults = matchResults
 }
+
+func (kd *KlineData) SetMarketIDs(marketIDs map[string]int64) {
+	kd.marketIDs = marketIDs
+}
+
+// GetMarketID returns the market id of the product kept with the data, or the one in the market id map for the data
+// persisted without it
+func (kd *KlineData) GetMarketID(product string) (int64, bool) {
+	if marketID, ok := kd.marketIDs[product]; ok {
+		return marketID, true
+	}
+	marketID, ok := marketIDMap[product]
+	return marketID, ok
+}
+
+// klineDataJSON is the json form of KlineData persisted in the stream outbox
+type klineDataJSON struct {
+	Height        int64                  `json:"height"`
+	MatchResults  []*backend.MatchResult `json:"match_results"`
+	NewTokenPairs []*dex.TokenPair       `json:"new_token_pairs"`
+	MarketIDs     map[string]int64       `json:"market_ids,omitempty"`
+}
+
+func (kd KlineData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(klineDataJSON{
+		Height:        kd.Height,
+		MatchResults:  kd.matchResults,
+		NewTokenPairs: kd.newTokenPairs,
+		MarketIDs:     kd.marketIDs,
+	})
+}
+
+func (kd *KlineData) UnmarshalJSON(bz []byte) error {
+	var data klineDataJSON
+	if err := json.Unmarshal(bz, &data); err != nil {
+		return err
+	}
+	kd.Height = data.Height
+	kd.matchResults = data.MatchResults
+	kd.newTokenPairs = data.NewTokenPairs
+	kd.marketIDs = data.MarketIDs
+	return nil
+}
+
+// KeyedMatchResult is the match result sent to the market service with the idempotent message key
+type KeyedMatchResult struct {
+	backend.MatchResult
+	MsgKey string `json:"msg_key"`
+}
+
+// NewKeyedMatchResult creates the seq-th match result of the block written to the engine
+func NewKeyedMatchResult(matchResult backend.MatchResult, engine string, seq int) KeyedMatchResult {
+	return KeyedMatchResult{
+		MatchResult: matchResult,
+		MsgKey:      outbox.MsgKey(matchResult.BlockHeight, engine, seq),
+	}
+}
@@ -21,6 +21,7 @@ func EndBlocker(ctx sdk.Context, k Keeper) {
 
 	// prepare task data
 	sd := createStreamTaskWithData(ctx, k.stream)
+	putOutbox(k.stream, sd)
 	sc := Context{
 		blockHeight: ctx.BlockHeight(),
 		stream:      k.stream,
@@ -100,8 +101,19 @@ func createStreamTaskWithData(ctx sdk.Context, s *Stream) *TaskWithData {
 	sd.Task = NewTask(ctx.BlockHeight())
 	sd.dataMap = make(map[Kind]types.IStreamData)
 
+	// the websocket, nats and webhook engines share the push data, as the depth book cache is updated once a block
+	var wsdata *websocket.PushData
+	getPushData := func() *websocket.PushData {
+		if wsdata == nil {
+			websocket.InitialCache(ctx, s.orderKeeper, s.dexKeeper, s.logger)
+			wsdata = websocket.NewPushData()
+			wsdata.SetData(ctx, s.orderKeeper, s.tokenKeeper, s.dexKeeper, s.swapKeeper, s.Cache)
+		}
+		return wsdata
+	}
+
 	for engineType := range s.engines {
-		streamKind, ok := EngineKind2StreamKindMap[engineType]
+		streamKind, ok := s.streamKinds[engineType]
 		if ok {
 			sd.Task.DoneMap[streamKind] = false
 		}
@@ -118,15 +130,12 @@ func createStreamTaskWithData(ctx sdk.Context, s *Stream) *TaskWithData {
 			data = pBlock
 		case EngineKlineKind:
 			pData := kline.NewKlineData()
-			pData.SetData(ctx, s.orderKeeper, s.Cache)
+			pData.SetData(ctx, s.orderKeeper, s.dexKeeper, s.Cache)
 			// should init token pair map here
 			kline.InitTokenPairMap(ctx, s.dexKeeper)
 			data = pData
-		case EngineWebSocketKind:
-			websocket.InitialCache(ctx, s.orderKeeper, s.dexKeeper, s.logger)
-			wsdata := websocket.NewPushData()
-			wsdata.SetData(ctx, s.orderKeeper, s.tokenKeeper, s.dexKeeper, s.swapKeeper, s.Cache)
-			data = wsdata
+		case EngineWebSocketKind, EngineNatsKind, EngineWebhookKind:
+			data = getPushData()
 		}
 
 		sd.dataMap[streamKind] = data
@@ -147,6 +156,7 @@ func executeStreamTask(s *Stream, task *TaskWithData) (taskConst TaskConst, err
 
 	if success && err == nil {
 		s.distrLatestTask = &taskResult
+		ackOutbox(s, s.distrLatestTask, false)
 		if s.distrLatestTask.GetStatus() != TaskStatusSuccess {
 			return TaskPhase2NextActionRestart, nil
 		}
@@ -158,6 +168,7 @@ func executeStreamTask(s *Stream, task *TaskWithData) (taskConst TaskConst, err
 }
 
 func execute(sc Context) {
+	redeliverOutbox(sc.stream, sc.blockHeight)
 	for {
 		p1Status, p1err := prepareStreamTask(sc.blockHeight, sc.stream)
 		if p1err != nil {
@@ -172,6 +183,8 @@ func execute(sc Context) {
 			err := fmt.Errorf("stream unexpected exception, %+v", p1err)
 			panic(err)
 		case TaskPhase1NextActionJumpNextBlock:
+			// the block was done by another worker
+			ackOutbox(sc.stream, &Task{Height: sc.blockHeight}, true)
 			return
 		default:
 			if p1Status != TaskPhase1NextActionNewTask {
@@ -1,18 +1,23 @@
 package stream
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/okex/exchain/x/stream/common/kline"
 	"github.com/okex/exchain/x/stream/kafkaclient"
+	"github.com/okex/exchain/x/stream/natsclient"
+	"github.com/okex/exchain/x/stream/outbox"
+	"github.com/okex/exchain/x/stream/webhook"
 
 	"github.com/okex/exchain/x/stream/websocket"
 
 	"github.com/pkg/errors"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
 
 	"github.com/tendermint/tendermint/libs/log"
 
@@ -36,26 +41,38 @@ const (
 	StreamPulsarKind    Kind = 0x03
 	StreamWebSocketKind Kind = 0x04
 	StreamKafkaKind     Kind = 0x05
+	StreamPostgresKind  Kind = 0x06
+	StreamNativeKind    Kind = 0x07
+	StreamNatsKind      Kind = 0x08
+	StreamWebhookKind   Kind = 0x09
 
 	EngineNilKind       EngineKind = 0x00
 	EngineAnalysisKind  EngineKind = 0x01
 	EngineNotifyKind    EngineKind = 0x02
 	EngineKlineKind     EngineKind = 0x03
 	EngineWebSocketKind EngineKind = 0x04
+	EngineNatsKind      EngineKind = 0x05
+	EngineWebhookKind   EngineKind = 0x06
 )
 
 var StreamKind2EngineKindMap = map[Kind]EngineKind{
 	StreamMysqlKind:     EngineAnalysisKind,
+	StreamPostgresKind:  EngineAnalysisKind,
 	StreamRedisKind:     EngineNotifyKind,
 	StreamPulsarKind:    EngineKlineKind,
 	StreamKafkaKind:     EngineKlineKind,
 	StreamWebSocketKind: EngineWebSocketKind,
+	StreamNativeKind:    EngineWebSocketKind,
+	StreamNatsKind:      EngineNatsKind,
+	StreamWebhookKind:   EngineWebhookKind,
 }
 
 var EngineKind2StreamKindMap = map[EngineKind]Kind{
 	EngineAnalysisKind:  StreamMysqlKind,
 	EngineNotifyKind:    StreamRedisKind,
 	EngineWebSocketKind: StreamWebSocketKind,
+	EngineNatsKind:      StreamNatsKind,
+	EngineWebhookKind:   StreamWebhookKind,
 }
 
 type MySQLEngine struct {
@@ -107,6 +124,54 @@ func (e *MySQLEngine) Write(data types.IStreamData, success *bool) {
 
 }
 
+type PostgresEngine struct {
+	url    string
+	logger log.Logger
+	orm    *backend.ORM
+}
+
+func NewPostgresEngine(url string, log log.Logger, cfg *appCfg.StreamConfig) (types.IStreamEngine, error) {
+	ormTmp := analyservice.NewPostgresORM(url)
+	log.Info("NewAnalysisService succeed")
+	// connect postgres through streamUrl
+	return &PostgresEngine{
+		url:    url,
+		logger: log,
+		orm:    ormTmp,
+	}, nil
+}
+
+func (e *PostgresEngine) URL() string {
+	return e.url
+}
+
+func (e *PostgresEngine) Write(data types.IStreamData, success *bool) {
+	e.logger.Debug("Entering PostgresEngine write")
+	enData, ok := data.(*analyservice.DataAnalysis)
+	if !ok {
+		panic(fmt.Sprintf("PostgresEngine Convert data %+v to DataAnalysis failed", data))
+	}
+
+	results, err := e.orm.BatchInsertOrUpdate(enData.NewOrders, enData.UpdatedOrders, enData.Deals, enData.MatchResults,
+		enData.FeeDetails, enData.Trans, enData.SwapInfos, enData.ClaimInfos)
+	if err != nil {
+		e.logger.Error(fmt.Sprintf("PostgresEngine write failed: %s, results: %v", err.Error(), results))
+		*success = false
+
+		if pqerr, ok := err.(*pq.Error); ok {
+			e.logger.Error(fmt.Sprintf("PostgresError: %+v", err.Error()))
+			// duplicate key value violates unique constraint
+			if pqerr.Code == "23505" {
+				e.logger.Error(fmt.Sprintf("PostgresEngine write failed becoz 23505: %s, considered success, result: %+v", err.Error(), results))
+				*success = true
+			}
+		}
+	} else {
+		e.logger.Debug(fmt.Sprintf("PostgresEngine write result: %+v", results))
+		*success = true
+	}
+}
+
 type PulsarEngine struct {
 	url            string
 	logger         log.Logger
@@ -162,6 +227,18 @@ func (e *PulsarEngine) Write(data types.IStreamData, success *bool) {
 	}
 }
 
+func (e *PulsarEngine) OutboxName() string {
+	return pulsarclient.EngineName
+}
+
+func (e *PulsarEngine) DecodeData(bz []byte) (types.IStreamData, error) {
+	data := kline.NewKlineData()
+	if err := json.Unmarshal(bz, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
 type KafkaEngine struct {
 	url           string
 	logger        log.Logger
@@ -203,6 +280,18 @@ func (ke *KafkaEngine) Write(data types.IStreamData, success *bool) {
 	}
 }
 
+func (ke *KafkaEngine) OutboxName() string {
+	return kafkaclient.EngineName
+}
+
+func (ke *KafkaEngine) DecodeData(bz []byte) (types.IStreamData, error) {
+	data := kline.NewKlineData()
+	if err := json.Unmarshal(bz, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
 type RedisEngine struct {
 	url    string
 	logger log.Logger
@@ -249,15 +338,115 @@ func (e *RedisEngine) Write(data types.IStreamData, success *bool) {
 	}
 }
 
+type NatsEngine struct {
+	url      string
+	logger   log.Logger
+	producer *natsclient.NatsProducer
+}
+
+func NewNatsEngine(url string, logger log.Logger, cfg *appCfg.StreamConfig) (types.IStreamEngine, error) {
+	producer, err := natsclient.NewNatsProducerFromViper(url)
+	if err != nil {
+		logger.Error(fmt.Sprintf("create nats producer failed: %s", err.Error()))
+		return nil, err
+	}
+	logger.Info("create nats producer succeed")
+	return &NatsEngine{
+		url:      url,
+		logger:   logger,
+		producer: producer,
+	}, nil
+}
+
+func (ne *NatsEngine) URL() string {
+	return ne.url
+}
+
+func (ne *NatsEngine) Write(data types.IStreamData, success *bool) {
+	ne.logger.Debug("Entering NatsEngine Write")
+	enData, ok := data.(*websocket.PushData)
+	if !ok {
+		panic(fmt.Sprintf("Convert data %+v to PushData failed", data))
+	}
+
+	messages, err := enData.ChannelMessages()
+	if err != nil {
+		ne.logger.Error(fmt.Sprintf("nats engine write failed: %s", err.Error()))
+		*success = false
+		return
+	}
+	for seq, msg := range messages {
+		if err := ne.producer.Send(enData.Height, seq, msg.Channel, msg.Data); err != nil {
+			ne.logger.Error(fmt.Sprintf("nats engine write failed: %s, channel: %s", err.Error(), msg.Channel))
+			*success = false
+			return
+		}
+	}
+	ne.logger.Debug(fmt.Sprintf("nats engine write result: %d messages", len(messages)))
+	*success = true
+}
+
+type WebhookEngine struct {
+	url        string
+	logger     log.Logger
+	dispatcher *webhook.Dispatcher
+}
+
+// NewWebhookEngine creates the engine delivering to the endpoints in the json file at url
+func NewWebhookEngine(url string, logger log.Logger, cfg *appCfg.StreamConfig) (types.IStreamEngine, error) {
+	endpoints, err := webhook.LoadEndpoints(url)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("create webhook dispatcher with %d endpoints", len(endpoints)))
+	return &WebhookEngine{
+		url:        url,
+		logger:     logger,
+		dispatcher: webhook.NewDispatcher(endpoints, webhook.ConfigFromViper(), logger),
+	}, nil
+}
+
+func (we *WebhookEngine) URL() string {
+	return we.url
+}
+
+func (we *WebhookEngine) Write(data types.IStreamData, success *bool) {
+	we.logger.Debug("Entering WebhookEngine Write")
+	enData, ok := data.(*websocket.PushData)
+	if !ok {
+		panic(fmt.Sprintf("Convert data %+v to PushData failed", data))
+	}
+
+	messages, err := enData.ChannelMessages()
+	if err != nil {
+		we.logger.Error(fmt.Sprintf("webhook engine write failed: %s", err.Error()))
+		*success = false
+		return
+	}
+	events := make([]webhook.Event, 0, len(messages))
+	for seq, msg := range messages {
+		events = append(events, webhook.Event{
+			Channel: msg.Channel,
+			MsgKey:  outbox.MsgKey(enData.Height, webhook.EngineName, seq),
+			Data:    msg.Data,
+		})
+	}
+	*success = we.dispatcher.Dispatch(enData.Height, events)
+}
+
 type EngineCreator func(url string, logger log.Logger, cfg *appCfg.StreamConfig) (types.IStreamEngine, error)
 
 func GetEngineCreator(eKind EngineKind, sKind Kind) (EngineCreator, error) {
 	m := map[string]EngineCreator{
 		fmt.Sprintf("%d_%d", EngineAnalysisKind, StreamMysqlKind):      NewMySQLEngine,
+		fmt.Sprintf("%d_%d", EngineAnalysisKind, StreamPostgresKind):   NewPostgresEngine,
 		fmt.Sprintf("%d_%d", EngineNotifyKind, StreamRedisKind):        NewRedisEngine,
 		fmt.Sprintf("%d_%d", EngineKlineKind, StreamPulsarKind):        NewPulsarEngine,
 		fmt.Sprintf("%d_%d", EngineWebSocketKind, StreamWebSocketKind): websocket.NewEngine,
 		fmt.Sprintf("%d_%d", EngineKlineKind, StreamKafkaKind):         NewKafkaEngine,
+		fmt.Sprintf("%d_%d", EngineWebSocketKind, StreamNativeKind):    websocket.NewNativeEngine,
+		fmt.Sprintf("%d_%d", EngineNatsKind, StreamNatsKind):           NewNatsEngine,
+		fmt.Sprintf("%d_%d", EngineWebhookKind, StreamWebhookKind):     NewWebhookEngine,
 	}
 
 	key := fmt.Sprintf("%d_%d", eKind, sKind)
@@ -277,8 +466,10 @@ func ParseStreamEngineConfig(logger log.Logger, cfg *appCfg.StreamConfig) (map[E
 	for _, item := range list {
 		enginesConf := strings.Split(item, "|")
 
-		// Desktop Stream Engine Mode: mysql | websocket
-		// HA Stream Engine Mode: mysql | redis | pulsar(kafka)
+		// Desktop Stream Engine Mode: mysql(postgres) | websocket(native)
+		// HA Stream Engine Mode: mysql(postgres) | redis | pulsar(kafka)
+		// Fan-out Stream Engine Mode: nats | webhook, e.g. nats|nats|nats://127.0.0.1:4222 and
+		// webhook|webhook|/path/to/webhook_endpoints.json
 
 		if len(enginesConf) != 3 {
 			return nil, fmt.Errorf("expected list in a form of \"engine_type:stream_type:stream_url\" pairs, given pair %s, list %s", item, list)
@@ -303,6 +494,25 @@ func ParseStreamEngineConfig(logger log.Logger, cfg *appCfg.StreamConfig) (map[E
 	return engines, nil
 }
 
+// ParseStreamKinds returns the stream kinds of the engines in the engine config on a local copy of
+// EngineKind2StreamKindMap, which keeps the defaults untouched
+func ParseStreamKinds(cfg *appCfg.StreamConfig) map[EngineKind]Kind {
+	streamKinds := make(map[EngineKind]Kind, len(EngineKind2StreamKindMap))
+	for engineKind, streamKind := range EngineKind2StreamKindMap {
+		streamKinds[engineKind] = streamKind
+	}
+	for _, item := range strings.Split(cfg.Engine, ",") {
+		enginesConf := strings.Split(item, "|")
+		if len(enginesConf) != 3 {
+			continue
+		}
+		if streamKind := StringToStreamKind(enginesConf[1]); streamKind != StreamNilKind {
+			streamKinds[StringToEngineKind(enginesConf[0])] = streamKind
+		}
+	}
+	return streamKinds
+}
+
 func StringToEngineKind(kind string) EngineKind {
 	kind = strings.ToLower(kind)
 	switch kind {
@@ -314,6 +524,10 @@ func StringToEngineKind(kind string) EngineKind {
 		return EngineKlineKind
 	case "websocket":
 		return EngineWebSocketKind
+	case "nats":
+		return EngineNatsKind
+	case "webhook":
+		return EngineWebhookKind
 	default:
 		return EngineNilKind
 	}
@@ -324,16 +538,22 @@ func StringToStreamKind(kind string) Kind {
 	switch kind {
 	case "mysql":
 		return StreamMysqlKind
+	case "postgres":
+		return StreamPostgresKind
 	case "redis":
 		return StreamRedisKind
 	case "pulsar":
-		EngineKind2StreamKindMap[EngineKlineKind] = StreamPulsarKind
 		return StreamPulsarKind
 	case "websocket":
 		return StreamWebSocketKind
+	case "native":
+		return StreamNativeKind
 	case "kafka":
-		EngineKind2StreamKindMap[EngineKlineKind] = StreamKafkaKind
 		return StreamKafkaKind
+	case "nats":
+		return StreamNatsKind
+	case "webhook":
+		return StreamWebhookKind
 	default:
 		return StreamNilKind
 	}
@@ -126,6 +126,10 @@ func TestStringToEngineKind(t *testing.T) {
 	require.Equal(t, EngineNotifyKind, StringToEngineKind(kind))
 	kind = "kline"
 	require.Equal(t, EngineKlineKind, StringToEngineKind(kind))
+	kind = "nats"
+	require.Equal(t, EngineNatsKind, StringToEngineKind(kind))
+	kind = "webhook"
+	require.Equal(t, EngineWebhookKind, StringToEngineKind(kind))
 	kind = ""
 	require.Equal(t, EngineNilKind, StringToEngineKind(kind))
 }
@@ -137,6 +141,34 @@ func TestStringToStreamKind(t *testing.T) {
 	require.Equal(t, StreamRedisKind, StringToStreamKind(kind))
 	kind = "pulsar"
 	require.Equal(t, StreamPulsarKind, StringToStreamKind(kind))
+	kind = "Postgres"
+	require.Equal(t, StreamPostgresKind, StringToStreamKind(kind))
+	kind = "mysql"
+	require.Equal(t, StreamMysqlKind, StringToStreamKind(kind))
+	kind = "nats"
+	require.Equal(t, StreamNatsKind, StringToStreamKind(kind))
+	kind = "webhook"
+	require.Equal(t, StreamWebhookKind, StringToStreamKind(kind))
+	kind = "native"
+	require.Equal(t, StreamNativeKind, StringToStreamKind(kind))
+	kind = "websocket"
+	require.Equal(t, StreamWebSocketKind, StringToStreamKind(kind))
 	kind = ""
 	require.Equal(t, StreamNilKind, StringToStreamKind(kind))
 }
+
+func TestParseStreamKinds(t *testing.T) {
+	cfg := appCfg.DefaultStreamConfig()
+	cfg.Engine = "analysis|postgres|" + MYSQLURL + ",kline|kafka|" + PULSARURL + ",websocket|native|" + REDISURL
+	streamKinds := ParseStreamKinds(cfg)
+	require.Equal(t, StreamPostgresKind, streamKinds[EngineAnalysisKind])
+	require.Equal(t, StreamKafkaKind, streamKinds[EngineKlineKind])
+	require.Equal(t, StreamNativeKind, streamKinds[EngineWebSocketKind])
+	require.Equal(t, StreamRedisKind, streamKinds[EngineNotifyKind])
+
+	// the defaults are kept
+	require.Equal(t, StreamMysqlKind, EngineKind2StreamKindMap[EngineAnalysisKind])
+	require.Equal(t, StreamWebSocketKind, EngineKind2StreamKindMap[EngineWebSocketKind])
+	_, ok := EngineKind2StreamKindMap[EngineKlineKind]
+	require.False(t, ok)
+}
@@ -15,6 +15,9 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 )
 
+// EngineName is the name of the engine in the stream outbox and the message keys
+const EngineName = "kafka"
+
 type KafkaProducer struct {
 	kline.MarketConfig
 	*kafka.Writer
@@ -72,17 +75,17 @@ func (kp *KafkaProducer) SendAllMsg(data *kline.KlineData, logger log.Logger) (m
 	var errChan = make(chan error, len(matchResults))
 	var wg sync.WaitGroup
 	wg.Add(len(matchResults))
-	for _, matchResult := range matchResults {
-		go func(matchResult backend.MatchResult) {
+	for i, matchResult := range matchResults {
+		go func(seq int, matchResult backend.MatchResult) {
 			defer wg.Done()
-			marketID, ok := kline.GetMarketIDMap()[matchResult.Product]
+			marketID, ok := data.GetMarketID(matchResult.Product)
 			if !ok {
 				err := fmt.Errorf("failed to find %s marketId", matchResult.Product)
 				errChan <- err
 				return
 			}
 
-			msg, err := json.Marshal(&matchResult)
+			msg, err := json.Marshal(kline.NewKeyedMatchResult(matchResult, EngineName, seq))
 			if err != nil {
 				errChan <- err
 				return
@@ -104,7 +107,7 @@ func (kp *KafkaProducer) SendAllMsg(data *kline.KlineData, logger log.Logger) (m
 					matchResult.Quantity, matchResult.Price, matchResult.Product,
 				),
 			)
-		}(*matchResult)
+		}(i, *matchResult)
 	}
 	wg.Wait()
 
@@ -0,0 +1,93 @@
+package natsclient
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/okex/exchain/x/stream/outbox"
+	"github.com/spf13/viper"
+)
+
+const (
+	// EngineName is the name of the engine in the message keys
+	EngineName = "nats"
+
+	FlagNatsStream        = "stream.nats_stream"
+	FlagNatsSubjectPrefix = "stream.nats_subject_prefix"
+)
+
+// Publisher publishes the message to the subject, the message id is used by the server to deduplicate the messages
+type Publisher interface {
+	Publish(subject string, data []byte, msgID string) error
+}
+
+type jetStreamPublisher struct {
+	js nats.JetStreamContext
+}
+
+// NewJetStreamPublisher connects the NATS server at url, and creates the JetStream stream capturing the subjects of the
+// prefix if it doesn't exist
+func NewJetStreamPublisher(url, stream, subjectPrefix string) (Publisher, error) {
+	nc, err := nats.Connect(url, nats.Name("exchain-stream"), nats.MaxReconnects(-1), nats.Timeout(5*time.Second))
+	if err != nil {
+		return nil, err
+	}
+	js, err := nc.JetStream()
+	if err != nil {
+		nc.Close()
+		return nil, err
+	}
+
+	if _, err := js.StreamInfo(stream); err != nil {
+		if _, err := js.AddStream(&nats.StreamConfig{Name: stream, Subjects: []string{subjectPrefix + ".>"}}); err != nil {
+			nc.Close()
+			return nil, fmt.Errorf("failed to add JetStream stream %s: %s", stream, err)
+		}
+	}
+	return &jetStreamPublisher{js: js}, nil
+}
+
+func (p *jetStreamPublisher) Publish(subject string, data []byte, msgID string) error {
+	_, err := p.js.Publish(subject, data, nats.MsgId(msgID))
+	return err
+}
+
+type NatsProducer struct {
+	publisher     Publisher
+	subjectPrefix string
+}
+
+func NewNatsProducer(publisher Publisher, subjectPrefix string) *NatsProducer {
+	return &NatsProducer{
+		publisher:     publisher,
+		subjectPrefix: subjectPrefix,
+	}
+}
+
+// NewNatsProducerFromViper creates the producer publishing to the JetStream of the NATS server at url
+func NewNatsProducerFromViper(url string) (*NatsProducer, error) {
+	subjectPrefix := viper.GetString(FlagNatsSubjectPrefix)
+	publisher, err := NewJetStreamPublisher(url, viper.GetString(FlagNatsStream), subjectPrefix)
+	if err != nil {
+		return nil, err
+	}
+	return NewNatsProducer(publisher, subjectPrefix), nil
+}
+
+// Send publishes the seq-th message of the block to the subject of the channel
+func (p *NatsProducer) Send(height int64, seq int, channel string, data []byte) error {
+	return p.publisher.Publish(p.Subject(channel), data, outbox.MsgKey(height, EngineName, seq))
+}
+
+// Subject returns the subject of the channel, e.g. prefix.dex_spot.order.tbtc_tusdk.ex1... for
+// dex_spot/order:tbtc_tusdk:ex1..., so the subscribers can filter the channels with wildcards
+func (p *NatsProducer) Subject(channel string) string {
+	subject := strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_").Replace(channel)
+	subject = strings.NewReplacer("/", ".", ":", ".").Replace(subject)
+	if p.subjectPrefix == "" {
+		return subject
+	}
+	return p.subjectPrefix + "." + subject
+}
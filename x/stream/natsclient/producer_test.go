@@ -0,0 +1,43 @@
+package natsclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type publishedMsg struct {
+	subject string
+	data    string
+	msgID   string
+}
+
+type mockPublisher struct {
+	msgs []publishedMsg
+}
+
+func (p *mockPublisher) Publish(subject string, data []byte, msgID string) error {
+	p.msgs = append(p.msgs, publishedMsg{subject, string(data), msgID})
+	return nil
+}
+
+func TestNatsProducer_Subject(t *testing.T) {
+	producer := NewNatsProducer(&mockPublisher{}, "exchain")
+	require.Equal(t, "exchain.dex_spot.order.tbtc_tusdk.ex1abc", producer.Subject("dex_spot/order:tbtc_tusdk:ex1abc"))
+	require.Equal(t, "exchain.dex_spot.ticker.a_b_c", producer.Subject("dex_spot/ticker:a.b*c"))
+
+	producer = NewNatsProducer(&mockPublisher{}, "")
+	require.Equal(t, "dex_spot.matches.tokt_tusdk", producer.Subject("dex_spot/matches:tokt_tusdk"))
+}
+
+func TestNatsProducer_Send(t *testing.T) {
+	publisher := &mockPublisher{}
+	producer := NewNatsProducer(publisher, "exchain")
+	require.NoError(t, producer.Send(10, 0, "dex_spot/account:tokt:ex1abc", []byte(`{"available":"1"}`)))
+	require.NoError(t, producer.Send(10, 1, "dex_spot/ticker:tokt_tusdk", []byte(`{"price":"1"}`)))
+
+	require.Equal(t, []publishedMsg{
+		{"exchain.dex_spot.account.tokt.ex1abc", `{"available":"1"}`, "10-nats-0"},
+		{"exchain.dex_spot.ticker.tokt_tusdk", `{"price":"1"}`, "10-nats-1"},
+	}, publisher.msgs)
+}
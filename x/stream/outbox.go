@@ -0,0 +1,199 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	appCfg "github.com/cosmos/cosmos-sdk/server/config"
+	"github.com/okex/exchain/x/stream/outbox"
+	"github.com/okex/exchain/x/stream/types"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	FlagOutboxEnable       = "stream.outbox_enable"
+	FlagOutboxRetainBlocks = "stream.outbox_retain_blocks"
+
+	outboxDir = "data"
+)
+
+// OutboxDir returns the directory of the stream outbox db in the node home
+func OutboxDir() string {
+	return filepath.Join(viper.GetString(flags.FlagHome), outboxDir)
+}
+
+// outboxEngines returns the engines whose data is kept in the outbox by their stream kinds
+func (s *Stream) outboxEngines() map[Kind]types.IOutboxEngine {
+	engines := make(map[Kind]types.IOutboxEngine)
+	for engineKind, engine := range s.engines {
+		if outboxEngine, ok := engine.(types.IOutboxEngine); ok {
+			engines[s.streamKinds[engineKind]] = outboxEngine
+		}
+	}
+	return engines
+}
+
+// putOutbox persists the data of the task for the outbox engines, before the task is executed
+func putOutbox(s *Stream, task *TaskWithData) {
+	if s.outbox == nil {
+		return
+	}
+	for streamKind, engine := range s.outboxEngines() {
+		data, ok := task.dataMap[streamKind]
+		if !ok || data == nil {
+			continue
+		}
+		bz, err := json.Marshal(data)
+		if err == nil {
+			err = s.outbox.Put(engine.OutboxName(), task.Height, bz)
+		}
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("stream outbox failed to put data of %s at height %d: %s", engine.OutboxName(), task.Height, err))
+		}
+	}
+}
+
+// ackOutbox acknowledges the data of the task written by the outbox engines. If all is true, the data is
+// acknowledged for all the engines as the task was done by another worker
+func ackOutbox(s *Stream, task *Task, all bool) {
+	if s.outbox == nil || task == nil {
+		return
+	}
+	for streamKind, engine := range s.outboxEngines() {
+		if !all && !task.DoneMap[streamKind] {
+			continue
+		}
+		if err := s.outbox.Ack(engine.OutboxName(), task.Height); err != nil {
+			s.logger.Error(fmt.Sprintf("stream outbox failed to ack data of %s at height %d: %s", engine.OutboxName(), task.Height, err))
+		}
+	}
+}
+
+// redeliverOutbox writes the data of the blocks before the height which hasn't been acknowledged, e.g. the node was
+// restarted before it was written, and prunes the data out of the retained blocks. The data of an engine is written in
+// order and the rest is left to the next block once a write fails
+func redeliverOutbox(s *Stream, height int64) {
+	if s.outbox == nil {
+		return
+	}
+	for _, engine := range s.outboxEngines() {
+		name := engine.OutboxName()
+		heights, err := s.outbox.PendingHeights(name, height)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("stream outbox failed to get pending heights of %s: %s", name, err))
+			continue
+		}
+		for _, pendingHeight := range heights {
+			if err := writeOutbox(s.outbox, engine, pendingHeight); err != nil {
+				s.logger.Error(fmt.Sprintf("stream outbox failed to redeliver data of %s at height %d: %s", name, pendingHeight, err))
+				break
+			}
+			if err := s.outbox.Ack(name, pendingHeight); err != nil {
+				s.logger.Error(fmt.Sprintf("stream outbox failed to ack data of %s at height %d: %s", name, pendingHeight, err))
+			}
+			s.logger.Info(fmt.Sprintf("stream outbox redelivered data of %s at height %d", name, pendingHeight))
+		}
+
+		if retainBlocks := viper.GetInt64(FlagOutboxRetainBlocks); retainBlocks > 0 && height > retainBlocks {
+			if _, err := s.outbox.Prune(name, height-retainBlocks); err != nil {
+				s.logger.Error(fmt.Sprintf("stream outbox failed to prune data of %s: %s", name, err))
+			}
+		}
+	}
+}
+
+// writeOutbox writes the data of the block in the outbox to the engine
+func writeOutbox(ob *outbox.Outbox, engine types.IOutboxEngine, height int64) error {
+	bz, err := ob.Get(engine.OutboxName(), height)
+	if err != nil {
+		return err
+	}
+	if bz == nil {
+		return fmt.Errorf("no data found, it may be pruned")
+	}
+	data, err := engine.DecodeData(bz)
+	if err != nil {
+		return err
+	}
+
+	success := false
+	engine.Write(data, &success)
+	if !success {
+		return fmt.Errorf("engine write failed")
+	}
+	return nil
+}
+
+// ReplayOutbox writes the data of the blocks in [startHeight, endHeight] kept in the outbox to the engine of the
+// stream kind configured, and returns the number of blocks replayed. The outbox db can't be opened while the node
+// is running
+func ReplayOutbox(logger log.Logger, cfg *appCfg.StreamConfig, dir string, streamKind string, startHeight, endHeight int64) (int, error) {
+	engine, err := createOutboxEngine(logger, cfg, streamKind)
+	if err != nil {
+		return 0, err
+	}
+
+	ob, err := outbox.OpenOutbox(dir)
+	if err != nil {
+		return 0, err
+	}
+	defer ob.Close()
+
+	var heights []int64
+	err = ob.Range(engine.OutboxName(), startHeight, endHeight, func(height int64, _ []byte) error {
+		heights = append(heights, height)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i, height := range heights {
+		if err := writeOutbox(ob, engine, height); err != nil {
+			return i, fmt.Errorf("failed to replay data of %s at height %d: %s", engine.OutboxName(), height, err)
+		}
+		if err := ob.Ack(engine.OutboxName(), height); err != nil {
+			return i, err
+		}
+		logger.Info(fmt.Sprintf("stream outbox replayed data of %s at height %d", engine.OutboxName(), height))
+	}
+	return len(heights), nil
+}
+
+// createOutboxEngine creates the outbox engine of the stream kind in the engine config
+func createOutboxEngine(logger log.Logger, cfg *appCfg.StreamConfig, streamKind string) (types.IOutboxEngine, error) {
+	sKind := StringToStreamKind(streamKind)
+	eKind, ok := StreamKind2EngineKindMap[sKind]
+	if !ok {
+		return nil, fmt.Errorf("unknown stream kind %s", streamKind)
+	}
+
+	var url string
+	for _, item := range strings.Split(cfg.Engine, ",") {
+		enginesConf := strings.Split(item, "|")
+		if len(enginesConf) == 3 && StringToEngineKind(enginesConf[0]) == eKind && StringToStreamKind(enginesConf[1]) == sKind {
+			url = enginesConf[2]
+		}
+	}
+	if url == "" {
+		return nil, fmt.Errorf("stream kind %s isn't configured in the stream engine %s", streamKind, cfg.Engine)
+	}
+
+	creator, err := GetEngineCreator(eKind, sKind)
+	if err != nil {
+		return nil, err
+	}
+	engine, err := creator(url, logger, cfg)
+	if err != nil {
+		return nil, err
+	}
+	outboxEngine, ok := engine.(types.IOutboxEngine)
+	if !ok {
+		return nil, fmt.Errorf("stream kind %s doesn't support the outbox", streamKind)
+	}
+	return outboxEngine, nil
+}
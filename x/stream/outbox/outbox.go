@@ -0,0 +1,154 @@
+package outbox
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	// DBName is the name of the outbox db in the data directory of the node
+	DBName = "stream_outbox"
+)
+
+var (
+	prefixPayload = []byte{0x01}
+	prefixPending = []byte{0x02}
+)
+
+// Outbox persists the stream payload of each block per engine. A payload is pending until it's acknowledged by the
+// engine, so it's redelivered after the node restarts, and it's kept after the acknowledgement for replaying until
+// it's pruned
+type Outbox struct {
+	db dbm.DB
+}
+
+// NewOutbox creates an outbox on the db
+func NewOutbox(db dbm.DB) *Outbox {
+	return &Outbox{db: db}
+}
+
+// OpenOutbox opens the outbox goleveldb in dir
+func OpenOutbox(dir string) (*Outbox, error) {
+	db, err := dbm.NewGoLevelDB(DBName, dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewOutbox(db), nil
+}
+
+// Close closes the db of the outbox
+func (o *Outbox) Close() error {
+	return o.db.Close()
+}
+
+// Put stores the payload of the block for the engine as pending
+func (o *Outbox) Put(engine string, height int64, payload []byte) error {
+	batch := o.db.NewBatch()
+	defer batch.Close()
+	batch.Set(payloadKey(engine, height), payload)
+	batch.Set(pendingKey(engine, height), []byte{})
+	return batch.WriteSync()
+}
+
+// Ack marks the payload of the block as delivered to the engine
+func (o *Outbox) Ack(engine string, height int64) error {
+	return o.db.DeleteSync(pendingKey(engine, height))
+}
+
+// Get returns the payload of the block for the engine, nil if it's not stored or pruned
+func (o *Outbox) Get(engine string, height int64) ([]byte, error) {
+	return o.db.Get(payloadKey(engine, height))
+}
+
+// PendingHeights returns the heights below the height whose payloads haven't been acknowledged by the engine, in
+// ascending order
+func (o *Outbox) PendingHeights(engine string, below int64) (heights []int64, err error) {
+	err = o.iterate(prefixPending, engine, 0, below-1, func(height int64, _ []byte) error {
+		heights = append(heights, height)
+		return nil
+	})
+	return heights, err
+}
+
+// Range calls fn with the payloads of the blocks in [startHeight, endHeight] for the engine in ascending order
+func (o *Outbox) Range(engine string, startHeight, endHeight int64, fn func(height int64, payload []byte) error) error {
+	return o.iterate(prefixPayload, engine, startHeight, endHeight, fn)
+}
+
+// Prune deletes the acknowledged payloads below the height for the engine and returns the number deleted
+func (o *Outbox) Prune(engine string, below int64) (int, error) {
+	var heights []int64
+	err := o.iterate(prefixPayload, engine, 0, below-1, func(height int64, _ []byte) error {
+		heights = append(heights, height)
+		return nil
+	})
+	if err != nil || len(heights) == 0 {
+		return 0, err
+	}
+
+	batch := o.db.NewBatch()
+	defer batch.Close()
+	pruned := 0
+	for _, height := range heights {
+		pending, err := o.db.Has(pendingKey(engine, height))
+		if err != nil {
+			return 0, err
+		}
+		if pending {
+			continue
+		}
+		batch.Delete(payloadKey(engine, height))
+		pruned++
+	}
+	return pruned, batch.Write()
+}
+
+func (o *Outbox) iterate(prefix []byte, engine string, startHeight, endHeight int64, fn func(height int64, value []byte) error) error {
+	if startHeight < 0 {
+		startHeight = 0
+	}
+	if endHeight < startHeight {
+		return nil
+	}
+	it, err := o.db.Iterator(heightKey(prefix, engine, startHeight), heightKey(prefix, engine, endHeight+1))
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		key := it.Key()
+		height := int64(binary.BigEndian.Uint64(key[len(key)-8:]))
+		if err := fn(height, it.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func payloadKey(engine string, height int64) []byte {
+	return heightKey(prefixPayload, engine, height)
+}
+
+func pendingKey(engine string, height int64) []byte {
+	return heightKey(prefixPending, engine, height)
+}
+
+// heightKey is prefix | engine | 0x00 | big endian height, so the keys of an engine are sorted by height
+func heightKey(prefix []byte, engine string, height int64) []byte {
+	key := make([]byte, 0, len(prefix)+len(engine)+9)
+	key = append(key, prefix...)
+	key = append(key, engine...)
+	key = append(key, 0x00)
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return append(key, bz...)
+}
+
+// MsgKey returns the idempotent key of the seq-th message of the block written to the engine, by which the consumers
+// deduplicate the messages redelivered or replayed
+func MsgKey(height int64, engine string, seq int) string {
+	return fmt.Sprintf("%d-%s-%d", height, engine, seq)
+}
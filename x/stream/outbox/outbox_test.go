@@ -0,0 +1,58 @@
+package outbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestOutbox(t *testing.T) {
+	ob := NewOutbox(dbm.NewMemDB())
+	for height := int64(1); height <= 5; height++ {
+		require.NoError(t, ob.Put("kafka", height, []byte{byte(height)}))
+	}
+	require.NoError(t, ob.Put("pulsar", 3, []byte{3}))
+
+	heights, err := ob.PendingHeights("kafka", 5)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2, 3, 4}, heights)
+
+	require.NoError(t, ob.Ack("kafka", 1))
+	require.NoError(t, ob.Ack("kafka", 3))
+	heights, err = ob.PendingHeights("kafka", 10)
+	require.NoError(t, err)
+	require.Equal(t, []int64{2, 4, 5}, heights)
+
+	payload, err := ob.Get("kafka", 3)
+	require.NoError(t, err)
+	require.Equal(t, []byte{3}, payload)
+
+	var ranged []int64
+	require.NoError(t, ob.Range("kafka", 2, 4, func(height int64, payload []byte) error {
+		require.Equal(t, []byte{byte(height)}, payload)
+		ranged = append(ranged, height)
+		return nil
+	}))
+	require.Equal(t, []int64{2, 3, 4}, ranged)
+
+	// pending payloads aren't pruned
+	pruned, err := ob.Prune("kafka", 4)
+	require.NoError(t, err)
+	require.Equal(t, 2, pruned)
+	payload, err = ob.Get("kafka", 1)
+	require.NoError(t, err)
+	require.Nil(t, payload)
+	payload, err = ob.Get("kafka", 2)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	// the other engine isn't affected
+	heights, err = ob.PendingHeights("pulsar", 10)
+	require.NoError(t, err)
+	require.Equal(t, []int64{3}, heights)
+}
+
+func TestMsgKey(t *testing.T) {
+	require.Equal(t, "100-kafka-2", MsgKey(100, "kafka", 2))
+}
@@ -0,0 +1,102 @@
+package stream
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/okex/exchain/x/backend"
+	"github.com/okex/exchain/x/stream/common/kline"
+	"github.com/okex/exchain/x/stream/outbox"
+	"github.com/okex/exchain/x/stream/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+type mockOutboxEngine struct {
+	written []int64
+	fail    bool
+}
+
+func (e *mockOutboxEngine) URL() string {
+	return ""
+}
+
+func (e *mockOutboxEngine) Write(data types.IStreamData, success *bool) {
+	if e.fail {
+		*success = false
+		return
+	}
+	e.written = append(e.written, data.BlockHeight())
+	*success = true
+}
+
+func (e *mockOutboxEngine) OutboxName() string {
+	return "mock"
+}
+
+func (e *mockOutboxEngine) DecodeData(bz []byte) (types.IStreamData, error) {
+	data := kline.NewKlineData()
+	err := json.Unmarshal(bz, data)
+	return data, err
+}
+
+func TestOutbox_Redeliver(t *testing.T) {
+	engine := &mockOutboxEngine{}
+	s := &Stream{
+		logger:      log.NewNopLogger(),
+		engines:     map[EngineKind]types.IStreamEngine{EngineKlineKind: engine},
+		streamKinds: map[EngineKind]Kind{EngineKlineKind: StreamKafkaKind},
+		outbox:      outbox.NewOutbox(dbm.NewMemDB()),
+	}
+
+	newTask := func(height int64) *TaskWithData {
+		data := kline.NewKlineData()
+		data.Height = height
+		data.SetMatchResults([]*backend.MatchResult{{BlockHeight: height, Product: "tbtc_tusdk", Price: 1, Quantity: 2}})
+		data.SetMarketIDs(map[string]int64{"tbtc_tusdk": 7})
+		task := &TaskWithData{Task: NewTask(height), dataMap: map[Kind]types.IStreamData{StreamKafkaKind: data}}
+		task.DoneMap[StreamKafkaKind] = false
+		return task
+	}
+
+	// blocks 1 and 2 weren't written before the restart, while 3 was
+	for height := int64(1); height <= 3; height++ {
+		putOutbox(s, newTask(height))
+	}
+	done := newTask(3).Task
+	done.DoneMap[StreamKafkaKind] = true
+	ackOutbox(s, done, false)
+
+	engine.fail = true
+	redeliverOutbox(s, 4)
+	require.Empty(t, engine.written)
+
+	engine.fail = false
+	redeliverOutbox(s, 4)
+	require.Equal(t, []int64{1, 2}, engine.written)
+	heights, err := s.outbox.PendingHeights("mock", 4)
+	require.NoError(t, err)
+	require.Empty(t, heights)
+
+	// the payload keeps the match results
+	bz, err := s.outbox.Get("mock", 2)
+	require.NoError(t, err)
+	data, err := engine.DecodeData(bz)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), data.(*kline.KlineData).GetMatchResults()[0].BlockHeight)
+
+	// and the market ids to be replayed without the app
+	_, ok := kline.GetMarketIDMap()["tbtc_tusdk"]
+	require.False(t, ok)
+	marketID, ok := data.(*kline.KlineData).GetMarketID("tbtc_tusdk")
+	require.True(t, ok)
+	require.Equal(t, int64(7), marketID)
+}
+
+func TestKeyedMatchResult(t *testing.T) {
+	bz, err := json.Marshal(kline.NewKeyedMatchResult(backend.MatchResult{BlockHeight: 10, Product: "tbtc_tusdk"}, "kafka", 1))
+	require.NoError(t, err)
+	require.Contains(t, string(bz), `"block_height":10`)
+	require.Contains(t, string(bz), `"msg_key":"10-kafka-1"`)
+}
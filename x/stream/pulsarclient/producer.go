@@ -17,6 +17,9 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 )
 
+// EngineName is the name of the engine in the stream outbox and the message keys
+const EngineName = "pulsar"
+
 type PulsarProducer struct {
 	kline.MarketConfig
 	producers []*pulsar.ManagedProducer
@@ -92,17 +95,17 @@ func (pp *PulsarProducer) SendAllMsg(data *kline.KlineData, logger log.Logger) (
 	var errChan = make(chan error, len(matchResults))
 	var wg sync.WaitGroup
 	wg.Add(len(matchResults))
-	for _, matchResult := range matchResults {
-		go func(matchResult backend.MatchResult) {
+	for i, matchResult := range matchResults {
+		go func(seq int, matchResult backend.MatchResult) {
 			defer wg.Done()
-			marketID, ok := kline.GetMarketIDMap()[matchResult.Product]
+			marketID, ok := data.GetMarketID(matchResult.Product)
 			if !ok {
 				err := fmt.Errorf("failed to find %s marketId", matchResult.Product)
 				errChan <- err
 				return
 			}
 
-			msg, err := json.Marshal(&matchResult)
+			msg, err := json.Marshal(kline.NewKeyedMatchResult(matchResult, EngineName, seq))
 			if err != nil {
 				errChan <- err
 				return
@@ -122,7 +125,7 @@ func (pp *PulsarProducer) SendAllMsg(data *kline.KlineData, logger log.Logger) (
 					matchResult.Quantity, matchResult.Price, matchResult.Product,
 				),
 			)
-		}(*matchResult)
+		}(i, *matchResult)
 	}
 	wg.Wait()
 
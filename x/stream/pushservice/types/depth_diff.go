@@ -0,0 +1,104 @@
+package types
+
+import (
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// depthChecksumLevels is the number of levels of each side covered by the checksum
+const depthChecksumLevels = 25
+
+// DepthDiff is the change of the depth book of a product in a block. The levels are the ones updated in the book,
+// and a level with zero quantity is removed from it. A client applies the diff when its book is at PrevUpdateID,
+// then checks the checksum of the book against Checksum
+type DepthDiff struct {
+	Product      string     `json:"instrument_id"`
+	PrevUpdateID int64      `json:"prev_update_id"`
+	UpdateID     int64      `json:"update_id"`
+	Asks         [][]string `json:"asks"`
+	Bids         [][]string `json:"bids"`
+	Checksum     int32      `json:"checksum"`
+	Timestamp    string     `json:"timestamp"`
+}
+
+// SequenceBookRes sets the update id and the checksum of the book
+func SequenceBookRes(book *BookRes, updateID int64) {
+	book.UpdateID = updateID
+	book.Checksum = DepthChecksum(book.Asks, book.Bids)
+}
+
+// NewDepthDiff returns the diff from the book prev to the book cur, which is sequenced by updateID.
+// It returns false if nothing changed, and cur keeps the update id of prev
+func NewDepthDiff(prev *BookRes, cur *BookRes, updateID int64) (DepthDiff, bool) {
+	var prevAsks, prevBids [][]string
+	var prevUpdateID int64
+	if prev != nil {
+		prevAsks, prevBids, prevUpdateID = prev.Asks, prev.Bids, prev.UpdateID
+	}
+
+	asks := diffDepthLevels(prevAsks, cur.Asks, false)
+	bids := diffDepthLevels(prevBids, cur.Bids, true)
+	if len(asks) == 0 && len(bids) == 0 && prev != nil {
+		SequenceBookRes(cur, prevUpdateID)
+		return DepthDiff{}, false
+	}
+
+	SequenceBookRes(cur, updateID)
+	return DepthDiff{
+		Product:      cur.Product,
+		PrevUpdateID: prevUpdateID,
+		UpdateID:     updateID,
+		Asks:         asks,
+		Bids:         bids,
+		Checksum:     cur.Checksum,
+		Timestamp:    cur.Timestamp,
+	}, true
+}
+
+// diffDepthLevels returns the levels changed or removed from prev to cur, sorted as the side of the book
+func diffDepthLevels(prev, cur [][]string, desc bool) [][]string {
+	prevLevels := make(map[string][]string, len(prev))
+	for _, level := range prev {
+		prevLevels[level[0]] = level
+	}
+
+	var changed [][]string
+	for _, level := range cur {
+		prevLevel, ok := prevLevels[level[0]]
+		if !ok || prevLevel[1] != level[1] || prevLevel[2] != level[2] {
+			changed = append(changed, level)
+		}
+		delete(prevLevels, level[0])
+	}
+	for price := range prevLevels {
+		changed = append(changed, []string{price, "0", "0"})
+	}
+
+	sort.SliceStable(changed, func(i, j int) bool {
+		pi, pj := sdk.MustNewDecFromStr(changed[i][0]), sdk.MustNewDecFromStr(changed[j][0])
+		if desc {
+			return pi.GT(pj)
+		}
+		return pi.LT(pj)
+	})
+	return changed
+}
+
+// DepthChecksum returns the crc32 of the top levels of the book, which are joined as
+// "bid_price:bid_quantity:ask_price:ask_quantity:..." level by level, and the side with less levels is skipped
+func DepthChecksum(asks, bids [][]string) int32 {
+	var fields []string
+	for i := 0; i < depthChecksumLevels; i++ {
+		if i < len(bids) {
+			fields = append(fields, fmt.Sprintf("%s:%s", bids[i][0], bids[i][1]))
+		}
+		if i < len(asks) {
+			fields = append(fields, fmt.Sprintf("%s:%s", asks[i][0], asks[i][1]))
+		}
+	}
+	return int32(crc32.ChecksumIEEE([]byte(strings.Join(fields, ":"))))
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDepthDiff(t *testing.T) {
+	book := BookRes{
+		Product: "xxb_okt",
+		Asks:    [][]string{{"1.1", "10", "1"}, {"1.2", "5", "2"}},
+		Bids:    [][]string{{"1.0", "3", "1"}, {"0.9", "4", "1"}},
+	}
+	diff, changed := NewDepthDiff(nil, &book, 10)
+	require.True(t, changed)
+	require.EqualValues(t, 0, diff.PrevUpdateID)
+	require.EqualValues(t, 10, diff.UpdateID)
+	require.Equal(t, book.Asks, diff.Asks)
+	require.Equal(t, book.Bids, diff.Bids)
+	require.EqualValues(t, 10, book.UpdateID)
+	require.Equal(t, book.Checksum, diff.Checksum)
+
+	// nothing changed, the book keeps the update id
+	same := BookRes{Product: book.Product, Asks: book.Asks, Bids: book.Bids}
+	_, changed = NewDepthDiff(&book, &same, 11)
+	require.False(t, changed)
+	require.EqualValues(t, 10, same.UpdateID)
+
+	// a level changed, a level added and a level removed on each side
+	next := BookRes{
+		Product: "xxb_okt",
+		Asks:    [][]string{{"1.05", "1", "1"}, {"1.1", "10", "1"}},
+		Bids:    [][]string{{"1.0", "2", "1"}, {"0.8", "4", "1"}},
+	}
+	diff, changed = NewDepthDiff(&same, &next, 12)
+	require.True(t, changed)
+	require.EqualValues(t, 10, diff.PrevUpdateID)
+	require.EqualValues(t, 12, diff.UpdateID)
+	require.Equal(t, [][]string{{"1.05", "1", "1"}, {"1.2", "0", "0"}}, diff.Asks)
+	require.Equal(t, [][]string{{"1.0", "2", "1"}, {"0.9", "0", "0"}, {"0.8", "4", "1"}}, diff.Bids)
+	require.Equal(t, DepthChecksum(next.Asks, next.Bids), diff.Checksum)
+}
+
+func TestDepthChecksum(t *testing.T) {
+	asks := [][]string{{"1.1", "10", "1"}, {"1.2", "5", "2"}}
+	bids := [][]string{{"1.0", "3", "1"}}
+	expected := int32(crc32.ChecksumIEEE([]byte("1.0:3:1.1:10:1.2:5")))
+	require.Equal(t, expected, DepthChecksum(asks, bids))
+	require.Equal(t, int32(crc32.ChecksumIEEE(nil)), DepthChecksum(nil, nil))
+}
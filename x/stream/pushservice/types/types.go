@@ -173,6 +173,9 @@ type BookRes struct {
 	Bids      [][]string `json:"bids"`
 	Product   string     `json:"instrument_id"`
 	Timestamp string     `json:"timestamp"`
+	// UpdateID and Checksum are set on the books sequenced with the depth diffs, see DepthDiff
+	UpdateID int64 `json:"update_id,omitempty"`
+	Checksum int32 `json:"checksum,omitempty"`
 }
 
 func (bri *BookResItem) toJSONList() []string {
@@ -224,10 +227,10 @@ func ConvertBookRes(product string, orderKeeper types.OrderKeeper, depthBook *or
 	}
 
 	bookRes := BookRes{
-		asks,
-		bids,
-		product,
-		time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
+		Asks:      asks,
+		Bids:      bids,
+		Product:   product,
+		Timestamp: time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
 	}
 	return bookRes
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/okex/exchain/x/stream/eureka"
 	"github.com/okex/exchain/x/stream/nacos"
+	"github.com/okex/exchain/x/stream/outbox"
 	"github.com/okex/exchain/x/stream/websocket"
 
 	appCfg "github.com/cosmos/cosmos-sdk/server/config"
@@ -44,6 +45,7 @@ type Stream struct {
 	cdc            *codec.Codec // The wire codec for binary encoding/decoding.
 	logger         log.Logger
 	engines        map[EngineKind]types.IStreamEngine
+	streamKinds    map[EngineKind]Kind
 	Cache          *common.Cache
 	AnalysisEnable bool
 
@@ -55,6 +57,7 @@ type Stream struct {
 	coordinator     *Coordinator
 	cacheQueue      *CacheQueue
 	cfg             *appCfg.StreamConfig
+	outbox          *outbox.Outbox
 }
 
 func NewStream(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dexKeeper types.DexKeeper, swapKeeper types.SwapKeeper, farmKeeper types.FarmKeeper, cdc *codec.Codec, logger log.Logger, cfg *appCfg.Config) *Stream {
@@ -134,9 +137,20 @@ func NewStream(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dex
 	}
 
 	se.engines = engines
+	se.streamKinds = ParseStreamKinds(se.cfg)
 	se.logger.Info(fmt.Sprintf("%d engines created, verbose info: %+v", len(se.engines), se.engines))
 	se.AnalysisEnable = se.engines[EngineAnalysisKind] != nil
 
+	// open the outbox if any engine keeps its data in the outbox
+	if viper.GetBool(FlagOutboxEnable) && len(se.outboxEngines()) > 0 {
+		se.outbox, err = outbox.OpenOutbox(OutboxDir())
+		if err != nil {
+			errStr := fmt.Sprintf("open stream outbox failed: %+v", err)
+			logger.Error(errStr)
+			panic(errStr)
+		}
+	}
+
 	se.taskChan = make(chan *TaskWithData, 1)
 	se.resultChan = make(chan Task, 1)
 	se.distrLatestTask = nil
@@ -151,9 +165,13 @@ func NewStream(orderKeeper types.OrderKeeper, tokenKeeper types.TokenKeeper, dex
 
 	se.logger.Info("NewStreamEngine success.")
 
-	// Enable websocket
-	if se.engines[EngineWebSocketKind] != nil {
-		go websocket.StartWSServer(logger, se.engines[EngineWebSocketKind].URL())
+	// Enable websocket, the native engine serves the websocket itself
+	if engine := se.engines[EngineWebSocketKind]; engine != nil {
+		if nativeEngine, ok := engine.(*websocket.NativeEngine); ok {
+			go nativeEngine.Start()
+		} else {
+			go websocket.StartWSServer(logger, engine.URL())
+		}
 	}
 
 	return se
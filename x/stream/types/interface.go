@@ -12,6 +12,14 @@ type IStreamEngine interface {
 	Write(data IStreamData, success *bool)
 }
 
+// IOutboxEngine is the stream engine whose data of each block is kept in the stream outbox until it's written, so
+// the data is redelivered after the node restarts and can be replayed
+type IOutboxEngine interface {
+	IStreamEngine
+	OutboxName() string
+	DecodeData(bz []byte) (IStreamData, error)
+}
+
 type StreamDataKind byte
 
 const (
@@ -0,0 +1,244 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	// EngineName is the name of the engine in the message keys
+	EngineName = "webhook"
+
+	FlagWebhookMaxRetries = "stream.webhook_max_retries"
+	FlagWebhookTimeout    = "stream.webhook_timeout"
+	FlagWebhookQueueSize  = "stream.webhook_queue_size"
+
+	HeaderTimestamp = "X-Exchain-Timestamp"
+	HeaderSignature = "X-Exchain-Signature"
+
+	maxBackoff = time.Minute
+)
+
+// Endpoint is a webhook receiving the events of its topics. A topic is a channel with optional filters separated by
+// ":", where "*" matches any filter, e.g. "dex_spot/order", "dex_spot/order:tbtc_tusdk" or
+// "dex_spot/account:*:ex1...". No topics means all the channels
+type Endpoint struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Topics []string `json:"topics"`
+}
+
+// Match returns whether the channel matches a topic of the endpoint
+func (e Endpoint) Match(channel string) bool {
+	if len(e.Topics) == 0 {
+		return true
+	}
+	segments := strings.Split(channel, ":")
+	for _, topic := range e.Topics {
+		if matchTopic(strings.Split(topic, ":"), segments) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchTopic(topicSegments, channelSegments []string) bool {
+	if len(topicSegments) > len(channelSegments) {
+		return false
+	}
+	for i, segment := range topicSegments {
+		if segment != "*" && segment != channelSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadEndpoints loads the json array of the endpoints from the file
+func LoadEndpoints(path string) ([]Endpoint, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var endpoints []Endpoint
+	if err := json.Unmarshal(bz, &endpoints); err != nil {
+		return nil, fmt.Errorf("invalid webhook endpoints file %s: %s", path, err)
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.URL == "" {
+			return nil, fmt.Errorf("invalid webhook endpoints file %s: empty url", path)
+		}
+	}
+	return endpoints, nil
+}
+
+// Event is a message of the block delivered to the webhooks, the consumers deduplicate the events by the message key
+type Event struct {
+	Channel string          `json:"channel"`
+	MsgKey  string          `json:"msg_key"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Delivery is the body posted to an endpoint, which is the events of a block matching the topics of the endpoint
+type Delivery struct {
+	Height int64   `json:"height"`
+	Events []Event `json:"events"`
+}
+
+// Sign returns the hex HMAC-SHA256 of "timestamp.body" by the secret, which is sent in the signature header with the
+// timestamp in the timestamp header
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Config configures the delivery of the dispatcher. A failed delivery is retried MaxRetries times with the backoff
+// doubled from Backoff
+type Config struct {
+	MaxRetries int
+	Timeout    time.Duration
+	QueueSize  int
+	Backoff    time.Duration
+}
+
+// ConfigFromViper reads the config of the dispatcher from viper
+func ConfigFromViper() Config {
+	return Config{
+		MaxRetries: viper.GetInt(FlagWebhookMaxRetries),
+		Timeout:    viper.GetDuration(FlagWebhookTimeout),
+		QueueSize:  viper.GetInt(FlagWebhookQueueSize),
+		Backoff:    time.Second,
+	}
+}
+
+// Dispatcher delivers the events to the endpoints asynchronously, each endpoint has its own queue so a slow endpoint
+// doesn't delay the others
+type Dispatcher struct {
+	workers []*endpointWorker
+}
+
+type endpointWorker struct {
+	endpoint Endpoint
+	cfg      Config
+	client   *http.Client
+	queue    chan Delivery
+	logger   log.Logger
+}
+
+// NewDispatcher creates the dispatcher and starts the delivery to the endpoints
+func NewDispatcher(endpoints []Endpoint, cfg Config, logger log.Logger) *Dispatcher {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 1
+	}
+	d := &Dispatcher{}
+	for _, endpoint := range endpoints {
+		w := &endpointWorker{
+			endpoint: endpoint,
+			cfg:      cfg,
+			client:   &http.Client{Timeout: cfg.Timeout},
+			queue:    make(chan Delivery, cfg.QueueSize),
+			logger:   logger,
+		}
+		d.workers = append(d.workers, w)
+		go w.run()
+	}
+	return d
+}
+
+// Dispatch queues the events of the block matching the topics to each endpoint. It returns false if the queue of an
+// endpoint is full
+func (d *Dispatcher) Dispatch(height int64, events []Event) bool {
+	ok := true
+	for _, w := range d.workers {
+		delivery := Delivery{Height: height}
+		for _, event := range events {
+			if w.endpoint.Match(event.Channel) {
+				delivery.Events = append(delivery.Events, event)
+			}
+		}
+		if len(delivery.Events) == 0 {
+			continue
+		}
+
+		select {
+		case w.queue <- delivery:
+		default:
+			w.logger.Error(fmt.Sprintf("webhook queue of %s is full, block %d isn't delivered", w.endpoint.URL, height))
+			ok = false
+		}
+	}
+	return ok
+}
+
+// Close stops the delivery after the queued deliveries are done
+func (d *Dispatcher) Close() {
+	for _, w := range d.workers {
+		close(w.queue)
+	}
+}
+
+func (w *endpointWorker) run() {
+	for delivery := range w.queue {
+		if err := w.deliver(delivery); err != nil {
+			w.logger.Error(fmt.Sprintf("webhook failed to deliver block %d to %s: %s", delivery.Height, w.endpoint.URL, err))
+		}
+	}
+}
+
+func (w *endpointWorker) deliver(delivery Delivery) error {
+	body, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+
+	backoff := w.cfg.Backoff
+	for attempt := 0; ; attempt++ {
+		if err = w.post(body); err == nil || attempt >= w.cfg.MaxRetries {
+			return err
+		}
+		w.logger.Debug(fmt.Sprintf("webhook retries block %d to %s in %s: %s", delivery.Height, w.endpoint.URL, backoff, err))
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (w *endpointWorker) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, w.endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	if w.endpoint.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(w.endpoint.Secret, timestamp, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = ioutil.ReadAll(resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestEndpoint_Match(t *testing.T) {
+	require.True(t, Endpoint{}.Match("dex_spot/order:tbtc_tusdk:ex1abc"))
+
+	endpoint := Endpoint{Topics: []string{"dex_spot/order:tbtc_tusdk", "dex_spot/account:*:ex1abc"}}
+	require.True(t, endpoint.Match("dex_spot/order:tbtc_tusdk:ex1abc"))
+	require.False(t, endpoint.Match("dex_spot/order:tokt_tusdk:ex1abc"))
+	require.True(t, endpoint.Match("dex_spot/account:tokt:ex1abc"))
+	require.False(t, endpoint.Match("dex_spot/account:tokt:ex1def"))
+	require.False(t, endpoint.Match("dex_spot/account"))
+	require.False(t, endpoint.Match("dex_spot/ticker:tbtc_tusdk"))
+}
+
+func TestLoadEndpoints(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webhook")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "endpoints.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`[{"url":"https://example.com/hook","secret":"s","topics":["dex_spot/order"]}]`), 0600))
+	endpoints, err := LoadEndpoints(path)
+	require.NoError(t, err)
+	require.Equal(t, []Endpoint{{URL: "https://example.com/hook", Secret: "s", Topics: []string{"dex_spot/order"}}}, endpoints)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`[{"secret":"s"}]`), 0600))
+	_, err = LoadEndpoints(path)
+	require.Error(t, err)
+}
+
+func TestDispatcher(t *testing.T) {
+	var mtx sync.Mutex
+	var deliveries []Delivery
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		attempts++
+		// the first attempt fails
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		require.Equal(t, Sign("secret", timestamp, body), r.Header.Get(HeaderSignature))
+
+		var delivery Delivery
+		require.NoError(t, json.Unmarshal(body, &delivery))
+		deliveries = append(deliveries, delivery)
+	}))
+	defer server.Close()
+
+	endpoints := []Endpoint{{URL: server.URL, Secret: "secret", Topics: []string{"dex_spot/order"}}}
+	dispatcher := NewDispatcher(endpoints, Config{MaxRetries: 2, Timeout: time.Second, QueueSize: 10, Backoff: time.Millisecond}, log.NewNopLogger())
+	defer dispatcher.Close()
+
+	require.True(t, dispatcher.Dispatch(10, []Event{
+		{Channel: "dex_spot/order:tbtc_tusdk:ex1abc", MsgKey: "10-webhook-0", Data: json.RawMessage(`[{"order_id":"1"}]`)},
+		{Channel: "dex_spot/ticker:tbtc_tusdk", MsgKey: "10-webhook-1", Data: json.RawMessage(`{"price":"1"}`)},
+	}))
+	// no events matched
+	require.True(t, dispatcher.Dispatch(11, []Event{{Channel: "dex_spot/ticker:tbtc_tusdk", MsgKey: "11-webhook-0"}}))
+
+	require.Eventually(t, func() bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return len(deliveries) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	require.Equal(t, 2, attempts)
+	require.Equal(t, int64(10), deliveries[0].Height)
+	require.Len(t, deliveries[0].Events, 1)
+	require.Equal(t, "10-webhook-0", deliveries[0].Events[0].MsgKey)
+	require.JSONEq(t, `[{"order_id":"1"}]`, string(deliveries[0].Events[0].Data))
+}
+
+func TestDispatcher_QueueFull(t *testing.T) {
+	block := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer server.Close()
+	defer close(block)
+
+	dispatcher := NewDispatcher([]Endpoint{{URL: server.URL}}, Config{Timeout: 5 * time.Second, QueueSize: 1}, log.NewNopLogger())
+	events := []Event{{Channel: "dex_spot/order:tbtc_tusdk:ex1abc", Data: json.RawMessage(`[]`)}}
+	full := false
+	for height := int64(1); height <= 3 && !full; height++ {
+		full = !dispatcher.Dispatch(height, events)
+	}
+	require.True(t, full)
+}
@@ -369,6 +369,7 @@ func (conn *Conn) cliSubscribe(op *BaseOp) (err error) {
 	// 4. push initial data
 	initialDataMap := map[string]func(topic *SubscriptionTopic){
 		DexSpotDepthBook: conn.initialDepthBook,
+		DexSpotDepthDiff: conn.initialDepthBook,
 	}
 	for _, topic := range topics {
 		initialDataFunc, ok := initialDataMap[topic.Channel]
@@ -29,6 +29,7 @@ func InitialCache(ctx sdk.Context, orderKeeper types.OrderKeeper, dexKeeper type
 		for _, tokenPair := range tokenPairs {
 			depthBook := orderKeeper.GetDepthBookCopy(tokenPair.Name())
 			bookRes := pushservice.ConvertBookRes(tokenPair.Name(), orderKeeper, depthBook, size)
+			pushservice.SequenceBookRes(&bookRes, ctx.BlockHeight())
 			depthBooksMap[tokenPair.Name()] = bookRes
 		}
 		logger.Debug("initial websocket cache", "depthbook", depthBooksMap)
@@ -39,14 +40,27 @@ func InitialCache(ctx sdk.Context, orderKeeper types.OrderKeeper, dexKeeper type
 }
 
 func GetDepthBookFromCache(product string) (depthBook pushservice.BookRes, ok bool) {
+	// the cache is initialized at the first block
+	if singletonCache == nil {
+		return depthBook, false
+	}
 	singletonCache.lock.RLock()
 	defer singletonCache.lock.RUnlock()
 	depthBook, ok = singletonCache.depthBooksMap[product]
 	return
 }
 
-func UpdateDepthBookCache(product string, bookRes pushservice.BookRes) {
+// UpdateDepthBookCache sequences the book of the block at height against the cached one, and returns the diff
+// between them. It returns false if nothing changed
+func UpdateDepthBookCache(product string, bookRes pushservice.BookRes, height int64) (pushservice.DepthDiff, bool) {
 	singletonCache.lock.Lock()
 	defer singletonCache.lock.Unlock()
+
+	var prev *pushservice.BookRes
+	if cached, ok := singletonCache.depthBooksMap[product]; ok {
+		prev = &cached
+	}
+	diff, changed := pushservice.NewDepthDiff(prev, &bookRes, height)
 	singletonCache.depthBooksMap[product] = bookRes
+	return diff, changed
 }
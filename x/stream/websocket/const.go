@@ -21,6 +21,7 @@ const (
 	DexSpotAllTicker3s = "dex_spot/all_ticker_3s"
 	DexSpotTicker      = "dex_spot/ticker"
 	DexSpotDepthBook   = "dex_spot/optimized_depth"
+	DexSpotDepthDiff   = "dex_spot/depth_diff"
 
 	eventSubscribe   = "subscribe"
 	eventUnsubscribe = "unsubscribe"
@@ -0,0 +1,465 @@
+package websocket
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	appCfg "github.com/cosmos/cosmos-sdk/server/config"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/websocket"
+	"github.com/okex/exchain/x/stream/types"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	FlagNativeMaxConns         = "stream.native_ws_max_conns"
+	FlagNativeMaxSubscriptions = "stream.native_ws_max_subscriptions"
+	FlagNativeSendBuffer       = "stream.native_ws_send_buffer"
+	FlagNativeLoginExpiry      = "stream.native_ws_login_expiry"
+
+	// time allowed to read the next pong message from the peer
+	nativePongWait = 60 * time.Second
+	// send pings to peer with this period, which must be less than nativePongWait
+	nativePingPeriod = nativePongWait * 9 / 10
+	// maximum message size allowed from peer
+	nativeMaxMessageSize = 4096
+
+	eventNativeLogin = "login"
+
+	defaultNativeSendBuffer  = 256
+	defaultNativeLoginExpiry = 5 * time.Minute
+)
+
+// NativeConfig limits the connections of the native engine, zero MaxConns or MaxSubscriptions means no limit
+type NativeConfig struct {
+	MaxConns         int
+	MaxSubscriptions int
+	SendBuffer       int
+	LoginExpiry      time.Duration
+}
+
+// NativeConfigFromViper returns the NativeConfig set by the flags
+func NativeConfigFromViper() NativeConfig {
+	return NativeConfig{
+		MaxConns:         viper.GetInt(FlagNativeMaxConns),
+		MaxSubscriptions: viper.GetInt(FlagNativeMaxSubscriptions),
+		SendBuffer:       viper.GetInt(FlagNativeSendBuffer),
+		LoginExpiry:      viper.GetDuration(FlagNativeLoginExpiry),
+	}
+}
+
+// NativeEngine serves the channels from the node over websocket, without the tendermint rpc or any message broker
+type NativeEngine struct {
+	url    string
+	logger log.Logger
+	hub    *Hub
+}
+
+func NewNativeEngine(url string, logger log.Logger, cfg *appCfg.StreamConfig) (types.IStreamEngine, error) {
+	logger = logger.With("module", "native-websocket")
+	return &NativeEngine{url: url, logger: logger, hub: NewHub(NativeConfigFromViper(), logger)}, nil
+}
+
+func (engine *NativeEngine) URL() string {
+	return engine.url
+}
+
+func (engine *NativeEngine) Write(data types.IStreamData, success *bool) {
+	defer func() {
+		if e := recover(); e != nil {
+			*success = false
+			engine.logger.Error("error: NativeEngine Write", "err", e)
+		}
+	}()
+
+	messages, err := data.(*PushData).ChannelMessages()
+	if err != nil {
+		panic(err)
+	}
+	for _, msg := range messages {
+		engine.hub.Publish(msg.Channel, msg.Data)
+	}
+	*success = true
+}
+
+// Start serves the websocket and the depth snapshot at the url of the engine
+func (engine *NativeEngine) Start() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws/v3", engine.hub.ServeWS)
+	mux.HandleFunc("/ws/v3/depth", depthSnapshotHandler)
+	engine.logger.Info("Starting native WebSocket server on ", engine.url)
+	if err := http.ListenAndServe(engine.url, mux); err != nil {
+		panic(err)
+	}
+}
+
+// Hub maintains the connections and their subscriptions of the native engine
+type Hub struct {
+	cfg    NativeConfig
+	logger log.Logger
+
+	mtx   sync.RWMutex
+	conns map[*nativeConn]struct{}
+	subs  map[string]map[*nativeConn]struct{}
+}
+
+func NewHub(cfg NativeConfig, logger log.Logger) *Hub {
+	if cfg.SendBuffer <= 0 {
+		cfg.SendBuffer = defaultNativeSendBuffer
+	}
+	if cfg.LoginExpiry <= 0 {
+		cfg.LoginExpiry = defaultNativeLoginExpiry
+	}
+	return &Hub{
+		cfg:    cfg,
+		logger: logger,
+		conns:  make(map[*nativeConn]struct{}),
+		subs:   make(map[string]map[*nativeConn]struct{}),
+	}
+}
+
+// Publish pushes the json value to the subscribers of the channel. A subscriber too slow to receive the messages
+// is disconnected, as dropping messages breaks the books and the sequences kept by the clients
+func (h *Hub) Publish(channel string, value []byte) {
+	h.mtx.RLock()
+	conns := make([]*nativeConn, 0, len(h.subs[channel]))
+	for conn := range h.subs[channel] {
+		conns = append(conns, conn)
+	}
+	h.mtx.RUnlock()
+	if len(conns) == 0 {
+		return
+	}
+
+	msg, err := newTableResponse(FormSubscriptionTopic(channel).Channel, "update", value)
+	if err != nil {
+		h.logger.Error("failed to publish", "channel", channel, "err", err)
+		return
+	}
+	for _, conn := range conns {
+		if !conn.enqueue(msg) {
+			h.logger.Info("disconnect slow connection", "remote", conn.ws.RemoteAddr().String())
+			conn.close()
+		}
+	}
+}
+
+// ServeWS upgrades the http request to a websocket connection of the hub
+func (h *Hub) ServeWS(w http.ResponseWriter, r *http.Request) {
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.Debug(fmt.Sprintf("ServeWS upgrade error: %s", err.Error()))
+		return
+	}
+
+	conn := &nativeConn{
+		hub:      h,
+		ws:       ws,
+		send:     make(chan []byte, h.cfg.SendBuffer),
+		done:     make(chan struct{}),
+		channels: make(map[string]struct{}),
+	}
+	if err := h.register(conn); err != nil {
+		_ = ws.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseTryAgainLater, err.Error()), time.Now().Add(writeWait))
+		ws.Close()
+		return
+	}
+
+	go conn.writeLoop()
+	go conn.readLoop()
+}
+
+func (h *Hub) register(conn *nativeConn) error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if h.cfg.MaxConns > 0 && len(h.conns) >= h.cfg.MaxConns {
+		return fmt.Errorf("too many connections, the limit is %d", h.cfg.MaxConns)
+	}
+	h.conns[conn] = struct{}{}
+	return nil
+}
+
+func (h *Hub) unregister(conn *nativeConn) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	delete(h.conns, conn)
+	for channel := range conn.channels {
+		h.removeSubscription(conn, channel)
+	}
+}
+
+func (h *Hub) subscribe(conn *nativeConn, channel string) error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if _, ok := conn.channels[channel]; ok {
+		return nil
+	}
+	if h.cfg.MaxSubscriptions > 0 && len(conn.channels) >= h.cfg.MaxSubscriptions {
+		return fmt.Errorf("too many subscriptions, the limit is %d", h.cfg.MaxSubscriptions)
+	}
+	conn.channels[channel] = struct{}{}
+	if h.subs[channel] == nil {
+		h.subs[channel] = make(map[*nativeConn]struct{})
+	}
+	h.subs[channel][conn] = struct{}{}
+	return nil
+}
+
+func (h *Hub) unsubscribe(conn *nativeConn, channel string) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.removeSubscription(conn, channel)
+}
+
+func (h *Hub) removeSubscription(conn *nativeConn, channel string) {
+	delete(conn.channels, channel)
+	if subs, ok := h.subs[channel]; ok {
+		delete(subs, conn)
+		if len(subs) == 0 {
+			delete(h.subs, channel)
+		}
+	}
+}
+
+// nativeConn is a client connection of the hub, the channels are guarded by the mutex of the hub
+type nativeConn struct {
+	hub          *Hub
+	ws           *websocket.Conn
+	send         chan []byte
+	done         chan struct{}
+	closeOnce    sync.Once
+	channels     map[string]struct{}
+	loginAddress string
+}
+
+// enqueue queues the message to send without blocking, it returns false if the send buffer is full
+func (conn *nativeConn) enqueue(msg []byte) bool {
+	select {
+	case <-conn.done:
+		return true
+	default:
+	}
+	select {
+	case conn.send <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+func (conn *nativeConn) enqueueJSON(v interface{}) {
+	msg, err := json.Marshal(v)
+	if err != nil {
+		conn.hub.logger.Error("failed to marshal response", "err", err)
+		return
+	}
+	if !conn.enqueue(msg) {
+		conn.close()
+	}
+}
+
+func (conn *nativeConn) close() {
+	conn.closeOnce.Do(func() {
+		close(conn.done)
+		conn.hub.unregister(conn)
+		conn.ws.Close()
+	})
+}
+
+func (conn *nativeConn) readLoop() {
+	defer conn.close()
+
+	conn.ws.SetReadLimit(nativeMaxMessageSize)
+	_ = conn.ws.SetReadDeadline(time.Now().Add(nativePongWait))
+	conn.ws.SetPongHandler(func(string) error {
+		return conn.ws.SetReadDeadline(time.Now().Add(nativePongWait))
+	})
+
+	for {
+		msgType, msg, err := conn.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		if msgType == websocket.BinaryMessage {
+			if msg, err = gzipDecode(msg); err != nil {
+				return
+			}
+		}
+
+		if string(msg) == "ping" {
+			conn.enqueue([]byte("pong"))
+			continue
+		}
+		var op BaseOp
+		if err := json.Unmarshal(msg, &op); err != nil {
+			conn.enqueueJSON(ErrorResponse{Event: "error", Message: "invalid request", ErrorCode: 30043})
+			continue
+		}
+		switch op.Op {
+		case eventSubscribe:
+			conn.handleSubscribe(op.Args)
+		case eventUnsubscribe:
+			conn.handleUnsubscribe(op.Args)
+		case eventNativeLogin:
+			conn.handleLogin(op.Args)
+		default:
+			conn.enqueueJSON(ErrorResponse{Event: "error", Message: fmt.Sprintf("unsupported op %s", op.Op), ErrorCode: 30043})
+		}
+	}
+}
+
+func (conn *nativeConn) writeLoop() {
+	ticker := time.NewTicker(nativePingPeriod)
+	defer func() {
+		ticker.Stop()
+		conn.close()
+	}()
+
+	for {
+		select {
+		case msg := <-conn.send:
+			_ = conn.ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.ws.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			_ = conn.ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.ws.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-conn.done:
+			return
+		}
+	}
+}
+
+// channelOf returns the channel subscribed by the topic, and appends the login address to the private topic
+func (conn *nativeConn) channelOf(topic *SubscriptionTopic) (string, error) {
+	if topic.NeedLogin() {
+		if conn.loginAddress == "" {
+			return "", fmt.Errorf("User not logged in / User must be logined in, before subscribe:%s", topic.Channel)
+		}
+		topic.Filter = fmt.Sprintf("%s:%s", topic.Filter, conn.loginAddress)
+	}
+	return topic.ToString()
+}
+
+func (conn *nativeConn) handleSubscribe(args []string) {
+	for _, arg := range args {
+		topic := FormSubscriptionTopic(arg)
+		channel, err := conn.channelOf(topic)
+		if err == nil {
+			err = conn.hub.subscribe(conn, channel)
+		}
+		if err != nil {
+			conn.enqueueJSON(ErrorResponse{Event: "error", Message: err.Error(), ErrorCode: 30041})
+			continue
+		}
+		conn.enqueueJSON(EventResponse{Event: eventSubscribe, Channel: channel})
+
+		// push the depth book as the partial data
+		if topic.Channel == DexSpotDepthBook || topic.Channel == DexSpotDepthDiff {
+			if depthBook, ok := GetDepthBookFromCache(topic.Filter); ok {
+				if value, err := json.Marshal(depthBook); err == nil {
+					if msg, err := newTableResponse(topic.Channel, "partial", value); err == nil {
+						conn.enqueue(msg)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (conn *nativeConn) handleUnsubscribe(args []string) {
+	for _, arg := range args {
+		channel, err := conn.channelOf(FormSubscriptionTopic(arg))
+		if err != nil {
+			conn.enqueueJSON(ErrorResponse{Event: "error", Message: err.Error(), ErrorCode: 30041})
+			continue
+		}
+		conn.hub.unsubscribe(conn, channel)
+		conn.enqueueJSON(EventResponse{Event: eventUnsubscribe, Channel: channel})
+	}
+}
+
+func (conn *nativeConn) handleLogin(args []string) {
+	address, err := verifyLogin(args, time.Now(), conn.hub.cfg.LoginExpiry)
+	if err != nil {
+		conn.enqueueJSON(ErrorResponse{Event: "error", Message: err.Error(), ErrorCode: 30042})
+		return
+	}
+	conn.loginAddress = address
+	conn.enqueueJSON(EventResponse{Event: eventNativeLogin, Success: "true"})
+}
+
+// verifyLogin verifies the login args [address, timestamp, signature] and returns the bech32 address logged in.
+// The signature is the personal sign (EIP-191) of "address:timestamp" by the key of the address, and the timestamp
+// in seconds must be within the expiry from now
+func verifyLogin(args []string, now time.Time, expiry time.Duration) (string, error) {
+	if len(args) != 3 {
+		return "", fmt.Errorf("invalid login args, expected [address, timestamp, signature]")
+	}
+
+	var address sdk.AccAddress
+	if ethcmn.IsHexAddress(args[0]) {
+		address = ethcmn.HexToAddress(args[0]).Bytes()
+	} else {
+		var err error
+		if address, err = sdk.AccAddressFromBech32(args[0]); err != nil {
+			return "", fmt.Errorf("invalid login address %s", args[0])
+		}
+	}
+
+	timestamp, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid login timestamp %s", args[1])
+	}
+	if diff := now.Sub(time.Unix(timestamp, 0)); diff > expiry || diff < -expiry {
+		return "", fmt.Errorf("login timestamp %s expired", args[1])
+	}
+
+	sig, err := hex.DecodeString(strings.TrimPrefix(args[2], "0x"))
+	if err != nil || len(sig) != 65 {
+		return "", fmt.Errorf("invalid login signature")
+	}
+	// the recovery id of the wallets is 27 or 28
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pubKey, err := ethcrypto.SigToPub(accounts.TextHash([]byte(args[0]+":"+args[1])), sig)
+	if err != nil {
+		return "", fmt.Errorf("invalid login signature")
+	}
+	if !address.Equals(sdk.AccAddress(ethcrypto.PubkeyToAddress(*pubKey).Bytes())) {
+		return "", fmt.Errorf("login signature mismatches the address")
+	}
+	return address.String(), nil
+}
+
+// newTableResponse returns the table response of the json value, the elements of a json array become the data
+func newTableResponse(table, action string, value []byte) ([]byte, error) {
+	resp := TableResponse{Table: table, Action: action}
+	if trimmed := strings.TrimSpace(string(value)); strings.HasPrefix(trimmed, "[") {
+		var items []json.RawMessage
+		if err := json.Unmarshal(value, &items); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			resp.Data = append(resp.Data, item)
+		}
+	} else {
+		resp.Data = []interface{}{json.RawMessage(value)}
+	}
+	return json.Marshal(resp)
+}
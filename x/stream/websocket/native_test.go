@@ -0,0 +1,166 @@
+package websocket
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/accounts"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/websocket"
+	"github.com/okex/exchain/x/backend"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func dialHub(t *testing.T, hub *Hub) *websocket.Conn {
+	server := httptest.NewServer(hubHandler{hub})
+	t.Cleanup(server.Close)
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { ws.Close() })
+	return ws
+}
+
+type hubHandler struct {
+	hub *Hub
+}
+
+func (h hubHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.hub.ServeWS(w, r)
+}
+
+func sendOp(t *testing.T, ws *websocket.Conn, op string, args ...string) {
+	require.NoError(t, ws.WriteJSON(BaseOp{Op: op, Args: args}))
+}
+
+func readMessage(t *testing.T, ws *websocket.Conn) map[string]interface{} {
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, msg, err := ws.ReadMessage()
+	require.NoError(t, err)
+	var res map[string]interface{}
+	require.NoError(t, json.Unmarshal(msg, &res))
+	return res
+}
+
+func TestHub_SubscribeAndPublish(t *testing.T) {
+	hub := NewHub(NativeConfig{}, log.NewNopLogger())
+	ws := dialHub(t, hub)
+
+	sendOp(t, ws, eventSubscribe, "dex_spot/ticker:tbtc_tusdk")
+	res := readMessage(t, ws)
+	require.Equal(t, eventSubscribe, res["event"])
+	require.Equal(t, "dex_spot/ticker:tbtc_tusdk", res["Channel"])
+
+	hub.Publish("dex_spot/ticker:tokt_tusdk", []byte(`{"price":"2"}`))
+	hub.Publish("dex_spot/ticker:tbtc_tusdk", []byte(`{"price":"1"}`))
+	res = readMessage(t, ws)
+	require.Equal(t, "dex_spot/ticker", res["table"])
+	require.Equal(t, "update", res["action"])
+	require.Equal(t, []interface{}{map[string]interface{}{"price": "1"}}, res["data"])
+
+	sendOp(t, ws, eventUnsubscribe, "dex_spot/ticker:tbtc_tusdk")
+	res = readMessage(t, ws)
+	require.Equal(t, eventUnsubscribe, res["event"])
+	require.Eventually(t, func() bool {
+		hub.mtx.RLock()
+		defer hub.mtx.RUnlock()
+		return len(hub.subs) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestHub_Limits(t *testing.T) {
+	hub := NewHub(NativeConfig{MaxSubscriptions: 1}, log.NewNopLogger())
+	ws := dialHub(t, hub)
+
+	// private channels need login
+	sendOp(t, ws, eventSubscribe, "dex_spot/account:tokt")
+	res := readMessage(t, ws)
+	require.Equal(t, "error", res["event"])
+
+	sendOp(t, ws, eventSubscribe, "dex_spot/ticker:tbtc_tusdk", "dex_spot/ticker:tokt_tusdk")
+	require.Equal(t, eventSubscribe, readMessage(t, ws)["event"])
+	require.Equal(t, "error", readMessage(t, ws)["event"])
+
+	hub = NewHub(NativeConfig{MaxConns: 1}, log.NewNopLogger())
+	dialHub(t, hub)
+	server := httptest.NewServer(hubHandler{hub})
+	defer server.Close()
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err == nil {
+		// the connection over the limit is closed once upgraded
+		require.NoError(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+		_, _, err = ws.ReadMessage()
+		ws.Close()
+	}
+	require.Error(t, err)
+}
+
+func TestVerifyLogin(t *testing.T) {
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	hexAddress := ethcrypto.PubkeyToAddress(key.PublicKey).Hex()
+	address := sdk.AccAddress(ethcrypto.PubkeyToAddress(key.PublicKey).Bytes())
+
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	sign := func(addr, ts string) string {
+		sig, err := ethcrypto.Sign(accounts.TextHash([]byte(addr+":"+ts)), key)
+		require.NoError(t, err)
+		sig[64] += 27
+		return hex.EncodeToString(sig)
+	}
+
+	loginAddress, err := verifyLogin([]string{hexAddress, timestamp, sign(hexAddress, timestamp)}, now, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, address.String(), loginAddress)
+
+	loginAddress, err = verifyLogin([]string{address.String(), timestamp, "0x" + sign(address.String(), timestamp)}, now, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, address.String(), loginAddress)
+
+	// expired
+	_, err = verifyLogin([]string{hexAddress, timestamp, sign(hexAddress, timestamp)}, now.Add(2*time.Minute), time.Minute)
+	require.Error(t, err)
+
+	// signed by another key
+	other, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	otherAddress := ethcrypto.PubkeyToAddress(other.PublicKey).Hex()
+	_, err = verifyLogin([]string{otherAddress, timestamp, sign(otherAddress, timestamp)}, now, time.Minute)
+	require.Error(t, err)
+
+	_, err = verifyLogin([]string{hexAddress, timestamp}, now, time.Minute)
+	require.Error(t, err)
+}
+
+func TestNewTableResponse(t *testing.T) {
+	msg, err := newTableResponse("dex_spot/order", "update", []byte(`[{"id":1},{"id":2}]`))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"table":"dex_spot/order","action":"update","data":[{"id":1},{"id":2}]}`, string(msg))
+
+	msg, err = newTableResponse("dex_spot/ticker", "update", []byte(`{"price":"1"}`))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"table":"dex_spot/ticker","action":"update","data":[{"price":"1"}]}`, string(msg))
+}
+
+func TestPushData_ChannelMessages(t *testing.T) {
+	data := NewPushData()
+	data.MatchesMap["tokt_tusdk"] = backend.MatchResult{}
+	data.MatchesMap["tbtc_tusdk"] = backend.MatchResult{}
+	data.BackendEvents = []BackendEvent{{Channel: "dex_spot/ticker:tbtc_tusdk", Data: `{"price":"1"}`}}
+
+	messages, err := data.ChannelMessages()
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+	require.Equal(t, DexSpotMatch+":tbtc_tusdk", messages[0].Channel)
+	require.Equal(t, DexSpotMatch+":tokt_tusdk", messages[1].Channel)
+	require.Equal(t, "dex_spot/ticker:tbtc_tusdk", messages[2].Channel)
+	require.Equal(t, `{"price":"1"}`, string(messages[2].Data))
+}
@@ -1,6 +1,7 @@
 package websocket
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os/signal"
@@ -43,8 +44,24 @@ func bridgeMsgHandlerWithLogger(logger log.Logger) func(w http.ResponseWriter, r
 	}
 }
 
+// depthSnapshotHandler serves the depth book of a product, sequenced with the depth diffs
+func depthSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	product := r.URL.Query().Get("instrument_id")
+	depthBook, ok := GetDepthBookFromCache(product)
+	if !ok {
+		http.Error(w, fmt.Sprintf("depth book of %s not found", product), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(depthBook); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func StartWSServer(logger log.Logger, endpoint string) {
 	http.HandleFunc("/ws/v3", bridgeMsgHandlerWithLogger(logger))
+	http.HandleFunc("/ws/v3/depth", depthSnapshotHandler)
 	logger.Info("Starting WebSocket server on ", endpoint)
 	err := http.ListenAndServe(endpoint, nil)
 	if err != nil {
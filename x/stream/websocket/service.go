@@ -49,40 +49,8 @@ func (engine *Engine) Write(data types.IStreamData, success *bool) {
 	engine.logger.Debug(fmt.Sprintf("error: WebSocketEngine Write data:%v", wsData.RedisBlock))
 	events := sdk.Events{}
 
-	// 1. collect account events
-	for key, value := range wsData.AccountsMap {
-		channel := fmt.Sprintf("%s:%s", DexSpotAccount, key)
-		event, err := engine.NewEvent(channel, value)
-		if err != nil {
-			panic(err)
-		}
-		events = append(events, event)
-	}
-
-	// 2. collect order events
-	for key, value := range wsData.OrdersMap {
-		channel := fmt.Sprintf("%s:%s", DexSpotOrder, key)
-		event, err := engine.NewEvent(channel, value)
-		if err != nil {
-			panic(err)
-		}
-		events = append(events, event)
-	}
-
-	// 3. collect matches events
-	for key, value := range wsData.MatchesMap {
-		channel := fmt.Sprintf("%s:%s", DexSpotMatch, key)
-		event, err := engine.NewEvent(channel, value)
-		if err != nil {
-			panic(err)
-		}
-		events = append(events, event)
-	}
-
-	// 4. collect depth_book events
-	for key, value := range wsData.DepthBooksMap {
-		channel := fmt.Sprintf("%s:%s", DexSpotDepthBook, key)
-		event, err := engine.NewEvent(channel, value)
+	for _, item := range wsData.channelDataList() {
+		event, err := engine.NewEvent(item.channel, item.data)
 		if err != nil {
 			panic(err)
 		}
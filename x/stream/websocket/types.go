@@ -1,6 +1,10 @@
 package websocket
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/okex/exchain/x/stream/common"
 	pushservice "github.com/okex/exchain/x/stream/pushservice/types"
@@ -9,12 +13,27 @@ import (
 
 type PushData struct {
 	*pushservice.RedisBlock
-	eventMgr *sdk.EventManager
+	DepthDiffsMap map[string]pushservice.DepthDiff
+	// BackendEvents are the tickers and klines emitted by the backend in the block, which are pushed by the native engine
+	BackendEvents []BackendEvent
+	eventMgr      *sdk.EventManager
+}
+
+// BackendEvent is the json data pushed to a channel by the backend
+type BackendEvent struct {
+	Channel string
+	Data    string
+}
+
+// channelData is the data pushed to a channel
+type channelData struct {
+	channel string
+	data    interface{}
 }
 
 func NewPushData() *PushData {
 	baseData := pushservice.NewRedisBlock()
-	pd := PushData{RedisBlock: baseData, eventMgr: nil}
+	pd := PushData{RedisBlock: baseData, DepthDiffsMap: make(map[string]pushservice.DepthDiff), eventMgr: nil}
 	return &pd
 }
 
@@ -23,14 +42,94 @@ func (data *PushData) SetData(ctx sdk.Context, orderKeeper types.OrderKeeper, to
 	data.eventMgr = ctx.EventManager()
 	data.RedisBlock.SetData(ctx, orderKeeper, tokenKeeper, dexKeeper, swapKeeper, cache)
 
-	// update depthBook cache
+	// update depthBook cache, and collect the diffs of the books
 	products := orderKeeper.GetUpdatedDepthbookKeys()
 	for _, product := range products {
 		depthBook := orderKeeper.GetDepthBookCopy(product)
 		bookRes := pushservice.ConvertBookRes(product, orderKeeper, depthBook, 200)
-		UpdateDepthBookCache(product, bookRes)
+		if diff, changed := UpdateDepthBookCache(product, bookRes, ctx.BlockHeight()); changed {
+			data.DepthDiffsMap[product] = diff
+		}
+	}
+
+	// collect the events emitted by the backend, whose end blocker runs before the stream's
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type != eventTypeBackend {
+			continue
+		}
+		var backendEvent BackendEvent
+		for _, attr := range event.Attributes {
+			switch string(attr.Key) {
+			case "channel":
+				backendEvent.Channel = string(attr.Value)
+			case "data":
+				backendEvent.Data = string(attr.Value)
+			}
+		}
+		if backendEvent.Channel != "" {
+			data.BackendEvents = append(data.BackendEvents, backendEvent)
+		}
+	}
+
+}
+
+// channelDataList returns the data of the block pushed to the channels, except the backend events
+func (data *PushData) channelDataList() []channelData {
+	var list []channelData
+
+	// 1. account data
+	for key, value := range data.AccountsMap {
+		list = append(list, channelData{fmt.Sprintf("%s:%s", DexSpotAccount, key), value})
+	}
+
+	// 2. order data
+	for key, value := range data.OrdersMap {
+		list = append(list, channelData{fmt.Sprintf("%s:%s", DexSpotOrder, key), value})
+	}
+
+	// 3. matches data
+	for key, value := range data.MatchesMap {
+		list = append(list, channelData{fmt.Sprintf("%s:%s", DexSpotMatch, key), value})
 	}
 
+	// 4. depth_book data
+	for key, value := range data.DepthBooksMap {
+		list = append(list, channelData{fmt.Sprintf("%s:%s", DexSpotDepthBook, key), value})
+	}
+
+	// 5. depth_diff data
+	for key, value := range data.DepthDiffsMap {
+		list = append(list, channelData{fmt.Sprintf("%s:%s", DexSpotDepthDiff, key), value})
+	}
+	return list
+}
+
+// ChannelMessage is the json data of the block pushed to a channel
+type ChannelMessage struct {
+	Channel string
+	Data    []byte
+}
+
+// ChannelMessages returns the json data of the block pushed to the channels sorted by the channels, followed by the
+// backend events in order, so the sequence of a message in the block is deterministic
+func (data *PushData) ChannelMessages() ([]ChannelMessage, error) {
+	list := data.channelDataList()
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].channel < list[j].channel
+	})
+
+	messages := make([]ChannelMessage, 0, len(list)+len(data.BackendEvents))
+	for _, item := range list {
+		value, err := json.Marshal(item.data)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, ChannelMessage{Channel: item.channel, Data: value})
+	}
+	for _, event := range data.BackendEvents {
+		messages = append(messages, ChannelMessage{Channel: event.Channel, Data: []byte(event.Data)})
+	}
+	return messages, nil
 }
 
 func (data PushData) DataType() types.StreamDataKind {